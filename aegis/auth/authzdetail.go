@@ -0,0 +1,90 @@
+package auth
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/gin-gonic/gin"
+	"github.com/go-json-experiment/json"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/aegis/utilities/schema"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// bindAuthorizationDetails 解析 query / 表单中以 JSON 字符串传输的 authorization_details（RFC 9396 §2）
+func bindAuthorizationDetails(c *gin.Context, details *tokendef.AuthorizationDetails) *autherrors.AuthError {
+	raw := formOrQuery(c, "authorization_details")
+	if raw == "" {
+		return nil
+	}
+	if err := json.Unmarshal([]byte(raw), details); err != nil {
+		return autherrors.NewInvalidAuthorizationDetails(err.Error())
+	}
+	return nil
+}
+
+// resolveAuthorizationDetails 按 audience 服务声明的类型校验 authorization_details 并拆分到各服务（RFC 9396 §5）
+//   - 每条授权详情的 type 须被至少一个 audience 服务声明，否则整个请求被拒绝
+//   - 服务为该 type 配置了 schema 时，按 schema 校验 type 以外的字段
+//   - 同一 type 被多个 audience 声明时分别归属各服务
+func (h *Handler) resolveAuthorizationDetails(ctx context.Context, audiences []string, details tokendef.AuthorizationDetails) (map[string]tokendef.AuthorizationDetails, *autherrors.AuthError) {
+	if len(details) == 0 {
+		return nil, nil
+	}
+	if err := details.Validate(); err != nil {
+		return nil, autherrors.NewInvalidAuthorizationDetails(err.Error())
+	}
+
+	declared := make(map[string]map[string]*models.ServiceAuthorizationDetailType, len(audiences))
+	for _, aud := range audiences {
+		types, err := h.cache.GetServiceAuthorizationDetailTypes(ctx, aud)
+		if err != nil {
+			logger.Errorf("[Handler] 获取授权详情类型失败 - ServiceID: %s, Error: %v", aud, err)
+			return nil, autherrors.NewServerError("query authorization detail types failed")
+		}
+		byType := make(map[string]*models.ServiceAuthorizationDetailType, len(types))
+		for i := range types {
+			byType[types[i].Type] = &types[i]
+		}
+		declared[aud] = byType
+	}
+
+	resolved := make(map[string]tokendef.AuthorizationDetails, len(audiences))
+	for i, detail := range details {
+		matched := false
+		for _, aud := range audiences {
+			detailType, ok := declared[aud][detail.Type()]
+			if !ok {
+				continue
+			}
+			if err := validateAuthorizationDetail(detailType, detail); err != nil {
+				return nil, autherrors.NewInvalidAuthorizationDetails(fmt.Sprintf("authorization_details[%d]: %v", i, err))
+			}
+			resolved[aud] = append(resolved[aud], detail)
+			matched = true
+		}
+		if !matched {
+			return nil, autherrors.NewInvalidAuthorizationDetails(fmt.Sprintf("authorization_details[%d]: unknown type %q", i, detail.Type()))
+		}
+	}
+	return resolved, nil
+}
+
+// validateAuthorizationDetail 按服务声明的 schema 校验单条授权详情（不含 type 字段）
+func validateAuthorizationDetail(detailType *models.ServiceAuthorizationDetailType, detail tokendef.AuthorizationDetail) error {
+	if detailType.JSONSchema == nil || *detailType.JSONSchema == "" {
+		return nil
+	}
+	s, err := schema.Parse([]byte(*detailType.JSONSchema))
+	if err != nil {
+		logger.Errorf("[Handler] 授权详情 schema 无效 - ServiceID: %s, Type: %s, Error: %v", detailType.ServiceID, detailType.Type, err)
+		return fmt.Errorf("type %q is misconfigured", detailType.Type)
+	}
+	fields := maps.Clone(map[string]any(detail))
+	delete(fields, tokendef.AuthorizationDetailFieldType)
+	return s.Validate(fields)
+}
@@ -0,0 +1,37 @@
+package auth
+
+import (
+	"testing"
+
+	"github.com/heliannuuthus/aegis/models"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+func TestValidateAuthorizationDetail(t *testing.T) {
+	t.Parallel()
+
+	schema := `{"type": "object", "required": ["actions"], "additionalProperties": false,
+		"properties": {"actions": {"type": "array", "items": {"enum": ["read", "delete"]}}, "identifier": {"type": "string"}}}`
+	recipe := &models.ServiceAuthorizationDetailType{ServiceID: "iris", Type: "recipe", JSONSchema: &schema}
+
+	tests := []struct {
+		name       string
+		detailType *models.ServiceAuthorizationDetailType
+		detail     tokendef.AuthorizationDetail
+		wantErr    bool
+	}{
+		{name: "valid", detailType: recipe, detail: tokendef.AuthorizationDetail{"type": "recipe", "actions": []any{"read"}, "identifier": "r1"}},
+		{name: "missing actions", detailType: recipe, detail: tokendef.AuthorizationDetail{"type": "recipe"}, wantErr: true},
+		{name: "unknown field", detailType: recipe, detail: tokendef.AuthorizationDetail{"type": "recipe", "actions": []any{"read"}, "owner": "alice"}, wantErr: true},
+		{name: "unknown action", detailType: recipe, detail: tokendef.AuthorizationDetail{"type": "recipe", "actions": []any{"write"}}, wantErr: true},
+		{name: "no schema", detailType: &models.ServiceAuthorizationDetailType{Type: "menu"}, detail: tokendef.AuthorizationDetail{"type": "menu", "anything": true}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if err := validateAuthorizationDetail(tt.detailType, tt.detail); (err != nil) != tt.wantErr {
+				t.Fatalf("validateAuthorizationDetail() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -7,6 +7,7 @@ import "errors"
 const (
 	HeaderAuthorization = "Authorization" // Authorization 请求头
 	BearerPrefix        = "Bearer "       // Bearer Token 前缀
+	DPoPPrefix          = "DPoP "         // DPoP 绑定 Token 前缀（RFC 9449 §7.1）
 
 	// Query 参数
 	QueryClientID = "client_id" // Client ID 查询参数
@@ -19,3 +20,9 @@ const (
 
 // errIdentifiedUser 内部哨兵错误：resolveUser 识别到已有用户，需前端确认关联
 var errIdentifiedUser = errors.New("identified existing user")
+
+// errConsentRequired 内部哨兵错误：已有授权未覆盖本次请求（或 prompt=consent），需跳转同意页
+var errConsentRequired = errors.New("consent required")
+
+// errMFARequired 内部哨兵错误：风险评估要求追加第二因子，flow 已进入 mfa 阶段，需跳转 MFA 页
+var errMFARequired = errors.New("mfa required")
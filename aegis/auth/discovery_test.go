@@ -0,0 +1,49 @@
+package auth
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/heliannuuthus/aegis/internal/authorize"
+)
+
+func TestBuildDiscoveryDocument(t *testing.T) {
+	t.Parallel()
+
+	doc := buildDiscoveryDocument("https://aegis.heliannuuthus.com/api/")
+
+	if doc.Issuer != "https://aegis.heliannuuthus.com/api/" {
+		t.Fatalf("issuer = %q", doc.Issuer)
+	}
+	endpoints := map[string]string{
+		"authorization": doc.AuthorizationEndpoint,
+		"token":         doc.TokenEndpoint,
+		"userinfo":      doc.UserInfoEndpoint,
+		"pubkeys":       doc.PasetoKeysEndpoint,
+		"par":           doc.PushedAuthorizationRequestEndpoint,
+	}
+	want := map[string]string{
+		"authorization": "https://aegis.heliannuuthus.com/api/auth/authorize",
+		"token":         "https://aegis.heliannuuthus.com/api/auth/token",
+		"userinfo":      "https://aegis.heliannuuthus.com/api/auth/userinfo",
+		"pubkeys":       "https://aegis.heliannuuthus.com/api/auth/pubkeys",
+		"par":           "https://aegis.heliannuuthus.com/api/auth/par",
+	}
+	for name, got := range endpoints {
+		if got != want[name] {
+			t.Fatalf("%s endpoint = %q, want %q", name, got, want[name])
+		}
+	}
+
+	for _, grant := range []string{authorize.GrantTypeAuthorizationCode, authorize.GrantTypeClientCredentials, authorize.GrantTypeDeviceCode} {
+		if !slices.Contains(doc.GrantTypesSupported, grant) {
+			t.Fatalf("grant %q not advertised", grant)
+		}
+	}
+	if !slices.Equal(doc.CodeChallengeMethodsSupported, []string{"S256"}) {
+		t.Fatalf("code_challenge_methods_supported = %v", doc.CodeChallengeMethodsSupported)
+	}
+	if !slices.Equal(doc.DPoPSigningAlgValuesSupported, []string{"ES256", "EdDSA"}) {
+		t.Fatalf("dpop_signing_alg_values_supported = %v", doc.DPoPSigningAlgValuesSupported)
+	}
+}
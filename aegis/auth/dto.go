@@ -3,8 +3,17 @@ package auth
 import (
 	"fmt"
 	"strings"
+
+	"github.com/heliannuuthus/aegis/internal/cache"
+	pkgtoken "github.com/heliannuuthus/pkg/aegis/utilities/token"
 )
 
+// PushedAuthorizationResponse PAR 响应（RFC 9126 §2.2）
+type PushedAuthorizationResponse struct {
+	RequestURI string `json:"request_uri"`
+	ExpiresIn  int    `json:"expires_in"` // request_uri 有效期（秒）
+}
+
 // LoginRequest 登录请求
 type LoginRequest struct {
 	// 必填：身份标识
@@ -82,6 +91,16 @@ type RevokeRequest struct {
 	ClientID string `form:"client_id"`
 }
 
+// LogoutDeliveriesResponse 后端通道登出通知投递记录响应
+type LogoutDeliveriesResponse struct {
+	Deliveries []*cache.LogoutDelivery `json:"deliveries"`
+}
+
+// RevocationListResponse 吊销列表响应（token 为 aegis 签名的 PASETO v4.public）
+type RevocationListResponse struct {
+	Token string `json:"token"`
+}
+
 // CheckRequest 关系检查请求
 // 使用 CT 认证，检查指定主体是否具有指定的关系权限
 type CheckRequest struct {
@@ -116,6 +135,26 @@ type ConfirmIdentifyRequest struct {
 	Confirm bool `json:"confirm"` // true=确认关联，false=取消
 }
 
+// ConsentRequest 授权同意请求
+type ConsentRequest struct {
+	Accept bool `json:"accept"` // true=同意授权，false=拒绝
+}
+
+// MFACompleteRequest MFA 完成请求（flow 由 aegis-session Cookie 确定）
+type MFACompleteRequest struct {
+	ChallengeToken string `json:"challenge_token" binding:"required"` // 第二因子 Challenge 完成后签发的 ChallengeToken
+}
+
+// MFAContextResponse MFA 阶段上下文（前端据此渲染第二因子并发起 Challenge）
+type MFAContextResponse struct {
+	Status          string   `json:"status"`           // 固定为 mfa_required
+	FlowID          string   `json:"flow_id"`          // AuthFlow ID
+	AllowedChannels []string `json:"allowed_channels"` // 允许的第二因子 channel_type
+	Type            string   `json:"type"`             // 发起 Challenge 时使用的业务场景（固定为 mfa）
+	Channel         string   `json:"channel"`          // 发起 Challenge 时使用的 channel（用户 OpenID）
+	ExpiresIn       int      `json:"expires_in"`       // MFA 阶段剩余秒数
+}
+
 // ApplicationInfo 应用信息
 type ApplicationInfo struct {
 	DomainID string  `json:"domain_id"`
@@ -136,4 +175,46 @@ type ServiceInfo struct {
 type AuthContextResponse struct {
 	Application *ApplicationInfo `json:"application,omitempty"`
 	Service     *ServiceInfo     `json:"service,omitempty"`
+	Scopes      []string         `json:"scopes,omitempty"` // 待同意的 scope（仅 authenticated 阶段返回）
+
+	AuthorizationDetails pkgtoken.AuthorizationDetails `json:"authorization_details,omitempty"` // 待同意的授权详情（仅 authenticated 阶段返回）
+	UserCode             string                        `json:"user_code,omitempty"`             // 待确认的设备授权 user_code（仅设备授权 flow 的 authenticated 阶段返回）
+}
+
+// UserInfoResponse OIDC UserInfo 响应（/auth/userinfo），字段按 UAT 授权的 scope 返回
+type UserInfoResponse struct {
+	Sub         string `json:"sub"`
+	Nickname    string `json:"nickname,omitempty"`     // scope 包含 profile 时返回
+	Picture     string `json:"picture,omitempty"`      // scope 包含 profile 时返回
+	Email       string `json:"email,omitempty"`        // scope 包含 email 时返回
+	PhoneNumber string `json:"phone_number,omitempty"` // scope 包含 phone 时返回
+}
+
+// DiscoveryDocument OIDC Discovery 文档（/.well-known/openid-configuration）
+// Token 为 PASETO v4，公钥通过 paseto_keys_endpoint 按 client_id 获取，不提供 JWKS
+type DiscoveryDocument struct {
+	Issuer                             string   `json:"issuer"`
+	AuthorizationEndpoint              string   `json:"authorization_endpoint"`
+	TokenEndpoint                      string   `json:"token_endpoint"`
+	UserInfoEndpoint                   string   `json:"userinfo_endpoint"`
+	RevocationEndpoint                 string   `json:"revocation_endpoint"`
+	IntrospectionEndpoint              string   `json:"introspection_endpoint"`
+	EndSessionEndpoint                 string   `json:"end_session_endpoint"`
+	PushedAuthorizationRequestEndpoint string   `json:"pushed_authorization_request_endpoint"`
+	RequirePushedAuthorizationRequests bool     `json:"require_pushed_authorization_requests"`
+	RegistrationEndpoint               string   `json:"registration_endpoint"`
+	RequestParameterSupported          bool     `json:"request_parameter_supported"`
+	DeviceAuthorizationEndpoint        string   `json:"device_authorization_endpoint"`
+	PasetoKeysEndpoint                 string   `json:"paseto_keys_endpoint"`
+	ResponseTypesSupported             []string `json:"response_types_supported"`
+	GrantTypesSupported                []string `json:"grant_types_supported"`
+	ScopesSupported                    []string `json:"scopes_supported"`
+	CodeChallengeMethodsSupported      []string `json:"code_challenge_methods_supported"`
+	PromptValuesSupported              []string `json:"prompt_values_supported"`
+	SubjectTypesSupported              []string `json:"subject_types_supported"`
+	IDTokenSigningAlgValuesSupported   []string `json:"id_token_signing_alg_values_supported"`
+	ClaimsSupported                    []string `json:"claims_supported"`
+	DPoPSigningAlgValuesSupported      []string `json:"dpop_signing_alg_values_supported"`
+	BackchannelLogoutSupported         bool     `json:"backchannel_logout_supported"`
+	BackchannelLogoutSessionSupported  bool     `json:"backchannel_logout_session_supported"`
 }
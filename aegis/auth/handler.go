@@ -20,14 +20,21 @@ import (
 	"github.com/heliannuuthus/aegis/internal/authorize"
 	"github.com/heliannuuthus/aegis/internal/cache"
 	"github.com/heliannuuthus/aegis/internal/challenge"
+	"github.com/heliannuuthus/aegis/internal/consent"
+	"github.com/heliannuuthus/aegis/internal/logout"
+	"github.com/heliannuuthus/aegis/internal/mfa"
+	"github.com/heliannuuthus/aegis/internal/password"
+	"github.com/heliannuuthus/aegis/internal/registration"
 	"github.com/heliannuuthus/aegis/internal/token"
 	"github.com/heliannuuthus/aegis/internal/types"
 	"github.com/heliannuuthus/aegis/internal/user"
 	"github.com/heliannuuthus/aegis/models"
 	"github.com/heliannuuthus/aegis/profile"
 	aegisguard "github.com/heliannuuthus/pkg/aegis/guard"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
 	pkgtoken "github.com/heliannuuthus/pkg/aegis/utilities/token"
 	"github.com/heliannuuthus/pkg/async"
+	"github.com/heliannuuthus/pkg/binding"
 	"github.com/heliannuuthus/pkg/helpers"
 	"github.com/heliannuuthus/pkg/logger"
 )
@@ -39,11 +46,17 @@ type Handler struct {
 	authenticateSvc *authenticate.Service
 	authorizeSvc    *authorize.Service
 	challengeSvc    *challenge.Service
+	consentSvc      *consent.Service
+	logoutSvc       *logout.Service
+	registrationSvc *registration.Service
+	mfaStage        *mfa.Stage
+	passwordSvc     *password.Service
 	userSvc         *user.Service
 	cache           *cache.Manager
 	tokenSvc        *token.Service
 	profileHandler  *profile.Handler
 	pool            *async.Pool
+	dpopVerifier    *dpop.Verifier
 }
 
 // NewHandler 创建认证处理器
@@ -51,6 +64,11 @@ func NewHandler(
 	authenticateSvc *authenticate.Service,
 	authorizeSvc *authorize.Service,
 	challengeSvc *challenge.Service,
+	consentSvc *consent.Service,
+	logoutSvc *logout.Service,
+	registrationSvc *registration.Service,
+	mfaStage *mfa.Stage,
+	passwordSvc *password.Service,
 	userSvc *user.Service,
 	cache *cache.Manager,
 	tokenSvc *token.Service,
@@ -61,11 +79,17 @@ func NewHandler(
 		authenticateSvc: authenticateSvc,
 		authorizeSvc:    authorizeSvc,
 		challengeSvc:    challengeSvc,
+		consentSvc:      consentSvc,
+		logoutSvc:       logoutSvc,
+		registrationSvc: registrationSvc,
+		mfaStage:        mfaStage,
+		passwordSvc:     passwordSvc,
 		userSvc:         userSvc,
 		cache:           cache,
 		tokenSvc:        tokenSvc,
 		profileHandler:  profileHandler,
 		pool:            pool,
+		dpopVerifier:    dpop.NewVerifier(cache.DPoPReplayCache(), dpop.DefaultWindow),
 	}
 }
 
@@ -111,21 +135,101 @@ func GetClientClaims(c *gin.Context) *pkgtoken.ClientToken {
 
 // --- 认证会话 ---
 
+// PushedAuthorize POST /auth/par
+// 推送授权请求（RFC 9126，CT 认证，可携带 request 请求对象）：预先校验请求参数并签发一次性 request_uri，
+// 浏览器随后仅携带 client_id 与 request_uri 调用 /auth/authorize，避免参数暴露与篡改
+func (h *Handler) PushedAuthorize(c *gin.Context) {
+	catClaims, err := h.clientTokenFromRequest(c)
+	if err != nil {
+		logger.Debugf("[Handler] par verify CT failed: %v", err)
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_client"`)
+		h.tokenErrorResponse(c, autherrors.NewInvalidClient("invalid client token"))
+		return
+	}
+
+	var req types.AuthRequest
+	if formOrQuery(c, "request") != "" {
+		pushed, authErr := h.bindRequestObject(c)
+		if authErr != nil {
+			h.tokenErrorResponse(c, authErr)
+			return
+		}
+		req = *pushed
+	} else if err := c.ShouldBind(&req); err != nil {
+		h.tokenErrorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	} else if authErr := bindAuthorizationDetails(c, &req.AuthorizationDetails); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	if req.ClientID != catClaims.ClientID() {
+		h.tokenErrorResponse(c, autherrors.NewInvalidRequest("client_id does not match client token"))
+		return
+	}
+	if c.PostForm("request_uri") != "" || req.GetString("request_uri") != "" {
+		h.tokenErrorResponse(c, autherrors.NewInvalidRequest("request_uri must not be pushed"))
+		return
+	}
+	if authErr := validateAuthorizeRequest(&req); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+
+	ctx := c.Request.Context()
+	app, err := h.cache.GetApplication(ctx, req.ClientID)
+	if err != nil {
+		h.tokenErrorResponse(c, autherrors.NewClientNotFoundf("application not found: %s", req.ClientID))
+		return
+	}
+	if !app.ValidateAllowedRedirectURI(req.RedirectURI) {
+		h.tokenErrorResponse(c, autherrors.NewInvalidRequest("invalid redirect_uri"))
+		return
+	}
+	audiences, authErr := collectAudiences(&req)
+	if authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	if _, authErr := h.validateAudiences(ctx, req.ClientID, audiences); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	if _, authErr := h.resolveAuthorizationDetails(ctx, audiences, req.AuthorizationDetails); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+
+	id := types.GeneratePushedRequestID()
+	expiresIn := config.GetPARExpiresIn()
+	if err := h.cache.SavePushedAuthRequest(ctx, id, &req, expiresIn); err != nil {
+		logger.Errorf("[Handler] 保存 PAR 请求失败: %v", err)
+		h.tokenErrorResponse(c, autherrors.NewServerError("save pushed authorization request failed"))
+		return
+	}
+
+	logger.Infof("[Handler] PAR 签发 request_uri - ClientID: %s, Audiences: %v", req.ClientID, audiences)
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusCreated, PushedAuthorizationResponse{
+		RequestURI: types.RequestURIPrefix + id,
+		ExpiresIn:  int(expiresIn.Seconds()),
+	})
+}
+
 // Authorize POST /auth/authorize
-// 创建认证会话
+// 创建认证会话（携带 request_uri 时使用 PAR 推送的请求参数，携带 request 时使用请求对象）
 func (h *Handler) Authorize(c *gin.Context) {
-	var req types.AuthRequest
-	if err := c.ShouldBind(&req); err != nil {
-		h.authorizeErrorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+	req, pushed, authErr := h.bindAuthorizeRequest(c)
+	if authErr != nil {
+		h.authorizeErrorResponse(c, authErr)
 		return
 	}
 
-	if authErr := validateAuthorizeRequest(&req); authErr != nil {
+	if authErr := validateAuthorizeRequest(req); authErr != nil {
 		h.authorizeErrorResponse(c, authErr)
 		return
 	}
 
-	ctx := c.Request.Context()
+	ctx := requestContext(c)
 	logger.Debugf("[Handler] Authorize request: %+v", req)
 
 	app, err := h.cache.GetApplication(ctx, req.ClientID)
@@ -133,36 +237,47 @@ func (h *Handler) Authorize(c *gin.Context) {
 		h.authorizeErrorResponse(c, autherrors.NewClientNotFoundf("application not found: %s", req.ClientID))
 		return
 	}
+	if app.RequirePushedAuthRequests && !pushed {
+		h.authorizeErrorResponse(c, autherrors.NewInvalidRequest("pushed authorization request required"))
+		return
+	}
 	if !app.ValidateAllowedRedirectURI(req.RedirectURI) {
 		h.authorizeErrorResponse(c, autherrors.NewInvalidRequest("invalid redirect_uri"))
 		return
 	}
 
-	audiences, authErr := collectAudiences(&req)
+	audiences, authErr := collectAudiences(req)
 	if authErr != nil {
-		h.authorizeErrorResponse(c, authErr)
+		h.authorizeFailure(c, req, authErr)
 		return
 	}
 
 	svc, authErr := h.validateAudiences(ctx, req.ClientID, audiences)
 	if authErr != nil {
-		h.authorizeErrorResponse(c, authErr)
+		h.authorizeFailure(c, req, authErr)
+		return
+	}
+
+	details, authErr := h.resolveAuthorizationDetails(ctx, audiences, req.AuthorizationDetails)
+	if authErr != nil {
+		h.authorizeFailure(c, req, authErr)
 		return
 	}
 
 	idpConfigs, err := h.cache.ListApplicationIDPConfigs(ctx, req.ClientID)
 	if err != nil {
-		h.authorizeErrorResponse(c, autherrors.NewServerError("query idp configs failed"))
+		h.authorizeFailure(c, req, autherrors.NewServerError("query idp configs failed"))
 		return
 	}
 	if len(idpConfigs) == 0 {
-		h.authorizeErrorResponse(c, autherrors.NewNoConnectionAvailable(""))
+		h.authorizeFailure(c, req, autherrors.NewNoConnectionAvailable(""))
 		return
 	}
 
-	flow := types.NewAuthFlow(&req, time.Duration(config.GetCookieMaxAge())*time.Second, config.GetAuthFlowMaxLifetime())
+	flow := types.NewAuthFlow(req, time.Duration(config.GetCookieMaxAge())*time.Second, config.GetAuthFlowMaxLifetime())
 	flow.Application = &app.Application
 	flow.Service = &svc.Service
+	flow.AuthorizationDetails = details
 	flow.SetConnectionMap(h.authenticateSvc.SetConnections(idpConfigs))
 
 	if !req.Prompt.Contains(types.PromptLogin) {
@@ -172,7 +287,7 @@ func (h *Handler) Authorize(c *gin.Context) {
 	}
 
 	if req.Prompt.Contains(types.PromptNone) {
-		h.authorizeErrorResponse(c, autherrors.NewLoginRequired("no active SSO session"))
+		h.authorizeFailure(c, req, autherrors.NewLoginRequired("no active SSO session"))
 		return
 	}
 
@@ -237,6 +352,15 @@ func (h *Handler) GetContext(c *gin.Context) {
 		}
 	}
 
+	// 待同意阶段返回本次请求的 scope，供同意页展示
+	if flow.State == types.FlowStateAuthenticated {
+		if scopes, err := h.authorizeSvc.ComputeGrantedScopes(flow); err == nil {
+			resp.Scopes = scopes
+		}
+		resp.AuthorizationDetails = flow.Request.AuthorizationDetails
+		resp.UserCode = flow.DeviceUserCode()
+	}
+
 	c.JSON(http.StatusOK, resp)
 }
 
@@ -286,7 +410,7 @@ func (h *Handler) Login(c *gin.Context) {
 		return
 	}
 
-	ctx := helpers.WithRemoteIP(c.Request.Context(), c.ClientIP())
+	ctx := requestContext(c)
 
 	// 1. 获取 AuthFlow
 	flow := h.authenticateSvc.GetAndValidateFlow(ctx, flowID)
@@ -310,6 +434,7 @@ func (h *Handler) Login(c *gin.Context) {
 	}
 	flow.SetConnection(req.Connection)
 	flow.SetExtra(types.ExtraKeyStrategy, req.Strategy)
+	flow.SetExtra(types.ExtraKeyPrincipal, req.Principal)
 
 	// 3. 执行认证流程（已验证的 connection 跳过）
 	passed, err := h.authenticate(c, ctx, flow, &req)
@@ -333,8 +458,17 @@ func (h *Handler) Login(c *gin.Context) {
 	}
 	logger.Infof("[Handler] 用户解析完成 - FlowID: %s, UserID: %s", flow.ID, flow.User.OpenID)
 
-	// 5. 授权并生成授权码
+	// 5. 授权并生成授权码（需用户同意时签发 SSO 后跳转同意页，需 MFA 时跳转 MFA 页）
 	authCode, err := h.authorizeAndGenerateCode(ctx, flow)
+	if errors.Is(err, errMFARequired) {
+		forwardNext(c, flow)
+		return
+	}
+	if errors.Is(err, errConsentRequired) {
+		h.issueSSOCookie(c, ctx, flow)
+		forwardNext(c, flow)
+		return
+	}
 	if err != nil {
 		logger.Errorf("[Handler] 授权签发失败 - FlowID: %s, Error: %v", flow.ID, err)
 		h.errorResponse(c, err)
@@ -346,8 +480,13 @@ func (h *Handler) Login(c *gin.Context) {
 	h.issueSSOCookie(c, ctx, flow)
 
 	// 7. 构建最终重定向
+	location, err := h.authCodeRedirectURL(ctx, flow, authCode)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
 	clearAuthSessionCookie(c)
-	actionRedirect(c, buildAuthCodeRedirectURL(flow.Request.RedirectURI, authCode))
+	actionRedirect(c, location)
 }
 
 // --- IDP authentication entry ---
@@ -437,18 +576,34 @@ func (h *Handler) IDPs(c *gin.Context) {
 	})
 }
 
-// OAuthCallback completes an upstream Google/GitHub authorization request.
+// OAuthCallback completes an upstream Google/GitHub/OIDC authorization request,
+// or accepts a SAML response posted to the same URL (the SP's ACS).
 // The state transaction is consumed atomically before exchanging the authorization code.
+// Binding transactions link the upstream identity to a signed-in user instead of logging in.
 func (h *Handler) OAuthCallback(c *gin.Context) {
 	c.Header("Cache-Control", "no-store")
 	c.Header("Referrer-Policy", "no-referrer")
 
-	connection, flowID, transaction, ok := h.consumeOAuthCallbackTransaction(c)
+	connection, transaction, ok := h.consumeOAuthCallbackTransaction(c)
 	if !ok {
 		return
 	}
+	if transaction.IsBinding() {
+		h.completeIdentityBinding(c, connection, transaction)
+		return
+	}
 
-	ctx := helpers.WithRemoteIP(c.Request.Context(), c.ClientIP())
+	flowID, err := getAuthSessionCookie(c)
+	if err != nil || flowID == "" {
+		h.errorResponse(c, autherrors.NewFlowNotFound("missing session"))
+		return
+	}
+	if transaction.FlowID != flowID {
+		h.errorResponse(c, autherrors.NewInvalidRequest("oauth transaction mismatch"))
+		return
+	}
+
+	ctx := requestContext(c)
 	flow := h.authenticateSvc.GetAndValidateFlow(ctx, flowID)
 	if flow.Failed() {
 		h.flowErrorResponse(c, flow)
@@ -465,7 +620,7 @@ func (h *Handler) OAuthCallback(c *gin.Context) {
 		return
 	}
 
-	code := c.Query("code")
+	_, code := oauthCallbackParams(c, connection)
 	if code == "" {
 		h.errorResponse(c, autherrors.NewInvalidRequest("authorization code is required"))
 		return
@@ -474,6 +629,7 @@ func (h *Handler) OAuthCallback(c *gin.Context) {
 	flow.SetConnection(connection)
 	flow.SetExtra(types.ExtraKeyOAuthRedirectURI, transaction.RedirectURI)
 	flow.SetExtra(types.ExtraKeyOAuthCodeVerifier, transaction.CodeVerifier)
+	flow.SetExtra(types.ExtraKeyOAuthNonce, transaction.Nonce)
 	req := &LoginRequest{Connection: connection, Proof: code}
 	passed, err := h.authenticate(c, ctx, flow, req)
 	if err != nil {
@@ -496,13 +652,27 @@ func (h *Handler) OAuthCallback(c *gin.Context) {
 	}
 
 	authCode, err := h.authorizeAndGenerateCode(ctx, flow)
+	if errors.Is(err, errMFARequired) {
+		browserRedirect(c, config.GetEndpointMFA())
+		return
+	}
+	if errors.Is(err, errConsentRequired) {
+		h.issueSSOCookie(c, ctx, flow)
+		browserRedirect(c, config.GetEndpointConsent())
+		return
+	}
 	if err != nil {
 		h.errorResponse(c, err)
 		return
 	}
 	h.issueSSOCookie(c, ctx, flow)
+	location, err := h.authCodeRedirectURL(ctx, flow, authCode)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
 	clearAuthSessionCookie(c)
-	browserRedirect(c, buildAuthCodeRedirectURL(flow.Request.RedirectURI, authCode))
+	browserRedirect(c, location)
 }
 
 // --- Challenge ---
@@ -645,7 +815,7 @@ func (h *Handler) ConfirmIdentify(c *gin.Context) {
 		return
 	}
 
-	ctx := c.Request.Context()
+	ctx := requestContext(c)
 
 	flow := h.authenticateSvc.GetAndValidateFlow(ctx, flowID)
 	if flow.Failed() {
@@ -714,7 +884,7 @@ func (h *Handler) ConfirmIdentify(c *gin.Context) {
 
 	// 授权并生成授权码
 	authCode, err := h.authorizeAndGenerateCode(ctx, flow)
-	if err != nil {
+	if err != nil && !errors.Is(err, errConsentRequired) && !errors.Is(err, errMFARequired) {
 		h.errorResponse(c, err)
 		return
 	}
@@ -727,12 +897,93 @@ func (h *Handler) ConfirmIdentify(c *gin.Context) {
 		}
 	})
 
+	// 需 MFA 时跳转 MFA 页（SSO Token 在 MFA 完成后签发）
+	if errors.Is(err, errMFARequired) {
+		forwardNext(c, flow)
+		return
+	}
+
 	// 签发 SSO Token
 	h.issueSSOCookie(c, ctx, flow)
 
+	// 需用户同意时跳转同意页
+	if err != nil {
+		forwardNext(c, flow)
+		return
+	}
+
 	// 构建最终重定向
+	location, err := h.authCodeRedirectURL(ctx, flow, authCode)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
 	clearAuthSessionCookie(c)
-	actionRedirect(c, buildAuthCodeRedirectURL(flow.Request.RedirectURI, authCode))
+	actionRedirect(c, location)
+}
+
+// --- 授权同意 ---
+
+// Consent POST /auth/consent
+// 用户在同意页确认或拒绝授权：确认后记录授权并签发授权码，拒绝后以 access_denied 回跳应用
+func (h *Handler) Consent(c *gin.Context) {
+	var req ConsentRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	ctx := requestContext(c)
+	flow := h.loadAuthFlow(c, ctx)
+	if flow == nil {
+		return
+	}
+	defer h.saveFlow(ctx, flow)
+
+	if flow.State != types.FlowStateAuthenticated || flow.User == nil {
+		h.errorResponse(c, autherrors.NewFlowInvalid("flow is not awaiting consent"))
+		return
+	}
+
+	if !req.Accept {
+		location, err := h.consentDeniedURL(ctx, flow)
+		if err != nil {
+			h.errorResponse(c, err)
+			return
+		}
+		logger.Infof("[Consent] 用户拒绝授权 - FlowID: %s, AppID: %s", flow.ID, flow.Application.AppID)
+		clearAuthSessionCookie(c)
+		actionRedirect(c, location)
+		return
+	}
+
+	grantedScopes, err := h.authorizeSvc.ComputeGrantedScopes(flow)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	if err := h.consentSvc.Grant(ctx, flow.User.OpenID, flow.Application.AppID, consent.Requested(flow, grantedScopes)); err != nil {
+		logger.Errorf("[Consent] 记录授权失败 - FlowID: %s, Error: %v", flow.ID, err)
+		h.errorResponse(c, autherrors.NewServerError("save consent failed"))
+		return
+	}
+
+	authCode, err := h.generateAuthCode(ctx, flow, grantedScopes)
+	if errors.Is(err, errMFARequired) {
+		forwardNext(c, flow)
+		return
+	}
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	location, err := h.authCodeRedirectURL(ctx, flow, authCode)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	clearAuthSessionCookie(c)
+	actionRedirect(c, location)
 }
 
 // --- Token ---
@@ -740,9 +991,11 @@ func (h *Handler) ConfirmIdentify(c *gin.Context) {
 // Token POST /auth/token
 //
 // 按 Content-Type 路由：
-//   - application/x-www-form-urlencoded：authorization_code（单/多 audience 由 flow 决定，响应分别为扁平或 keyed）+ refresh_token。
+//   - application/x-www-form-urlencoded：authorization_code（单/多 audience 由 flow 决定，响应分别为扁平或 keyed）+ refresh_token，
+//     token-exchange（RFC 8693，UAT 作为 subject_token，CT 作为 actor_token，签发带 act 的降权 UAT），
+//     device_code（RFC 8628，按 interval 轮询，返回 authorization_pending / slow_down / expired_token）。
 //     提交 code 换取 token 时统一使用 form，客户端按响应结构解析即可。
-//   - application/json：client_credentials 等多 audience 场景。
+//   - application/json：多 audience authorization_code；client_credentials（CT 认证，签发 SAT，audience 单个或 audiences 多个）。
 func (h *Handler) Token(c *gin.Context) {
 	if c.ContentType() == "application/json" {
 		h.tokenMultiAudience(c)
@@ -751,6 +1004,106 @@ func (h *Handler) Token(c *gin.Context) {
 	h.tokenForm(c)
 }
 
+// --- 设备授权 ---
+
+// DeviceCode POST /auth/device/code
+// 设备授权请求（RFC 8628 §3.1），返回 device_code / user_code / verification_uri
+func (h *Handler) DeviceCode(c *gin.Context) {
+	var req authorize.DeviceAuthorizationRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.tokenErrorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	ctx := c.Request.Context()
+
+	if _, err := h.cache.GetApplication(ctx, req.ClientID); err != nil {
+		h.tokenErrorResponse(c, autherrors.NewClientNotFoundf("application not found: %s", req.ClientID))
+		return
+	}
+	if _, authErr := h.validateAudiences(ctx, req.ClientID, []string{req.Audience}); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+
+	resp, err := h.authorizeSvc.CreateDeviceAuthorization(ctx, &req)
+	if err != nil {
+		logger.Errorf("[Device] 创建设备授权失败 - client_id: %s, error: %v", req.ClientID, err)
+		h.tokenErrorResponse(c, err)
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, resp)
+}
+
+// DeviceVerify POST /auth/device/verify
+// 验证页提交 user_code，创建绑定该设备授权的 AuthFlow，后续走常规登录流程
+func (h *Handler) DeviceVerify(c *gin.Context) {
+	var req authorize.DeviceVerifyRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.authorizeErrorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	ctx := helpers.WithRemoteIP(c.Request.Context(), c.ClientIP())
+
+	sessionID, _ := getAuthSessionCookie(c)
+	device, err := h.authorizeSvc.LookupDeviceAuthorization(ctx, req.UserCode, sessionID)
+	if err != nil {
+		h.authorizeErrorResponse(c, err)
+		return
+	}
+
+	app, err := h.cache.GetApplication(ctx, device.ClientID)
+	if err != nil {
+		h.authorizeErrorResponse(c, autherrors.NewClientNotFoundf("application not found: %s", device.ClientID))
+		return
+	}
+	svc, authErr := h.validateAudiences(ctx, device.ClientID, []string{device.Audience})
+	if authErr != nil {
+		h.authorizeErrorResponse(c, authErr)
+		return
+	}
+
+	idpConfigs, err := h.cache.ListApplicationIDPConfigs(ctx, device.ClientID)
+	if err != nil {
+		h.authorizeErrorResponse(c, autherrors.NewServerError("query idp configs failed"))
+		return
+	}
+	if len(idpConfigs) == 0 {
+		h.authorizeErrorResponse(c, autherrors.NewNoConnectionAvailable(""))
+		return
+	}
+
+	authReq := &types.AuthRequest{
+		ResponseType: types.ResponseTypeDeviceCode,
+		ClientID:     device.ClientID,
+		Audience:     device.Audience,
+		Scope:        binding.SpaceDelimited(strings.Fields(device.Scope)),
+	}
+	authReq.Set(types.RequestParamUserCode, device.UserCode)
+
+	flow := types.NewAuthFlow(authReq, time.Duration(config.GetCookieMaxAge())*time.Second, config.GetAuthFlowMaxLifetime())
+	flow.Application = &app.Application
+	flow.Service = &svc.Service
+	flow.SetConnectionMap(h.authenticateSvc.SetConnections(idpConfigs))
+
+	if redirected := h.trySSOFastPath(c, ctx, flow); redirected {
+		return
+	}
+
+	if err := h.authenticateSvc.SaveFlow(ctx, flow); err != nil {
+		h.authorizeErrorResponse(c, autherrors.NewServerError("save flow failed"))
+		return
+	}
+
+	logger.Infof("[Device] 验证页绑定设备授权 - FlowID: %s, client_id: %s", flow.ID, device.ClientID)
+
+	setAuthSessionCookie(c, flow.ID)
+	forwardNext(c, flow)
+}
+
 // --- 权限检查 ---
 
 // Check POST /auth/check
@@ -805,6 +1158,49 @@ func (h *Handler) Check(c *gin.Context) {
 	})
 }
 
+// --- Token 自省 ---
+
+// Introspect POST /auth/introspect
+// Token 自省（RFC 7662，使用 CT 认证），仅对 audience 为调用方的 token 返回详情
+func (h *Handler) Introspect(c *gin.Context) {
+	catClaims, err := h.clientTokenFromRequest(c)
+	if err != nil {
+		logger.Debugf("[Handler] introspect verify CT failed: %v", err)
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_client"`)
+		h.tokenErrorResponse(c, autherrors.NewInvalidClient("invalid client token"))
+		return
+	}
+
+	var req authorize.IntrospectionRequest
+	if err := c.ShouldBind(&req); err != nil {
+		h.tokenErrorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, h.authorizeSvc.Introspect(c.Request.Context(), catClaims.ClientID(), &req))
+}
+
+// LogoutDeliveries GET /auth/backchannel-logout/deliveries
+// 应用查询自身的后端通道登出通知投递记录（CT 认证）
+func (h *Handler) LogoutDeliveries(c *gin.Context) {
+	catClaims, err := h.clientTokenFromRequest(c)
+	if err != nil {
+		logger.Debugf("[Handler] logout deliveries verify CT failed: %v", err)
+		h.errorResponse(c, autherrors.NewUnauthorized("invalid client token"))
+		return
+	}
+
+	deliveries, err := h.logoutSvc.Deliveries(c.Request.Context(), catClaims.ClientID())
+	if err != nil {
+		logger.Errorf("[Handler] 查询登出通知投递记录失败: %v", err)
+		h.errorResponse(c, autherrors.NewServerError("list logout deliveries failed"))
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, LogoutDeliveriesResponse{Deliveries: deliveries})
+}
+
 // --- 登出与撤销 ---
 
 // Revoke POST /auth/revoke
@@ -817,12 +1213,36 @@ func (h *Handler) Revoke(c *gin.Context) {
 	}
 
 	// RFC 7009: 即使 token 无效，也应返回 200
-	if err := h.cache.DelRefreshToken(c.Request.Context(), req.Token); err != nil {
+	if err := h.authorizeSvc.RevokeToken(c.Request.Context(), req.Token); err != nil {
 		logger.Warnf("[Handler] revoke token failed: %v", err)
 	}
 	c.Status(http.StatusOK)
 }
 
+// Revocations GET /auth/revocations
+// 获取 access token 吊销列表（使用 CT 认证，仅返回调用方 audience 的列表）
+// 响应为 aegis 签名的 PASETO token，资源服务按 Cache-Control 轮询并在本地校验
+func (h *Handler) Revocations(c *gin.Context) {
+	catClaims, err := h.clientTokenFromRequest(c)
+	if err != nil {
+		logger.Debugf("[Handler] revocations verify CT failed: %v", err)
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_token"`)
+		h.errorResponse(c, autherrors.NewUnauthorized("invalid client token"))
+		return
+	}
+
+	signed, err := h.authorizeSvc.IssueRevocationList(c.Request.Context(), catClaims.ClientID())
+	if err != nil {
+		logger.Errorf("[Handler] issue revocation list failed: %v", err)
+		h.errorResponse(c, autherrors.NewServerError("issue revocation list failed"))
+		return
+	}
+
+	maxAge := int(config.GetRevocationListMaxAge().Seconds())
+	c.Header("Cache-Control", fmt.Sprintf("private, max-age=%d", maxAge))
+	c.JSON(http.StatusOK, RevocationListResponse{Token: signed})
+}
+
 // Logout POST /auth/logout
 // 登出（撤销 refresh token + 清除 SSO cookie）
 func (h *Handler) Logout(c *gin.Context) {
@@ -885,30 +1305,126 @@ func (h *Handler) PublicKeys(c *gin.Context) {
 	c.JSON(http.StatusOK, publicKey)
 }
 
-func (h *Handler) consumeOAuthCallbackTransaction(c *gin.Context) (string, string, *idp.OAuthTransaction, bool) {
+// UserInfo GET/POST /auth/userinfo
+// OIDC UserInfo（UAT 认证），按 UAT 授权的 scope 返回用户资料
+func (h *Handler) UserInfo(c *gin.Context) {
+	tokenStr, dpopScheme := accessTokenFromHeader(c.GetHeader(HeaderAuthorization))
+	if tokenStr == "" {
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer)
+		h.tokenErrorResponse(c, autherrors.NewUnauthorized("missing access token"))
+		return
+	}
+
+	t, err := h.tokenSvc.Verify(c.Request.Context(), tokenStr)
+	if err != nil {
+		logger.Debugf("[Handler] userinfo verify token failed: %v", err)
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_token"`)
+		h.tokenErrorResponse(c, autherrors.NewUnauthorized("invalid access token"))
+		return
+	}
+	uat, ok := t.(*pkgtoken.UserAccessToken)
+	if !ok || !uat.Identified() {
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_token"`)
+		h.tokenErrorResponse(c, autherrors.NewUnauthorized("user access token required"))
+		return
+	}
+	if err := h.verifyDPoPBinding(c, authEndpointURL("/userinfo"), tokenStr, uat.JKT(), dpopScheme); err != nil {
+		logger.Debugf("[Handler] userinfo DPoP 校验失败: %v", err)
+		c.Header("WWW-Authenticate", dpop.TokenType+` error="invalid_dpop_proof"`)
+		h.tokenErrorResponse(c, autherrors.NewUnauthorized("invalid DPoP proof"))
+		return
+	}
+	if h.authorizeSvc.IsAccessTokenRevoked(c.Request.Context(), uat) {
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_token"`)
+		h.tokenErrorResponse(c, autherrors.NewUnauthorized("access token revoked"))
+		return
+	}
+	if _, ok := uat.Scopes()[pkgtoken.ScopeOpenID]; !ok {
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="insufficient_scope", scope="openid"`)
+		h.tokenErrorResponse(c, autherrors.NewAccessDenied("openid scope required"))
+		return
+	}
+
+	c.Header("Cache-Control", "no-store")
+	c.JSON(http.StatusOK, UserInfoResponse{
+		Sub:         uat.OpenID(),
+		Nickname:    uat.Nickname(),
+		Picture:     uat.Picture(),
+		Email:       uat.Email(),
+		PhoneNumber: uat.Phone(),
+	})
+}
+
+// Discovery GET /.well-known/openid-configuration
+// OIDC Discovery 文档，端点均由 config.GetIssuer() 派生
+func (h *Handler) Discovery(c *gin.Context) {
+	maxAge := int(config.GetPublicKeyCacheMaxAge().Seconds())
+	c.Header("Cache-Control", fmt.Sprintf("public, max-age=%d", maxAge))
+	c.JSON(http.StatusOK, buildDiscoveryDocument(config.GetIssuer()))
+}
+
+// SAMLMetadata GET /auth/idps/:connection/metadata/:client_id
+// 应用在 SAML connection 下的 SP 元数据，该地址同时是 SP EntityID，供企业 IdP 管理员导入
+func (h *Handler) SAMLMetadata(c *gin.Context) {
+	connection := c.Param("connection")
+	if !idp.IsSAMLConnection(connection) {
+		h.errorResponse(c, autherrors.NewNotFoundf("connection %s has no saml metadata", connection))
+		return
+	}
+	auth, ok := authenticator.GlobalRegistry().Get(connection)
+	if !ok {
+		h.errorResponse(c, autherrors.NewNotFoundf("connection %s is not configured", connection))
+		return
+	}
+	publisher, ok := auth.(idp.MetadataPublisher)
+	if !ok {
+		h.errorResponse(c, autherrors.NewNotFoundf("connection %s has no saml metadata", connection))
+		return
+	}
+
+	metadata, err := publisher.Metadata(c.Request.Context(), c.Param("client_id"))
+	if err != nil {
+		logger.Warnf("[SAMLMetadata] 生成 SP 元数据失败 - Connection: %s, ClientID: %s, Error: %v", connection, c.Param("client_id"), err)
+		h.errorResponse(c, autherrors.NewNotFound("saml connection is not configured for this client"))
+		return
+	}
+	c.Header("Cache-Control", "no-cache")
+	c.Data(http.StatusOK, "application/samlmetadata+xml", metadata)
+}
+
+func (h *Handler) consumeOAuthCallbackTransaction(c *gin.Context) (string, *idp.OAuthTransaction, bool) {
 	connection := c.Param("connection")
 	if !idp.IsOAuthRedirectConnection(connection) {
 		h.errorResponse(c, autherrors.NewInvalidRequest("unsupported oauth connection"))
-		return "", "", nil, false
+		return "", nil, false
 	}
 
-	flowID, err := getAuthSessionCookie(c)
-	if err != nil || flowID == "" {
-		h.errorResponse(c, autherrors.NewFlowNotFound("missing session"))
-		return "", "", nil, false
+	// SAML 使用 HTTP-POST binding 回传断言，OAuth / OIDC 以 GET query 回调
+	if idp.IsSAMLConnection(connection) != (c.Request.Method == http.MethodPost) {
+		h.errorResponse(c, autherrors.NewInvalidRequest("unsupported callback method"))
+		return "", nil, false
 	}
 
-	state := c.Query("state")
+	state, _ := oauthCallbackParams(c, connection)
 	transaction, err := h.cache.ConsumeOAuthTransaction(c.Request.Context(), state)
 	if err != nil {
 		h.errorResponse(c, autherrors.NewInvalidRequest("invalid or expired oauth state"))
-		return "", "", nil, false
+		return "", nil, false
 	}
-	if transaction.FlowID != flowID || transaction.Connection != connection {
+	if transaction.Connection != connection {
 		h.errorResponse(c, autherrors.NewInvalidRequest("oauth transaction mismatch"))
-		return "", "", nil, false
+		return "", nil, false
 	}
-	return connection, flowID, transaction, true
+	return connection, transaction, true
+}
+
+// oauthCallbackParams 读取上游回调携带的 state 与凭证：
+// SAML 为表单中的 RelayState / SAMLResponse，OAuth / OIDC 为 query 中的 state / code
+func oauthCallbackParams(c *gin.Context, connection string) (state, proof string) {
+	if idp.IsSAMLConnection(connection) {
+		return c.PostForm("RelayState"), c.PostForm("SAMLResponse")
+	}
+	return c.Query("state"), c.Query("code")
 }
 
 func (h *Handler) clientTokenFromRequest(c *gin.Context) (*pkgtoken.ClientToken, error) {
@@ -928,6 +1444,64 @@ func (h *Handler) clientTokenFromRequest(c *gin.Context) (*pkgtoken.ClientToken,
 	return claims, nil
 }
 
+// accessTokenFromHeader 从 Authorization 头提取 access token，并返回是否使用 DPoP scheme
+func accessTokenFromHeader(auth string) (string, bool) {
+	if len(auth) > len(DPoPPrefix) && strings.EqualFold(auth[:len(DPoPPrefix)], DPoPPrefix) {
+		return auth[len(DPoPPrefix):], true
+	}
+	return bearerToken(auth), false
+}
+
+// dpopThumbprint 校验 token 请求携带的 DPoP proof（RFC 9449 §4.3），返回公钥指纹；未携带时返回空串
+func (h *Handler) dpopThumbprint(c *gin.Context) (string, error) {
+	proofs := c.Request.Header.Values(dpop.HeaderName)
+	if len(proofs) == 0 {
+		return "", nil
+	}
+	if len(proofs) > 1 {
+		return "", autherrors.NewInvalidDPoPProof("multiple DPoP proofs")
+	}
+
+	proof, err := h.dpopVerifier.Verify(c.Request.Context(), proofs[0], http.MethodPost, authEndpointURL("/token"), "")
+	if err != nil {
+		logger.Warnf("[Token] DPoP proof 校验失败: %v", err)
+		return "", autherrors.NewInvalidDPoPProof("invalid DPoP proof")
+	}
+	return proof.JKT, nil
+}
+
+// verifyDPoPBinding 校验资源访问的 DPoP 绑定（RFC 9449 §7）
+// 绑定的 token 须以 DPoP scheme 出示并附带同一密钥的 proof，未绑定的 token 不得以 DPoP scheme 出示
+func (h *Handler) verifyDPoPBinding(c *gin.Context, htu, tokenStr, jkt string, scheme bool) error {
+	if jkt == "" {
+		if scheme {
+			return errors.New("token is not DPoP-bound")
+		}
+		return nil
+	}
+	if !scheme {
+		return errors.New("DPoP-bound token presented as bearer")
+	}
+
+	proofs := c.Request.Header.Values(dpop.HeaderName)
+	if len(proofs) != 1 {
+		return errors.New("expected exactly one DPoP proof")
+	}
+	proof, err := h.dpopVerifier.Verify(c.Request.Context(), proofs[0], c.Request.Method, htu, tokenStr)
+	if err != nil {
+		return err
+	}
+	if proof.JKT != jkt {
+		return errors.New("proof key does not match token binding")
+	}
+	return nil
+}
+
+// authEndpointURL 由 issuer 派生的 /auth 端点绝对地址（与 Discovery 文档一致）
+func authEndpointURL(path string) string {
+	return strings.TrimRight(config.GetIssuer(), "/") + "/auth" + path
+}
+
 func bearerToken(auth string) string {
 	if strings.HasPrefix(auth, BearerPrefix) {
 		return auth[len(BearerPrefix):]
@@ -950,19 +1524,130 @@ func (h *Handler) openIDFromRequestOrSSOCookie(c *gin.Context, app *models.Appli
 	return ""
 }
 
+// revokeAndClearSSO 结束当前 SSO 会话并吊销用户 token，参与会话的应用通过后端通道收到登出通知
 func (h *Handler) revokeAndClearSSO(c *gin.Context, openID string) {
+	ctx := c.Request.Context()
+	if sid := h.ssoSessionID(c, ctx); sid != "" {
+		h.logoutSvc.EndSession(ctx, sid)
+	}
 	if openID != "" {
-		if err := h.cache.DelUserRefreshTokens(c.Request.Context(), openID); err != nil {
+		if err := h.authorizeSvc.RevokeSubject(ctx, openID); err != nil {
 			logger.Warnf("[Handler] logout revoke tokens failed: %v", err)
 		}
 	}
 	clearSSOCookie(c)
 }
 
+// ssoSessionID 从 SSO cookie 中读取会话标识，cookie 缺失或无效时返回空
+func (h *Handler) ssoSessionID(c *gin.Context, ctx context.Context) string {
+	ssoTokenString, err := getSSOCookie(c)
+	if err != nil || ssoTokenString == "" {
+		return ""
+	}
+	t, err := h.tokenSvc.Verify(ctx, ssoTokenString)
+	if err != nil {
+		return ""
+	}
+	if ssoToken, ok := t.(*token.SSOToken); ok {
+		return ssoToken.GetSessionID()
+	}
+	return ""
+}
+
 // ==================== 私有方法（按引用顺序） ====================
 
 // --- Authorize 引用链 ---
 
+// bindAuthorizeRequest 解析 authorize 请求参数，返回请求是否来自 PAR
+// 携带 request_uri 时仅接受 client_id，其余参数以推送的请求为准；request_uri 一次性消费
+// 携带 request 时验证请求对象并与外层参数合并
+func (h *Handler) bindAuthorizeRequest(c *gin.Context) (*types.AuthRequest, bool, *autherrors.AuthError) {
+	requestURI := formOrQuery(c, "request_uri")
+	hasRequestObject := formOrQuery(c, "request") != ""
+	if requestURI != "" && hasRequestObject {
+		return nil, false, autherrors.NewInvalidRequest("request and request_uri are mutually exclusive")
+	}
+	if hasRequestObject {
+		req, authErr := h.bindRequestObject(c)
+		return req, false, authErr
+	}
+	if requestURI == "" {
+		var req types.AuthRequest
+		if err := c.ShouldBind(&req); err != nil {
+			return nil, false, autherrors.NewInvalidRequest(err.Error())
+		}
+		if authErr := bindAuthorizationDetails(c, &req.AuthorizationDetails); authErr != nil {
+			return nil, false, authErr
+		}
+		return &req, false, nil
+	}
+
+	clientID := formOrQuery(c, "client_id")
+	if clientID == "" {
+		return nil, false, autherrors.NewInvalidRequest("client_id is required")
+	}
+	id, ok := strings.CutPrefix(requestURI, types.RequestURIPrefix)
+	if !ok {
+		return nil, false, autherrors.NewInvalidRequestURI("unsupported request_uri")
+	}
+	req, err := h.cache.ConsumePushedAuthRequest(c.Request.Context(), id)
+	if err != nil {
+		if !errors.Is(err, cache.ErrPushedAuthRequestNotFound) {
+			logger.Errorf("[Handler] 读取 PAR 请求失败: %v", err)
+		}
+		return nil, false, autherrors.NewInvalidRequestURI("invalid or expired request_uri")
+	}
+	if req.ClientID != clientID {
+		return nil, false, autherrors.NewInvalidRequestURI("request_uri was not issued to this client")
+	}
+	return req, true, nil
+}
+
+// bindRequestObject 验证 request 参数携带的请求对象（JAR，RFC 9101，应用密钥签名的 PASETO v4.public）
+// 标记 jti 已使用后以请求对象作为授权参数，外层 query / 表单仅读取 client_id / response_type
+func (h *Handler) bindRequestObject(c *gin.Context) (*types.AuthRequest, *autherrors.AuthError) {
+	outer := url.Values{}
+	for k, vs := range c.Request.URL.Query() {
+		outer[k] = append(outer[k], vs...)
+	}
+	for k, vs := range c.Request.PostForm {
+		outer[k] = append(outer[k], vs...)
+	}
+
+	clientID := outer.Get("client_id")
+	if clientID == "" {
+		return nil, autherrors.NewInvalidRequest("client_id is required")
+	}
+	claims, err := h.tokenSvc.VerifyRequestObject(c.Request.Context(), clientID, outer.Get("request"))
+	if err != nil {
+		logger.Debugf("[Handler] 请求对象验证失败 - ClientID: %s, Error: %v", clientID, err)
+		return nil, autherrors.NewInvalidRequestObject("invalid request object")
+	}
+
+	// 请求对象在 exp 前只能使用一次，防止截获后重放
+	jti, ttl, authErr := requestObjectReplayWindow(claims, time.Now())
+	if authErr != nil {
+		return nil, authErr
+	}
+	claimed, err := h.cache.ClaimRequestObject(c.Request.Context(), clientID, jti, ttl)
+	if err != nil {
+		logger.Errorf("[Handler] 标记请求对象失败 - ClientID: %s, Error: %v", clientID, err)
+		return nil, autherrors.NewServerError("claim request object failed")
+	}
+	if !claimed {
+		return nil, autherrors.NewInvalidRequestObject("request object has already been used")
+	}
+	return mergeRequestObject(outer, claims)
+}
+
+// formOrQuery 读取 query 或表单参数（JSON 请求体不解析）
+func formOrQuery(c *gin.Context, key string) string {
+	if v := c.Query(key); v != "" {
+		return v
+	}
+	return c.PostForm(key)
+}
+
 func validateAuthorizeRequest(req *types.AuthRequest) *autherrors.AuthError {
 	if strings.Contains(req.ResponseType, "code") {
 		if req.CodeChallenge == "" || req.CodeChallengeMethod == "" {
@@ -978,9 +1663,27 @@ func validateAuthorizeRequest(req *types.AuthRequest) *autherrors.AuthError {
 	if req.Audience != "" && len(req.Audiences) > 0 {
 		return autherrors.NewInvalidRequest("audience and audiences are mutually exclusive")
 	}
+	if req.Prompt.Contains(types.PromptNone) && len(req.Prompt) > 1 {
+		return autherrors.NewInvalidRequest("prompt=none must not be combined with other values")
+	}
+	if req.MaxAge != nil && *req.MaxAge < 0 {
+		return autherrors.NewInvalidRequest("max_age must be a non-negative integer")
+	}
 	return nil
 }
 
+// authorizeFailure redirect_uri 校验通过后的 authorize 错误响应
+// prompt=none 时不展示任何界面，错误通过 error/state 参数回跳 redirect_uri（OIDC Core §3.1.2.6）
+func (h *Handler) authorizeFailure(c *gin.Context, req *types.AuthRequest, err error) {
+	if !req.Prompt.Contains(types.PromptNone) {
+		h.authorizeErrorResponse(c, err)
+		return
+	}
+	authErr := autherrors.ToAuthError(err)
+	logger.Infof("[Handler] prompt=none 静默认证失败 - ClientID: %s, Error: %s", req.ClientID, authErr.Code)
+	actionRedirect(c, buildErrorRedirectURL(req.RedirectURI, authErr.Code, req.State))
+}
+
 func collectAudiences(req *types.AuthRequest) ([]string, *autherrors.AuthError) {
 	if req.Audience != "" {
 		return []string{req.Audience}, nil
@@ -1022,15 +1725,25 @@ func (h *Handler) validateAudiences(ctx context.Context, clientID string, audien
 }
 
 // trySSOFastPath 尝试 SSO 快速路径：如果用户有有效 SSO 会话，直接签发授权码并重定向。
-// 返回 true 表示已处理（成功重定向），false 表示需要走正常登录流程。
+// SSO 会话认证时间超过 max_age 时不走快速路径（强制重新登录）。
+// 已有授权未覆盖本次请求时跳转同意页（prompt=none 时回跳 consent_required）。
+// 返回 true 表示已处理（已响应），false 表示需要走正常登录流程。
 func (h *Handler) trySSOFastPath(c *gin.Context, ctx context.Context, flow *types.AuthFlow) bool {
 	ssoToken, ssoUser := h.resolveSSO(c, ctx, flow.Application)
 	if ssoUser == nil {
 		return false
 	}
 
+	authTime := ssoToken.GetAuthTime()
+	if maxAge := flow.Request.MaxAge; maxAge != nil && time.Since(authTime) > time.Duration(*maxAge)*time.Second {
+		logger.Debugf("[Handler] SSO 会话超过 max_age - AuthTime: %s, MaxAge: %ds", authTime.Format(time.RFC3339), *maxAge)
+		return false
+	}
+
 	flow.User = ssoUser
 	flow.SetAuthenticated(ssoUser)
+	flow.AuthTime = authTime
+	flow.SessionID = ssoToken.GetSessionID()
 
 	for conn, cfg := range flow.ConnectionMap {
 		if cfg.Type == types.ConnTypeIDP {
@@ -1041,6 +1754,12 @@ func (h *Handler) trySSOFastPath(c *gin.Context, ctx context.Context, flow *type
 	logger.Debugf("[Handler] SSO 快速路径 - Connection: %s, User: %s", flow.Connection, flow.User.OpenID)
 
 	authCode, err := h.authorizeAndGenerateCode(ctx, flow)
+	if errors.Is(err, errConsentRequired) {
+		return h.forwardSSOInteraction(c, ctx, flow, ssoToken, autherrors.NewConsentRequired("user consent is required"))
+	}
+	if errors.Is(err, errMFARequired) {
+		return h.forwardSSOInteraction(c, ctx, flow, ssoToken, autherrors.NewInteractionRequired("multi-factor authentication is required"))
+	}
 	if err != nil {
 		logger.Warnf("[Handler] SSO 授权失败: %v", err)
 		return false
@@ -1051,8 +1770,31 @@ func (h *Handler) trySSOFastPath(c *gin.Context, ctx context.Context, flow *type
 		return false
 	}
 
+	location, err := h.authCodeRedirectURL(ctx, flow, authCode)
+	if err != nil {
+		logger.Warnf("[Handler] SSO 授权完成失败: %v", err)
+		return false
+	}
+
 	h.renewSSOCookie(c, ctx, ssoToken)
-	actionRedirect(c, buildAuthCodeRedirectURL(flow.Request.RedirectURI, authCode))
+	actionRedirect(c, location)
+	return true
+}
+
+// forwardSSOInteraction SSO 快速路径需要用户交互（同意或 MFA）：保存 flow 并跳转对应页面
+// prompt=none 时以 promptNoneErr 回跳应用
+func (h *Handler) forwardSSOInteraction(c *gin.Context, ctx context.Context, flow *types.AuthFlow, ssoToken *token.SSOToken, promptNoneErr *autherrors.AuthError) bool {
+	if flow.Request.Prompt.Contains(types.PromptNone) {
+		h.authorizeFailure(c, flow.Request, promptNoneErr)
+		return true
+	}
+	if err := h.authenticateSvc.SaveFlow(ctx, flow); err != nil {
+		logger.Warnf("[Handler] SSO flow 保存失败: %v", err)
+		return false
+	}
+	h.renewSSOCookie(c, ctx, ssoToken)
+	setAuthSessionCookie(c, flow.ID)
+	forwardNext(c, flow)
 	return true
 }
 
@@ -1101,7 +1843,7 @@ func (h *Handler) resolveSSO(c *gin.Context, ctx context.Context,
 	return ssoToken, user
 }
 
-// renewSSOCookie 续期 SSO Token（重新签发新 token 并更新 cookie，保留全部域身份）
+// renewSSOCookie 续期 SSO Token（重新签发新 token 并更新 cookie，保留全部域身份、认证时间和会话标识）
 func (h *Handler) renewSSOCookie(c *gin.Context, ctx context.Context, oldSSO *token.SSOToken) {
 	sso := pkgtoken.NewClaimsBuilder().
 		Issuer(token.SSOIssuer).
@@ -1109,7 +1851,9 @@ func (h *Handler) renewSSOCookie(c *gin.Context, ctx context.Context, oldSSO *to
 		Audience(token.SSOAudience).
 		ExpiresIn(config.GetSSOTTL()).
 		Build(token.NewSSOTokenBuilder().
-			Identities(oldSSO.GetIdentities()))
+			Identities(oldSSO.GetIdentities()).
+			AuthTime(oldSSO.GetAuthTime()).
+			SessionID(oldSSO.GetSessionID()))
 
 	tokenString, err := h.tokenSvc.Issue(ctx, sso)
 	if err != nil {
@@ -1271,7 +2015,7 @@ func (h *Handler) findExistingUser(ctx context.Context, domain idp.Domain, userI
 }
 
 // issueSSOCookie 签发 SSO Token 并设置 cookie
-// 合并已有 SSO 身份：如果用户已有其他域的 SSO 会话，保留并追加当前域身份
+// 合并已有 SSO 身份：如果用户已有其他域的 SSO 会话，保留并追加当前域身份，沿用原会话标识
 func (h *Handler) issueSSOCookie(c *gin.Context, ctx context.Context, flow *types.AuthFlow) {
 	if flow.User == nil || flow.Application == nil {
 		return
@@ -1279,15 +2023,21 @@ func (h *Handler) issueSSOCookie(c *gin.Context, ctx context.Context, flow *type
 
 	domainID := flow.Application.DomainID
 
-	// 尝试读取已有 SSO Token 中的身份
+	// 尝试读取已有 SSO Token 中的身份和会话标识
 	identities := make(map[string]string)
+	sessionID := ""
 	if existingToken, err := getSSOCookie(c); err == nil && existingToken != "" {
 		if t, err := h.tokenSvc.Verify(ctx, existingToken); err == nil {
 			if oldSSO, ok := t.(*token.SSOToken); ok {
 				identities = oldSSO.GetIdentities()
+				sessionID = oldSSO.GetSessionID()
 			}
 		}
 	}
+	if sessionID == "" {
+		sessionID = types.GenerateSessionID()
+	}
+	flow.SessionID = sessionID
 
 	// 追加/覆盖当前域的身份
 	identities[domainID] = flow.User.OpenID
@@ -1298,7 +2048,9 @@ func (h *Handler) issueSSOCookie(c *gin.Context, ctx context.Context, flow *type
 		Audience(token.SSOAudience).
 		ExpiresIn(config.GetSSOTTL()).
 		Build(token.NewSSOTokenBuilder().
-			Identities(identities))
+			Identities(identities).
+			AuthTime(flow.AuthTime).
+			SessionID(sessionID))
 
 	tokenString, err := h.tokenSvc.Issue(ctx, sso)
 	if err != nil {
@@ -1335,7 +2087,8 @@ func (h *Handler) handleExchange(c *gin.Context, ctx context.Context, req *chall
 		ExpiresIn(expiresIn).
 		Build(pkgtoken.NewChallengeTokenBuilder().
 			Subject(principal).
-			Type(req.Type))
+			Type(req.Type).
+			ChannelType(pkgtoken.ChannelType(req.ChannelType)))
 
 	tokenStr, err := h.tokenSvc.Issue(ctx, ct)
 	if err != nil {
@@ -1417,7 +2170,8 @@ func (h *Handler) handleMainVerification(c *gin.Context, ctx context.Context, ch
 		ExpiresIn(ch.ExpiresIn()).
 		Build(pkgtoken.NewChallengeTokenBuilder().
 			Subject(ch.Channel).
-			Type(ch.Type))
+			Type(ch.Type).
+			ChannelType(ch.ChannelType))
 
 	tokenStr, err := h.tokenSvc.Issue(ctx, ct)
 	if err != nil {
@@ -1494,9 +2248,25 @@ func (h *Handler) tokenForm(c *gin.Context) {
 		h.errorResponse(c, autherrors.NewInvalidRequest(err.Error()))
 		return
 	}
+	if authErr := bindAuthorizationDetails(c, &req.AuthorizationDetails); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
 
 	logger.Infof("[Token] 进入 token 交换 - grant_type: %s, client_id: %s", req.GrantType, req.ClientID)
 
+	jkt, err := h.dpopThumbprint(c)
+	if err != nil {
+		h.tokenErrorResponse(c, err)
+		return
+	}
+	req.DPoPJKT = jkt
+
+	// token-exchange 未携带 actor_token 时，以 Authorization 头中的 CT 作为调用方
+	if req.GrantType == authorize.GrantTypeTokenExchange && req.ActorToken == "" {
+		req.ActorToken = bearerToken(c.GetHeader(HeaderAuthorization))
+	}
+
 	if req.GrantType == authorize.GrantTypeAuthorizationCode {
 		resp, err := h.authorizeSvc.ExchangeAuthCodeForm(c.Request.Context(), &req)
 		if err != nil {
@@ -1528,8 +2298,20 @@ func (h *Handler) tokenMultiAudience(c *gin.Context) {
 		return
 	}
 
+	if req.GrantType == authorize.GrantTypeClientCredentials {
+		h.tokenClientCredentials(c, &req)
+		return
+	}
+
 	logger.Infof("[Token] 进入多 audience token 交换 - grant_type: %s, client_id: %s", req.GrantType, req.ClientID)
 
+	jkt, err := h.dpopThumbprint(c)
+	if err != nil {
+		h.tokenErrorResponse(c, err)
+		return
+	}
+	req.DPoPJKT = jkt
+
 	resp, err := h.authorizeSvc.ExchangeMultiAudienceToken(c.Request.Context(), &req)
 	if err != nil {
 		logger.Warnf("[Token] 多 audience token 交换失败 - grant_type: %s, client_id: %s, error: %v", req.GrantType, req.ClientID, err)
@@ -1541,10 +2323,67 @@ func (h *Handler) tokenMultiAudience(c *gin.Context) {
 	c.JSON(http.StatusOK, resp)
 }
 
+// tokenClientCredentials client_credentials 授权（需要 CT 认证）
+// 单 audience 返回扁平 TokenResponse，多 audience 返回 keyed MultiAudienceTokenResponse
+func (h *Handler) tokenClientCredentials(c *gin.Context, req *authorize.MultiAudienceTokenRequest) {
+	catClaims, err := h.clientTokenFromRequest(c)
+	if err != nil {
+		logger.Warnf("[Token] client_credentials CT 验证失败: %v", err)
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_client"`)
+		h.tokenErrorResponse(c, autherrors.NewInvalidClient("invalid client token"))
+		return
+	}
+	clientID := catClaims.ClientID()
+
+	logger.Infof("[Token] 进入 client_credentials 交换 - client_id: %s", clientID)
+
+	jkt, err := h.dpopThumbprint(c)
+	if err != nil {
+		h.tokenErrorResponse(c, err)
+		return
+	}
+	req.DPoPJKT = jkt
+
+	resp, err := h.authorizeSvc.ExchangeClientCredentials(c.Request.Context(), clientID, req)
+	if err != nil {
+		logger.Warnf("[Token] client_credentials 交换失败 - client_id: %s, error: %v", clientID, err)
+		h.tokenErrorResponse(c, err)
+		return
+	}
+
+	c.JSON(http.StatusOK, resp)
+}
+
 // --- 共享私有方法 ---
 
+// authCodeRedirectURL 授权完成后的重定向地址（同时记录应用参与了 flow 所属的 SSO 会话）
+// 设备授权 flow 将授权码绑定到 device_code 后跳转验证页完成态；其他 flow 携带授权码回跳 redirect_uri
+func (h *Handler) authCodeRedirectURL(ctx context.Context, flow *types.AuthFlow, authCode *cache.AuthorizationCode) (string, error) {
+	h.logoutSvc.Track(ctx, flow.SessionID, flow.User.OpenID, flow.Application.AppID)
+	if flow.DeviceUserCode() == "" {
+		return buildAuthCodeRedirectURL(flow.Request.RedirectURI, authCode), nil
+	}
+	if err := h.authorizeSvc.ApproveDeviceAuthorization(ctx, flow, authCode); err != nil {
+		return "", err
+	}
+	return buildDeviceApprovedURL(), nil
+}
+
+// consentDeniedURL 用户拒绝授权后的重定向地址
+// 设备授权 flow 标记拒绝后跳转验证页拒绝态；其他 flow 携带 error=access_denied 回跳 redirect_uri
+func (h *Handler) consentDeniedURL(ctx context.Context, flow *types.AuthFlow) (string, error) {
+	if flow.DeviceUserCode() == "" {
+		return buildErrorRedirectURL(flow.Request.RedirectURI, autherrors.CodeAccessDenied, flow.Request.State), nil
+	}
+	if err := h.authorizeSvc.DenyDeviceAuthorization(ctx, flow); err != nil {
+		return "", err
+	}
+	return buildDeviceStatusURL("denied"), nil
+}
+
 // authorizeAndGenerateCode 准备授权并生成授权码
 // 调用前需确保 flow 已通过 resolveUser 设置好 User 和 Identities
+// prompt=consent 或已有授权未覆盖本次请求时返回 errConsentRequired（flow 保持 authenticated）
 func (h *Handler) authorizeAndGenerateCode(ctx context.Context, flow *types.AuthFlow) (*cache.AuthorizationCode, error) {
 	// 1. 检查服务的身份要求
 	if err := h.authorizeSvc.CheckIdentityRequirements(ctx, flow); err != nil {
@@ -1558,16 +2397,34 @@ func (h *Handler) authorizeAndGenerateCode(ctx context.Context, flow *types.Auth
 		logger.Errorf("[Handler] 计算 scope 失败: %v", err)
 		return nil, err
 	}
-	flow.SetAuthorized(grantedScopes)
 
-	// 3. 生成授权码
-	authCode, err := h.authorizeSvc.GenerateAuthCode(ctx, flow)
+	// 3. 检查用户授权同意（authorization_details 不持久化为授权记录，每次均需用户确认）
+	// 设备授权必须由用户显式确认（RFC 8628 §5.4），即使已有 SSO 会话与授权记录，防止远程钓鱼
+	if flow.Request.Prompt.Contains(types.PromptConsent) || len(flow.AuthorizationDetails) > 0 || flow.DeviceUserCode() != "" {
+		return nil, errConsentRequired
+	}
+	covered, err := h.consentSvc.Covered(ctx, flow.User.OpenID, flow.Application.AppID, consent.Requested(flow, grantedScopes))
 	if err != nil {
-		logger.Errorf("[Handler] 生成授权码失败: %v", err)
-		return nil, autherrors.NewServerError(err.Error())
+		logger.Errorf("[Handler] 查询授权同意失败: %v", err)
+		return nil, autherrors.NewServerError("query consent failed")
+	}
+	if !covered {
+		return nil, errConsentRequired
 	}
 
-	return authCode, nil
+	return h.generateAuthCode(ctx, flow, grantedScopes)
+}
+
+// generateAuthCode 写入授权结果，经风险评估后生成授权码
+// 风险评估要求 MFA 时返回 errMFARequired（flow 进入 mfa 阶段，授权码在 MFA 完成后生成）
+func (h *Handler) generateAuthCode(ctx context.Context, flow *types.AuthFlow, grantedScopes []string) (*cache.AuthorizationCode, error) {
+	flow.SetAuthorized(grantedScopes)
+
+	rc := h.riskContext(ctx, flow)
+	if err := h.requireMFA(ctx, flow, rc); err != nil {
+		return nil, err
+	}
+	return h.issueAuthCode(ctx, flow, rc)
 }
 
 // --- 错误响应 ---
@@ -1704,6 +2561,7 @@ func getSSOCookie(c *gin.Context) (string, error) {
 // 根据 flow.State 决定跳转目标:
 //   - initialized -> login（需要登录）
 //   - authenticated -> consent（需要授权同意）
+//   - mfa -> mfa（需要完成第二因子）
 //   - authorized/completed -> 跳转回应用
 //   - failed -> login（前端通过 /auth/context 获取错误状态）
 func forwardNext(c *gin.Context, flow *types.AuthFlow) {
@@ -1713,6 +2571,9 @@ func forwardNext(c *gin.Context, flow *types.AuthFlow) {
 	case types.FlowStateAuthenticated:
 		targetURL = config.GetEndpointConsent()
 
+	case types.FlowStateMFA:
+		targetURL = config.GetEndpointMFA()
+
 	case types.FlowStateAuthorized, types.FlowStateCompleted:
 		targetURL = flow.Request.RedirectURI
 
@@ -1752,6 +2613,14 @@ func validateOAuthAuthorizationURL(connection, rawURL string, transaction *idp.O
 	if err != nil || u.Scheme != "https" || u.User != nil || u.Fragment != "" {
 		return errors.New("invalid oauth authorization URL")
 	}
+	if idp.IsSAMLConnection(connection) {
+		// SSO 地址来自管理员配置的 IdP 元数据，只校验 AuthnRequest 与 RelayState 绑定到本次 transaction
+		query := u.Query()
+		if query.Get("RelayState") != transaction.State || query.Get("SAMLRequest") == "" {
+			return errors.New("saml authn request URL does not match transaction")
+		}
+		return nil
+	}
 
 	if err := validateOAuthProviderEndpoint(connection, u); err != nil {
 		return err
@@ -1775,7 +2644,10 @@ func validateOAuthProviderEndpoint(connection string, u *url.URL) error {
 			return errors.New("unexpected github authorization endpoint")
 		}
 	default:
-		return errors.New("unsupported oauth connection")
+		// 通用 OIDC 的授权端点来自管理员配置（或上游发现文档），此处只要求 HTTPS（已在调用方校验）
+		if !idp.IsOIDCConnection(connection) {
+			return errors.New("unsupported oauth connection")
+		}
 	}
 	return nil
 }
@@ -1855,6 +2727,73 @@ func buildActionURL(actions []string) string {
 	return u.String()
 }
 
+// buildDiscoveryDocument 构建 OIDC Discovery 文档
+func buildDiscoveryDocument(issuer string) *DiscoveryDocument {
+	base := strings.TrimRight(issuer, "/") + "/auth"
+	return &DiscoveryDocument{
+		Issuer:                             issuer,
+		AuthorizationEndpoint:              base + "/authorize",
+		TokenEndpoint:                      base + "/token",
+		UserInfoEndpoint:                   base + "/userinfo",
+		RevocationEndpoint:                 base + "/revoke",
+		IntrospectionEndpoint:              base + "/introspect",
+		EndSessionEndpoint:                 base + "/logout",
+		PushedAuthorizationRequestEndpoint: base + "/par",
+		RegistrationEndpoint:               base + "/register",
+		RequestParameterSupported:          true,
+		DeviceAuthorizationEndpoint:        base + "/device/code",
+		PasetoKeysEndpoint:                 base + "/pubkeys",
+		DPoPSigningAlgValuesSupported:      dpop.SupportedAlgs,
+		ResponseTypesSupported:             []string{types.ResponseTypeCode},
+		GrantTypesSupported: []string{
+			authorize.GrantTypeAuthorizationCode,
+			authorize.GrantTypeRefreshToken,
+			authorize.GrantTypeClientCredentials,
+			authorize.GrantTypeTokenExchange,
+			authorize.GrantTypeDeviceCode,
+		},
+		ScopesSupported: []string{
+			authorize.ScopeOpenID,
+			authorize.ScopeProfile,
+			authorize.ScopeEmail,
+			authorize.ScopePhone,
+			authorize.ScopeOfflineAccess,
+		},
+		CodeChallengeMethodsSupported:     []string{"S256"},
+		PromptValuesSupported:             []string{types.PromptNone, types.PromptLogin, types.PromptConsent},
+		SubjectTypesSupported:             []string{"public"},
+		IDTokenSigningAlgValuesSupported:  []string{pkgtoken.PasetoVersion + "." + pkgtoken.PasetoPurpose},
+		ClaimsSupported:                   []string{"sub", "iss", "aud", "exp", "iat", "auth_time", "sid", "nonce", "nickname", "picture", "email", "phone_number"},
+		BackchannelLogoutSupported:        true,
+		BackchannelLogoutSessionSupported: true,
+	}
+}
+
+// buildDeviceApprovedURL 设备授权完成后跳转验证页的完成态
+func buildDeviceApprovedURL() string {
+	return buildDeviceStatusURL("approved")
+}
+
+func buildDeviceStatusURL(status string) string {
+	u, err := url.Parse(config.GetEndpointDevice())
+	if err != nil {
+		u = &url.URL{}
+	}
+	q := u.Query()
+	q.Set("status", status)
+	u.RawQuery = q.Encode()
+	return u.String()
+}
+
+// buildErrorRedirectURL 授权失败时回跳 redirect_uri（RFC 6749 §4.1.2.1）
+func buildErrorRedirectURL(redirectURI, code, state string) string {
+	location := redirectURI + "?error=" + url.QueryEscape(code)
+	if state != "" {
+		location += "&state=" + url.QueryEscape(state)
+	}
+	return location
+}
+
 func buildAuthCodeRedirectURL(redirectURI string, authCode *cache.AuthorizationCode) string {
 	location := redirectURI + "?code=" + url.QueryEscape(authCode.Code)
 	if authCode.State != "" {
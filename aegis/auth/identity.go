@@ -0,0 +1,79 @@
+package auth
+
+import (
+	"net/url"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/profile"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// --- 身份绑定 ---
+
+// completeIdentityBinding 完成 POST /user/identities/:idp 发起的跳转类身份绑定
+// 校验回调来自发起绑定的浏览器后，用授权码换取上游身份并绑定到发起绑定的用户，
+// 结果以 query 参数带回身份管理页（成功为 status=bound，失败为 error=<code>）
+func (h *Handler) completeIdentityBinding(c *gin.Context, connection string, transaction *idp.OAuthTransaction) {
+	state, err := c.Cookie(profile.BindingCookie)
+	profile.ClearBindingCookie(c)
+	if err != nil || state != transaction.State {
+		logger.Warnf("[OAuthCallback] 身份绑定浏览器不匹配 - OpenID: %s, Connection: %s", transaction.OpenID, connection)
+		browserRedirect(c, identityBindingURL(connection, autherrors.CodeAccessDenied))
+		return
+	}
+	if c.Query("error") != "" {
+		browserRedirect(c, identityBindingURL(connection, autherrors.CodeAccessDenied))
+		return
+	}
+	_, code := oauthCallbackParams(c, connection)
+	if code == "" {
+		browserRedirect(c, identityBindingURL(connection, autherrors.CodeInvalidRequest))
+		return
+	}
+
+	ctx := c.Request.Context()
+	provider, err := profile.ResolveIdentityProvider(connection)
+	if err != nil {
+		browserRedirect(c, identityBindingURL(connection, autherrors.ToAuthError(err).Code))
+		return
+	}
+	identity, err := provider.Identify(ctx, transaction.ClientID, code, &idp.OAuthLoginContext{
+		RedirectURI:  transaction.RedirectURI,
+		CodeVerifier: transaction.CodeVerifier,
+		Nonce:        transaction.Nonce,
+	})
+	if err != nil {
+		logger.Errorf("[OAuthCallback] 身份绑定上游认证失败 - OpenID: %s, Connection: %s, Error: %v", transaction.OpenID, connection, err)
+		browserRedirect(c, identityBindingURL(connection, "provider_failed"))
+		return
+	}
+	if err := h.userSvc.BindIdentity(ctx, transaction.OpenID, identity); err != nil {
+		logger.Warnf("[OAuthCallback] 身份绑定失败 - OpenID: %s, Connection: %s, Error: %v", transaction.OpenID, connection, err)
+		browserRedirect(c, identityBindingURL(connection, autherrors.ToAuthError(err).Code))
+		return
+	}
+
+	logger.Infof("[OAuthCallback] 身份绑定成功 - OpenID: %s, Connection: %s", transaction.OpenID, connection)
+	browserRedirect(c, identityBindingURL(connection, ""))
+}
+
+// identityBindingURL 身份绑定结果页地址，errCode 为空表示绑定成功
+func identityBindingURL(connection, errCode string) string {
+	u, err := url.Parse(config.GetEndpointIdentity())
+	if err != nil {
+		u = &url.URL{Path: config.DefaultAegisEndpointIdentity}
+	}
+	query := u.Query()
+	query.Set("idp", connection)
+	if errCode != "" {
+		query.Set("error", errCode)
+	} else {
+		query.Set("status", "bound")
+	}
+	u.RawQuery = query.Encode()
+	return u.String()
+}
@@ -0,0 +1,194 @@
+package auth
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/mfa"
+	"github.com/heliannuuthus/aegis/internal/risk"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// deviceIDLength 设备标识长度
+const deviceIDLength = 32
+
+// --- MFA ---
+
+// GetMFAContext GET /auth/mfa
+// 获取 MFA 阶段上下文：允许的第二因子与发起 Challenge 使用的 channel
+func (h *Handler) GetMFAContext(c *gin.Context) {
+	ctx := c.Request.Context()
+	flow := h.loadAuthFlow(c, ctx)
+	if flow == nil {
+		return
+	}
+	defer h.saveFlow(ctx, flow)
+
+	if flow.State != types.FlowStateMFA || flow.User == nil {
+		h.errorResponse(c, autherrors.NewFlowInvalid("flow is not awaiting mfa"))
+		return
+	}
+
+	c.JSON(http.StatusOK, &MFAContextResponse{
+		Status:          "mfa_required",
+		FlowID:          flow.ID,
+		AllowedChannels: flow.MFAAllowedChannels,
+		Type:            mfa.BizTypeMFA,
+		Channel:         flow.User.OpenID,
+		ExpiresIn:       max(int(time.Until(flow.MFAExpiresAt).Seconds()), 0),
+	})
+}
+
+// CompleteMFA POST /auth/mfa/complete
+// 提交第二因子的 ChallengeToken：校验通过后签发授权码并回跳应用，
+// 超时或失败次数达到上限时 flow 失效，需重新登录
+func (h *Handler) CompleteMFA(c *gin.Context) {
+	var req MFACompleteRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	ctx := requestContext(c)
+	flow := h.loadAuthFlow(c, ctx)
+	if flow == nil {
+		return
+	}
+	defer h.saveFlow(ctx, flow)
+
+	if flow.State != types.FlowStateMFA || flow.User == nil {
+		h.errorResponse(c, autherrors.NewFlowInvalid("flow is not awaiting mfa"))
+		return
+	}
+	if flow.MFAExpired() {
+		flow.Fail(autherrors.NewFlowExpired("mfa stage expired"))
+		h.flowErrorResponse(c, flow)
+		return
+	}
+
+	if err := h.mfaStage.Complete(ctx, flow, req.ChallengeToken); err != nil {
+		flow.MFAAttempts++
+		if flow.MFAAttempts >= config.GetMFAMaxAttempts() {
+			logger.Warnf("[MFA] 尝试次数达到上限 - FlowID: %s, OpenID: %s", flow.ID, flow.User.OpenID)
+			flow.Fail(autherrors.NewAccessDenied("too many mfa attempts"))
+		}
+		h.errorResponse(c, err)
+		return
+	}
+	flow.CompleteMFA()
+
+	authCode, err := h.issueAuthCode(ctx, flow, h.riskContext(ctx, flow))
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	h.issueSSOCookie(c, ctx, flow)
+	location, err := h.authCodeRedirectURL(ctx, flow, authCode)
+	if err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	clearAuthSessionCookie(c)
+	actionRedirect(c, location)
+}
+
+// --- MFA 引用链 ---
+
+// requireMFA 风险评估：需要追加第二因子时将 flow 置为 mfa 阶段并返回 errMFARequired
+// 未启用风险评估（rc 为 nil）或本 flow 已完成 MFA 时直接放行
+func (h *Handler) requireMFA(ctx context.Context, flow *types.AuthFlow, rc *risk.Context) error {
+	if rc == nil || flow.MFACompletedAt != nil {
+		return nil
+	}
+
+	assessment, channels, err := h.mfaStage.Evaluate(ctx, flow, rc)
+	if err != nil {
+		var authErr *autherrors.AuthError
+		if errors.As(err, &authErr) {
+			return authErr
+		}
+		logger.Errorf("[Handler] 风险评估失败 - FlowID: %s, Error: %v", flow.ID, err)
+		return autherrors.NewServerError("evaluate login risk failed")
+	}
+	flow.RiskLevel = string(assessment.Level)
+	flow.RiskReason = assessment.Reason
+	if len(channels) == 0 {
+		return nil
+	}
+
+	flow.RequireMFA(channels, string(assessment.Level), assessment.Reason, config.GetMFAExpiresIn())
+	logger.Infof("[Handler] 风险评估要求 MFA - FlowID: %s, OpenID: %s, Level: %s, Reason: %s, Channels: %v",
+		flow.ID, rc.UserID, assessment.Level, assessment.Reason, channels)
+	return errMFARequired
+}
+
+// issueAuthCode 生成授权码，启用风险评估时记录本次登录基线
+func (h *Handler) issueAuthCode(ctx context.Context, flow *types.AuthFlow, rc *risk.Context) (*cache.AuthorizationCode, error) {
+	authCode, err := h.authorizeSvc.GenerateAuthCode(ctx, flow)
+	if err != nil {
+		logger.Errorf("[Handler] 生成授权码失败: %v", err)
+		return nil, autherrors.NewServerError(err.Error())
+	}
+	if rc != nil {
+		h.mfaStage.Record(ctx, rc)
+	}
+	return authCode, nil
+}
+
+// riskContext 构建风险评估上下文，未启用风险评估时返回 nil
+func (h *Handler) riskContext(ctx context.Context, flow *types.AuthFlow) *risk.Context {
+	if !config.GetMFARiskEnabled() || flow.User == nil || flow.Application == nil {
+		return nil
+	}
+	loginMethod := flow.Connection
+	if strategy := flow.GetExtra(types.ExtraKeyStrategy); strategy != "" {
+		loginMethod += ":" + strategy
+	}
+	return &risk.Context{
+		UserID:        flow.User.OpenID,
+		ClientID:      flow.Application.AppID,
+		LoginMethod:   loginMethod,
+		IP:            helpers.RemoteIPFrom(ctx),
+		DeviceID:      risk.DeviceIDFrom(ctx),
+		LastLoginAt:   flow.User.LastLoginAt,
+		FailurePolicy: h.authenticateSvc.FailurePolicy(flow, flow.GetExtra(types.ExtraKeyPrincipal)),
+	}
+}
+
+// requestContext 为请求上下文附加风险评估所需的客户端信号（IP、设备标识）
+func requestContext(c *gin.Context) context.Context {
+	ctx := helpers.WithRemoteIP(c.Request.Context(), c.ClientIP())
+	return risk.WithDeviceID(ctx, deviceID(c))
+}
+
+// deviceID 读取设备标识 Cookie；启用风险评估且 Cookie 不存在时签发新的设备标识
+func deviceID(c *gin.Context) string {
+	if !config.GetMFARiskEnabled() {
+		return ""
+	}
+	if id, err := c.Cookie(config.GetMFADeviceCookieName()); err == nil && id != "" {
+		return id
+	}
+	id := helpers.GenerateID(deviceIDLength)
+	cookie := &http.Cookie{ // #nosec G124 -- secure cookie flags default to true and are controlled by deployment config.
+		Name:     config.GetMFADeviceCookieName(),
+		Value:    id,
+		MaxAge:   int(config.GetMFARiskBaselineTTL().Seconds()),
+		Path:     config.GetCookiePath(),
+		Domain:   config.GetCookieDomain(),
+		Secure:   config.GetCookieSecure(),
+		HttpOnly: true,
+		SameSite: http.SameSiteLaxMode,
+	}
+	http.SetCookie(c.Writer, cookie)
+	return id
+}
@@ -40,6 +40,34 @@ func TestValidateOAuthAuthorizationURL(t *testing.T) {
 			connection: idp.TypeGithub,
 			rawURL:     "https://github.com/login/oauth/authorize?" + query.Encode(),
 		},
+		{
+			name:       "oidc transaction",
+			connection: idp.TypeOIDCPrefix + "keycloak",
+			rawURL:     "https://sso.example/realms/main/protocol/openid-connect/auth?" + query.Encode(),
+		},
+		{
+			name:       "oidc plain http endpoint",
+			connection: idp.TypeOIDCPrefix + "keycloak",
+			rawURL:     "http://sso.example/realms/main/protocol/openid-connect/auth?" + query.Encode(),
+			wantErr:    true,
+		},
+		{
+			name:       "saml transaction",
+			connection: idp.TypeSAMLPrefix + "acme",
+			rawURL:     "https://idp.example/saml/sso?" + url.Values{"SAMLRequest": {"request"}, "RelayState": {tx.State}}.Encode(),
+		},
+		{
+			name:       "saml mismatched relay state",
+			connection: idp.TypeSAMLPrefix + "acme",
+			rawURL:     "https://idp.example/saml/sso?" + url.Values{"SAMLRequest": {"request"}, "RelayState": {"other"}}.Encode(),
+			wantErr:    true,
+		},
+		{
+			name:       "unknown connection",
+			connection: "gitlab",
+			rawURL:     "https://gitlab.com/oauth/authorize?" + query.Encode(),
+			wantErr:    true,
+		},
 		{
 			name:       "lookalike host",
 			connection: idp.TypeGoogle,
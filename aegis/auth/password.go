@@ -0,0 +1,43 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/pkg/helpers"
+)
+
+// --- 密码重置 ---
+
+// ResetPasswordRequest 密码重置请求（应用由 aegis-session Cookie 对应的 AuthFlow 确定）
+type ResetPasswordRequest struct {
+	Connection     string `json:"connection" binding:"required"`
+	ChallengeToken string `json:"challenge_token" binding:"required"`
+	Password       string `json:"password" binding:"required"`
+}
+
+// ResetPassword POST /auth/password/reset
+// 用户先以 forget_password 场景完成 email-code / sms-code Challenge，再携带 ChallengeToken 与新密码重置密码；
+// ChallengeToken 须由当前登录会话的应用申请（不信任请求体中的 client_id）；成功后该用户全部 token 与会话失效，需重新登录
+func (h *Handler) ResetPassword(c *gin.Context) {
+	var req ResetPasswordRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.errorResponse(c, autherrors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	ctx := helpers.WithRemoteIP(c.Request.Context(), c.ClientIP())
+	flow := h.loadAuthFlow(c, ctx)
+	if flow == nil {
+		return
+	}
+	defer h.saveFlow(ctx, flow)
+
+	if err := h.passwordSvc.Reset(ctx, flow, req.Connection, req.ChallengeToken, req.Password); err != nil {
+		h.errorResponse(c, err)
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
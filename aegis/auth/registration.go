@@ -0,0 +1,107 @@
+package auth
+
+import (
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/registration"
+	"github.com/heliannuuthus/aegis/models"
+	pkgtoken "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// RegisterClient POST /auth/register
+// 动态客户端注册（RFC 7591，初始访问令牌认证）：创建应用、应用密钥与 IDP 配置，
+// 返回 client_secret（应用 seed）与 registration_access_token，二者仅在此返回一次
+func (h *Handler) RegisterClient(c *gin.Context) {
+	domainID, ok := h.registrationSvc.AuthorizeInitialAccess(bearerToken(c.GetHeader(HeaderAuthorization)))
+	if !ok {
+		c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_token"`)
+		h.tokenErrorResponse(c, autherrors.NewInvalidToken("invalid initial access token"))
+		return
+	}
+
+	var meta registration.ClientMetadata
+	if err := c.ShouldBindJSON(&meta); err != nil {
+		h.tokenErrorResponse(c, autherrors.NewInvalidClientMetadata(err.Error()))
+		return
+	}
+
+	info, authErr := h.registrationSvc.Register(c.Request.Context(), domainID, &meta)
+	if authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusCreated, info)
+}
+
+// GetRegisteredClient GET /auth/register/:client_id
+// 读取客户端配置（RFC 7592 §2.1，registration_access_token 认证）
+func (h *Handler) GetRegisteredClient(c *gin.Context) {
+	reg, ok := h.authenticateRegistration(c)
+	if !ok {
+		return
+	}
+	info, authErr := h.registrationSvc.Get(c.Request.Context(), reg)
+	if authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, info)
+}
+
+// UpdateRegisteredClient PUT /auth/register/:client_id
+// 整体替换客户端配置（RFC 7592 §2.2，registration_access_token 认证），请求中缺省的字段将被清空
+func (h *Handler) UpdateRegisteredClient(c *gin.Context) {
+	reg, ok := h.authenticateRegistration(c)
+	if !ok {
+		return
+	}
+
+	var req registration.UpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		h.tokenErrorResponse(c, autherrors.NewInvalidClientMetadata(err.Error()))
+		return
+	}
+
+	info, authErr := h.registrationSvc.Update(c.Request.Context(), reg, &req)
+	if authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	c.Header("Cache-Control", "no-store")
+	c.Header("Pragma", "no-cache")
+	c.JSON(http.StatusOK, info)
+}
+
+// DeleteRegisteredClient DELETE /auth/register/:client_id
+// 注销客户端（RFC 7592 §2.3，registration_access_token 认证）
+func (h *Handler) DeleteRegisteredClient(c *gin.Context) {
+	reg, ok := h.authenticateRegistration(c)
+	if !ok {
+		return
+	}
+	if authErr := h.registrationSvc.Delete(c.Request.Context(), reg); authErr != nil {
+		h.tokenErrorResponse(c, authErr)
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
+// authenticateRegistration 校验 registration_access_token，失败时写入 401 响应
+func (h *Handler) authenticateRegistration(c *gin.Context) (*models.Application, bool) {
+	reg, authErr := h.registrationSvc.Authenticate(c.Request.Context(), c.Param("client_id"), bearerToken(c.GetHeader(HeaderAuthorization)))
+	if authErr != nil {
+		if authErr.HTTPStatus == http.StatusUnauthorized {
+			c.Header("WWW-Authenticate", pkgtoken.TokenTypeBearer+` error="invalid_token"`)
+		}
+		h.tokenErrorResponse(c, authErr)
+		return nil, false
+	}
+	return reg, true
+}
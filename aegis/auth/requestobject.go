@@ -0,0 +1,106 @@
+package auth
+
+import (
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin/binding"
+	"github.com/go-json-experiment/json"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/types"
+)
+
+// requestObjectRegisteredClaims 请求对象中的 PASETO 注册声明，不作为授权参数合并
+var requestObjectRegisteredClaims = map[string]bool{
+	"iss": true,
+	"aud": true,
+	"sub": true,
+	"exp": true,
+	"iat": true,
+	"nbf": true,
+	"jti": true,
+	"typ": true,
+}
+
+// requestObjectOuterParams 携带请求对象时仍读取的外层参数（RFC 9101 §5：OAuth 2.0 要求的 client_id / response_type）
+var requestObjectOuterParams = []string{"client_id", "response_type"}
+
+// mergeRequestObject 以已验签的请求对象作为授权参数（JAR，RFC 9101 §6.3）
+//   - 外层未签名参数除 client_id / response_type 外一律忽略，防止篡改签名请求
+//   - client_id / response_type 不得重复出现，且与请求对象中的取值必须一致
+//   - 请求对象不得嵌套 request / request_uri
+func mergeRequestObject(outer url.Values, claims map[string]any) (*types.AuthRequest, *autherrors.AuthError) {
+	merged := make(map[string]any, len(claims)+len(requestObjectOuterParams))
+	for _, k := range requestObjectOuterParams {
+		vs := outer[k]
+		if len(vs) == 0 {
+			continue
+		}
+		if len(vs) > 1 {
+			return nil, autherrors.NewInvalidRequestf("duplicate parameter: %s", k)
+		}
+		merged[k] = vs[0]
+	}
+
+	for k, v := range claims {
+		if requestObjectRegisteredClaims[k] {
+			continue
+		}
+		if k == "request" || k == "request_uri" {
+			return nil, autherrors.NewInvalidRequestObject(k + " must not be nested in request object")
+		}
+		if outerValue, ok := merged[k].(string); ok {
+			if s, scalar := claimString(v); !scalar || s != outerValue {
+				return nil, autherrors.NewInvalidRequestf("parameter %s conflicts with request object", k)
+			}
+		}
+		merged[k] = v
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return nil, autherrors.NewInvalidRequestObject("malformed request object")
+	}
+	var req types.AuthRequest
+	if err := json.Unmarshal(data, &req); err != nil {
+		return nil, autherrors.NewInvalidRequestObject("malformed request object: " + err.Error())
+	}
+	if err := binding.Validator.ValidateStruct(&req); err != nil {
+		return nil, autherrors.NewInvalidRequest(err.Error())
+	}
+	return &req, nil
+}
+
+// claimString 标量 claim 的字符串形式（用于与外层参数比较），非标量返回 false
+func claimString(v any) (string, bool) {
+	switch val := v.(type) {
+	case string:
+		return val, true
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64), true
+	case bool:
+		return strconv.FormatBool(val), true
+	default:
+		return "", false
+	}
+}
+
+// requestObjectReplayWindow 从请求对象 claims 中取出 jti 与剩余有效期（用于一次性使用标记）
+func requestObjectReplayWindow(claims map[string]any, now time.Time) (string, time.Duration, *autherrors.AuthError) {
+	jti, _ := claims["jti"].(string)
+	if jti == "" {
+		return "", 0, autherrors.NewInvalidRequestObject("request object jti is required")
+	}
+	raw, _ := claims["exp"].(string)
+	exp, err := time.Parse(time.RFC3339, raw)
+	if err != nil {
+		return "", 0, autherrors.NewInvalidRequestObject("request object exp is required")
+	}
+	ttl := exp.Sub(now)
+	if ttl <= 0 {
+		return "", 0, autherrors.NewInvalidRequestObject("request object expired")
+	}
+	return jti, ttl, nil
+}
@@ -0,0 +1,142 @@
+package auth
+
+import (
+	"net/url"
+	"testing"
+	"time"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+)
+
+func TestMergeRequestObject(t *testing.T) {
+	t.Parallel()
+
+	claims := func() map[string]any {
+		return map[string]any{
+			"iss":                   "app",
+			"aud":                   "https://aegis.heliannuuthus.com/api",
+			"exp":                   "2099-01-01T00:00:00Z",
+			"typ":                   "oauth-authz-req",
+			"client_id":             "app",
+			"response_type":         "code",
+			"redirect_uri":          "https://app.example/callback",
+			"code_challenge":        "challenge",
+			"code_challenge_method": "S256",
+			"scope":                 "openid profile",
+			"max_age":               float64(300),
+			"audiences": map[string]any{
+				"iris": map[string]any{"scope": "iris:read"},
+			},
+			"authorization_details": []any{
+				map[string]any{"type": "recipe", "actions": []any{"read"}},
+			},
+		}
+	}
+
+	t.Run("request object is authoritative and outer parameters are ignored", func(t *testing.T) {
+		t.Parallel()
+		outer := url.Values{
+			"client_id":    {"app"},
+			"request":      {"v4.public.x"},
+			"state":        {"xyz"},
+			"redirect_uri": {"https://evil.example/callback"},
+		}
+		req, authErr := mergeRequestObject(outer, claims())
+		if authErr != nil {
+			t.Fatalf("mergeRequestObject() error = %v", authErr)
+		}
+		if req.ClientID != "app" || req.RedirectURI != "https://app.example/callback" || req.State != "" {
+			t.Fatalf("merged request = %+v", req)
+		}
+		if req.MaxAge == nil || *req.MaxAge != 300 {
+			t.Fatalf("max_age = %v", req.MaxAge)
+		}
+		if req.Audiences["iris"] == nil || req.Audiences["iris"].Scope != "iris:read" {
+			t.Fatalf("audiences = %v", req.Audiences)
+		}
+		if len(req.AuthorizationDetails) != 1 || req.AuthorizationDetails[0].Type() != "recipe" {
+			t.Fatalf("authorization_details = %v", req.AuthorizationDetails)
+		}
+		if len(req.Params) != 0 {
+			t.Fatalf("registered claims leaked into params: %v", req.Params)
+		}
+	})
+
+	tests := []struct {
+		name   string
+		outer  url.Values
+		mutate func(map[string]any)
+		code   string
+	}{
+		{
+			name:  "matching duplicate is allowed",
+			outer: url.Values{"client_id": {"app"}, "response_type": {"code"}},
+		},
+		{
+			name:  "conflicting value",
+			outer: url.Values{"client_id": {"app"}, "response_type": {"token"}},
+			code:  autherrors.CodeInvalidRequest,
+		},
+		{
+			name:  "repeated outer parameter",
+			outer: url.Values{"client_id": {"app", "app"}},
+			code:  autherrors.CodeInvalidRequest,
+		},
+		{
+			name:  "repeated ignored parameter",
+			outer: url.Values{"client_id": {"app"}, "state": {"a", "b"}},
+		},
+		{
+			name:   "nested request_uri",
+			outer:  url.Values{"client_id": {"app"}},
+			mutate: func(c map[string]any) { c["request_uri"] = "urn:ietf:params:oauth:request_uri:x" },
+			code:   autherrors.CodeInvalidRequestObject,
+		},
+		{
+			name:   "missing required parameter",
+			outer:  url.Values{"client_id": {"app"}},
+			mutate: func(c map[string]any) { delete(c, "redirect_uri") },
+			code:   autherrors.CodeInvalidRequest,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			c := claims()
+			if tt.mutate != nil {
+				tt.mutate(c)
+			}
+			_, authErr := mergeRequestObject(tt.outer, c)
+			switch {
+			case tt.code == "" && authErr != nil:
+				t.Fatalf("mergeRequestObject() error = %v", authErr)
+			case tt.code != "" && (authErr == nil || authErr.Code != tt.code):
+				t.Fatalf("mergeRequestObject() error = %v, want %s", authErr, tt.code)
+			}
+		})
+	}
+}
+
+func TestRequestObjectReplayWindow(t *testing.T) {
+	t.Parallel()
+
+	now := time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC)
+	jti, ttl, authErr := requestObjectReplayWindow(map[string]any{"jti": "abc", "exp": "2026-01-01T00:05:00Z"}, now)
+	if authErr != nil || jti != "abc" || ttl != 5*time.Minute {
+		t.Fatalf("requestObjectReplayWindow() = %q, %v, %v", jti, ttl, authErr)
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]any
+	}{
+		{name: "missing jti", claims: map[string]any{"exp": "2026-01-01T00:05:00Z"}},
+		{name: "missing exp", claims: map[string]any{"jti": "abc"}},
+		{name: "expired", claims: map[string]any{"jti": "abc", "exp": "2025-12-31T23:59:00Z"}},
+	}
+	for _, tt := range tests {
+		if _, _, authErr := requestObjectReplayWindow(tt.claims, now); authErr == nil || authErr.Code != autherrors.CodeInvalidRequestObject {
+			t.Errorf("%s: error = %v, want %s", tt.name, authErr, autherrors.CodeInvalidRequestObject)
+		}
+	}
+}
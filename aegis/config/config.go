@@ -22,6 +22,8 @@ const (
 	DefaultAegisEndpointConsent  = "/consent"
 	DefaultAegisEndpointMFA      = "/mfa"
 	DefaultAegisEndpointCallback = "/callback"
+	DefaultAegisEndpointDevice   = "/device"
+	DefaultAegisEndpointIdentity = "/identities"
 
 	// Cache 默认值
 	DefaultAegisCacheSize       = int64(1000)
@@ -39,6 +41,17 @@ const (
 	DefaultAegisTOTPEnrollmentExpiresIn = 5 * time.Minute
 	DefaultAegisRefreshTokenExpiresIn   = 7 * 24 * time.Hour
 	DefaultAegisPublicKeyCacheMaxAge    = 3 * time.Hour
+	DefaultAegisDeviceCodeExpiresIn     = 10 * time.Minute
+	DefaultAegisDevicePollInterval      = 5 * time.Second
+	DefaultAegisPARExpiresIn            = 60 * time.Second
+	DefaultAegisRevocationListMaxAge    = 30 * time.Second
+	DefaultAegisRevocationSubjectTTL    = 24 * time.Hour
+
+	// 后端通道登出默认值
+	DefaultAegisBackchannelLogoutMaxAttempts   = 3
+	DefaultAegisBackchannelLogoutRetryInterval = 2 * time.Second
+	DefaultAegisBackchannelLogoutTimeout       = 5 * time.Second
+	DefaultAegisBackchannelLogoutLogTTL        = 7 * 24 * time.Hour
 )
 
 // Cfg 返回 Aegis 配置单例
@@ -67,6 +80,9 @@ func Validate() error {
 			errs = append(errs, fmt.Errorf("必需配置 %s 未设置", key))
 		}
 	}
+	if GetSMSConfig().Provider == SMSProviderHTTP && strings.TrimSpace(Cfg().GetString("sms.http.endpoint")) == "" {
+		errs = append(errs, fmt.Errorf("sms.provider 为 http 时必需配置 sms.http.endpoint"))
+	}
 	if _, err := GetSSOMasterKeys(); err != nil {
 		errs = append(errs, err)
 	}
@@ -184,6 +200,24 @@ func GetEndpointCallback() string {
 	return endpoint
 }
 
+// GetEndpointDevice 获取设备授权验证端点（用户输入 user_code 的页面）
+func GetEndpointDevice() string {
+	endpoint := Cfg().GetString("aegis.endpoint/device")
+	if endpoint == "" {
+		return DefaultAegisEndpointDevice
+	}
+	return endpoint
+}
+
+// GetEndpointIdentity 获取身份绑定结果页（OAuth 绑定回调完成后跳转）
+func GetEndpointIdentity() string {
+	endpoint := Cfg().GetString("aegis.endpoint/identity")
+	if endpoint == "" {
+		return DefaultAegisEndpointIdentity
+	}
+	return endpoint
+}
+
 // GetIDPRedirectURI 获取上游 OAuth Provider 的固定回调地址。
 // 回调地址只来自服务端配置，禁止由浏览器请求覆盖。
 func GetIDPRedirectURI(connection string) string {
@@ -194,6 +228,11 @@ func GetIDPRedirectURI(connection string) string {
 	return strings.TrimRight(GetEndpoint(), "/") + "/" + connection + "/callback"
 }
 
+// GetSAMLEntityID 获取应用在 SAML connection 下的 SP EntityID（同时是 SP 元数据地址）
+func GetSAMLEntityID(connection, appID string) string {
+	return strings.TrimRight(GetIssuer(), "/") + "/auth/idps/" + connection + "/metadata/" + url.PathEscape(appID)
+}
+
 // ==================== Cache 配置 ====================
 
 // GetCacheKeyPrefix 获取缓存 key 前缀
@@ -207,6 +246,12 @@ func GetCacheKeyPrefix(cacheType string) string {
 		"oauth_state":                  "auth:oauth:state:",
 		"refresh_token":                "auth:rt:",
 		"user_token":                   "auth:user:rt:",
+		"refresh_token_family":         "auth:rt:family:",
+		"refresh_token_used":           "auth:rt:used:",
+		"revoked_jti":                  "auth:revoked:jti:",
+		"revoked_subject":              "auth:revoked:sub:",
+		"subject_audiences":            "auth:revoked:aud:",
+		"dpop_jti":                     "auth:dpop:jti:",
 		"otp":                          "auth:otp:",
 		"challenge":                    "auth:ch:",
 		"totp_enrollment":              "totp:enrollment:",
@@ -219,6 +264,19 @@ func GetCacheKeyPrefix(cacheType string) string {
 		"application-service-relation": "app-svc-rel:",
 		"app-service":                  "app-svc:",
 		"challenge-config":             "ch-cfg:",
+		"authorization-detail-type":    "authz-detail-type:",
+		"device_code":                  "auth:device:code:",
+		"user_code":                    "auth:device:user:",
+		"device_poll":                  "auth:device:poll:",
+		"par":                          "auth:par:",
+		"sso_session":                  "auth:sso:session:",
+		"sso_user_session":             "auth:sso:user:",
+		"logout_delivery":              "auth:logout:delivery:",
+		"login_baseline":               "auth:risk:baseline:",
+		"totp_step":                    "auth:mfa:totp:step:",
+		"challenge_token_used":         "auth:ch-token:used:",
+		"request_object_used":          "auth:ro:used:",
+		"saml_assertion_used":          "auth:saml:used:",
 	}
 	if prefix, ok := defaultPrefixes[cacheType]; ok {
 		return prefix
@@ -321,6 +379,63 @@ func GetRefreshTokenExpiresIn() time.Duration {
 	return DefaultAegisRefreshTokenExpiresIn
 }
 
+// GetDeviceCodeExpiresIn 获取 DeviceCode 过期时间
+func GetDeviceCodeExpiresIn() time.Duration {
+	if val := Cfg().GetDuration("aegis.cache.device_code.expires_in"); val > 0 {
+		return val
+	}
+	return DefaultAegisDeviceCodeExpiresIn
+}
+
+// GetDevicePollInterval 获取设备轮询 token 的最小间隔
+func GetDevicePollInterval() time.Duration {
+	if val := Cfg().GetDuration("aegis.cache.device_code.interval"); val >= time.Second {
+		return val
+	}
+	return DefaultAegisDevicePollInterval
+}
+
+// 设备验证页 user_code 猜测限制默认值
+const (
+	DefaultDeviceVerifyFailThreshold = 10               // 窗口内输错 10 次后限流
+	DefaultDeviceVerifyFailWindow    = 15 * time.Minute // 错误计数窗口
+)
+
+// DefaultSmsOTPFailThreshold 单个短信验证码允许输错的次数，达到后作废
+const DefaultSmsOTPFailThreshold = 5
+
+// GetSmsOTPFailThreshold 获取短信验证码输错次数上限（按 challenge 计数，窗口与验证码有效期一致）
+func GetSmsOTPFailThreshold() int {
+	if val := Cfg().GetInt("aegis.challenge.access-control.sms-code.fail-threshold"); val > 0 {
+		return val
+	}
+	return DefaultSmsOTPFailThreshold
+}
+
+// GetDeviceVerifyFailThreshold 获取设备验证页 user_code 错误次数阈值（按 IP 与会话分别计数）
+func GetDeviceVerifyFailThreshold() int {
+	if val := Cfg().GetInt("aegis.device.access-control.fail-threshold"); val > 0 {
+		return val
+	}
+	return DefaultDeviceVerifyFailThreshold
+}
+
+// GetDeviceVerifyFailWindow 获取设备验证页 user_code 错误计数窗口
+func GetDeviceVerifyFailWindow() time.Duration {
+	if val := Cfg().GetDuration("aegis.device.access-control.fail-window"); val > 0 {
+		return val
+	}
+	return DefaultDeviceVerifyFailWindow
+}
+
+// GetPARExpiresIn 获取 PAR request_uri 过期时间
+func GetPARExpiresIn() time.Duration {
+	if val := Cfg().GetDuration("aegis.cache.par.expires_in"); val > 0 {
+		return val
+	}
+	return DefaultAegisPARExpiresIn
+}
+
 // GetPublicKeyCacheMaxAge 获取公钥缓存最大时间
 func GetPublicKeyCacheMaxAge() time.Duration {
 	if val := Cfg().GetDuration("aegis.cache.public_key.max_age"); val > 0 {
@@ -329,6 +444,65 @@ func GetPublicKeyCacheMaxAge() time.Duration {
 	return DefaultAegisPublicKeyCacheMaxAge
 }
 
+// GetRevocationListMaxAge 获取吊销列表缓存时间（资源服务按此间隔轮询）
+func GetRevocationListMaxAge() time.Duration {
+	if val := Cfg().GetDuration("aegis.revocation.max_age"); val >= time.Second {
+		return val
+	}
+	return DefaultAegisRevocationListMaxAge
+}
+
+// GetRevocationSubjectTTL 获取按用户吊销条目的保留时间（应不短于最长的 access token 有效期）
+func GetRevocationSubjectTTL() time.Duration {
+	if val := Cfg().GetDuration("aegis.revocation.subject_ttl"); val > 0 {
+		return val
+	}
+	return DefaultAegisRevocationSubjectTTL
+}
+
+// GetBackchannelLogoutMaxAttempts 获取后端通道登出通知的最大投递次数（含首次）
+func GetBackchannelLogoutMaxAttempts() int {
+	if val := Cfg().GetInt("aegis.backchannel_logout.max_attempts"); val > 0 {
+		return val
+	}
+	return DefaultAegisBackchannelLogoutMaxAttempts
+}
+
+// GetBackchannelLogoutRetryInterval 获取后端通道登出通知的首次重试间隔（之后按指数退避）
+func GetBackchannelLogoutRetryInterval() time.Duration {
+	if val := Cfg().GetDuration("aegis.backchannel_logout.retry_interval"); val > 0 {
+		return val
+	}
+	return DefaultAegisBackchannelLogoutRetryInterval
+}
+
+// GetBackchannelLogoutTimeout 获取单次投递的 HTTP 超时
+func GetBackchannelLogoutTimeout() time.Duration {
+	if val := Cfg().GetDuration("aegis.backchannel_logout.timeout"); val > 0 {
+		return val
+	}
+	return DefaultAegisBackchannelLogoutTimeout
+}
+
+// GetBackchannelLogoutAllowPrivateNetworks 是否允许向回环、链路本地与私有地址投递登出通知（默认禁止，防止 SSRF）
+// 仅在管理员配置的应用部署于内网时开启
+func GetBackchannelLogoutAllowPrivateNetworks() bool {
+	return Cfg().GetBool("aegis.backchannel_logout.allow_private_networks")
+}
+
+// GetBackchannelLogoutLogTTL 获取投递记录的保留时间
+func GetBackchannelLogoutLogTTL() time.Duration {
+	if val := Cfg().GetDuration("aegis.backchannel_logout.log_ttl"); val > 0 {
+		return val
+	}
+	return DefaultAegisBackchannelLogoutLogTTL
+}
+
+// GetRegistrationInitialAccessTokens 获取动态客户端注册的初始访问令牌（domain_id -> 令牌 SHA-256 摘要列表，十六进制）
+func GetRegistrationInitialAccessTokens() map[string][]string {
+	return Cfg().GetStringMapStringSlice("aegis.registration.initial-access-tokens")
+}
+
 // GetMailConfig 获取邮件配置
 func GetMailConfig() *MailConfig {
 	c := Cfg()
@@ -486,6 +660,290 @@ func GetLoginACFailWindow(connection string) time.Duration {
 	return DefaultLoginACFailWindow
 }
 
+// Login 锁定默认值
+const (
+	DefaultLoginLockoutFreeAttempts   = 3                // 窗口内前 3 次失败不退避
+	DefaultLoginLockoutBaseDelay      = time.Second      // 首次退避 1 秒，此后每次失败翻倍
+	DefaultLoginLockoutMaxDelay       = 5 * time.Minute  // 退避上限
+	DefaultLoginLockoutThreshold      = 10               // 窗口内失败 10 次锁定账号
+	DefaultLoginLockoutDuration       = 30 * time.Minute // 锁定时长
+	DefaultLoginLockoutFailWindow     = time.Hour        // 失败计数窗口
+	DefaultLoginLockoutIPFreeAttempts = 20               // 同一 IP 窗口内前 20 次失败不退避
+)
+
+// LoginLockout 账号密码登录的锁定策略
+type LoginLockout struct {
+	FreeAttempts   int           // 每个标识不退避的失败次数
+	BaseDelay      time.Duration // 首次退避时长
+	MaxDelay       time.Duration // 退避上限
+	Threshold      int           // 每个标识触发锁定的失败次数（0 = 不锁定）
+	Duration       time.Duration // 锁定时长
+	FailWindow     time.Duration // 失败计数窗口
+	IPFreeAttempts int           // 每个 IP 不退避的失败次数（IP 维度只退避不锁定）
+}
+
+// GetLoginLockout 获取登录锁定策略
+// [aegis.login.lockout] 为全局策略，[aegis.login.lockout.{connection}] 中设置的项覆盖全局策略
+func GetLoginLockout(connection string) *LoginLockout {
+	c := Cfg()
+	lockout := &LoginLockout{
+		FreeAttempts:   DefaultLoginLockoutFreeAttempts,
+		BaseDelay:      DefaultLoginLockoutBaseDelay,
+		MaxDelay:       DefaultLoginLockoutMaxDelay,
+		Threshold:      DefaultLoginLockoutThreshold,
+		Duration:       DefaultLoginLockoutDuration,
+		FailWindow:     DefaultLoginLockoutFailWindow,
+		IPFreeAttempts: DefaultLoginLockoutIPFreeAttempts,
+	}
+	for _, prefix := range []string{"aegis.login.lockout", "aegis.login.lockout." + connection} {
+		if c.IsSet(prefix + ".free-attempts") {
+			lockout.FreeAttempts = c.GetInt(prefix + ".free-attempts")
+		}
+		if v := c.GetDuration(prefix + ".base-delay"); v > 0 {
+			lockout.BaseDelay = v
+		}
+		if v := c.GetDuration(prefix + ".max-delay"); v > 0 {
+			lockout.MaxDelay = v
+		}
+		if c.IsSet(prefix + ".lock-threshold") {
+			lockout.Threshold = c.GetInt(prefix + ".lock-threshold")
+		}
+		if v := c.GetDuration(prefix + ".lock-duration"); v > 0 {
+			lockout.Duration = v
+		}
+		if v := c.GetDuration(prefix + ".fail-window"); v > 0 {
+			lockout.FailWindow = v
+		}
+		if c.IsSet(prefix + ".ip-free-attempts") {
+			lockout.IPFreeAttempts = c.GetInt(prefix + ".ip-free-attempts")
+		}
+	}
+	return lockout
+}
+
+// ==================== MFA 配置 ====================
+
+// MFA 默认值
+const (
+	DefaultMFAExpiresIn            = 5 * time.Minute     // MFA 阶段默认时限
+	DefaultMFAMaxAttempts          = 5                   // 单个 flow 内 MFA 完成的最大尝试次数
+	DefaultMFARiskRequireLevel     = "medium"            // 达到该风险等级时要求 MFA
+	DefaultMFARiskFailureThreshold = 3                   // 近期登录失败达到该次数视为高风险
+	DefaultMFARiskDormantAfter     = 30 * 24 * time.Hour // 距上次登录超过该时长视为休眠账号
+	DefaultMFARiskBaselineTTL      = 90 * 24 * time.Hour // 登录基线（已知设备与网络）保留时长
+	DefaultMFADeviceCookieName     = "aegis-device"      // 设备标识 Cookie 默认名称
+	DefaultMFARecoveryCodeCount    = 10                  // 每次生成的恢复码数量
+	DefaultMFATOTPPeriod           = 30                  // TOTP 时间步长（秒）
+	DefaultMFATOTPDigits           = 6                   // TOTP 位数
+	DefaultMFATOTPAlgorithm        = "SHA1"              // TOTP HMAC 算法
+	DefaultMFATOTPSkew             = 1                   // TOTP 验证时前后容忍的时间步数
+)
+
+// GetMFARiskEnabled 是否启用风险驱动的 MFA 阶段（默认关闭）
+func GetMFARiskEnabled() bool {
+	return Cfg().GetBool("mfa.risk.enabled")
+}
+
+// GetMFARiskRequireLevel 获取要求 MFA 的最低风险等级（low / medium / high）
+func GetMFARiskRequireLevel() string {
+	if v := Cfg().GetString("mfa.risk.require-level"); v != "" {
+		return v
+	}
+	return DefaultMFARiskRequireLevel
+}
+
+// GetMFARiskFailureThreshold 获取判定为高风险的近期登录失败次数
+func GetMFARiskFailureThreshold() int {
+	if v := Cfg().GetInt("mfa.risk.failure-threshold"); v > 0 {
+		return v
+	}
+	return DefaultMFARiskFailureThreshold
+}
+
+// GetMFARiskDormantAfter 获取判定为休眠账号的登录间隔
+func GetMFARiskDormantAfter() time.Duration {
+	if v := Cfg().GetDuration("mfa.risk.dormant-after"); v > 0 {
+		return v
+	}
+	return DefaultMFARiskDormantAfter
+}
+
+// GetMFARiskBaselineTTL 获取登录基线保留时长（同时作为设备 Cookie 的 MaxAge）
+func GetMFARiskBaselineTTL() time.Duration {
+	if v := Cfg().GetDuration("mfa.risk.baseline-ttl"); v > 0 {
+		return v
+	}
+	return DefaultMFARiskBaselineTTL
+}
+
+// GetMFAExpiresIn 获取 MFA 阶段时限
+func GetMFAExpiresIn() time.Duration {
+	if v := Cfg().GetDuration("mfa.expires-in"); v > 0 {
+		return v
+	}
+	return DefaultMFAExpiresIn
+}
+
+// GetMFAMaxAttempts 获取单个 flow 内 MFA 完成的最大尝试次数
+func GetMFAMaxAttempts() int {
+	if v := Cfg().GetInt("mfa.max-attempts"); v > 0 {
+		return v
+	}
+	return DefaultMFAMaxAttempts
+}
+
+// GetMFADeviceCookieName 获取设备标识 Cookie 名称
+func GetMFADeviceCookieName() string {
+	if name := Cfg().GetString("mfa.device-cookie-name"); name != "" {
+		return name
+	}
+	return DefaultMFADeviceCookieName
+}
+
+// GetMFARecoveryCodeCount 获取每次生成的恢复码数量
+func GetMFARecoveryCodeCount() int {
+	if v := Cfg().GetInt("mfa.recovery-code.count"); v > 0 {
+		return v
+	}
+	return DefaultMFARecoveryCodeCount
+}
+
+// TOTPConfig TOTP 参数
+type TOTPConfig struct {
+	Period    uint   // 时间步长（秒）
+	Digits    int    // 位数：6 / 8
+	Algorithm string // HMAC 算法：SHA1 / SHA256 / SHA512
+	Skew      uint   // 前后容忍的时间步数
+}
+
+// GetTOTPConfig 获取 TOTP 参数
+// period / digits / algorithm 写入绑定时的 otpauth URI 并随凭证保存，修改只影响之后的绑定；skew 对所有凭证生效
+func GetTOTPConfig() *TOTPConfig {
+	c := Cfg()
+	cfg := &TOTPConfig{
+		Period:    DefaultMFATOTPPeriod,
+		Digits:    DefaultMFATOTPDigits,
+		Algorithm: DefaultMFATOTPAlgorithm,
+		Skew:      DefaultMFATOTPSkew,
+	}
+	if v := c.GetUint("mfa.totp.period"); v > 0 {
+		cfg.Period = v
+	}
+	if v := c.GetInt("mfa.totp.digits"); v == 6 || v == 8 {
+		cfg.Digits = v
+	}
+	if v := strings.ToUpper(c.GetString("mfa.totp.algorithm")); v != "" {
+		cfg.Algorithm = v
+	}
+	if c.IsSet("mfa.totp.skew") {
+		cfg.Skew = c.GetUint("mfa.totp.skew")
+	}
+	return cfg
+}
+
+// ==================== Identity 配置 ====================
+
+// DefaultIdentityReauthMaxAge 绑定 / 解绑身份要求的认证新鲜度
+const DefaultIdentityReauthMaxAge = 10 * time.Minute
+
+// GetIdentityReauthMaxAge 获取绑定 / 解绑身份时 access token auth_time 距今的最长时间
+func GetIdentityReauthMaxAge() time.Duration {
+	if v := Cfg().GetDuration("identity.reauth-max-age"); v > 0 {
+		return v
+	}
+	return DefaultIdentityReauthMaxAge
+}
+
+// ==================== Password 配置 ====================
+
+// 密码策略默认值
+const (
+	DefaultPasswordMinLength = 8
+	DefaultPasswordMaxLength = 128
+)
+
+// argon2id 默认参数
+const (
+	DefaultPasswordArgon2Memory      = 64 * 1024 // KiB
+	DefaultPasswordArgon2Iterations  = 3
+	DefaultPasswordArgon2Parallelism = 2
+)
+
+// PasswordPolicy 密码策略
+type PasswordPolicy struct {
+	MinLength          int  // 最短长度（按字符计）
+	MaxLength          int  // 最长长度（按字符计）
+	CharacterClasses   int  // 至少包含的字符类别数（小写 / 大写 / 数字 / 符号）
+	DisallowIdentifier bool // 禁止包含用户名 / 邮箱
+	History            int  // 不得与最近 N 个密码（含当前密码）相同，0 表示不检查
+}
+
+// GetPasswordPolicy 获取域的密码策略
+// [password.policy] 为全局策略，[password.policy.<domain>] 中设置的项覆盖全局策略
+func GetPasswordPolicy(domain string) *PasswordPolicy {
+	c := Cfg()
+	policy := &PasswordPolicy{
+		MinLength:          DefaultPasswordMinLength,
+		MaxLength:          DefaultPasswordMaxLength,
+		DisallowIdentifier: true,
+	}
+	prefixes := []string{"password.policy"}
+	if domain != "" {
+		prefixes = append(prefixes, "password.policy."+domain)
+	}
+	for _, prefix := range prefixes {
+		if v := c.GetInt(prefix + ".min-length"); v > 0 {
+			policy.MinLength = v
+		}
+		if v := c.GetInt(prefix + ".max-length"); v > 0 {
+			policy.MaxLength = v
+		}
+		if c.IsSet(prefix + ".character-classes") {
+			policy.CharacterClasses = c.GetInt(prefix + ".character-classes")
+		}
+		if c.IsSet(prefix + ".disallow-identifier") {
+			policy.DisallowIdentifier = c.GetBool(prefix + ".disallow-identifier")
+		}
+		if c.IsSet(prefix + ".history") {
+			policy.History = c.GetInt(prefix + ".history")
+		}
+	}
+	return policy
+}
+
+// GetPasswordBreachedFile 获取离线泄露密码库文件路径，未配置时不做泄露检查
+func GetPasswordBreachedFile() string {
+	return Cfg().GetString("password.breached-file")
+}
+
+// Argon2Config argon2id 哈希参数
+type Argon2Config struct {
+	Memory      uint32 // 内存（KiB）
+	Iterations  uint32 // 迭代次数
+	Parallelism uint8  // 并行度
+}
+
+// GetPasswordArgon2Config 获取 argon2id 参数
+// 调整后，旧参数生成的哈希在下次登录成功时自动以新参数重新计算
+func GetPasswordArgon2Config() *Argon2Config {
+	c := Cfg()
+	cfg := &Argon2Config{
+		Memory:      DefaultPasswordArgon2Memory,
+		Iterations:  DefaultPasswordArgon2Iterations,
+		Parallelism: DefaultPasswordArgon2Parallelism,
+	}
+	if v := c.GetUint32("password.argon2.memory"); v > 0 {
+		cfg.Memory = v
+	}
+	if v := c.GetUint32("password.argon2.iterations"); v > 0 {
+		cfg.Iterations = v
+	}
+	if v := c.GetUint("password.argon2.parallelism"); v > 0 && v <= 255 {
+		cfg.Parallelism = uint8(v)
+	}
+	return cfg
+}
+
 // ==================== SSO 配置 ====================
 
 // SSO 默认值
@@ -608,6 +1066,35 @@ type MailConfig struct {
 	Password string
 }
 
+// ==================== SMS 配置 ====================
+
+// 短信发送方式
+const (
+	SMSProviderLog  = "log"  // 不实际发送，验证码仅追加到文件，本地开发用
+	SMSProviderHTTP = "http" // HTTP 网关
+)
+
+// SMSConfig 短信配置
+type SMSConfig struct {
+	Provider string        // log / http
+	File     string        // log 模式下追加验证码记录的文件（可空）
+	Endpoint string        // http 模式下的网关地址
+	Token    string        // http 模式下的网关 Bearer Token（可空）
+	Timeout  time.Duration // http 模式下的请求超时（0 使用默认值）
+}
+
+// GetSMSConfig 获取短信配置（sms.provider 无默认值，未显式配置时 Provider 为空，短信验证码因子不注册）
+func GetSMSConfig() *SMSConfig {
+	c := Cfg()
+	return &SMSConfig{
+		Provider: c.GetString("sms.provider"),
+		File:     c.GetString("sms.log.file"),
+		Endpoint: c.GetString("sms.http.endpoint"),
+		Token:    c.GetString("sms.http.token"),
+		Timeout:  c.GetDuration("sms.http.timeout"),
+	}
+}
+
 // mailProviderDefaults 邮件服务商默认配置
 type mailProviderDefaults struct {
 	host   string
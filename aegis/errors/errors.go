@@ -39,6 +39,60 @@ func NewInvalidGrant(description string) *AuthError {
 	return New(http.StatusBadRequest, CodeInvalidGrant, description)
 }
 
+func NewInvalidScope(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidScope, description)
+}
+
+func NewInvalidScopef(format string, args ...any) *AuthError {
+	return Newf(http.StatusBadRequest, CodeInvalidScope, format, args...)
+}
+
+func NewAuthorizationPending(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeAuthorizationPending, description)
+}
+
+func NewSlowDown(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeSlowDown, description)
+}
+
+func NewExpiredToken(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeExpiredToken, description)
+}
+
+// NewDeviceAccessDenied 设备轮询时用户已拒绝授权（RFC 8628 §3.5，token 端点统一 400）
+func NewDeviceAccessDenied(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeAccessDenied, description)
+}
+
+func NewInvalidDPoPProof(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidDPoPProof, description)
+}
+
+// NewInvalidRequestObject 请求对象验签失败或内容无效（RFC 9101 §7.1）
+func NewInvalidRequestObject(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidRequestObject, description)
+}
+
+// NewInvalidRequestURI request_uri 无效、已过期或已使用（RFC 9126 §4）
+func NewInvalidRequestURI(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidRequestURI, description)
+}
+
+// NewInvalidAuthorizationDetails authorization_details 格式错误、类型未知或超出已授予范围（RFC 9396 §5）
+func NewInvalidAuthorizationDetails(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidAuthorizationDetails, description)
+}
+
+// NewInvalidRedirectURI 注册的 redirect_uris 无效（RFC 7591 §3.2.2）
+func NewInvalidRedirectURI(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidRedirectURI, description)
+}
+
+// NewInvalidClientMetadata 注册的客户端元数据无效（RFC 7591 §3.2.2）
+func NewInvalidClientMetadata(description string) *AuthError {
+	return New(http.StatusBadRequest, CodeInvalidClientMetadata, description)
+}
+
 func NewInvalidCredentials(description string) *AuthError {
 	return New(http.StatusUnauthorized, CodeInvalidCredentials, description)
 }
@@ -57,6 +111,10 @@ func NewUnauthorizedf(format string, args ...any) *AuthError {
 	return Newf(http.StatusUnauthorized, CodeInvalidToken, format, args...)
 }
 
+func NewInvalidClient(description string) *AuthError {
+	return New(http.StatusUnauthorized, CodeInvalidClient, description)
+}
+
 func NewInvalidToken(description string) *AuthError {
 	return New(http.StatusUnauthorized, CodeInvalidToken, description)
 }
@@ -65,6 +123,14 @@ func NewLoginRequired(description string) *AuthError {
 	return New(http.StatusUnauthorized, CodeLoginRequired, description)
 }
 
+func NewConsentRequired(description string) *AuthError {
+	return New(http.StatusUnauthorized, CodeConsentRequired, description)
+}
+
+func NewInteractionRequired(description string) *AuthError {
+	return New(http.StatusUnauthorized, CodeInteractionRequired, description)
+}
+
 // ==================== 403 Forbidden ====================
 
 func NewAccessDenied(description string) *AuthError {
@@ -124,6 +190,11 @@ func NewFlowInvalid(description string) *AuthError {
 	return New(http.StatusConflict, CodeFlowInvalid, description)
 }
 
+// 409 Conflict — 上游身份已绑定到其他用户，或用户已绑定同一 IDP 的其他账号
+func NewIdentityConflict(description string) *AuthError {
+	return New(http.StatusConflict, CodeIdentityConflict, description)
+}
+
 // ==================== 422 Unprocessable Entity ====================
 
 // 422 Unprocessable Entity — Challenge 过期（验证码超时）
@@ -131,6 +202,15 @@ func NewChallengeExpired(description string) *AuthError {
 	return New(http.StatusUnprocessableEntity, CodeChallengeExpired, description)
 }
 
+// 422 Unprocessable Entity — 新密码不满足密码策略，reason 为未通过的规则
+func NewWeakPassword(reason, description string) *AuthError {
+	err := New(http.StatusUnprocessableEntity, CodeWeakPassword, description)
+	err.Data = map[string]any{
+		"reason": reason,
+	}
+	return err
+}
+
 // ==================== 426 Upgrade Required ====================
 
 func NewNoConnectionAvailable(description string) *AuthError {
@@ -207,13 +287,40 @@ const (
 	// 400 Bad Request
 	CodeInvalidRequest     = "invalid_request"
 	CodeInvalidGrant       = "invalid_grant"
+	CodeInvalidScope       = "invalid_scope"
 	CodeInvalidCredentials = "invalid_credentials"
 	CodeClientNotFound     = "client_not_found"
 	CodeServiceNotFound    = "service_not_found"
 
+	// 400 Device Authorization Grant（RFC 8628 §3.5）
+	CodeAuthorizationPending = "authorization_pending"
+	CodeSlowDown             = "slow_down"
+	CodeExpiredToken         = "expired_token"
+
+	// 400 DPoP（RFC 9449 §5）
+	CodeInvalidDPoPProof = "invalid_dpop_proof"
+
+	// 400 Pushed Authorization Request（RFC 9126 §4）
+	CodeInvalidRequestURI = "invalid_request_uri"
+
+	// 400 Request Object（RFC 9101 §7.1）
+	CodeInvalidRequestObject = "invalid_request_object"
+
+	// 400 Rich Authorization Requests（RFC 9396 §5）
+	CodeInvalidAuthorizationDetails = "invalid_authorization_details"
+
+	// 400 Dynamic Client Registration（RFC 7591 §3.2.2）
+	CodeInvalidRedirectURI    = "invalid_redirect_uri"
+	CodeInvalidClientMetadata = "invalid_client_metadata"
+
 	// 401 Unauthorized
-	CodeInvalidToken  = "invalid_token"
-	CodeLoginRequired = "login_required"
+	CodeInvalidToken    = "invalid_token"
+	CodeInvalidClient   = "invalid_client"
+	CodeLoginRequired   = "login_required"
+	CodeConsentRequired = "consent_required"
+
+	// 401 OIDC Core §3.1.2.6：prompt=none 时需要用户交互（如 MFA）
+	CodeInteractionRequired = "interaction_required"
 
 	// 403 Forbidden
 	CodeAccessDenied = "access_denied"
@@ -227,8 +334,12 @@ const (
 	CodeFlowExpired  = "flow_expired"
 	CodeFlowInvalid  = "flow_invalid"
 
+	// 409 Conflict
+	CodeIdentityConflict = "identity_conflict"
+
 	// 422 Unprocessable Entity
 	CodeChallengeExpired = "challenge_expired"
+	CodeWeakPassword     = "weak_password"
 
 	// 426 Upgrade Required
 	CodeNoConnectionAvailable = "no_connection_available"
@@ -2,6 +2,8 @@ package authenticate
 
 import (
 	"context"
+	"errors"
+	"fmt"
 
 	autherrors "github.com/heliannuuthus/aegis/errors"
 	"github.com/heliannuuthus/aegis/internal/authenticator"
@@ -71,12 +73,17 @@ func (a *IDPAuthenticator) Authenticate(ctx context.Context, flow *types.AuthFlo
 		extraParams = append(extraParams, &idp.OAuthLoginContext{
 			RedirectURI:  flow.GetExtra(types.ExtraKeyOAuthRedirectURI),
 			CodeVerifier: flow.GetExtra(types.ExtraKeyOAuthCodeVerifier),
+			Nonce:        flow.GetExtra(types.ExtraKeyOAuthNonce),
 		})
 	}
 
 	userInfo, err := a.provider.Login(ctx, proof, extraParams...)
 	if err != nil {
 		if connection == idp.TypeUser || connection == idp.TypeStaff {
+			// 锁定与凭证错误对外一致，保留 ErrUserLocked 供锁定策略区分
+			if errors.Is(err, idp.ErrUserLocked) {
+				return false, fmt.Errorf("%w: %w", autherrors.NewInvalidCredentials("authentication failed"), err)
+			}
 			return false, autherrors.NewInvalidCredentials("authentication failed")
 		}
 		return false, autherrors.NewServerErrorf("idp login failed: %v", err)
@@ -97,6 +104,24 @@ func (a *IDPAuthenticator) Authenticate(ctx context.Context, flow *types.AuthFlo
 	return true, nil
 }
 
+// Identify 用上游凭证换取身份，不依赖 AuthFlow（已登录用户绑定身份时使用）
+// clientID 用于解析 IDP 密钥；oauthCtx 为跳转类 IDP 回调时的 PKCE 材料，其他 IDP 传 nil
+func (a *IDPAuthenticator) Identify(ctx context.Context, clientID, proof string, oauthCtx *idp.OAuthLoginContext) (*models.UserIdentity, error) {
+	params := []any{clientID}
+	if oauthCtx != nil {
+		params = append(params, oauthCtx)
+	}
+	userInfo, err := a.provider.Login(ctx, proof, params...)
+	if err != nil {
+		return nil, autherrors.NewServerErrorf("idp login failed: %v", err)
+	}
+	if userInfo == nil {
+		return nil, autherrors.NewServerError("idp login returned nil user info")
+	}
+	connection := a.provider.Type()
+	return userInfo.ToUserIdentity(string(idp.GetDomain(connection)), connection), nil
+}
+
 // Resolve 通过 principal 查找用户信息（委托 Provider.Resolve）
 // 实现 authenticator.IdentityResolver 接口
 func (a *IDPAuthenticator) Resolve(ctx context.Context, principal string) (*models.TUserInfo, error) {
@@ -112,6 +137,15 @@ func (a *IDPAuthenticator) Initiate(ctx context.Context, initiation *idp.Initiat
 	return provider.Initiate(ctx, initiation, strategy)
 }
 
+// Metadata 生成应用的 SP 元数据（委托 idp.MetadataPublisher，如 SAML）
+func (a *IDPAuthenticator) Metadata(ctx context.Context, appID string) ([]byte, error) {
+	publisher, ok := a.provider.(idp.MetadataPublisher)
+	if !ok {
+		return nil, autherrors.NewInvalidRequestf("provider %s does not publish metadata", a.provider.Type())
+	}
+	return publisher.Metadata(ctx, appID)
+}
+
 // ==================== Exchanger 实现（条件） ====================
 
 // Exchange 用平台授权码换取 principal（如小程序 code 换手机号）
@@ -0,0 +1,104 @@
+package authenticate
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/pkg/accessctl"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+	"github.com/heliannuuthus/pkg/mail/templates"
+)
+
+// lockoutConnection 是否对 connection 启用登录锁定（仅账号密码登录）
+func lockoutConnection(connection string) bool {
+	return connection == idp.TypeUser || connection == idp.TypeStaff
+}
+
+// buildLockouts 构建登录锁定策略：标识维度（退避 + 锁定）与 IP 维度（仅退避）
+// Key 维度：rl:lock:{connection}:{principal}、rl:lock:ip:{connection}:{ip}
+// 标识维度不区分 audience：锁定针对账号本身
+func buildLockouts(ctx context.Context, connection, principal string) (identifier, ip *accessctl.Lockout) {
+	cfg := config.GetLoginLockout(connection)
+	if principal != "" {
+		identifier = accessctl.NewLockout(types.RateLimitKeyPrefixLoginLock+connection+":"+strings.ToLower(principal)).
+			FailWindow(cfg.FailWindow).
+			Backoff(cfg.FreeAttempts, cfg.BaseDelay, cfg.MaxDelay).
+			LockAt(cfg.Threshold)
+	}
+	if remoteIP := helpers.RemoteIPFrom(ctx); remoteIP != "" {
+		ip = accessctl.NewLockout(types.RateLimitKeyPrefixLoginLockIP+connection+":"+remoteIP).
+			FailWindow(cfg.FailWindow).
+			Backoff(cfg.IPFreeAttempts, cfg.BaseDelay, cfg.MaxDelay)
+	}
+	return identifier, ip
+}
+
+// isLockoutFailure 认证结果是否计入锁定失败次数（凭证错误计入，请求格式错误等不计入）
+// 账号已锁定时不校验密码也不计数：锁定时已清零标识计数，解锁（到期或管理员解锁）后不残留退避
+func isLockoutFailure(success bool, err error) bool {
+	if err != nil {
+		if errors.Is(err, idp.ErrUserLocked) {
+			return false
+		}
+		return autherrors.Is(err, autherrors.CodeInvalidCredentials)
+	}
+	return !success
+}
+
+// recordLockoutFailure 记录一次登录失败，标识维度达到阈值时锁定账号
+func (s *Service) recordLockoutFailure(ctx context.Context, flow *types.AuthFlow, principal string, identifier, ip *accessctl.Lockout) {
+	s.ac.Fail(ctx, ip)
+	count, locked := s.ac.Fail(ctx, identifier)
+	if !locked {
+		return
+	}
+	logger.Warnf("[Authenticate] 登录失败达到锁定阈值 - FlowID: %s, Connection: %s, Failures: %d", flow.ID, flow.Connection, count)
+	s.lockUser(ctx, flow.Connection, principal)
+	// 锁定状态以用户记录为准，计数清零后锁定期满重新计数
+	s.ac.Clear(ctx, identifier)
+}
+
+// lockUser 锁定 principal 对应的用户并发送安全警告邮件
+// principal 不对应任何用户时不做处理（仍按标识维度退避）；已锁定的用户不重复锁定与通知
+func (s *Service) lockUser(ctx context.Context, connection, principal string) {
+	if s.hermes == nil {
+		return
+	}
+	user, _, err := idp.ResolveUserIdentity(ctx, s.hermes, connection, principal)
+	if err != nil {
+		return
+	}
+	now := time.Now()
+	if user.IsLocked(now) {
+		return
+	}
+
+	lockedUntil := now.Add(config.GetLoginLockout(connection).Duration)
+	if err := s.hermes.PatchUser(ctx, user.OpenID, map[string]any{"locked_until": lockedUntil}); err != nil {
+		logger.Errorf("[Authenticate] 锁定用户失败 - OpenID: %s, Error: %v", user.OpenID, err)
+		return
+	}
+	s.cache.InvalidateUser(ctx, user.OpenID)
+	logger.Warnf("[Authenticate] 用户已锁定 - OpenID: %s, Connection: %s, LockedUntil: %v", user.OpenID, connection, lockedUntil)
+
+	if s.mail == nil || user.Email == nil || *user.Email == "" {
+		return
+	}
+	details := []templates.DetailItem{
+		{Label: "事件", Value: "多次登录失败，账号已临时锁定"},
+		{Label: "锁定至", Value: lockedUntil.Format(time.DateTime)},
+	}
+	if remoteIP := helpers.RemoteIPFrom(ctx); remoteIP != "" {
+		details = append(details, templates.DetailItem{Label: "IP 地址", Value: remoteIP})
+	}
+	if err := s.mail.SendSecurityAlert(ctx, *user.Email, details, ""); err != nil {
+		logger.Warnf("[Authenticate] 发送锁定通知失败 - OpenID: %s, Error: %v", user.OpenID, err)
+	}
+}
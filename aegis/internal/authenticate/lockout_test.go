@@ -0,0 +1,59 @@
+package authenticate
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+)
+
+// stubProvider 返回固定登录错误的 user Provider
+type stubProvider struct {
+	idp.Provider
+	err error
+}
+
+func (*stubProvider) Type() string { return idp.TypeUser }
+
+func (p *stubProvider) Login(context.Context, string, ...any) (*models.TUserInfo, error) {
+	return nil, p.err
+}
+
+func TestIsLockoutFailure(t *testing.T) {
+	login := func(err error) error {
+		_, err = NewIDPAuthenticator(&stubProvider{err: err}).Authenticate(context.Background(), &types.AuthFlow{}, "password")
+		return err
+	}
+
+	tests := []struct {
+		name    string
+		success bool
+		err     error
+		want    bool
+	}{
+		{name: "wrong password", err: login(errors.New("invalid credentials")), want: true},
+		{name: "user locked", err: login(idp.ErrUserLocked), want: false},
+		{name: "malformed request", err: autherrors.NewInvalidRequest("proof is required"), want: false},
+		{name: "rejected without error", success: false, want: true},
+		{name: "succeeded", success: true, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := isLockoutFailure(tt.success, tt.err); got != tt.want {
+				t.Fatalf("isLockoutFailure(%v, %v) = %v, want %v", tt.success, tt.err, got, tt.want)
+			}
+		})
+	}
+}
+
+func TestLockedLoginLooksLikeInvalidCredentials(t *testing.T) {
+	_, err := NewIDPAuthenticator(&stubProvider{err: idp.ErrUserLocked}).Authenticate(context.Background(), &types.AuthFlow{}, "password")
+	authErr := autherrors.ToAuthError(err)
+	if authErr.Code != autherrors.CodeInvalidCredentials || authErr.Description != "authentication failed" {
+		t.Fatalf("Authenticate() error = %+v, want invalid_credentials without lock details", authErr)
+	}
+}
@@ -12,21 +12,28 @@ import (
 	"github.com/heliannuuthus/aegis/internal/cache"
 	"github.com/heliannuuthus/aegis/internal/types"
 	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
 	"github.com/heliannuuthus/pkg/accessctl"
 	"github.com/heliannuuthus/pkg/logger"
+	"github.com/heliannuuthus/pkg/mail"
 )
 
 // Service 认证服务
 type Service struct {
-	cache *cache.Manager
-	ac    *accessctl.Manager
+	cache  *cache.Manager
+	ac     *accessctl.Manager
+	hermes *hermes.Client
+	mail   *mail.Sender
 }
 
 // NewService 创建认证服务
-func NewService(cache *cache.Manager, ac *accessctl.Manager) *Service {
+// hermes 与 mail 用于账号密码登录失败达到阈值时锁定用户并发送通知，mail 可为 nil
+func NewService(cache *cache.Manager, ac *accessctl.Manager, hermesClient *hermes.Client, mailSender *mail.Sender) *Service {
 	return &Service{
-		cache: cache,
-		ac:    ac,
+		cache:  cache,
+		ac:     ac,
+		hermes: hermesClient,
+		mail:   mailSender,
 	}
 }
 
@@ -106,6 +113,7 @@ func (s *Service) SaveFlow(ctx context.Context, flow *types.AuthFlow) error {
 // remoteIP 通过 context 传递（ctxutil.WithRemoteIP）
 //
 // Strike 后置：仅在认证失败后计数，达阈值时返回 TooManyRequests error（429 + retry_after）
+// 账号密码登录（user / staff）另有锁定策略：认证前检查退避，失败后按标识与 IP 计数，标识维度达到阈值时锁定用户
 func (s *Service) Authenticate(ctx context.Context, flow *types.AuthFlow, params ...any) (bool, error) {
 	if !flow.CanAuthenticate() {
 		return false, autherrors.NewFlowInvalid("flow state does not allow authentication")
@@ -116,7 +124,24 @@ func (s *Service) Authenticate(ctx context.Context, flow *types.AuthFlow, params
 		return false, autherrors.NewInvalidRequestf("unsupported connection: %s", flow.Connection)
 	}
 
+	principal := extractStringParam(params, 1)
+	var identifierLockout, ipLockout *accessctl.Lockout
+	if lockoutConnection(flow.Connection) {
+		identifierLockout, ipLockout = buildLockouts(ctx, flow.Connection, principal)
+		if wait := s.ac.Backoff(ctx, identifierLockout, ipLockout); wait > 0 {
+			logger.Warnf("[Authenticate] 登录失败退避中 - FlowID: %s, Connection: %s, RetryAfter: %ds", flow.ID, flow.Connection, wait)
+			return false, autherrors.NewTooManyRequests(wait)
+		}
+	}
+
 	success, err := auth.Authenticate(ctx, flow, params...)
+	if lockoutConnection(flow.Connection) {
+		if isLockoutFailure(success, err) {
+			s.recordLockoutFailure(ctx, flow, principal, identifierLockout, ipLockout)
+		} else if success {
+			s.ac.Clear(ctx, identifierLockout)
+		}
+	}
 	if err != nil {
 		return false, err
 	}
@@ -125,7 +150,6 @@ func (s *Service) Authenticate(ctx context.Context, flow *types.AuthFlow, params
 		return true, nil
 	}
 
-	principal := extractStringParam(params, 1)
 	policy := buildACPolicy(flow, principal)
 	if action, retryAfter := s.ac.Strike(ctx, policy); action == accessctl.ACRateLimited {
 		logger.Warnf("[Authenticate] 认证失败且频率达阈值，限流 - FlowID: %s, RetryAfter: %ds", flow.ID, retryAfter)
@@ -194,6 +218,15 @@ func (s *Service) addReferencedConnections(result map[string]*types.ConnectionCo
 	}
 }
 
+// FailurePolicy 返回 principal 在 flow 当前 connection 下的登录失败计数策略（供风险评估只读查询）
+// principal 为空时返回 nil
+func (s *Service) FailurePolicy(flow *types.AuthFlow, principal string) *accessctl.Policy {
+	if principal == "" {
+		return nil
+	}
+	return buildACPolicy(flow, principal)
+}
+
 // buildACPolicy 构建验证频率计数策略
 // Key 维度：rl:login:{audience}:{connection}:{principal}
 func buildACPolicy(flow *types.AuthFlow, principal string) *accessctl.Policy {
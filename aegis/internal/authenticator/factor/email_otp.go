@@ -106,7 +106,10 @@ func (p *EmailOTPProvider) sendOTP(ctx context.Context, ch *types.Challenge) err
 	return nil
 }
 
-// otpScene 将业务类型映射到邮件模板场景
+// otpSceneLogin 登录场景（未匹配到其他场景的业务类型均视为登录）
+const otpSceneLogin = "otp_login"
+
+// otpScene 将业务类型映射到邮件 / 短信模板场景
 func otpScene(typ string) string {
 	switch typ {
 	case "register":
@@ -124,6 +127,6 @@ func otpScene(typ string) string {
 	case "delete_account":
 		return "otp_delete_account"
 	default:
-		return "otp_login"
+		return otpSceneLogin
 	}
 }
@@ -9,9 +9,11 @@ import (
 
 // 因子类型常量
 const (
-	TypeEmailOTP = string(types.ChannelTypeEmailOTP) // 邮件验证码
-	TypeTOTP     = "totp"                            // 时间动态口令
-	TypeWebAuthn = "webauthn"                        // WebAuthn/FIDO2
+	TypeEmailOTP     = string(types.ChannelTypeEmailOTP)     // 邮件验证码
+	TypeSmsOTP       = string(types.ChannelTypeSmsOTP)       // 短信验证码
+	TypeTOTP         = "totp"                                // 时间动态口令
+	TypeWebAuthn     = "webauthn"                            // WebAuthn/FIDO2
+	TypeRecoveryCode = string(types.ChannelTypeRecoveryCode) // 一次性恢复码
 )
 
 // Provider 认证因子提供者接口
@@ -0,0 +1,61 @@
+package factor
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/heliannuuthus/aegis/internal/types"
+)
+
+var (
+	_ Provider = (*RecoveryCodeFactor)(nil)
+)
+
+type RecoveryCodeVerifier interface {
+	VerifyCode(ctx context.Context, openid, code string) (bool, error)
+}
+
+// RecoveryCodeFactor 恢复码认证因子（每个恢复码仅可使用一次）
+type RecoveryCodeFactor struct {
+	verifier RecoveryCodeVerifier
+}
+
+// NewRecoveryCodeFactor 创建恢复码认证因子
+func NewRecoveryCodeFactor(verifier RecoveryCodeVerifier) *RecoveryCodeFactor {
+	return &RecoveryCodeFactor{
+		verifier: verifier,
+	}
+}
+
+// Type 返回因子类型标识
+func (*RecoveryCodeFactor) Type() string {
+	return TypeRecoveryCode
+}
+
+func (p *RecoveryCodeFactor) Initiate(_ context.Context, challenge *types.Challenge) error {
+	if challenge.Channel == "" {
+		return fmt.Errorf("user_id is required for recovery code")
+	}
+	return nil
+}
+
+// Verify 验证并消费恢复码
+// proof: 恢复码
+func (p *RecoveryCodeFactor) Verify(ctx context.Context, challenge *types.Challenge, proof string) (bool, error) {
+	if proof == "" {
+		return false, nil
+	}
+
+	if challenge == nil || challenge.Channel == "" {
+		return false, nil
+	}
+
+	return p.verifier.VerifyCode(ctx, challenge.Channel, proof)
+}
+
+// Prepare 准备前端公开配置
+func (*RecoveryCodeFactor) Prepare() *types.ConnectionConfig {
+	return &types.ConnectionConfig{
+		Connection: TypeRecoveryCode,
+	}
+}
@@ -0,0 +1,147 @@
+package factor
+
+import (
+	"context"
+	"fmt"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/accessctl"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+var _ Provider = (*SmsOTPProvider)(nil)
+
+// SMSSender 短信发送接口
+// scene 与邮件验证码一致，由业务层根据 challenge.Type 映射
+type SMSSender interface {
+	SendCode(ctx context.Context, phone, code, scene string) error
+}
+
+// SmsOTPProvider 短信验证码认证因子 Provider
+// 每个验证码输错达到 config.GetSmsOTPFailThreshold() 次后作废，须重新发起 challenge
+type SmsOTPProvider struct {
+	smsSender SMSSender
+	cache     *cache.Manager
+	users     *hermes.Client
+	ac        *accessctl.Manager
+}
+
+// NewSmsOTPProvider 创建短信验证码认证因子 Provider
+func NewSmsOTPProvider(smsSender SMSSender, cache *cache.Manager, users *hermes.Client, ac *accessctl.Manager) *SmsOTPProvider {
+	return &SmsOTPProvider{
+		smsSender: smsSender,
+		cache:     cache,
+		users:     users,
+		ac:        ac,
+	}
+}
+
+// Type 返回因子类型标识
+func (*SmsOTPProvider) Type() string {
+	return TypeSmsOTP
+}
+
+// Initiate 发送短信验证码
+// 登录场景下手机号未注册时不发送短信（短信有成本），但仍按成功返回，避免暴露手机号是否已注册；
+// 此时未生成 OTP，后续验证必然失败
+func (p *SmsOTPProvider) Initiate(ctx context.Context, challenge *types.Challenge) error {
+	if !idp.IsPhonePrincipal(challenge.Channel) {
+		return fmt.Errorf("invalid phone format: %s", helpers.MaskPhone(challenge.Channel))
+	}
+
+	if otpScene(challenge.Type) == otpSceneLogin {
+		if _, err := p.users.GetUserByPhone(ctx, challenge.Channel); err != nil {
+			if status.Code(err) != codes.NotFound {
+				return fmt.Errorf("resolve phone: %w", err)
+			}
+			logger.Infof("[SmsOTP] 手机号未注册，跳过发送 - Phone: %s", helpers.MaskPhone(challenge.Channel))
+			return nil
+		}
+	}
+	return p.sendOTP(ctx, challenge)
+}
+
+// Verify 验证短信验证码
+// proof: OTP 验证码
+func (p *SmsOTPProvider) Verify(ctx context.Context, challenge *types.Challenge, proof string) (bool, error) {
+	if proof == "" {
+		return false, nil
+	}
+
+	if challenge == nil || challenge.ID == "" {
+		return false, nil
+	}
+
+	otpKey := types.CacheKeyPrefixSmsOTP + challenge.ID
+	storedCode, err := p.cache.GetOTP(ctx, otpKey)
+	if err != nil {
+		return false, nil
+	}
+
+	if storedCode != proof {
+		p.strike(ctx, challenge.ID)
+		return false, nil
+	}
+
+	// 验证成功，删除 OTP
+	if err := p.cache.DeleteOTP(ctx, otpKey); err != nil {
+		logger.Warnf("[SmsOTP] 删除 OTP 失败: %v", err)
+	}
+
+	return true, nil
+}
+
+// Prepare 准备前端公开配置
+func (*SmsOTPProvider) Prepare() *types.ConnectionConfig {
+	return &types.ConnectionConfig{
+		Connection: TypeSmsOTP,
+	}
+}
+
+// ==================== 内部方法 ====================
+
+// strike 记录一次输错，达到上限时作废验证码（6 位验证码不能在有效期内被逐个猜中）
+func (p *SmsOTPProvider) strike(ctx context.Context, challengeID string) {
+	policy := accessctl.NewPolicy(types.RateLimitKeyPrefixSmsOTPVerify + challengeID).
+		FailWindow(config.GetOTPExpiresIn()).
+		ThrottleAt(config.GetSmsOTPFailThreshold())
+	if action, _ := p.ac.Strike(ctx, policy); action != accessctl.ACRateLimited {
+		return
+	}
+	if err := p.cache.DeleteOTP(ctx, types.CacheKeyPrefixSmsOTP+challengeID); err != nil {
+		logger.Warnf("[SmsOTP] 作废 OTP 失败: %v", err)
+		return
+	}
+	logger.Warnf("[SmsOTP] 验证码输错次数达到上限，已作废 - ChallengeID: %s", challengeID)
+}
+
+// sendOTP 发送短信验证码
+func (p *SmsOTPProvider) sendOTP(ctx context.Context, ch *types.Challenge) error {
+	code, err := helpers.GenerateOTP(6)
+	if err != nil {
+		return err
+	}
+
+	otpKey := types.CacheKeyPrefixSmsOTP + ch.ID
+	if err := p.cache.SaveOTP(ctx, otpKey, code); err != nil {
+		return err
+	}
+
+	if p.smsSender != nil {
+		if err := p.smsSender.SendCode(ctx, ch.Channel, code, otpScene(ch.Type)); err != nil {
+			logger.Errorf("[SmsOTP] 发送短信失败: %v", err)
+			return err
+		}
+	}
+
+	logger.Infof("[SmsOTP] 已发送验证码 - Phone: %s", helpers.MaskPhone(ch.Channel))
+	return nil
+}
@@ -0,0 +1,263 @@
+package factor
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/accessctl"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+	"github.com/heliannuuthus/pkg/throttle"
+	hermesv1 "github.com/heliannuuthus/proto/gen/proto/hermes/v1"
+)
+
+// otpRedis 进程内 Redis 替身：带过期时间的字符串（按替身时钟判断），Eval 仅支持 throttle 的 record 脚本（不区分窗口）
+type otpRedis struct {
+	pkgredis.Client
+	mu       sync.Mutex
+	now      time.Time
+	strings  map[string]string
+	deadline map[string]time.Time
+	records  map[string]int64
+}
+
+func newOTPRedis() *otpRedis {
+	return &otpRedis{
+		now:      time.Now(),
+		strings:  make(map[string]string),
+		deadline: make(map[string]time.Time),
+		records:  make(map[string]int64),
+	}
+}
+
+func (r *otpRedis) Set(_ context.Context, key string, value any, ttl time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strings[key] = value.(string)
+	r.deadline[key] = r.now.Add(ttl)
+	return nil
+}
+
+func (r *otpRedis) Get(_ context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.strings[key]
+	if !ok || !r.now.Before(r.deadline[key]) {
+		return "", pkgredis.ErrNil
+	}
+	return value, nil
+}
+
+func (r *otpRedis) Del(_ context.Context, keys ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		delete(r.strings, key)
+		delete(r.deadline, key)
+	}
+	return nil
+}
+
+func (r *otpRedis) Eval(_ context.Context, script string, keys []string, _ ...any) (any, error) {
+	if !strings.Contains(script, "local member = ARGV[3]") {
+		return nil, errors.New("unsupported script")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.records[keys[0]]++
+	return r.records[keys[0]], nil
+}
+
+// advance 模拟时间流逝
+func (r *otpRedis) advance(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.now = r.now.Add(d)
+}
+
+// sentSMS 一条已发送的短信
+type sentSMS struct {
+	phone, code, scene string
+}
+
+// recordingSMSSender 记录发送内容的 SMSSender
+type recordingSMSSender struct {
+	sent []sentSMS
+}
+
+func (s *recordingSMSSender) SendCode(_ context.Context, phone, code, scene string) error {
+	s.sent = append(s.sent, sentSMS{phone: phone, code: code, scene: scene})
+	return nil
+}
+
+// userConn hermes gRPC 替身：GetByPhonePlain 对 registered 中的手机号返回空用户，其余返回 err（默认 NotFound）
+type userConn struct {
+	registered map[string]bool
+	err        error
+}
+
+func (c *userConn) Invoke(_ context.Context, _ string, args, _ any, _ ...grpc.CallOption) error {
+	if req, ok := args.(*hermesv1.GetByPhonePlainRequest); ok && c.registered[req.GetPhone()] {
+		return nil
+	}
+	if c.err != nil {
+		return c.err
+	}
+	return status.Error(codes.NotFound, "user not found")
+}
+
+func (*userConn) NewStream(context.Context, *grpc.StreamDesc, string, ...grpc.CallOption) (grpc.ClientStream, error) {
+	return nil, errors.New("streams are not supported")
+}
+
+func newSmsOTPProvider(t *testing.T, conn *userConn) (*SmsOTPProvider, *recordingSMSSender, *otpRedis) {
+	t.Helper()
+	redis := newOTPRedis()
+	cm := cache.NewManager(nil, redis)
+	t.Cleanup(cm.Close)
+	sender := &recordingSMSSender{}
+	ac := accessctl.NewManager(throttle.NewThrottler(redis))
+	return NewSmsOTPProvider(sender, cm, hermes.New(conn), ac), sender, redis
+}
+
+func smsChallenge(typ string) *types.Challenge {
+	return &types.Challenge{ID: "ch-1", Type: typ, Channel: "+8613800138000"}
+}
+
+// initiateSmsOTP 发起 challenge 并返回发送的验证码
+func initiateSmsOTP(t *testing.T, p *SmsOTPProvider, sender *recordingSMSSender, ch *types.Challenge) string {
+	t.Helper()
+	if err := p.Initiate(context.Background(), ch); err != nil {
+		t.Fatalf("Initiate() error = %v", err)
+	}
+	if len(sender.sent) == 0 {
+		t.Fatal("Initiate() sent no sms")
+	}
+	return sender.sent[len(sender.sent)-1].code
+}
+
+func TestSmsOTPInitiate(t *testing.T) {
+	tests := []struct {
+		name      string
+		typ       string
+		phone     string
+		conn      *userConn
+		wantErr   bool
+		wantScene string
+	}{
+		{name: "register", typ: "register", phone: "+8613800138000", conn: &userConn{}, wantScene: "otp_register"},
+		{name: "login with registered phone", typ: "login", phone: "+8613800138000", conn: &userConn{registered: map[string]bool{"+8613800138000": true}}, wantScene: otpSceneLogin},
+		{name: "login with unregistered phone", typ: "login", phone: "+8613800138000", conn: &userConn{}},
+		{name: "hermes unavailable", typ: "login", phone: "+8613800138000", conn: &userConn{err: status.Error(codes.Unavailable, "down")}, wantErr: true},
+		{name: "invalid phone", typ: "register", phone: "not-a-phone", conn: &userConn{}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, sender, _ := newSmsOTPProvider(t, tt.conn)
+			ch := &types.Challenge{ID: "ch-1", Type: tt.typ, Channel: tt.phone}
+
+			err := p.Initiate(context.Background(), ch)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("Initiate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantScene == "" {
+				// 未注册的手机号照常返回但不发送短信，之后任何验证码都无法通过
+				if len(sender.sent) != 0 {
+					t.Fatalf("sent = %+v, want nothing", sender.sent)
+				}
+				if ok, _ := p.Verify(context.Background(), ch, "000000"); ok {
+					t.Fatal("Verify() passed without a sent code")
+				}
+				return
+			}
+			if len(sender.sent) != 1 {
+				t.Fatalf("sent = %+v, want one sms", sender.sent)
+			}
+			got := sender.sent[0]
+			if got.phone != tt.phone || got.scene != tt.wantScene || len(got.code) != 6 {
+				t.Fatalf("sent = %+v, want 6-digit code to %s for %s", got, tt.phone, tt.wantScene)
+			}
+		})
+	}
+}
+
+func TestSmsOTPVerify(t *testing.T) {
+	p, sender, _ := newSmsOTPProvider(t, &userConn{})
+	ch := smsChallenge("register")
+	code := initiateSmsOTP(t, p, sender, ch)
+	ctx := context.Background()
+
+	tests := []struct {
+		name  string
+		ch    *types.Challenge
+		proof string
+		want  bool
+	}{
+		{name: "empty proof", ch: ch, proof: ""},
+		{name: "missing challenge", proof: code},
+		{name: "other challenge", ch: &types.Challenge{ID: "ch-2"}, proof: code},
+		{name: "wrong code", ch: ch, proof: "not-" + code},
+		{name: "correct code", ch: ch, proof: code, want: true},
+		// 验证成功后验证码即删除，不可重复使用
+		{name: "reused code", ch: ch, proof: code},
+	}
+	for _, tt := range tests {
+		ok, err := p.Verify(ctx, tt.ch, tt.proof)
+		if err != nil || ok != tt.want {
+			t.Fatalf("%s: Verify() = %v, %v, want %v", tt.name, ok, err, tt.want)
+		}
+	}
+}
+
+func TestSmsOTPVerifyExpired(t *testing.T) {
+	p, sender, redis := newSmsOTPProvider(t, &userConn{})
+	ch := smsChallenge("register")
+	code := initiateSmsOTP(t, p, sender, ch)
+
+	redis.advance(config.GetOTPExpiresIn())
+	if ok, err := p.Verify(context.Background(), ch, code); ok || err != nil {
+		t.Fatalf("Verify() after expiry = %v, %v, want false", ok, err)
+	}
+}
+
+func TestSmsOTPVerifyAttemptLimit(t *testing.T) {
+	threshold := config.GetSmsOTPFailThreshold()
+
+	tests := []struct {
+		name   string
+		misses int
+		want   bool
+	}{
+		{name: "below limit", misses: threshold - 1, want: true},
+		// 达到上限后验证码作废，正确的验证码也不再通过
+		{name: "limit reached", misses: threshold},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			p, sender, _ := newSmsOTPProvider(t, &userConn{})
+			ch := smsChallenge("register")
+			code := initiateSmsOTP(t, p, sender, ch)
+			ctx := context.Background()
+
+			for i := range tt.misses {
+				if ok, _ := p.Verify(ctx, ch, "wrong"); ok {
+					t.Fatalf("miss %d: Verify() passed with a wrong code", i)
+				}
+			}
+			if ok, err := p.Verify(ctx, ch, code); ok != tt.want || err != nil {
+				t.Fatalf("Verify() after %d misses = %v, %v, want %v", tt.misses, ok, err, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,41 @@
+package idp
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/go-json-experiment/json"
+)
+
+// ErrConnectionConfigMissing 域 / 应用 IDP 配置均未提供连接参数
+var ErrConnectionConfigMissing = errors.New("connection config is not configured")
+
+// DecodeConnectionConfig 按顺序浅合并多层连接参数（JSON，后者的同名项整体覆盖前者）并解码到 v
+// 通常依次传入域 IDP 配置与应用 IDP 配置的 config 字段
+func DecodeConnectionConfig(v any, layers ...*string) error {
+	merged := make(map[string]any)
+	for _, raw := range layers {
+		if raw == nil || *raw == "" {
+			continue
+		}
+		var layer map[string]any
+		if err := json.Unmarshal([]byte(*raw), &layer); err != nil {
+			return fmt.Errorf("解析连接参数失败: %w", err)
+		}
+		for k, val := range layer {
+			merged[k] = val
+		}
+	}
+	if len(merged) == 0 {
+		return ErrConnectionConfigMissing
+	}
+
+	data, err := json.Marshal(merged)
+	if err != nil {
+		return fmt.Errorf("序列化连接参数失败: %w", err)
+	}
+	if err := json.Unmarshal(data, v); err != nil {
+		return fmt.Errorf("解析连接参数失败: %w", err)
+	}
+	return nil
+}
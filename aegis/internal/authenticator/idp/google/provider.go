@@ -83,12 +83,12 @@ func (p *Provider) Login(ctx context.Context, proof string, params ...any) (*mod
 	return p.getUserInfo(ctx, accessToken)
 }
 
-// Initiate builds the upstream Google authorization URL from the trusted AuthFlow transaction.
+// Initiate builds the upstream Google authorization URL from the trusted login or binding transaction.
 func (p *Provider) Initiate(ctx context.Context, initiation *idp.InitiateContext, _ string) (*idp.InitiateResponse, error) {
-	if initiation == nil || initiation.Flow == nil || initiation.Flow.Request == nil || initiation.Transaction == nil {
+	if initiation == nil || initiation.Transaction == nil || initiation.ClientID() == "" {
 		return nil, errors.New("google oauth initiation context is incomplete")
 	}
-	clientID, _, err := p.cache.GetIDPKey(ctx, initiation.Flow.Request.ClientID, idp.TypeGoogle)
+	clientID, _, err := p.cache.GetIDPKey(ctx, initiation.ClientID(), idp.TypeGoogle)
 	if err != nil {
 		return nil, fmt.Errorf("解析 Google IDP 密钥失败: %w", err)
 	}
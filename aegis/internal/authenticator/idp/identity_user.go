@@ -10,6 +10,9 @@ import (
 
 var errIdentityUserNotFound = errors.New("user not found")
 
+// ErrUserLocked 用户处于登录失败锁定中（user / staff 账号密码登录）
+var ErrUserLocked = errors.New("user is locked")
+
 func ResolveUserIdentity(ctx context.Context, client *hermes.Client, idpType, principal string) (*models.UserWithDecrypted, *models.UserIdentity, error) {
 	if principal == "" {
 		return nil, nil, errIdentityUserNotFound
@@ -26,7 +29,7 @@ func ResolveUserIdentity(ctx context.Context, client *hermes.Client, idpType, pr
 			return user, identity, nil
 		}
 	}
-	if isPhonePrincipal(principal) {
+	if IsPhonePrincipal(principal) {
 		if user, identity, err := resolveByPhone(ctx, client, idpType, principal); err == nil {
 			return user, identity, nil
 		}
@@ -108,7 +111,8 @@ func isEmailPrincipal(s string) bool {
 	return false
 }
 
-func isPhonePrincipal(s string) bool {
+// IsPhonePrincipal 是否为手机号形式的标识（10~15 位数字，可带前导 +）
+func IsPhonePrincipal(s string) bool {
 	if len(s) < 10 || len(s) > 15 {
 		return false
 	}
@@ -13,33 +13,83 @@ import (
 
 const oauthRandomBytes = 32
 
-// OAuthTransaction binds one upstream OAuth authorization request to an AuthFlow.
-// State and CodeVerifier are server-only, short-lived, and consumed atomically.
+// OAuthTransaction binds one upstream OAuth authorization request to an AuthFlow,
+// or, for identity binding, to the signed-in user and the client that requested it.
+// State, CodeVerifier and Nonce are server-only, short-lived, and consumed atomically.
 type OAuthTransaction struct {
-	FlowID        string    `json:"flow_id"`
+	FlowID        string    `json:"flow_id,omitempty"`
+	OpenID        string    `json:"openid,omitempty"`
+	ClientID      string    `json:"client_id,omitempty"`
 	Connection    string    `json:"connection"`
 	RedirectURI   string    `json:"redirect_uri"`
 	State         string    `json:"state"`
 	CodeVerifier  string    `json:"code_verifier"`
 	CodeChallenge string    `json:"code_challenge"`
+	Nonce         string    `json:"nonce,omitempty"`
 	CreatedAt     time.Time `json:"created_at"`
 }
 
 // InitiateContext contains the trusted AuthFlow and, for redirect IDPs, its OAuth transaction.
+// Identity binding has no AuthFlow; the client is carried by the binding transaction.
 type InitiateContext struct {
 	Flow        *types.AuthFlow
 	Transaction *OAuthTransaction
 }
 
+// ClientID returns the application whose IDP keys sign the upstream request.
+func (c *InitiateContext) ClientID() string {
+	if c.Flow != nil && c.Flow.Request != nil {
+		return c.Flow.Request.ClientID
+	}
+	if c.Transaction != nil {
+		return c.Transaction.ClientID
+	}
+	return ""
+}
+
 // OAuthLoginContext carries server-side PKCE material into a Provider during callback completion.
+// Nonce is only checked by providers that receive an OpenID Connect id_token.
 type OAuthLoginContext struct {
 	RedirectURI  string
 	CodeVerifier string
+	Nonce        string
 }
 
-// NewOAuthTransaction creates state and an S256 PKCE pair for one upstream authorization request.
+// NewOAuthTransaction creates state, nonce and an S256 PKCE pair for one upstream authorization request.
 func NewOAuthTransaction(flowID, connection, redirectURI string) (*OAuthTransaction, error) {
-	if flowID == "" || connection == "" || redirectURI == "" {
+	if flowID == "" {
+		return nil, errors.New("oauth transaction context is incomplete")
+	}
+	tx, err := newOAuthTransaction(connection, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+	tx.FlowID = flowID
+	return tx, nil
+}
+
+// NewBindingTransaction creates an upstream authorization request that links the
+// resulting identity to openid instead of completing a login.
+func NewBindingTransaction(openid, clientID, connection, redirectURI string) (*OAuthTransaction, error) {
+	if openid == "" || clientID == "" {
+		return nil, errors.New("oauth transaction context is incomplete")
+	}
+	tx, err := newOAuthTransaction(connection, redirectURI)
+	if err != nil {
+		return nil, err
+	}
+	tx.OpenID = openid
+	tx.ClientID = clientID
+	return tx, nil
+}
+
+// IsBinding reports whether the transaction links an identity to a signed-in user.
+func (tx *OAuthTransaction) IsBinding() bool {
+	return tx.OpenID != ""
+}
+
+func newOAuthTransaction(connection, redirectURI string) (*OAuthTransaction, error) {
+	if connection == "" || redirectURI == "" {
 		return nil, errors.New("oauth transaction context is incomplete")
 	}
 	u, err := url.ParseRequestURI(redirectURI)
@@ -55,14 +105,18 @@ func NewOAuthTransaction(flowID, connection, redirectURI string) (*OAuthTransact
 	if err != nil {
 		return nil, err
 	}
+	nonce, err := randomBase64URL(oauthRandomBytes)
+	if err != nil {
+		return nil, err
+	}
 
 	return &OAuthTransaction{
-		FlowID:        flowID,
 		Connection:    connection,
 		RedirectURI:   redirectURI,
 		State:         state,
 		CodeVerifier:  verifier,
 		CodeChallenge: S256Challenge(verifier),
+		Nonce:         nonce,
 		CreatedAt:     time.Now().UTC(),
 	}, nil
 }
@@ -83,8 +137,10 @@ func randomBase64URL(size int) (string, error) {
 
 // IsOAuthRedirectConnection reports whether a connection uses an upstream browser redirect.
 // The connection name is the discriminator; no transport-level mode field is involved.
+// SAML connections reuse the same transaction: RelayState carries the state and the
+// AuthnRequest ID is derived from the nonce.
 func IsOAuthRedirectConnection(connection string) bool {
-	return connection == TypeGoogle || connection == TypeGithub
+	return connection == TypeGoogle || connection == TypeGithub || IsOIDCConnection(connection) || IsSAMLConnection(connection)
 }
 
 // OAuthLoginContextFromParams finds callback-only OAuth material appended by IDPAuthenticator.
@@ -30,6 +30,9 @@ func TestNewOAuthTransaction(t *testing.T) {
 	if got := S256Challenge(tx.CodeVerifier); got != tx.CodeChallenge {
 		t.Errorf("code challenge = %q, want %q", tx.CodeChallenge, got)
 	}
+	if len(tx.Nonce) != 43 || tx.Nonce == tx.State {
+		t.Errorf("nonce = %q, want a fresh 43-char value", tx.Nonce)
+	}
 }
 
 func TestNewOAuthTransactionRequiresContext(t *testing.T) {
@@ -55,3 +58,45 @@ func TestNewOAuthTransactionRequiresContext(t *testing.T) {
 		})
 	}
 }
+
+func TestNewBindingTransaction(t *testing.T) {
+	t.Parallel()
+
+	tx, err := NewBindingTransaction("user-1", "app-1", TypeGithub, "https://aegis.example/github/callback")
+	if err != nil {
+		t.Fatalf("NewBindingTransaction() error = %v", err)
+	}
+	if !tx.IsBinding() {
+		t.Error("IsBinding() = false, want true")
+	}
+	if tx.FlowID != "" {
+		t.Errorf("FlowID = %q, want empty", tx.FlowID)
+	}
+	if got := (&InitiateContext{Transaction: tx}).ClientID(); got != "app-1" {
+		t.Errorf("InitiateContext.ClientID() = %q, want %q", got, "app-1")
+	}
+
+	if _, err := NewBindingTransaction("", "app-1", TypeGithub, "https://aegis.example/github/callback"); err == nil {
+		t.Error("NewBindingTransaction() without openid error = nil, want error")
+	}
+}
+
+func TestIsOAuthRedirectConnection(t *testing.T) {
+	t.Parallel()
+
+	tests := map[string]bool{
+		TypeGoogle:                true,
+		TypeGithub:                true,
+		TypeOIDCPrefix + "gitlab": true,
+		TypeOIDCPrefix:            false,
+		TypeSAMLPrefix + "acme":   true,
+		TypeSAMLPrefix:            false,
+		TypeUser:                  false,
+		"oidc":                    false,
+	}
+	for connection, want := range tests {
+		if got := IsOAuthRedirectConnection(connection); got != want {
+			t.Errorf("IsOAuthRedirectConnection(%q) = %v, want %v", connection, got, want)
+		}
+	}
+}
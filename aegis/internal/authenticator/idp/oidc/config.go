@@ -0,0 +1,150 @@
+package oidc
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+
+	"github.com/go-json-experiment/json"
+	"github.com/go-json-experiment/json/jsontext"
+
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+)
+
+// 令牌端点客户端认证方式
+const (
+	AuthMethodClientSecretBasic = "client_secret_basic"
+	AuthMethodClientSecretPost  = "client_secret_post"
+)
+
+// Config 通用 OIDC 连接参数
+// 来自域 IDP 配置与应用 IDP 配置的 config 字段（应用级覆盖域级同名项，domainOnlyKeys 除外）；client_id / client_secret 由 IDPKey 提供
+type Config struct {
+	Issuer                  string       `json:"issuer,omitempty"`                 // 上游 issuer，校验 id_token 的 iss；端点未配置时用于发现
+	AuthorizationEndpoint   string       `json:"authorization_endpoint,omitempty"` // 以下端点显式配置时优先于发现文档
+	TokenEndpoint           string       `json:"token_endpoint,omitempty"`
+	UserInfoEndpoint        string       `json:"userinfo_endpoint,omitempty"`
+	JWKSURI                 string       `json:"jwks_uri,omitempty"`
+	Scopes                  []string     `json:"scopes,omitempty"`                     // 默认 openid email profile
+	TokenEndpointAuthMethod string       `json:"token_endpoint_auth_method,omitempty"` // client_secret_basic（默认）/ client_secret_post
+	Claims                  ClaimMapping `json:"claims,omitempty"`                     // claim → TUserInfo 映射
+}
+
+// ClaimMapping claim 映射，值为 id_token / userinfo 合并后 claims 上的 gjson 路径
+type ClaimMapping struct {
+	Subject  string `json:"subject,omitempty"`  // 默认 sub
+	Nickname string `json:"nickname,omitempty"` // 默认 name
+	Email    string `json:"email,omitempty"`    // 默认 email
+	Phone    string `json:"phone,omitempty"`    // 默认 phone_number
+	Picture  string `json:"picture,omitempty"`  // 默认 picture
+}
+
+var defaultScopes = []string{"openid", "email", "profile"}
+
+// domainOnlyKeys 决定上游身份来源的参数，只能在域 IDP 配置中设置
+// 用户身份仅按 (connection, sub) 唯一，应用级覆盖这些参数会让另一个上游签发的同名 sub 登录到已有用户
+var domainOnlyKeys = []string{"issuer", "authorization_endpoint", "token_endpoint", "userinfo_endpoint", "jwks_uri"}
+
+// parseConfig 合并域级与应用级 config（浅合并，应用级同名项整体覆盖）并补齐默认值
+// 应用级 config 不得设置 domainOnlyKeys 与 claims.subject；subject 映射始终取域级配置
+func parseConfig(domainConfig, appConfig *string) (*Config, error) {
+	if err := rejectDomainOnlyKeys(appConfig); err != nil {
+		return nil, err
+	}
+	var cfg Config
+	if err := idp.DecodeConnectionConfig(&cfg, domainConfig, appConfig); err != nil {
+		if errors.Is(err, idp.ErrConnectionConfigMissing) {
+			return nil, errors.New("OIDC 连接参数未配置")
+		}
+		return nil, err
+	}
+	var domain Config
+	if err := idp.DecodeConnectionConfig(&domain, domainConfig); err != nil && !errors.Is(err, idp.ErrConnectionConfigMissing) {
+		return nil, err
+	}
+	cfg.Claims.Subject = domain.Claims.Subject
+	cfg.applyDefaults()
+	if err := cfg.validate(); err != nil {
+		return nil, err
+	}
+	return &cfg, nil
+}
+
+// rejectDomainOnlyKeys 应用级 config 中出现只允许域级设置的参数时报错
+func rejectDomainOnlyKeys(appConfig *string) error {
+	if appConfig == nil || *appConfig == "" {
+		return nil
+	}
+	var layer map[string]jsontext.Value
+	if err := json.Unmarshal([]byte(*appConfig), &layer); err != nil {
+		return fmt.Errorf("解析连接参数失败: %w", err)
+	}
+	for _, key := range domainOnlyKeys {
+		if _, ok := layer[key]; ok {
+			return fmt.Errorf("应用级连接参数不允许设置 %s", key)
+		}
+	}
+	if raw, ok := layer["claims"]; ok {
+		var claims map[string]jsontext.Value
+		if err := json.Unmarshal(raw, &claims); err != nil {
+			return fmt.Errorf("解析连接参数失败: %w", err)
+		}
+		if _, ok := claims["subject"]; ok {
+			return errors.New("应用级连接参数不允许设置 claims.subject")
+		}
+	}
+	return nil
+}
+
+func (c *Config) applyDefaults() {
+	if len(c.Scopes) == 0 {
+		c.Scopes = slices.Clone(defaultScopes)
+	}
+	if c.TokenEndpointAuthMethod == "" {
+		c.TokenEndpointAuthMethod = AuthMethodClientSecretBasic
+	}
+	if c.Claims.Subject == "" {
+		c.Claims.Subject = "sub"
+	}
+	if c.Claims.Nickname == "" {
+		c.Claims.Nickname = "name"
+	}
+	if c.Claims.Email == "" {
+		c.Claims.Email = "email"
+	}
+	if c.Claims.Phone == "" {
+		c.Claims.Phone = "phone_number"
+	}
+	if c.Claims.Picture == "" {
+		c.Claims.Picture = "picture"
+	}
+}
+
+func (c *Config) validate() error {
+	switch c.TokenEndpointAuthMethod {
+	case AuthMethodClientSecretBasic, AuthMethodClientSecretPost:
+	default:
+		return fmt.Errorf("不支持的 token_endpoint_auth_method: %s", c.TokenEndpointAuthMethod)
+	}
+	// 请求 openid scope 时 id_token 的 iss 必须可校验，jwks_uri 未显式配置时从 issuer 的发现文档获取
+	if c.openID() && c.Issuer == "" {
+		return errors.New("请求 openid scope 时必须配置 issuer")
+	}
+	if c.Issuer == "" && (c.AuthorizationEndpoint == "" || c.TokenEndpoint == "") {
+		return errors.New("OIDC 连接参数缺少 issuer 或 authorization_endpoint / token_endpoint")
+	}
+	return nil
+}
+
+// openID 是否请求了 openid scope（决定上游是否返回 id_token）
+func (c *Config) openID() bool {
+	return slices.Contains(c.Scopes, "openid")
+}
+
+// needsDiscovery 是否需要从发现文档补齐端点
+func (c *Config) needsDiscovery() bool {
+	if c.Issuer == "" {
+		return false
+	}
+	return c.AuthorizationEndpoint == "" || c.TokenEndpoint == "" || (c.openID() && c.JWKSURI == "")
+}
@@ -0,0 +1,199 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rsa"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"time"
+
+	"github.com/golang-jwt/jwt/v5"
+)
+
+const (
+	clockSkew      = 2 * time.Minute // exp / iat 容忍的时钟偏差
+	minRSAKeyBits  = 2048
+	maxIDTokenSize = 16 << 10
+)
+
+// JWK 上游 JWKS 中的单个公钥（RFC 7517），仅解析验签所需字段
+type JWK struct {
+	Kty string `json:"kty"`
+	Kid string `json:"kid,omitempty"`
+	Use string `json:"use,omitempty"`
+	Alg string `json:"alg,omitempty"`
+	Crv string `json:"crv,omitempty"`
+	N   string `json:"n,omitempty"`
+	E   string `json:"e,omitempty"`
+	X   string `json:"x,omitempty"`
+	Y   string `json:"y,omitempty"`
+}
+
+// keyResolver 按 kid / alg 获取验签公钥
+type keyResolver func(ctx context.Context, kid, alg string) (crypto.PublicKey, error)
+
+// idTokenAlgs 允许的 id_token 签名算法（非对称算法，排除 none 与 HMAC）
+var idTokenAlgs = []string{"RS256", "RS384", "RS512", "PS256", "PS384", "PS512", "ES256", "ES384", "ES512", "EdDSA"}
+
+// idTokenExpectation id_token 校验期望值
+type idTokenExpectation struct {
+	Issuer   string
+	ClientID string
+	Nonce    string
+	Now      time.Time
+}
+
+// verifyIDToken 校验 id_token 的签名与 claims（OpenID Connect Core 1.0 §3.1.3.7），返回 claims
+// 签名算法限定在 idTokenAlgs 内，验签公钥按 header 的 kid 与 alg 从 JWKS 选取；nonce 必须存在且与授权请求一致
+func verifyIDToken(ctx context.Context, raw string, keys keyResolver, want idTokenExpectation) (map[string]any, error) {
+	if len(raw) > maxIDTokenSize {
+		return nil, errors.New("id_token 过大")
+	}
+	if want.Issuer == "" || want.Nonce == "" {
+		return nil, errors.New("缺少 id_token 校验所需的 issuer 或 nonce")
+	}
+
+	parser := jwt.NewParser(
+		jwt.WithValidMethods(idTokenAlgs),
+		jwt.WithIssuer(want.Issuer),
+		jwt.WithAudience(want.ClientID),
+		jwt.WithExpirationRequired(),
+		jwt.WithIssuedAt(),
+		jwt.WithLeeway(clockSkew),
+		jwt.WithTimeFunc(func() time.Time { return want.Now }),
+	)
+	claims := jwt.MapClaims{}
+	if _, err := parser.ParseWithClaims(raw, claims, func(token *jwt.Token) (any, error) {
+		kid, _ := token.Header["kid"].(string)
+		return keys(ctx, kid, token.Method.Alg())
+	}); err != nil {
+		return nil, fmt.Errorf("id_token 校验失败: %w", err)
+	}
+
+	if err := validateClaims(claims, want); err != nil {
+		return nil, err
+	}
+	return claims, nil
+}
+
+// validateClaims 校验 jwt 库未覆盖的 claims：iat 必须存在、多 audience 时的 azp、nonce、sub
+func validateClaims(claims jwt.MapClaims, want idTokenExpectation) error {
+	if iat, err := claims.GetIssuedAt(); err != nil || iat == nil {
+		return errors.New("id_token 缺少 iat")
+	}
+	audiences, err := claims.GetAudience()
+	if err != nil {
+		return errors.New("id_token audience 无效")
+	}
+	if azp, ok := claims["azp"]; (len(audiences) > 1 || ok) && azp != want.ClientID {
+		return errors.New("id_token azp 与 client_id 不匹配")
+	}
+	if nonce, _ := claims["nonce"].(string); nonce == "" || subtle.ConstantTimeCompare([]byte(nonce), []byte(want.Nonce)) != 1 {
+		return errors.New("id_token nonce 缺失或不匹配")
+	}
+	if sub, err := claims.GetSubject(); err != nil || sub == "" {
+		return errors.New("id_token 缺少 sub")
+	}
+	return nil
+}
+
+// selectKey 从 JWKS 中选出与 kid / alg 匹配的签名公钥；header 未带 kid 时要求 JWKS 中只有一把候选
+func selectKey(keys []JWK, kid, alg string) (crypto.PublicKey, error) {
+	var candidates []JWK
+	for _, k := range keys {
+		if k.Use != "" && k.Use != "sig" {
+			continue
+		}
+		if k.Alg != "" && k.Alg != alg {
+			continue
+		}
+		if kid != "" && k.Kid != kid {
+			continue
+		}
+		if !ktyMatches(k, alg) {
+			continue
+		}
+		candidates = append(candidates, k)
+	}
+	if len(candidates) != 1 {
+		return nil, fmt.Errorf("JWKS 中没有唯一匹配的公钥: kid=%s, alg=%s", kid, alg)
+	}
+	return candidates[0].publicKey()
+}
+
+func ktyMatches(k JWK, alg string) bool {
+	switch alg[:2] {
+	case "RS", "PS":
+		return k.Kty == "RSA"
+	case "ES":
+		return k.Kty == "EC" && k.Crv == map[string]string{"256": "P-256", "384": "P-384", "512": "P-521"}[alg[2:]]
+	default:
+		return k.Kty == "OKP" && k.Crv == "Ed25519"
+	}
+}
+
+// publicKey 将 JWK 转换为标准库公钥
+func (k JWK) publicKey() (crypto.PublicKey, error) {
+	switch k.Kty {
+	case "RSA":
+		n, err := base64.RawURLEncoding.DecodeString(k.N)
+		if err != nil {
+			return nil, errors.New("JWK n 编码无效")
+		}
+		e, err := base64.RawURLEncoding.DecodeString(k.E)
+		if err != nil || len(e) == 0 || len(e) > 4 {
+			return nil, errors.New("JWK e 编码无效")
+		}
+		pub := &rsa.PublicKey{N: new(big.Int).SetBytes(n), E: int(new(big.Int).SetBytes(e).Int64())}
+		if pub.N.BitLen() < minRSAKeyBits {
+			return nil, fmt.Errorf("RSA 公钥长度不足 %d 位", minRSAKeyBits)
+		}
+		return pub, nil
+	case "EC":
+		var curve elliptic.Curve
+		switch k.Crv {
+		case "P-256":
+			curve = elliptic.P256()
+		case "P-384":
+			curve = elliptic.P384()
+		case "P-521":
+			curve = elliptic.P521()
+		default:
+			return nil, fmt.Errorf("不支持的 EC 曲线: %s", k.Crv)
+		}
+		x, errX := base64.RawURLEncoding.DecodeString(k.X)
+		y, errY := base64.RawURLEncoding.DecodeString(k.Y)
+		if errX != nil || errY != nil {
+			return nil, errors.New("JWK 坐标编码无效")
+		}
+		size := (curve.Params().BitSize + 7) / 8
+		if len(x) != size || len(y) != size {
+			return nil, errors.New("JWK 坐标长度无效")
+		}
+		// ParseUncompressedPublicKey 会校验点在曲线上
+		uncompressed := append(append([]byte{4}, x...), y...)
+		pub, err := ecdsa.ParseUncompressedPublicKey(curve, uncompressed)
+		if err != nil {
+			return nil, errors.New("JWK 坐标不在曲线上")
+		}
+		return pub, nil
+	case "OKP":
+		if k.Crv != "Ed25519" {
+			return nil, fmt.Errorf("不支持的 OKP 曲线: %s", k.Crv)
+		}
+		x, err := base64.RawURLEncoding.DecodeString(k.X)
+		if err != nil || len(x) != ed25519.PublicKeySize {
+			return nil, errors.New("JWK x 编码无效")
+		}
+		return ed25519.PublicKey(x), nil
+	default:
+		return nil, fmt.Errorf("不支持的 JWK 类型: %s", k.Kty)
+	}
+}
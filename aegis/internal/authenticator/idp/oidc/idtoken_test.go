@@ -0,0 +1,244 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"encoding/base64"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+func signToken(t *testing.T, alg string, key crypto.Signer, kid string, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]any{"alg": alg, "kid": kid, "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+
+	var signature []byte
+	switch k := key.(type) {
+	case *rsa.PrivateKey:
+		sum := sha256.Sum256([]byte(input))
+		signature, err = rsa.SignPKCS1v15(rand.Reader, k, crypto.SHA256, sum[:])
+	case *ecdsa.PrivateKey:
+		sum := sha256.Sum256([]byte(input))
+		var r, s *big.Int
+		r, s, err = ecdsa.Sign(rand.Reader, k, sum[:])
+		if err == nil {
+			signature = make([]byte, 64)
+			r.FillBytes(signature[:32])
+			s.FillBytes(signature[32:])
+		}
+	case ed25519.PrivateKey:
+		signature = ed25519.Sign(k, []byte(input))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(signature)
+}
+
+// hmacToken 以 HS256 签名，模拟把公钥当作 HMAC 密钥的算法混淆攻击
+func hmacToken(t *testing.T, secret []byte, claims map[string]any) string {
+	t.Helper()
+
+	header, err := json.Marshal(map[string]any{"alg": "HS256", "kid": "k1", "typ": "JWT"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	payload, err := json.Marshal(claims)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := base64.RawURLEncoding.EncodeToString(header) + "." + base64.RawURLEncoding.EncodeToString(payload)
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(input))
+	return input + "." + base64.RawURLEncoding.EncodeToString(mac.Sum(nil))
+}
+
+func rsaJWK(kid string, key *rsa.PublicKey) JWK {
+	return JWK{
+		Kty: "RSA",
+		Kid: kid,
+		N:   base64.RawURLEncoding.EncodeToString(key.N.Bytes()),
+		E:   base64.RawURLEncoding.EncodeToString(big.NewInt(int64(key.E)).Bytes()),
+	}
+}
+
+func ecJWK(kid string, key *ecdsa.PublicKey) JWK {
+	size := (key.Curve.Params().BitSize + 7) / 8
+	return JWK{
+		Kty: "EC",
+		Kid: kid,
+		Crv: key.Curve.Params().Name,
+		X:   base64.RawURLEncoding.EncodeToString(key.X.FillBytes(make([]byte, size))),
+		Y:   base64.RawURLEncoding.EncodeToString(key.Y.FillBytes(make([]byte, size))),
+	}
+}
+
+func staticKeys(key crypto.PublicKey) keyResolver {
+	return func(context.Context, string, string) (crypto.PublicKey, error) {
+		return key, nil
+	}
+}
+
+func TestVerifyIDToken(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecKey, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	_, edKey, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := time.Now()
+	want := idTokenExpectation{Issuer: "https://sso.example", ClientID: "aegis", Nonce: "nonce-1", Now: now}
+	claims := func(overrides map[string]any) map[string]any {
+		c := map[string]any{
+			"iss":   "https://sso.example",
+			"aud":   "aegis",
+			"sub":   "user-1",
+			"nonce": "nonce-1",
+			"iat":   now.Unix(),
+			"exp":   now.Add(5 * time.Minute).Unix(),
+		}
+		for k, v := range overrides {
+			if v == nil {
+				delete(c, k)
+			} else {
+				c[k] = v
+			}
+		}
+		return c
+	}
+
+	tests := []struct {
+		name    string
+		token   string
+		keys    keyResolver
+		wantErr bool
+	}{
+		{name: "RS256", token: signToken(t, "RS256", rsaKey, "k1", claims(nil)), keys: staticKeys(&rsaKey.PublicKey)},
+		{name: "ES256", token: signToken(t, "ES256", ecKey, "k1", claims(nil)), keys: staticKeys(&ecKey.PublicKey)},
+		{name: "EdDSA", token: signToken(t, "EdDSA", edKey, "k1", claims(nil)), keys: staticKeys(edKey.Public())},
+		{name: "audience array", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"aud": []string{"other", "aegis"}, "azp": "aegis"})), keys: staticKeys(&rsaKey.PublicKey)},
+		{name: "alg none", token: signToken(t, "none", rsaKey, "k1", claims(nil)), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "key type mismatch", token: signToken(t, "RS256", rsaKey, "k1", claims(nil)), keys: staticKeys(&ecKey.PublicKey), wantErr: true},
+		{name: "wrong issuer", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"iss": "https://evil.example"})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "wrong audience", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"aud": "other"})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "multiple audiences without azp", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"aud": []string{"other", "aegis"}})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "expired", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"exp": now.Add(-time.Hour).Unix()})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "issued in future", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"iat": now.Add(time.Hour).Unix()})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "nonce mismatch", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"nonce": "replayed"})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "missing nonce", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"nonce": nil})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "missing iat", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"iat": nil})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+		{name: "HS256 with public key as secret", token: hmacToken(t, rsaKey.PublicKey.N.Bytes(), claims(nil)), keys: staticKeys(rsaKey.PublicKey.N.Bytes()), wantErr: true},
+		{name: "missing subject", token: signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"sub": nil})), keys: staticKeys(&rsaKey.PublicKey), wantErr: true},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			_, err := verifyIDToken(context.Background(), test.token, test.keys, want)
+			if (err != nil) != test.wantErr {
+				t.Fatalf("verifyIDToken() error = %v, wantErr %v", err, test.wantErr)
+			}
+		})
+	}
+
+	t.Run("no expected nonce", func(t *testing.T) {
+		t.Parallel()
+		noNonce := want
+		noNonce.Nonce = ""
+		token := signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"nonce": nil}))
+		if _, err := verifyIDToken(context.Background(), token, staticKeys(&rsaKey.PublicKey), noNonce); err == nil {
+			t.Fatal("verifyIDToken() accepted an id_token without a nonce to check")
+		}
+	})
+
+	t.Run("key looked up by kid and alg", func(t *testing.T) {
+		t.Parallel()
+		keys := func(_ context.Context, kid, alg string) (crypto.PublicKey, error) {
+			return selectKey([]JWK{rsaJWK("k1", &rsaKey.PublicKey), ecJWK("k2", &ecKey.PublicKey)}, kid, alg)
+		}
+		got, err := verifyIDToken(context.Background(), signToken(t, "ES256", ecKey, "k2", claims(nil)), keys, want)
+		if err != nil {
+			t.Fatalf("verifyIDToken() error = %v", err)
+		}
+		if got["sub"] != "user-1" {
+			t.Fatalf("claims sub = %v, want user-1", got["sub"])
+		}
+		if _, err := verifyIDToken(context.Background(), signToken(t, "ES256", ecKey, "k1", claims(nil)), keys, want); err == nil {
+			t.Fatal("verifyIDToken() accepted a kid that points at a key of another type")
+		}
+		if _, err := verifyIDToken(context.Background(), signToken(t, "RS256", rsaKey, "k3", claims(nil)), keys, want); err == nil {
+			t.Fatal("verifyIDToken() accepted an unknown kid")
+		}
+	})
+
+	t.Run("tampered payload", func(t *testing.T) {
+		t.Parallel()
+		token := strings.Split(signToken(t, "RS256", rsaKey, "k1", claims(nil)), ".")
+		other := strings.Split(signToken(t, "RS256", rsaKey, "k1", claims(map[string]any{"sub": "admin"})), ".")
+		forged := token[0] + "." + other[1] + "." + token[2]
+		if _, err := verifyIDToken(context.Background(), forged, staticKeys(&rsaKey.PublicKey), want); err == nil {
+			t.Fatal("verifyIDToken() accepted a tampered payload")
+		}
+	})
+}
+
+func TestSelectKey(t *testing.T) {
+	t.Parallel()
+
+	rsaKey, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	weakKey, err := rsa.GenerateKey(rand.Reader, 1024)
+	if err != nil {
+		t.Fatal(err)
+	}
+	encKey := rsaJWK("enc", &rsaKey.PublicKey)
+	encKey.Use = "enc"
+	keys := []JWK{rsaJWK("k1", &rsaKey.PublicKey), rsaJWK("weak", &weakKey.PublicKey), encKey}
+
+	if _, err := selectKey(keys, "k1", "RS256"); err != nil {
+		t.Errorf("selectKey(k1) error = %v", err)
+	}
+	if _, err := selectKey(keys, "k1", "ES256"); err == nil {
+		t.Error("selectKey() matched an RSA key for ES256")
+	}
+	if _, err := selectKey(keys, "weak", "RS256"); err == nil {
+		t.Error("selectKey() accepted a 1024-bit RSA key")
+	}
+	if _, err := selectKey(keys, "enc", "RS256"); err == nil {
+		t.Error("selectKey() accepted an encryption key")
+	}
+	if _, err := selectKey(keys, "", "RS256"); err == nil {
+		t.Error("selectKey() picked a key without kid among several candidates")
+	}
+}
@@ -0,0 +1,228 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+const (
+	discoveryPath    = "/.well-known/openid-configuration"
+	discoveryTTL     = time.Hour        // 发现文档缓存时长
+	jwksTTL          = time.Hour        // JWKS 缓存时长
+	jwksMinRefresh   = time.Minute      // 遇到未知 kid 时强制刷新 JWKS 的最小间隔（防止被 kid 刷爆上游）
+	httpTimeout      = 10 * time.Second // 上游请求超时
+	maxResponseBytes = 1 << 20          // 上游响应体上限
+)
+
+// Metadata 上游端点（显式配置与发现文档合并后的结果）
+type Metadata struct {
+	Issuer                string
+	AuthorizationEndpoint string
+	TokenEndpoint         string
+	UserInfoEndpoint      string
+	JWKSURI               string
+}
+
+type discoveryDocument struct {
+	Issuer                string `json:"issuer"`
+	AuthorizationEndpoint string `json:"authorization_endpoint"`
+	TokenEndpoint         string `json:"token_endpoint"`
+	UserInfoEndpoint      string `json:"userinfo_endpoint"`
+	JWKSURI               string `json:"jwks_uri"`
+}
+
+type cachedDiscovery struct {
+	doc       *discoveryDocument
+	fetchedAt time.Time
+}
+
+type cachedKeySet struct {
+	keys      []JWK
+	fetchedAt time.Time
+}
+
+// MetadataCache 发现文档与 JWKS 的进程内缓存，所有 OIDC connection 共用
+type MetadataCache struct {
+	client *http.Client
+
+	mu        sync.Mutex
+	discovery map[string]*cachedDiscovery // issuer → 发现文档
+	keySets   map[string]*cachedKeySet    // jwks_uri → 公钥集
+}
+
+// NewMetadataCache 创建元数据缓存
+func NewMetadataCache() *MetadataCache {
+	return &MetadataCache{
+		client:    &http.Client{Timeout: httpTimeout},
+		discovery: make(map[string]*cachedDiscovery),
+		keySets:   make(map[string]*cachedKeySet),
+	}
+}
+
+// Resolve 解析连接的上游端点：显式配置优先，缺失的端点从 issuer 的发现文档补齐
+func (m *MetadataCache) Resolve(ctx context.Context, cfg *Config) (*Metadata, error) {
+	meta := &Metadata{
+		Issuer:                cfg.Issuer,
+		AuthorizationEndpoint: cfg.AuthorizationEndpoint,
+		TokenEndpoint:         cfg.TokenEndpoint,
+		UserInfoEndpoint:      cfg.UserInfoEndpoint,
+		JWKSURI:               cfg.JWKSURI,
+	}
+	if cfg.needsDiscovery() {
+		doc, err := m.discover(ctx, cfg.Issuer)
+		if err != nil {
+			return nil, err
+		}
+		meta.AuthorizationEndpoint = firstNonEmpty(meta.AuthorizationEndpoint, doc.AuthorizationEndpoint)
+		meta.TokenEndpoint = firstNonEmpty(meta.TokenEndpoint, doc.TokenEndpoint)
+		meta.UserInfoEndpoint = firstNonEmpty(meta.UserInfoEndpoint, doc.UserInfoEndpoint)
+		meta.JWKSURI = firstNonEmpty(meta.JWKSURI, doc.JWKSURI)
+	}
+
+	for name, endpoint := range map[string]string{
+		"authorization_endpoint": meta.AuthorizationEndpoint,
+		"token_endpoint":         meta.TokenEndpoint,
+	} {
+		if err := requireHTTPS(endpoint); err != nil {
+			return nil, fmt.Errorf("%s 无效: %w", name, err)
+		}
+	}
+	for name, endpoint := range map[string]string{
+		"userinfo_endpoint": meta.UserInfoEndpoint,
+		"jwks_uri":          meta.JWKSURI,
+	} {
+		if endpoint == "" {
+			continue
+		}
+		if err := requireHTTPS(endpoint); err != nil {
+			return nil, fmt.Errorf("%s 无效: %w", name, err)
+		}
+	}
+	if cfg.openID() && meta.JWKSURI == "" {
+		return nil, errors.New("请求了 openid scope 但未配置 jwks_uri")
+	}
+	if !cfg.openID() && meta.UserInfoEndpoint == "" {
+		return nil, errors.New("未请求 openid scope 时必须配置 userinfo_endpoint")
+	}
+	return meta, nil
+}
+
+// discover 获取 issuer 的发现文档（OpenID Connect Discovery 1.0 §4），文档中的 issuer 必须与配置一致
+func (m *MetadataCache) discover(ctx context.Context, issuer string) (*discoveryDocument, error) {
+	m.mu.Lock()
+	cached, ok := m.discovery[issuer]
+	m.mu.Unlock()
+	if ok && time.Since(cached.fetchedAt) < discoveryTTL {
+		return cached.doc, nil
+	}
+
+	if err := requireHTTPS(issuer); err != nil {
+		return nil, fmt.Errorf("issuer 无效: %w", err)
+	}
+	var doc discoveryDocument
+	if err := m.getJSON(ctx, strings.TrimRight(issuer, "/")+discoveryPath, &doc); err != nil {
+		if ok {
+			logger.Warnf("[OIDC] 刷新发现文档失败，继续使用缓存 - Issuer: %s, Error: %v", issuer, err)
+			return cached.doc, nil
+		}
+		return nil, fmt.Errorf("获取发现文档失败: %w", err)
+	}
+	if doc.Issuer != issuer {
+		return nil, fmt.Errorf("发现文档 issuer 不匹配: %s", doc.Issuer)
+	}
+
+	m.mu.Lock()
+	m.discovery[issuer] = &cachedDiscovery{doc: &doc, fetchedAt: time.Now()}
+	m.mu.Unlock()
+	return &doc, nil
+}
+
+// Key 按 kid / alg 从 JWKS 选取验签公钥；缓存中找不到时（上游轮换密钥）限频刷新一次
+func (m *MetadataCache) Key(ctx context.Context, jwksURI, kid, alg string) (crypto.PublicKey, error) {
+	m.mu.Lock()
+	cached, ok := m.keySets[jwksURI]
+	m.mu.Unlock()
+
+	if ok && time.Since(cached.fetchedAt) < jwksTTL {
+		if key, err := selectKey(cached.keys, kid, alg); err == nil {
+			return key, nil
+		}
+		if time.Since(cached.fetchedAt) < jwksMinRefresh {
+			return nil, fmt.Errorf("JWKS 中没有匹配的公钥: kid=%s, alg=%s", kid, alg)
+		}
+	}
+
+	var set struct {
+		Keys []JWK `json:"keys"`
+	}
+	if err := m.getJSON(ctx, jwksURI, &set); err != nil {
+		return nil, fmt.Errorf("获取 JWKS 失败: %w", err)
+	}
+	m.mu.Lock()
+	m.keySets[jwksURI] = &cachedKeySet{keys: set.Keys, fetchedAt: time.Now()}
+	m.mu.Unlock()
+
+	return selectKey(set.Keys, kid, alg)
+}
+
+func (m *MetadataCache) getJSON(ctx context.Context, endpoint string, v any) error {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := m.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("[OIDC] close response body failed: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return fmt.Errorf("HTTP %d", resp.StatusCode)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return fmt.Errorf("读取响应失败: %w", err)
+	}
+	if err := json.Unmarshal(body, v); err != nil {
+		return fmt.Errorf("解析响应失败: %w", err)
+	}
+	return nil
+}
+
+// requireHTTPS 上游端点必须是不含 userinfo / fragment 的绝对 HTTPS 地址
+func requireHTTPS(raw string) error {
+	if raw == "" {
+		return errors.New("未配置")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil || u.Fragment != "" {
+		return fmt.Errorf("必须是绝对 HTTPS 地址: %s", raw)
+	}
+	return nil
+}
+
+func firstNonEmpty(values ...string) string {
+	for _, v := range values {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
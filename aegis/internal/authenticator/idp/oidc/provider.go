@@ -0,0 +1,333 @@
+package oidc
+
+import (
+	"context"
+	"crypto"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"github.com/tidwall/gjson"
+
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// Provider 通用 OIDC Provider
+// 一个实例对应一个 oidc-{name} connection，连接参数按应用从域 / 应用 IDP 配置中动态解析
+type Provider struct {
+	connection string
+	cache      *cache.Manager
+	metadata   *MetadataCache
+	client     *http.Client
+}
+
+// NewProvider 创建通用 OIDC Provider
+func NewProvider(connection string, cacheManager *cache.Manager, metadata *MetadataCache) *Provider {
+	return &Provider{
+		connection: connection,
+		cache:      cacheManager,
+		metadata:   metadata,
+		client:     &http.Client{Timeout: httpTimeout},
+	}
+}
+
+// Type 返回 IDP 类型（即 connection 本身，如 oidc-keycloak）
+func (p *Provider) Type() string {
+	return p.connection
+}
+
+// Login 用授权码换取令牌，校验 id_token 并按 claim 映射生成用户信息
+// proof: OAuth authorization code
+// params[0]: appID (string) — 用于解析 IDP 密钥与连接参数
+func (p *Provider) Login(ctx context.Context, proof string, params ...any) (*models.TUserInfo, error) {
+	if proof == "" {
+		return nil, errors.New("code is required")
+	}
+	oauthCtx := idp.OAuthLoginContextFromParams(params)
+	if oauthCtx == nil {
+		return nil, errors.New("oidc login context is missing")
+	}
+
+	appID := ""
+	if len(params) > 0 {
+		if v, ok := params[0].(string); ok {
+			appID = v
+		}
+	}
+	cfg, meta, err := p.resolve(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	clientID, clientSecret, err := p.cache.GetIDPKey(ctx, appID, p.connection)
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s IDP 密钥失败: %w", p.connection, err)
+	}
+
+	logger.Infof("[OIDC] 处理 OAuth 回调 - Connection: %s", p.connection)
+
+	tokens, err := p.exchangeCode(ctx, cfg, meta, proof, clientID, clientSecret, oauthCtx)
+	if err != nil {
+		return nil, err
+	}
+
+	claims := map[string]any{}
+	if cfg.openID() {
+		idToken := gjson.Get(tokens, "id_token").String()
+		if idToken == "" {
+			return nil, errors.New("响应中缺少 id_token")
+		}
+		claims, err = verifyIDToken(ctx, idToken, func(ctx context.Context, kid, alg string) (crypto.PublicKey, error) {
+			return p.metadata.Key(ctx, meta.JWKSURI, kid, alg)
+		}, idTokenExpectation{
+			Issuer:   meta.Issuer,
+			ClientID: clientID,
+			Nonce:    oauthCtx.Nonce,
+			Now:      time.Now(),
+		})
+		if err != nil {
+			logger.Warnf("[OIDC] id_token 校验失败 - Connection: %s, Error: %v", p.connection, err)
+			return nil, err
+		}
+	}
+
+	if meta.UserInfoEndpoint != "" {
+		accessToken := gjson.Get(tokens, "access_token").String()
+		if accessToken == "" {
+			return nil, errors.New("响应中缺少 access_token")
+		}
+		userInfo, err := p.getUserInfo(ctx, meta.UserInfoEndpoint, accessToken)
+		if err != nil {
+			return nil, err
+		}
+		// userinfo 的 sub 必须与 id_token 一致（OpenID Connect Core 1.0 §5.3.2）
+		if sub, ok := claims["sub"]; ok && userInfo["sub"] != sub {
+			return nil, errors.New("userinfo sub 与 id_token 不一致")
+		}
+		for k, v := range userInfo {
+			claims[k] = v
+		}
+	}
+
+	return mapClaims(cfg.Claims, claims)
+}
+
+// Initiate 按连接参数构建上游授权地址
+func (p *Provider) Initiate(ctx context.Context, initiation *idp.InitiateContext, _ string) (*idp.InitiateResponse, error) {
+	if initiation == nil || initiation.Transaction == nil || initiation.ClientID() == "" {
+		return nil, errors.New("oidc initiation context is incomplete")
+	}
+	cfg, meta, err := p.resolve(ctx, initiation.ClientID())
+	if err != nil {
+		return nil, err
+	}
+	clientID, _, err := p.cache.GetIDPKey(ctx, initiation.ClientID(), p.connection)
+	if err != nil {
+		return nil, fmt.Errorf("解析 %s IDP 密钥失败: %w", p.connection, err)
+	}
+	authorizationURL, err := buildAuthorizationURL(meta.AuthorizationEndpoint, clientID, cfg.Scopes, initiation.Transaction)
+	if err != nil {
+		return nil, err
+	}
+	return &idp.InitiateResponse{URL: authorizationURL}, nil
+}
+
+// Resolve 社交登录不支持通过 principal 本地查找
+func (p *Provider) Resolve(_ context.Context, _ string) (*models.TUserInfo, error) {
+	return nil, fmt.Errorf("%s provider does not support resolve", p.connection)
+}
+
+// FetchAdditionalInfo 补充获取用户信息
+func (p *Provider) FetchAdditionalInfo(_ context.Context, infoType string, _ ...any) (*idp.AdditionalInfo, error) {
+	return nil, fmt.Errorf("%s does not support fetching %s", p.connection, infoType)
+}
+
+// Prepare 准备前端所需的公开配置（密钥与连接参数动态解析，此处不含 Identifier）
+func (p *Provider) Prepare() *types.ConnectionConfig {
+	return &types.ConnectionConfig{
+		Connection: p.connection,
+	}
+}
+
+// resolve 解析应用的连接参数与上游端点
+func (p *Provider) resolve(ctx context.Context, appID string) (*Config, *Metadata, error) {
+	cfg, err := p.loadConfig(ctx, appID)
+	if err != nil {
+		return nil, nil, err
+	}
+	meta, err := p.metadata.Resolve(ctx, cfg)
+	if err != nil {
+		return nil, nil, fmt.Errorf("解析 %s 上游端点失败: %w", p.connection, err)
+	}
+	return cfg, meta, nil
+}
+
+// loadConfig 读取域 IDP 配置与应用 IDP 配置中的连接参数，应用级覆盖域级（issuer / 端点 / JWKS 只能由域级配置）
+func (p *Provider) loadConfig(ctx context.Context, appID string) (*Config, error) {
+	if appID == "" {
+		return nil, errors.New("app_id is required")
+	}
+	domainConfig, appConfig, err := p.cache.GetIDPConnectionConfig(ctx, appID, p.connection)
+	if err != nil {
+		return nil, fmt.Errorf("获取 %s 连接参数失败: %w", p.connection, err)
+	}
+	cfg, err := parseConfig(domainConfig, appConfig)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", p.connection, err)
+	}
+	return cfg, nil
+}
+
+// exchangeCode 用授权码换取令牌，返回令牌端点响应 JSON
+func (p *Provider) exchangeCode(ctx context.Context, cfg *Config, meta *Metadata, code, clientID, clientSecret string, oauthCtx *idp.OAuthLoginContext) (string, error) {
+	form := url.Values{}
+	form.Set("grant_type", "authorization_code")
+	form.Set("code", code)
+	form.Set("redirect_uri", oauthCtx.RedirectURI)
+	if oauthCtx.CodeVerifier != "" {
+		form.Set("code_verifier", oauthCtx.CodeVerifier)
+	}
+	if cfg.TokenEndpointAuthMethod == AuthMethodClientSecretPost {
+		form.Set("client_id", clientID)
+		form.Set("client_secret", clientSecret)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, meta.TokenEndpoint, strings.NewReader(form.Encode()))
+	if err != nil {
+		return "", fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	req.Header.Set("Accept", "application/json")
+	if cfg.TokenEndpointAuthMethod == AuthMethodClientSecretBasic {
+		// RFC 6749 §2.3.1：凭证先做 form-urlencoded 再 Basic
+		req.SetBasicAuth(url.QueryEscape(clientID), url.QueryEscape(clientSecret))
+	}
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.Errorf("[OIDC] 请求令牌失败 - Connection: %s, Error: %v", p.connection, err)
+		return "", fmt.Errorf("请求令牌失败: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("[OIDC] close response body failed: %v", err)
+		}
+	}()
+
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return "", fmt.Errorf("读取响应失败: %w", err)
+	}
+	bodyStr := string(bodyBytes)
+	logger.Debugf("[OIDC] token endpoint status: %d", resp.StatusCode)
+
+	if errMsg := gjson.Get(bodyStr, "error").String(); errMsg != "" {
+		errDesc := gjson.Get(bodyStr, "error_description").String()
+		logger.Errorf("[OIDC] 换取令牌失败 - Connection: %s, Error: %s - %s", p.connection, errMsg, errDesc)
+		return "", fmt.Errorf("换取令牌失败: %s", errMsg)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return "", fmt.Errorf("换取令牌失败: HTTP %d", resp.StatusCode)
+	}
+	return bodyStr, nil
+}
+
+// getUserInfo 获取 userinfo claims
+func (p *Provider) getUserInfo(ctx context.Context, endpoint, accessToken string) (map[string]any, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, endpoint, nil)
+	if err != nil {
+		return nil, fmt.Errorf("创建请求失败: %w", err)
+	}
+	req.Header.Set("Authorization", "Bearer "+accessToken)
+	req.Header.Set("Accept", "application/json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		logger.Errorf("[OIDC] 请求用户信息失败 - Connection: %s, Error: %v", p.connection, err)
+		return nil, fmt.Errorf("请求用户信息失败: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			logger.Warnf("[OIDC] close response body failed: %v", err)
+		}
+	}()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("获取用户信息失败: HTTP %d", resp.StatusCode)
+	}
+
+	bodyBytes, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBytes))
+	if err != nil {
+		return nil, fmt.Errorf("读取响应失败: %w", err)
+	}
+	// 签名 / 加密的 userinfo（application/jwt）不支持
+	var claims map[string]any
+	if err := json.Unmarshal(bodyBytes, &claims); err != nil {
+		return nil, fmt.Errorf("解析用户信息失败: %w", err)
+	}
+	return claims, nil
+}
+
+func buildAuthorizationURL(endpoint, clientID string, scopes []string, tx *idp.OAuthTransaction) (string, error) {
+	if endpoint == "" || clientID == "" || tx.RedirectURI == "" || tx.State == "" || tx.CodeChallenge == "" {
+		return "", errors.New("oidc authorization parameters are incomplete")
+	}
+	u, err := url.Parse(endpoint)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("client_id", clientID)
+	query.Set("redirect_uri", tx.RedirectURI)
+	query.Set("response_type", "code")
+	query.Set("scope", strings.Join(scopes, " "))
+	query.Set("state", tx.State)
+	query.Set("code_challenge", tx.CodeChallenge)
+	query.Set("code_challenge_method", "S256")
+	// openid 流程必须携带 nonce，回调时据此校验 id_token
+	if slices.Contains(scopes, "openid") {
+		if tx.Nonce == "" {
+			return "", errors.New("nonce is required for openid scope")
+		}
+		query.Set("nonce", tx.Nonce)
+	}
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+// mapClaims 按 claim 映射生成用户信息；email_verified 显式为 false 时不采信 email
+func mapClaims(mapping ClaimMapping, claims map[string]any) (*models.TUserInfo, error) {
+	raw, err := json.Marshal(claims)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 claims 失败: %w", err)
+	}
+	rawStr := string(raw)
+
+	subject := gjson.Get(rawStr, mapping.Subject).String()
+	if subject == "" {
+		return nil, fmt.Errorf("claims 中缺少 %s", mapping.Subject)
+	}
+	email := gjson.Get(rawStr, mapping.Email).String()
+	if verified := gjson.Get(rawStr, "email_verified"); verified.Exists() && !verified.Bool() {
+		email = ""
+	}
+
+	logger.Infof("[OIDC] 登录成功 - Subject: %s", subject)
+
+	return &models.TUserInfo{
+		TOpenID:  subject,
+		Nickname: gjson.Get(rawStr, mapping.Nickname).String(),
+		Email:    email,
+		Phone:    gjson.Get(rawStr, mapping.Phone).String(),
+		Picture:  gjson.Get(rawStr, mapping.Picture).String(),
+		RawData:  rawStr,
+	}, nil
+}
@@ -0,0 +1,189 @@
+package oidc
+
+import (
+	"net/url"
+	"testing"
+
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+)
+
+func strPtr(s string) *string { return &s }
+
+func TestBuildAuthorizationURL(t *testing.T) {
+	t.Parallel()
+
+	tx := &idp.OAuthTransaction{
+		RedirectURI:   "https://aegis.example/auth/idps/oidc-keycloak/callback",
+		State:         "oauth-state",
+		Nonce:         "oauth-nonce",
+		CodeChallenge: "pkce-challenge",
+	}
+	raw, err := buildAuthorizationURL(
+		"https://sso.example/realms/main/protocol/openid-connect/auth?kc_idp_hint=corp",
+		"keycloak-client",
+		[]string{"openid", "email"},
+		tx,
+	)
+	if err != nil {
+		t.Fatalf("buildAuthorizationURL() error = %v", err)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	if u.Host != "sso.example" || u.Path != "/realms/main/protocol/openid-connect/auth" {
+		t.Errorf("authorization endpoint = %s", u.String())
+	}
+
+	query := u.Query()
+	wants := map[string]string{
+		"client_id":             "keycloak-client",
+		"redirect_uri":          tx.RedirectURI,
+		"response_type":         "code",
+		"scope":                 "openid email",
+		"state":                 "oauth-state",
+		"nonce":                 "oauth-nonce",
+		"code_challenge":        "pkce-challenge",
+		"code_challenge_method": "S256",
+		"kc_idp_hint":           "corp",
+	}
+	for key, want := range wants {
+		if got := query.Get(key); got != want {
+			t.Errorf("query[%s] = %q, want %q", key, got, want)
+		}
+	}
+
+	raw, err = buildAuthorizationURL("https://oauth.example/authorize", "client", []string{"read:user"}, tx)
+	if err != nil {
+		t.Fatalf("buildAuthorizationURL() error = %v", err)
+	}
+	if u, _ := url.Parse(raw); u.Query().Has("nonce") {
+		t.Error("nonce sent without openid scope")
+	}
+
+	noNonce := *tx
+	noNonce.Nonce = ""
+	if _, err := buildAuthorizationURL("https://sso.example/authorize", "client", []string{"openid"}, &noNonce); err == nil {
+		t.Error("buildAuthorizationURL() built an openid request without nonce")
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	t.Parallel()
+
+	domain := strPtr(`{"issuer":"https://sso.example","scopes":["openid","email"],"claims":{"nickname":"preferred_username"}}`)
+	app := strPtr(`{"scopes":["openid","profile"],"token_endpoint_auth_method":"client_secret_post"}`)
+
+	cfg, err := parseConfig(domain, app)
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	if cfg.Issuer != "https://sso.example" {
+		t.Errorf("Issuer = %q", cfg.Issuer)
+	}
+	if len(cfg.Scopes) != 2 || cfg.Scopes[1] != "profile" {
+		t.Errorf("Scopes = %v, want application override", cfg.Scopes)
+	}
+	if cfg.TokenEndpointAuthMethod != AuthMethodClientSecretPost {
+		t.Errorf("TokenEndpointAuthMethod = %q", cfg.TokenEndpointAuthMethod)
+	}
+	if cfg.Claims.Nickname != "preferred_username" || cfg.Claims.Subject != "sub" {
+		t.Errorf("Claims = %+v", cfg.Claims)
+	}
+	if !cfg.needsDiscovery() {
+		t.Error("needsDiscovery() = false without explicit endpoints")
+	}
+
+	invalid := []*string{
+		nil,
+		strPtr(`{"scopes":["openid"]}`),
+		strPtr(`{"authorization_endpoint":"https://sso.example/authorize","token_endpoint":"https://sso.example/token"}`),
+		strPtr(`{"issuer":"https://sso.example","token_endpoint_auth_method":"private_key_jwt"}`),
+		strPtr(`not json`),
+	}
+	for _, raw := range invalid {
+		if _, err := parseConfig(raw, nil); err == nil {
+			t.Errorf("parseConfig(%v) error = nil", raw)
+		}
+	}
+
+	oauth2 := strPtr(`{"authorization_endpoint":"https://sso.example/authorize","token_endpoint":"https://sso.example/token","userinfo_endpoint":"https://sso.example/userinfo","scopes":["email"]}`)
+	if _, err := parseConfig(oauth2, nil); err != nil {
+		t.Errorf("parseConfig(oauth2 without openid) error = %v", err)
+	}
+}
+
+func TestParseConfigRejectsApplicationOverrides(t *testing.T) {
+	t.Parallel()
+
+	domain := strPtr(`{"issuer":"https://sso.example","claims":{"subject":"oid"}}`)
+	for _, app := range []string{
+		`{"issuer":"https://attacker.example"}`,
+		`{"jwks_uri":"https://attacker.example/jwks"}`,
+		`{"token_endpoint":"https://attacker.example/token"}`,
+		`{"claims":{"subject":"email"}}`,
+	} {
+		if _, err := parseConfig(domain, strPtr(app)); err == nil {
+			t.Errorf("parseConfig(app=%s) error = nil", app)
+		}
+	}
+
+	cfg, err := parseConfig(domain, strPtr(`{"claims":{"nickname":"preferred_username"}}`))
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	if cfg.Claims.Subject != "oid" {
+		t.Errorf("Claims.Subject = %q, want domain mapping", cfg.Claims.Subject)
+	}
+}
+
+func TestRequireHTTPS(t *testing.T) {
+	t.Parallel()
+
+	for raw, wantErr := range map[string]bool{
+		"https://sso.example/token":      false,
+		"http://sso.example/token":       true,
+		"https://user:pw@sso.example/":   true,
+		"https://sso.example/token#frag": true,
+		"/token":                         true,
+		"":                               true,
+	} {
+		if err := requireHTTPS(raw); (err != nil) != wantErr {
+			t.Errorf("requireHTTPS(%q) error = %v, wantErr %v", raw, err, wantErr)
+		}
+	}
+}
+
+func TestMapClaims(t *testing.T) {
+	t.Parallel()
+
+	mapping := ClaimMapping{Subject: "sub", Nickname: "profile.display", Email: "email", Phone: "phone_number", Picture: "picture"}
+	info, err := mapClaims(mapping, map[string]any{
+		"sub":            "user-1",
+		"profile":        map[string]any{"display": "Alice"},
+		"email":          "alice@example.com",
+		"email_verified": true,
+	})
+	if err != nil {
+		t.Fatalf("mapClaims() error = %v", err)
+	}
+	if info.TOpenID != "user-1" || info.Nickname != "Alice" || info.Email != "alice@example.com" {
+		t.Errorf("mapClaims() = %+v", info)
+	}
+	if info.RawData == "" {
+		t.Error("RawData is empty")
+	}
+
+	info, err = mapClaims(mapping, map[string]any{"sub": "user-2", "email": "bob@example.com", "email_verified": false})
+	if err != nil {
+		t.Fatalf("mapClaims() error = %v", err)
+	}
+	if info.Email != "" {
+		t.Errorf("unverified email was kept: %q", info.Email)
+	}
+
+	if _, err := mapClaims(mapping, map[string]any{"email": "x@example.com"}); err == nil {
+		t.Error("mapClaims() accepted claims without subject")
+	}
+}
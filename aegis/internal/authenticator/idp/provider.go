@@ -38,6 +38,11 @@ type Initiator interface {
 	Initiate(ctx context.Context, initiation *InitiateContext, strategy string) (*InitiateResponse, error)
 }
 
+// MetadataPublisher 对外发布 SP 元数据的 IDP（如 SAML），上游管理员据此配置信任关系。
+type MetadataPublisher interface {
+	Metadata(ctx context.Context, appID string) ([]byte, error)
+}
+
 // InitiateResponse IDP 认证入口响应。
 type InitiateResponse struct {
 	UID     string         `json:"uid,omitempty"`
@@ -0,0 +1,208 @@
+package saml
+
+import (
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/pem"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"strings"
+
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+)
+
+// SAML 2.0 命名空间与常量
+const (
+	nsProtocol  = "urn:oasis:names:tc:SAML:2.0:protocol"
+	nsAssertion = "urn:oasis:names:tc:SAML:2.0:assertion"
+	nsDSig      = "http://www.w3.org/2000/09/xmldsig#"
+
+	bindingHTTPRedirect = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect"
+	bindingHTTPPost     = "urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST"
+
+	statusSuccess         = "urn:oasis:names:tc:SAML:2.0:status:Success"
+	confirmationBearer    = "urn:oasis:names:tc:SAML:2.0:cm:bearer"
+	nameIDFormatTransient = "urn:oasis:names:tc:SAML:2.0:nameid-format:transient"
+)
+
+// Config SAML 连接参数
+// 来自域 IDP 配置与应用 IDP 配置的 config 字段（应用级覆盖域级同名项）；
+// idp_metadata 提供 IdP 元数据 XML，entity_id / sso_url / certificates 显式配置时优先于元数据
+type Config struct {
+	Metadata     string           `json:"idp_metadata,omitempty"`   // IdP 元数据 XML（EntityDescriptor）
+	EntityID     string           `json:"entity_id,omitempty"`      // IdP EntityID，校验响应与断言的 Issuer
+	SSOURL       string           `json:"sso_url,omitempty"`        // IdP 的 HTTP-Redirect SingleSignOnService 地址
+	Certificates []string         `json:"certificates,omitempty"`   // IdP 签名证书（PEM 或 base64 DER），支持多张用于轮换
+	NameIDFormat string           `json:"name_id_format,omitempty"` // AuthnRequest 的 NameIDPolicy Format，默认不指定
+	Attributes   AttributeMapping `json:"attributes,omitempty"`     // 属性 → TUserInfo 映射
+}
+
+// AttributeMapping 断言属性映射，值为 Attribute 的 Name（或 FriendlyName）
+type AttributeMapping struct {
+	Subject  string `json:"subject,omitempty"`  // 默认使用 NameID
+	Nickname string `json:"nickname,omitempty"` // 默认 displayName
+	Email    string `json:"email,omitempty"`    // 默认 email
+	Phone    string `json:"phone,omitempty"`    // 默认 phone
+	Picture  string `json:"picture,omitempty"`  // 默认不映射
+}
+
+// identityProvider 解析后的上游 IdP
+type identityProvider struct {
+	EntityID     string
+	SSOURL       string
+	Certificates []*x509.Certificate
+}
+
+// parseConfig 合并域级与应用级 config 并解析上游 IdP
+func parseConfig(layers ...*string) (*Config, *identityProvider, error) {
+	var cfg Config
+	if err := idp.DecodeConnectionConfig(&cfg, layers...); err != nil {
+		if errors.Is(err, idp.ErrConnectionConfigMissing) {
+			return nil, nil, errors.New("SAML 连接参数未配置")
+		}
+		return nil, nil, err
+	}
+	cfg.applyDefaults()
+
+	provider := &identityProvider{EntityID: cfg.EntityID, SSOURL: cfg.SSOURL}
+	var certs []string
+	if cfg.Metadata != "" {
+		md, err := parseIDPMetadata([]byte(cfg.Metadata))
+		if err != nil {
+			return nil, nil, err
+		}
+		if provider.EntityID == "" {
+			provider.EntityID = md.EntityID
+		}
+		if provider.SSOURL == "" {
+			provider.SSOURL = md.SSOURL
+		}
+		certs = md.certificates
+	}
+	if len(cfg.Certificates) > 0 {
+		certs = cfg.Certificates
+	}
+	for _, raw := range certs {
+		cert, err := parseCertificate(raw)
+		if err != nil {
+			return nil, nil, err
+		}
+		provider.Certificates = append(provider.Certificates, cert)
+	}
+
+	if provider.EntityID == "" {
+		return nil, nil, errors.New("SAML 连接参数缺少 IdP entity_id")
+	}
+	if err := requireHTTPS(provider.SSOURL); err != nil {
+		return nil, nil, fmt.Errorf("SAML sso_url 无效: %w", err)
+	}
+	if len(provider.Certificates) == 0 {
+		return nil, nil, errors.New("SAML 连接参数缺少 IdP 签名证书")
+	}
+	return &cfg, provider, nil
+}
+
+func (c *Config) applyDefaults() {
+	if c.Attributes.Nickname == "" {
+		c.Attributes.Nickname = "displayName"
+	}
+	if c.Attributes.Email == "" {
+		c.Attributes.Email = "email"
+	}
+	if c.Attributes.Phone == "" {
+		c.Attributes.Phone = "phone"
+	}
+}
+
+// idpMetadata IdP 元数据中用到的部分
+type idpMetadata struct {
+	EntityID     string
+	SSOURL       string
+	certificates []string
+}
+
+type entityDescriptor struct {
+	XMLName          xml.Name `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID         string   `xml:"entityID,attr"`
+	IDPSSODescriptor *struct {
+		KeyDescriptors []struct {
+			Use          string   `xml:"use,attr"`
+			Certificates []string `xml:"http://www.w3.org/2000/09/xmldsig# KeyInfo>X509Data>X509Certificate"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:metadata KeyDescriptor"`
+		SingleSignOnServices []struct {
+			Binding  string `xml:"Binding,attr"`
+			Location string `xml:"Location,attr"`
+		} `xml:"urn:oasis:names:tc:SAML:2.0:metadata SingleSignOnService"`
+	} `xml:"urn:oasis:names:tc:SAML:2.0:metadata IDPSSODescriptor"`
+}
+
+// parseIDPMetadata 解析 IdP 元数据：EntityID、HTTP-Redirect SSO 地址与签名证书（元数据本身的签名不校验，信任来自管理员配置）
+func parseIDPMetadata(data []byte) (*idpMetadata, error) {
+	if err := rejectDTD(data); err != nil {
+		return nil, err
+	}
+	var ed entityDescriptor
+	if err := xml.Unmarshal(data, &ed); err != nil {
+		return nil, fmt.Errorf("解析 IdP 元数据失败: %w", err)
+	}
+	if ed.IDPSSODescriptor == nil {
+		return nil, errors.New("上游 IdP 元数据缺少 IDPSSODescriptor")
+	}
+
+	md := &idpMetadata{EntityID: ed.EntityID}
+	for _, sso := range ed.IDPSSODescriptor.SingleSignOnServices {
+		if sso.Binding == bindingHTTPRedirect {
+			md.SSOURL = sso.Location
+			break
+		}
+	}
+	for _, kd := range ed.IDPSSODescriptor.KeyDescriptors {
+		if kd.Use != "" && kd.Use != "signing" {
+			continue
+		}
+		md.certificates = append(md.certificates, kd.Certificates...)
+	}
+	return md, nil
+}
+
+// parseCertificate 解析 PEM 或 base64 DER 证书
+func parseCertificate(raw string) (*x509.Certificate, error) {
+	raw = strings.TrimSpace(raw)
+	var der []byte
+	if block, _ := pem.Decode([]byte(raw)); block != nil {
+		der = block.Bytes
+	} else {
+		decoded, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(raw), ""))
+		if err != nil {
+			return nil, errors.New("上游 IdP 证书编码无效")
+		}
+		der = decoded
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		return nil, fmt.Errorf("解析 IdP 证书失败: %w", err)
+	}
+	return cert, nil
+}
+
+// rejectDTD 拒绝携带 DTD 的 XML（防御实体扩展类攻击）
+func rejectDTD(data []byte) error {
+	if strings.Contains(string(data), "<!DOCTYPE") {
+		return errors.New("XML 不允许包含 DTD")
+	}
+	return nil
+}
+
+// requireHTTPS 上游地址必须是不含 userinfo / fragment 的绝对 HTTPS 地址
+func requireHTTPS(raw string) error {
+	if raw == "" {
+		return errors.New("未配置")
+	}
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil || u.Fragment != "" {
+		return fmt.Errorf("必须是绝对 HTTPS 地址: %s", raw)
+	}
+	return nil
+}
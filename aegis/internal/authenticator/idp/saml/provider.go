@@ -0,0 +1,186 @@
+package saml
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// Provider SAML 2.0 SP Provider
+// 一个实例对应一个 saml-{name} connection，IdP 元数据 / 证书按应用从域 / 应用 IDP 配置中动态解析；
+// SP EntityID 按应用区分（config.GetSAMLEntityID），ACS 为 connection 的回调地址
+type Provider struct {
+	connection string
+	cache      *cache.Manager
+}
+
+// NewProvider 创建 SAML Provider
+func NewProvider(connection string, cacheManager *cache.Manager) *Provider {
+	return &Provider{
+		connection: connection,
+		cache:      cacheManager,
+	}
+}
+
+// Type 返回 IDP 类型（即 connection 本身，如 saml-acme）
+func (p *Provider) Type() string {
+	return p.connection
+}
+
+// Login 校验 IdP 回传的断言并按属性映射生成用户信息
+// proof: HTTP-POST binding 的 SAMLResponse（base64）
+// params[0]: appID (string) — 用于解析连接参数与 SP EntityID
+func (p *Provider) Login(ctx context.Context, proof string, params ...any) (*models.TUserInfo, error) {
+	if proof == "" {
+		return nil, errors.New("SAMLResponse is required")
+	}
+	oauthCtx := idp.OAuthLoginContextFromParams(params)
+	if oauthCtx == nil {
+		return nil, errors.New("saml login context is missing")
+	}
+
+	appID := ""
+	if len(params) > 0 {
+		if v, ok := params[0].(string); ok {
+			appID = v
+		}
+	}
+	cfg, provider, err := p.loadConfig(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+
+	logger.Infof("[SAML] 处理断言 - Connection: %s", p.connection)
+
+	info, err := parseResponse(proof, responseExpectation{
+		SPEntityID: config.GetSAMLEntityID(p.connection, appID),
+		ACSURL:     oauthCtx.RedirectURI,
+		RequestID:  requestID(oauthCtx.Nonce),
+		IDP:        provider,
+		Now:        time.Now(),
+	})
+	if err != nil {
+		logger.Warnf("[SAML] 断言校验失败 - Connection: %s, Error: %v", p.connection, err)
+		return nil, err
+	}
+	// 断言在有效期内只能使用一次
+	claimed, err := p.cache.ClaimSAMLAssertion(ctx, p.connection, info.ID, time.Until(info.ExpiresAt))
+	if err != nil {
+		return nil, err
+	}
+	if !claimed {
+		logger.Warnf("[SAML] 断言重放 - Connection: %s, AssertionID: %s", p.connection, info.ID)
+		return nil, errors.New("SAML 断言已被使用")
+	}
+	return mapAttributes(cfg.Attributes, info)
+}
+
+// Initiate 构建 HTTP-Redirect binding 的 AuthnRequest 地址
+func (p *Provider) Initiate(ctx context.Context, initiation *idp.InitiateContext, _ string) (*idp.InitiateResponse, error) {
+	if initiation == nil || initiation.Transaction == nil || initiation.ClientID() == "" {
+		return nil, errors.New("saml initiation context is incomplete")
+	}
+	appID := initiation.ClientID()
+	cfg, provider, err := p.loadConfig(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	redirectURL, err := buildRedirectURL(provider, config.GetSAMLEntityID(p.connection, appID), cfg.NameIDFormat, initiation.Transaction, time.Now())
+	if err != nil {
+		return nil, err
+	}
+	return &idp.InitiateResponse{URL: redirectURL}, nil
+}
+
+// Metadata 生成应用的 SP 元数据，供企业 IdP 管理员导入
+func (p *Provider) Metadata(ctx context.Context, appID string) ([]byte, error) {
+	cfg, _, err := p.loadConfig(ctx, appID)
+	if err != nil {
+		return nil, err
+	}
+	return buildSPMetadata(config.GetSAMLEntityID(p.connection, appID), config.GetIDPRedirectURI(p.connection), cfg.NameIDFormat)
+}
+
+// Resolve SAML 登录不支持通过 principal 本地查找
+func (p *Provider) Resolve(_ context.Context, _ string) (*models.TUserInfo, error) {
+	return nil, fmt.Errorf("%s provider does not support resolve", p.connection)
+}
+
+// FetchAdditionalInfo 补充获取用户信息
+func (p *Provider) FetchAdditionalInfo(_ context.Context, infoType string, _ ...any) (*idp.AdditionalInfo, error) {
+	return nil, fmt.Errorf("%s does not support fetching %s", p.connection, infoType)
+}
+
+// Prepare 准备前端所需的公开配置（连接参数动态解析，此处不含 Identifier）
+func (p *Provider) Prepare() *types.ConnectionConfig {
+	return &types.ConnectionConfig{
+		Connection: p.connection,
+	}
+}
+
+// loadConfig 读取域 IDP 配置与应用 IDP 配置中的连接参数，应用级覆盖域级
+func (p *Provider) loadConfig(ctx context.Context, appID string) (*Config, *identityProvider, error) {
+	if appID == "" {
+		return nil, nil, errors.New("app_id is required")
+	}
+	domainConfig, appConfig, err := p.cache.GetIDPConnectionConfig(ctx, appID, p.connection)
+	if err != nil {
+		return nil, nil, fmt.Errorf("获取 %s 连接参数失败: %w", p.connection, err)
+	}
+	cfg, provider, err := parseConfig(domainConfig, appConfig)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%s: %w", p.connection, err)
+	}
+	return cfg, provider, nil
+}
+
+// mapAttributes 按属性映射生成用户信息；subject 未映射属性时使用 NameID（transient NameID 每次登录都会变化，不可作为标识）
+func mapAttributes(mapping AttributeMapping, info *assertionInfo) (*models.TUserInfo, error) {
+	subject := info.NameID
+	if mapping.Subject != "" {
+		subject = firstValue(info.Attributes, mapping.Subject)
+	} else if info.NameIDFormat == nameIDFormatTransient {
+		return nil, errors.New("transient NameID 不能作为用户标识，请配置 attributes.subject")
+	}
+	if subject == "" {
+		return nil, errors.New("断言中缺少用户标识")
+	}
+
+	raw, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("序列化断言失败: %w", err)
+	}
+
+	logger.Infof("[SAML] 登录成功 - Subject: %s", subject)
+
+	return &models.TUserInfo{
+		TOpenID:  subject,
+		Nickname: firstValue(info.Attributes, mapping.Nickname),
+		Email:    firstValue(info.Attributes, mapping.Email),
+		Phone:    firstValue(info.Attributes, mapping.Phone),
+		Picture:  firstValue(info.Attributes, mapping.Picture),
+		RawData:  string(raw),
+	}, nil
+}
+
+func firstValue(attributes map[string][]string, name string) string {
+	if name == "" {
+		return ""
+	}
+	for _, v := range attributes[name] {
+		if v != "" {
+			return v
+		}
+	}
+	return ""
+}
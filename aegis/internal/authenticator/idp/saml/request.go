@@ -0,0 +1,131 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/xml"
+	"errors"
+	"fmt"
+	"net/url"
+	"time"
+
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+)
+
+type authnRequest struct {
+	XMLName                     xml.Name      `xml:"urn:oasis:names:tc:SAML:2.0:protocol AuthnRequest"`
+	ID                          string        `xml:"ID,attr"`
+	Version                     string        `xml:"Version,attr"`
+	IssueInstant                string        `xml:"IssueInstant,attr"`
+	Destination                 string        `xml:"Destination,attr"`
+	AssertionConsumerServiceURL string        `xml:"AssertionConsumerServiceURL,attr"`
+	ProtocolBinding             string        `xml:"ProtocolBinding,attr"`
+	Issuer                      issuer        `xml:"urn:oasis:names:tc:SAML:2.0:assertion Issuer"`
+	NameIDPolicy                *nameIDPolicy `xml:"urn:oasis:names:tc:SAML:2.0:protocol NameIDPolicy,omitempty"`
+}
+
+type issuer struct {
+	Value string `xml:",chardata"`
+}
+
+type nameIDPolicy struct {
+	Format      string `xml:"Format,attr,omitempty"`
+	AllowCreate bool   `xml:"AllowCreate,attr"`
+}
+
+// requestID 由 transaction 的 nonce 派生 AuthnRequest ID（xs:ID 须以字母或下划线开头）
+// 回调时 InResponseTo 必须等于该值，transaction 原子消费保证同一请求的响应只被接受一次
+func requestID(nonce string) string {
+	if nonce == "" {
+		return ""
+	}
+	return "_" + nonce
+}
+
+// buildRedirectURL 构建 HTTP-Redirect binding 的 AuthnRequest 地址（SAML 2.0 Bindings §3.4）
+// AuthnRequest 不签名；RelayState 携带 transaction state
+func buildRedirectURL(provider *identityProvider, spEntityID, nameIDFormat string, tx *idp.OAuthTransaction, now time.Time) (string, error) {
+	if tx == nil || tx.RedirectURI == "" || tx.State == "" || tx.Nonce == "" || spEntityID == "" {
+		return "", errors.New("saml authn request parameters are incomplete")
+	}
+	req := authnRequest{
+		ID:                          requestID(tx.Nonce),
+		Version:                     "2.0",
+		IssueInstant:                now.UTC().Format(time.RFC3339),
+		Destination:                 provider.SSOURL,
+		AssertionConsumerServiceURL: tx.RedirectURI,
+		ProtocolBinding:             bindingHTTPPost,
+		Issuer:                      issuer{Value: spEntityID},
+		NameIDPolicy:                &nameIDPolicy{Format: nameIDFormat, AllowCreate: true},
+	}
+	data, err := xml.Marshal(req)
+	if err != nil {
+		return "", fmt.Errorf("序列化 AuthnRequest 失败: %w", err)
+	}
+
+	var buf bytes.Buffer
+	w, err := flate.NewWriter(&buf, flate.BestCompression)
+	if err != nil {
+		return "", err
+	}
+	if _, err := w.Write(data); err != nil {
+		return "", err
+	}
+	if err := w.Close(); err != nil {
+		return "", err
+	}
+
+	u, err := url.Parse(provider.SSOURL)
+	if err != nil {
+		return "", err
+	}
+	query := u.Query()
+	query.Set("SAMLRequest", base64.StdEncoding.EncodeToString(buf.Bytes()))
+	query.Set("RelayState", tx.State)
+	u.RawQuery = query.Encode()
+	return u.String(), nil
+}
+
+type spEntityDescriptor struct {
+	XMLName         xml.Name        `xml:"urn:oasis:names:tc:SAML:2.0:metadata EntityDescriptor"`
+	EntityID        string          `xml:"entityID,attr"`
+	SPSSODescriptor spSSODescriptor `xml:"SPSSODescriptor"`
+}
+
+type spSSODescriptor struct {
+	AuthnRequestsSigned        bool                       `xml:"AuthnRequestsSigned,attr"`
+	WantAssertionsSigned       bool                       `xml:"WantAssertionsSigned,attr"`
+	ProtocolSupportEnumeration string                     `xml:"protocolSupportEnumeration,attr"`
+	NameIDFormats              []string                   `xml:"NameIDFormat,omitempty"`
+	AssertionConsumerServices  []assertionConsumerService `xml:"AssertionConsumerService"`
+}
+
+type assertionConsumerService struct {
+	Binding   string `xml:"Binding,attr"`
+	Location  string `xml:"Location,attr"`
+	Index     int    `xml:"index,attr"`
+	IsDefault bool   `xml:"isDefault,attr"`
+}
+
+// buildSPMetadata 生成应用的 SP 元数据：HTTP-POST ACS，要求断言签名，AuthnRequest 不签名
+func buildSPMetadata(spEntityID, acsURL, nameIDFormat string) ([]byte, error) {
+	md := spEntityDescriptor{
+		EntityID: spEntityID,
+		SPSSODescriptor: spSSODescriptor{
+			WantAssertionsSigned:       true,
+			ProtocolSupportEnumeration: nsProtocol,
+			AssertionConsumerServices: []assertionConsumerService{
+				{Binding: bindingHTTPPost, Location: acsURL, Index: 0, IsDefault: true},
+			},
+		},
+	}
+	if nameIDFormat != "" {
+		md.SPSSODescriptor.NameIDFormats = []string{nameIDFormat}
+	}
+	data, err := xml.MarshalIndent(md, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("序列化 SP 元数据失败: %w", err)
+	}
+	return append([]byte(xml.Header), data...), nil
+}
@@ -0,0 +1,131 @@
+package saml
+
+import (
+	"bytes"
+	"compress/flate"
+	"encoding/base64"
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strconv"
+	"testing"
+	"time"
+
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+)
+
+func TestBuildRedirectURL(t *testing.T) {
+	t.Parallel()
+
+	provider := &identityProvider{EntityID: testIDPEntityID, SSOURL: "https://idp.example/saml/sso?tenant=acme"}
+	tx := &idp.OAuthTransaction{
+		RedirectURI: testACSURL,
+		State:       "oauth-state",
+		Nonce:       "request-nonce",
+	}
+	raw, err := buildRedirectURL(provider, testSPEntityID, "", tx, time.Now())
+	if err != nil {
+		t.Fatalf("buildRedirectURL() error = %v", err)
+	}
+
+	u, err := url.Parse(raw)
+	if err != nil {
+		t.Fatalf("url.Parse() error = %v", err)
+	}
+	query := u.Query()
+	if u.Host != "idp.example" || u.Path != "/saml/sso" || query.Get("tenant") != "acme" {
+		t.Errorf("sso url = %s", raw)
+	}
+	if got := query.Get("RelayState"); got != "oauth-state" {
+		t.Errorf("RelayState = %q, want oauth-state", got)
+	}
+
+	deflated, err := base64.StdEncoding.DecodeString(query.Get("SAMLRequest"))
+	if err != nil {
+		t.Fatalf("SAMLRequest base64 error = %v", err)
+	}
+	data, err := io.ReadAll(flate.NewReader(bytes.NewReader(deflated)))
+	if err != nil {
+		t.Fatalf("SAMLRequest inflate error = %v", err)
+	}
+	var req authnRequest
+	if err := xml.Unmarshal(data, &req); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if req.ID != requestID(tx.Nonce) {
+		t.Errorf("ID = %q, want %q", req.ID, requestID(tx.Nonce))
+	}
+	if req.Issuer.Value != testSPEntityID {
+		t.Errorf("Issuer = %q", req.Issuer.Value)
+	}
+	if req.AssertionConsumerServiceURL != testACSURL || req.ProtocolBinding != bindingHTTPPost {
+		t.Errorf("ACS = %q (%s)", req.AssertionConsumerServiceURL, req.ProtocolBinding)
+	}
+	if req.Destination != provider.SSOURL {
+		t.Errorf("Destination = %q", req.Destination)
+	}
+}
+
+func TestBuildSPMetadata(t *testing.T) {
+	t.Parallel()
+
+	data, err := buildSPMetadata(testSPEntityID, testACSURL, "urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress")
+	if err != nil {
+		t.Fatalf("buildSPMetadata() error = %v", err)
+	}
+	var md spEntityDescriptor
+	if err := xml.Unmarshal(data, &md); err != nil {
+		t.Fatalf("xml.Unmarshal() error = %v", err)
+	}
+	if md.EntityID != testSPEntityID {
+		t.Errorf("entityID = %q", md.EntityID)
+	}
+	sp := md.SPSSODescriptor
+	if !sp.WantAssertionsSigned || sp.AuthnRequestsSigned {
+		t.Errorf("signing flags = want assertions %v, authn requests %v", sp.WantAssertionsSigned, sp.AuthnRequestsSigned)
+	}
+	if len(sp.AssertionConsumerServices) != 1 || sp.AssertionConsumerServices[0].Location != testACSURL || sp.AssertionConsumerServices[0].Binding != bindingHTTPPost {
+		t.Errorf("ACS = %+v", sp.AssertionConsumerServices)
+	}
+	if len(sp.NameIDFormats) != 1 {
+		t.Errorf("NameIDFormats = %v", sp.NameIDFormats)
+	}
+}
+
+func TestParseConfigFromMetadata(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	cert := base64.StdEncoding.EncodeToString(signer.cert.Raw)
+	metadata := `<md:EntityDescriptor xmlns:md="urn:oasis:names:tc:SAML:2.0:metadata" xmlns:ds="http://www.w3.org/2000/09/xmldsig#" entityID="` + testIDPEntityID + `">` +
+		`<md:IDPSSODescriptor protocolSupportEnumeration="urn:oasis:names:tc:SAML:2.0:protocol">` +
+		`<md:KeyDescriptor use="encryption"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>bm90LWEtY2VydA==</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:KeyDescriptor use="signing"><ds:KeyInfo><ds:X509Data><ds:X509Certificate>` + cert + `</ds:X509Certificate></ds:X509Data></ds:KeyInfo></md:KeyDescriptor>` +
+		`<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-POST" Location="https://idp.example/saml/post"/>` +
+		`<md:SingleSignOnService Binding="urn:oasis:names:tc:SAML:2.0:bindings:HTTP-Redirect" Location="https://idp.example/saml/sso"/>` +
+		`</md:IDPSSODescriptor></md:EntityDescriptor>`
+
+	domain := `{"idp_metadata":` + strconv.Quote(metadata) + `}`
+	app := `{"attributes":{"subject":"uid"}}`
+	cfg, provider, err := parseConfig(&domain, &app)
+	if err != nil {
+		t.Fatalf("parseConfig() error = %v", err)
+	}
+	if provider.EntityID != testIDPEntityID || provider.SSOURL != "https://idp.example/saml/sso" {
+		t.Errorf("provider = %+v", provider)
+	}
+	if len(provider.Certificates) != 1 || !provider.Certificates[0].Equal(signer.cert) {
+		t.Errorf("certificates = %d, want the signing certificate only", len(provider.Certificates))
+	}
+	if cfg.Attributes.Subject != "uid" || cfg.Attributes.Email != "email" {
+		t.Errorf("attributes = %+v", cfg.Attributes)
+	}
+
+	insecure := `{"entity_id":"` + testIDPEntityID + `","sso_url":"http://idp.example/sso","certificates":["` + cert + `"]}`
+	if _, _, err := parseConfig(&insecure); err == nil {
+		t.Error("parseConfig() with http sso_url error = nil, want error")
+	}
+	if _, _, err := parseConfig(nil, nil); err == nil {
+		t.Error("parseConfig() without config error = nil, want error")
+	}
+}
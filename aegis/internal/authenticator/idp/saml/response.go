@@ -0,0 +1,291 @@
+package saml
+
+import (
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+	"github.com/russellhaering/goxmldsig/etreeutils"
+)
+
+const (
+	clockSkew       = 2 * time.Minute // NotBefore / NotOnOrAfter 容忍的时钟偏差
+	maxResponseSize = 256 << 10       // SAMLResponse（base64）上限
+)
+
+// responseExpectation 响应校验期望值
+type responseExpectation struct {
+	SPEntityID string // Audience
+	ACSURL     string // Destination / Recipient
+	RequestID  string // InResponseTo，来自一次性消费的 transaction
+	IDP        *identityProvider
+	Now        time.Time
+}
+
+// assertionInfo 校验通过的断言内容
+type assertionInfo struct {
+	ID           string              `json:"-"` // Assertion ID，用于防重放
+	ExpiresAt    time.Time           `json:"-"` // 断言不再被接受的时间（含时钟偏差），防重放标记保留至此
+	NameID       string              `json:"name_id"`
+	NameIDFormat string              `json:"name_id_format,omitempty"`
+	SessionIndex string              `json:"session_index,omitempty"`
+	Attributes   map[string][]string `json:"attributes,omitempty"`
+}
+
+// parseResponse 解码并校验 HTTP-POST binding 的 SAMLResponse（SAML 2.0 Profiles §4.1.4.3）
+// 签名可以在 Response 或 Assertion 上（至少其一），后续只读取验签返回的元素，防止 XML 签名包装攻击；
+// 不支持加密断言与 IdP 发起的登录（InResponseTo 必须与本次 AuthnRequest 一致）。
+// Response 未签名时其 InResponseTo 不可信，此时要求已签名断言的 SubjectConfirmationData 携带本次请求的 InResponseTo
+func parseResponse(encoded string, want responseExpectation) (*assertionInfo, error) {
+	if len(encoded) > maxResponseSize {
+		return nil, errors.New("SAML 响应过大")
+	}
+	data, err := base64.StdEncoding.DecodeString(strings.Join(strings.Fields(encoded), ""))
+	if err != nil {
+		return nil, errors.New("SAML 响应编码无效")
+	}
+	if err := rejectDTD(data); err != nil {
+		return nil, err
+	}
+	doc := etree.NewDocument()
+	if err := doc.ReadFromBytes(data); err != nil {
+		return nil, fmt.Errorf("解析 SAML 响应失败: %w", err)
+	}
+	response := doc.Root()
+	if response == nil || !is(response, nsProtocol, "Response") {
+		return nil, errors.New("SAML 响应不是 samlp:Response")
+	}
+
+	validator := dsig.NewDefaultValidationContext(&dsig.MemoryX509CertificateStore{Roots: want.IDP.Certificates})
+	responseSigned := child(response, nsDSig, "Signature") != nil
+	if responseSigned {
+		if response, err = validator.Validate(response); err != nil {
+			return nil, fmt.Errorf("SAML 响应签名无效: %w", err)
+		}
+	}
+
+	if err := validateResponse(response, want); err != nil {
+		return nil, err
+	}
+
+	if len(children(response, nsAssertion, "EncryptedAssertion")) > 0 {
+		return nil, errors.New("不支持加密断言")
+	}
+	assertions := children(response, nsAssertion, "Assertion")
+	if len(assertions) != 1 {
+		return nil, errors.New("SAML 响应必须包含且仅包含一个 Assertion")
+	}
+	assertion := assertions[0]
+	if child(assertion, nsDSig, "Signature") != nil {
+		if assertion, err = validateDetached(validator, assertion); err != nil {
+			return nil, fmt.Errorf("断言签名无效: %w", err)
+		}
+	} else if !responseSigned {
+		return nil, errors.New("SAML 响应与 Assertion 均未签名")
+	}
+
+	return validateAssertion(assertion, want, responseSigned)
+}
+
+// validateDetached 校验嵌套元素的签名：先带上祖先声明的命名空间再验签
+func validateDetached(validator *dsig.ValidationContext, el *etree.Element) (*etree.Element, error) {
+	nsCtx, err := etreeutils.NSBuildParentContext(el)
+	if err != nil {
+		return nil, err
+	}
+	detached, err := etreeutils.NSDetatch(nsCtx, el)
+	if err != nil {
+		return nil, err
+	}
+	return validator.Validate(detached)
+}
+
+func validateResponse(response *etree.Element, want responseExpectation) error {
+	if response.SelectAttrValue("Version", "") != "2.0" {
+		return errors.New("SAML 响应版本不是 2.0")
+	}
+	if dest := response.SelectAttrValue("Destination", ""); dest != "" && dest != want.ACSURL {
+		return fmt.Errorf("SAML 响应 Destination 不匹配: %s", dest)
+	}
+	if irt := response.SelectAttrValue("InResponseTo", ""); want.RequestID == "" || irt != want.RequestID {
+		return errors.New("SAML 响应 InResponseTo 不匹配")
+	}
+	if issuer := child(response, nsAssertion, "Issuer"); issuer != nil && strings.TrimSpace(issuer.Text()) != want.IDP.EntityID {
+		return errors.New("SAML 响应 Issuer 不匹配")
+	}
+
+	statusCode := child(child(response, nsProtocol, "Status"), nsProtocol, "StatusCode")
+	if statusCode == nil {
+		return errors.New("SAML 响应缺少 StatusCode")
+	}
+	if code := statusCode.SelectAttrValue("Value", ""); code != statusSuccess {
+		if sub := child(statusCode, nsProtocol, "StatusCode"); sub != nil {
+			code += " / " + sub.SelectAttrValue("Value", "")
+		}
+		return fmt.Errorf("上游认证失败: %s", code)
+	}
+	return nil
+}
+
+func validateAssertion(assertion *etree.Element, want responseExpectation, responseSigned bool) (*assertionInfo, error) {
+	if assertion.SelectAttrValue("Version", "") != "2.0" {
+		return nil, errors.New("断言版本不是 2.0")
+	}
+	id := assertion.SelectAttrValue("ID", "")
+	if id == "" {
+		return nil, errors.New("断言缺少 ID")
+	}
+	issuer := child(assertion, nsAssertion, "Issuer")
+	if issuer == nil || strings.TrimSpace(issuer.Text()) != want.IDP.EntityID {
+		return nil, errors.New("断言 Issuer 不匹配")
+	}
+
+	subject := child(assertion, nsAssertion, "Subject")
+	if subject == nil {
+		return nil, errors.New("断言缺少 Subject")
+	}
+	expiresAt, err := validateSubjectConfirmation(subject, want, responseSigned)
+	if err != nil {
+		return nil, err
+	}
+	conditionsExpiry, err := validateConditions(child(assertion, nsAssertion, "Conditions"), want)
+	if err != nil {
+		return nil, err
+	}
+	if !conditionsExpiry.IsZero() && conditionsExpiry.Before(expiresAt) {
+		expiresAt = conditionsExpiry
+	}
+
+	info := &assertionInfo{
+		ID:         id,
+		ExpiresAt:  expiresAt.Add(clockSkew),
+		Attributes: make(map[string][]string),
+	}
+	if nameID := child(subject, nsAssertion, "NameID"); nameID != nil {
+		info.NameID = strings.TrimSpace(nameID.Text())
+		info.NameIDFormat = nameID.SelectAttrValue("Format", "")
+	}
+	if authn := child(assertion, nsAssertion, "AuthnStatement"); authn != nil {
+		info.SessionIndex = authn.SelectAttrValue("SessionIndex", "")
+	}
+	for _, statement := range children(assertion, nsAssertion, "AttributeStatement") {
+		for _, attr := range children(statement, nsAssertion, "Attribute") {
+			var values []string
+			for _, v := range children(attr, nsAssertion, "AttributeValue") {
+				values = append(values, strings.TrimSpace(v.Text()))
+			}
+			for _, key := range []string{attr.SelectAttrValue("Name", ""), attr.SelectAttrValue("FriendlyName", "")} {
+				if key != "" {
+					info.Attributes[key] = append(info.Attributes[key], values...)
+				}
+			}
+		}
+	}
+	return info, nil
+}
+
+// validateSubjectConfirmation 至少一个 bearer SubjectConfirmation 的 Recipient / NotOnOrAfter / InResponseTo 有效，返回其 NotOnOrAfter
+// Response 未签名时 InResponseTo 必须出现在（已签名的）SubjectConfirmationData 中
+func validateSubjectConfirmation(subject *etree.Element, want responseExpectation, responseSigned bool) (time.Time, error) {
+	for _, confirmation := range children(subject, nsAssertion, "SubjectConfirmation") {
+		if confirmation.SelectAttrValue("Method", "") != confirmationBearer {
+			continue
+		}
+		data := child(confirmation, nsAssertion, "SubjectConfirmationData")
+		if data == nil {
+			continue
+		}
+		if data.SelectAttrValue("Recipient", "") != want.ACSURL {
+			continue
+		}
+		if irt := data.SelectAttrValue("InResponseTo", ""); irt != want.RequestID && (irt != "" || !responseSigned) {
+			continue
+		}
+		notOnOrAfter, err := parseTime(data.SelectAttrValue("NotOnOrAfter", ""))
+		if err != nil || !want.Now.Before(notOnOrAfter.Add(clockSkew)) {
+			continue
+		}
+		if nb := data.SelectAttrValue("NotBefore", ""); nb != "" {
+			if notBefore, err := parseTime(nb); err != nil || want.Now.Add(clockSkew).Before(notBefore) {
+				continue
+			}
+		}
+		return notOnOrAfter, nil
+	}
+	return time.Time{}, errors.New("断言缺少有效的 bearer SubjectConfirmation")
+}
+
+// validateConditions 校验有效期与受众：每个 AudienceRestriction 都必须包含本应用的 SP EntityID；返回 NotOnOrAfter（未设置时为零值）
+func validateConditions(conditions *etree.Element, want responseExpectation) (time.Time, error) {
+	if conditions == nil {
+		return time.Time{}, errors.New("断言缺少 Conditions")
+	}
+	if nb := conditions.SelectAttrValue("NotBefore", ""); nb != "" {
+		notBefore, err := parseTime(nb)
+		if err != nil || want.Now.Add(clockSkew).Before(notBefore) {
+			return time.Time{}, errors.New("断言尚未生效")
+		}
+	}
+	var notOnOrAfter time.Time
+	if noa := conditions.SelectAttrValue("NotOnOrAfter", ""); noa != "" {
+		var err error
+		if notOnOrAfter, err = parseTime(noa); err != nil || !want.Now.Before(notOnOrAfter.Add(clockSkew)) {
+			return time.Time{}, errors.New("断言已过期")
+		}
+	}
+
+	restrictions := children(conditions, nsAssertion, "AudienceRestriction")
+	if len(restrictions) == 0 {
+		return time.Time{}, errors.New("断言缺少 AudienceRestriction")
+	}
+	for _, restriction := range restrictions {
+		matched := false
+		for _, audience := range children(restriction, nsAssertion, "Audience") {
+			if strings.TrimSpace(audience.Text()) == want.SPEntityID {
+				matched = true
+				break
+			}
+		}
+		if !matched {
+			return time.Time{}, errors.New("断言 Audience 不包含当前 SP")
+		}
+	}
+	return notOnOrAfter, nil
+}
+
+func parseTime(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, errors.New("missing time")
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+func is(el *etree.Element, namespace, tag string) bool {
+	return el.Tag == tag && el.NamespaceURI() == namespace
+}
+
+func child(el *etree.Element, namespace, tag string) *etree.Element {
+	if el == nil {
+		return nil
+	}
+	for _, c := range el.ChildElements() {
+		if is(c, namespace, tag) {
+			return c
+		}
+	}
+	return nil
+}
+
+func children(el *etree.Element, namespace, tag string) []*etree.Element {
+	var result []*etree.Element
+	for _, c := range el.ChildElements() {
+		if is(c, namespace, tag) {
+			result = append(result, c)
+		}
+	}
+	return result
+}
@@ -0,0 +1,320 @@
+package saml
+
+import (
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/base64"
+	"fmt"
+	"math/big"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/beevik/etree"
+	dsig "github.com/russellhaering/goxmldsig"
+)
+
+const (
+	testIDPEntityID = "https://idp.example/saml"
+	testSPEntityID  = "https://aegis.example/auth/idps/saml-acme/metadata/app-1"
+	testACSURL      = "https://aegis.example/auth/idps/saml-acme/callback"
+	testRequestID   = "_request-nonce"
+)
+
+type testSigner struct {
+	key  *rsa.PrivateKey
+	cert *x509.Certificate
+}
+
+func newTestSigner(t *testing.T) *testSigner {
+	t.Helper()
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("rsa.GenerateKey() error = %v", err)
+	}
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(1),
+		Subject:      pkix.Name{CommonName: "idp.example"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	if err != nil {
+		t.Fatalf("x509.CreateCertificate() error = %v", err)
+	}
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("x509.ParseCertificate() error = %v", err)
+	}
+	return &testSigner{key: key, cert: cert}
+}
+
+func (s *testSigner) sign(t *testing.T, el *etree.Element) *etree.Element {
+	t.Helper()
+	ctx, err := dsig.NewSigningContext(s.key, [][]byte{s.cert.Raw})
+	if err != nil {
+		t.Fatalf("dsig.NewSigningContext() error = %v", err)
+	}
+	ctx.Canonicalizer = dsig.MakeC14N10ExclusiveCanonicalizerWithPrefixList("")
+	signed, err := ctx.SignEnveloped(el)
+	if err != nil {
+		t.Fatalf("SignEnveloped() error = %v", err)
+	}
+	return signed
+}
+
+// assertionParams 构造断言的可变部分，零值使用合法默认值
+type assertionParams struct {
+	Issuer       string
+	Audience     string
+	Recipient    string
+	InResponseTo string
+	NotOnOrAfter time.Time
+
+	OmitInResponseTo bool // SubjectConfirmationData 不携带 InResponseTo
+}
+
+func (p assertionParams) withDefaults(now time.Time) assertionParams {
+	if p.Issuer == "" {
+		p.Issuer = testIDPEntityID
+	}
+	if p.Audience == "" {
+		p.Audience = testSPEntityID
+	}
+	if p.Recipient == "" {
+		p.Recipient = testACSURL
+	}
+	if p.InResponseTo == "" {
+		p.InResponseTo = testRequestID
+	}
+	if p.NotOnOrAfter.IsZero() {
+		p.NotOnOrAfter = now.Add(5 * time.Minute)
+	}
+	return p
+}
+
+func buildAssertion(t *testing.T, p assertionParams, now time.Time) *etree.Element {
+	t.Helper()
+	p = p.withDefaults(now)
+	inResponseTo := ` InResponseTo="` + p.InResponseTo + `"`
+	if p.OmitInResponseTo {
+		inResponseTo = ""
+	}
+	raw := fmt.Sprintf(`<saml:Assertion xmlns:saml="%[1]s" ID="_assertion-1" Version="2.0" IssueInstant="%[2]s">`+
+		`<saml:Issuer>%[3]s</saml:Issuer>`+
+		`<saml:Subject>`+
+		`<saml:NameID Format="urn:oasis:names:tc:SAML:1.1:nameid-format:emailAddress">alice@acme.example</saml:NameID>`+
+		`<saml:SubjectConfirmation Method="%[4]s">`+
+		`<saml:SubjectConfirmationData Recipient="%[5]s"%[6]s NotOnOrAfter="%[7]s"/>`+
+		`</saml:SubjectConfirmation>`+
+		`</saml:Subject>`+
+		`<saml:Conditions NotBefore="%[2]s" NotOnOrAfter="%[7]s">`+
+		`<saml:AudienceRestriction><saml:Audience>%[8]s</saml:Audience></saml:AudienceRestriction>`+
+		`</saml:Conditions>`+
+		`<saml:AuthnStatement AuthnInstant="%[2]s" SessionIndex="_session-1"/>`+
+		`<saml:AttributeStatement>`+
+		`<saml:Attribute Name="urn:oid:2.16.840.1.113730.3.1.241" FriendlyName="displayName"><saml:AttributeValue>Alice</saml:AttributeValue></saml:Attribute>`+
+		`<saml:Attribute Name="email"><saml:AttributeValue>alice@acme.example</saml:AttributeValue></saml:Attribute>`+
+		`</saml:AttributeStatement>`+
+		`</saml:Assertion>`,
+		nsAssertion, now.UTC().Format(time.RFC3339), p.Issuer, confirmationBearer,
+		p.Recipient, inResponseTo, p.NotOnOrAfter.UTC().Format(time.RFC3339), p.Audience)
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		t.Fatalf("ReadFromString() error = %v", err)
+	}
+	return doc.Root()
+}
+
+func buildResponse(t *testing.T, now time.Time, assertions ...*etree.Element) *etree.Element {
+	t.Helper()
+	raw := fmt.Sprintf(`<samlp:Response xmlns:samlp="%s" xmlns:saml="%s" ID="_response-1" Version="2.0" IssueInstant="%s" Destination="%s" InResponseTo="%s">`+
+		`<saml:Issuer>%s</saml:Issuer>`+
+		`<samlp:Status><samlp:StatusCode Value="%s"/></samlp:Status>`+
+		`</samlp:Response>`,
+		nsProtocol, nsAssertion, now.UTC().Format(time.RFC3339), testACSURL, testRequestID, testIDPEntityID, statusSuccess)
+	doc := etree.NewDocument()
+	if err := doc.ReadFromString(raw); err != nil {
+		t.Fatalf("ReadFromString() error = %v", err)
+	}
+	response := doc.Root()
+	for _, assertion := range assertions {
+		response.AddChild(assertion)
+	}
+	return response
+}
+
+func encodeResponse(t *testing.T, response *etree.Element) string {
+	t.Helper()
+	doc := etree.NewDocument()
+	doc.SetRoot(response)
+	data, err := doc.WriteToBytes()
+	if err != nil {
+		t.Fatalf("WriteToBytes() error = %v", err)
+	}
+	return base64.StdEncoding.EncodeToString(data)
+}
+
+func expectation(signer *testSigner, now time.Time) responseExpectation {
+	return responseExpectation{
+		SPEntityID: testSPEntityID,
+		ACSURL:     testACSURL,
+		RequestID:  testRequestID,
+		IDP: &identityProvider{
+			EntityID:     testIDPEntityID,
+			SSOURL:       "https://idp.example/saml/sso",
+			Certificates: []*x509.Certificate{signer.cert},
+		},
+		Now: now,
+	}
+}
+
+func TestParseResponseSignedAssertion(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	now := time.Now()
+	assertion := signer.sign(t, buildAssertion(t, assertionParams{}, now))
+	encoded := encodeResponse(t, buildResponse(t, now, assertion))
+
+	info, err := parseResponse(encoded, expectation(signer, now))
+	if err != nil {
+		t.Fatalf("parseResponse() error = %v", err)
+	}
+	if info.NameID != "alice@acme.example" {
+		t.Errorf("NameID = %q", info.NameID)
+	}
+	if info.SessionIndex != "_session-1" {
+		t.Errorf("SessionIndex = %q", info.SessionIndex)
+	}
+	if info.ID != "_assertion-1" {
+		t.Errorf("ID = %q", info.ID)
+	}
+	if want := now.Add(5*time.Minute + clockSkew).Truncate(time.Second); !info.ExpiresAt.Equal(want) {
+		t.Errorf("ExpiresAt = %v, want %v", info.ExpiresAt, want)
+	}
+	if got := firstValue(info.Attributes, "displayName"); got != "Alice" {
+		t.Errorf("displayName (FriendlyName) = %q, want Alice", got)
+	}
+	if got := firstValue(info.Attributes, "urn:oid:2.16.840.1.113730.3.1.241"); got != "Alice" {
+		t.Errorf("displayName (Name) = %q, want Alice", got)
+	}
+
+	user, err := mapAttributes(AttributeMapping{Nickname: "displayName", Email: "email"}, info)
+	if err != nil {
+		t.Fatalf("mapAttributes() error = %v", err)
+	}
+	if user.TOpenID != "alice@acme.example" || user.Nickname != "Alice" || user.Email != "alice@acme.example" {
+		t.Errorf("mapAttributes() = %+v", user)
+	}
+}
+
+func TestParseResponseSignedResponse(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	now := time.Now()
+	response := signer.sign(t, buildResponse(t, now, buildAssertion(t, assertionParams{}, now)))
+
+	if _, err := parseResponse(encodeResponse(t, response), expectation(signer, now)); err != nil {
+		t.Fatalf("parseResponse() error = %v", err)
+	}
+}
+
+func TestParseResponseRejects(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	other := newTestSigner(t)
+	now := time.Now()
+
+	signed := func(p assertionParams) string {
+		return encodeResponse(t, buildResponse(t, now, signer.sign(t, buildAssertion(t, p, now))))
+	}
+
+	tampered := buildResponse(t, now, signer.sign(t, buildAssertion(t, assertionParams{}, now)))
+	nameID := tampered.FindElement("./Assertion/Subject/NameID")
+	nameID.SetText("mallory@acme.example")
+
+	wrapped := buildResponse(t, now,
+		signer.sign(t, buildAssertion(t, assertionParams{}, now)),
+		buildAssertion(t, assertionParams{}, now),
+	)
+
+	tests := []struct {
+		name    string
+		encoded string
+	}{
+		{name: "unsigned", encoded: encodeResponse(t, buildResponse(t, now, buildAssertion(t, assertionParams{}, now)))},
+		{name: "untrusted signer", encoded: encodeResponse(t, buildResponse(t, now, other.sign(t, buildAssertion(t, assertionParams{}, now))))},
+		{name: "tampered", encoded: encodeResponse(t, tampered)},
+		{name: "extra assertion", encoded: encodeResponse(t, wrapped)},
+		{name: "wrong issuer", encoded: signed(assertionParams{Issuer: "https://evil.example"})},
+		{name: "wrong audience", encoded: signed(assertionParams{Audience: "https://other.example/sp"})},
+		{name: "wrong recipient", encoded: signed(assertionParams{Recipient: "https://evil.example/acs"})},
+		{name: "wrong in response to", encoded: signed(assertionParams{InResponseTo: "_other-request"})},
+		{name: "expired", encoded: signed(assertionParams{NotOnOrAfter: now.Add(-10 * time.Minute)})},
+		{name: "not base64", encoded: "%%%"},
+		{name: "dtd", encoded: base64.StdEncoding.EncodeToString([]byte(`<!DOCTYPE x [<!ENTITY a "b">]><x/>`))},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+			if _, err := parseResponse(tt.encoded, expectation(signer, now)); err == nil {
+				t.Fatal("parseResponse() error = nil, want error")
+			}
+		})
+	}
+}
+
+func TestParseResponseSubjectConfirmationInResponseTo(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	now := time.Now()
+	withoutIRT := assertionParams{OmitInResponseTo: true}
+
+	// Response 已签名：其 InResponseTo 可信，SubjectConfirmationData 可以不携带
+	signedResponse := signer.sign(t, buildResponse(t, now, buildAssertion(t, withoutIRT, now)))
+	if _, err := parseResponse(encodeResponse(t, signedResponse), expectation(signer, now)); err != nil {
+		t.Fatalf("parseResponse(signed response) error = %v", err)
+	}
+
+	// Response 未签名：InResponseTo 必须出现在已签名断言内
+	unsignedResponse := buildResponse(t, now, signer.sign(t, buildAssertion(t, withoutIRT, now)))
+	if _, err := parseResponse(encodeResponse(t, unsignedResponse), expectation(signer, now)); err == nil {
+		t.Fatal("parseResponse(unsigned response without assertion InResponseTo) error = nil")
+	}
+}
+
+func TestParseResponseRequiresRequestID(t *testing.T) {
+	t.Parallel()
+
+	signer := newTestSigner(t)
+	now := time.Now()
+	encoded := encodeResponse(t, buildResponse(t, now, signer.sign(t, buildAssertion(t, assertionParams{}, now))))
+
+	want := expectation(signer, now)
+	want.RequestID = ""
+	if _, err := parseResponse(encoded, want); err == nil || !strings.Contains(err.Error(), "InResponseTo") {
+		t.Fatalf("parseResponse() error = %v, want InResponseTo mismatch", err)
+	}
+}
+
+func TestMapAttributesRejectsTransientNameID(t *testing.T) {
+	t.Parallel()
+
+	info := &assertionInfo{NameID: "_transient", NameIDFormat: nameIDFormatTransient, Attributes: map[string][]string{"uid": {"alice"}}}
+	if _, err := mapAttributes(AttributeMapping{}, info); err == nil {
+		t.Fatal("mapAttributes() error = nil, want transient NameID error")
+	}
+	user, err := mapAttributes(AttributeMapping{Subject: "uid"}, info)
+	if err != nil {
+		t.Fatalf("mapAttributes() error = %v", err)
+	}
+	if user.TOpenID != "alice" {
+		t.Errorf("TOpenID = %q, want alice", user.TOpenID)
+	}
+}
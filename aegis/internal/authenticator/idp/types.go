@@ -1,6 +1,10 @@
 package idp
 
-import "github.com/heliannuuthus/aegis/config"
+import (
+	"strings"
+
+	"github.com/heliannuuthus/aegis/config"
+)
 
 // IDP 类型常量
 const (
@@ -28,8 +32,24 @@ const (
 
 	// 系统 - 全局身份
 	TypeGlobal = "global" // 全局身份（每个域一个，t_openid 作为该域下的 sub）
+
+	// 通用 - 可配置的 OIDC / OAuth2 上游（connection 为 oidc-{name}，如 oidc-keycloak）
+	TypeOIDCPrefix = "oidc-"
+
+	// 通用 - SAML 2.0 企业 IdP（connection 为 saml-{name}，如 saml-acme）
+	TypeSAMLPrefix = "saml-"
 )
 
+// IsOIDCConnection 是否为通用 OIDC connection（参数由域 / 应用 IDP 配置的 config 提供）
+func IsOIDCConnection(connection string) bool {
+	return len(connection) > len(TypeOIDCPrefix) && strings.HasPrefix(connection, TypeOIDCPrefix)
+}
+
+// IsSAMLConnection 是否为 SAML 2.0 connection（IdP 元数据 / 证书由域 / 应用 IDP 配置的 config 提供）
+func IsSAMLConnection(connection string) bool {
+	return len(connection) > len(TypeSAMLPrefix) && strings.HasPrefix(connection, TypeSAMLPrefix)
+}
+
 // Domain 用户域
 type Domain string
 
@@ -82,7 +102,7 @@ func IsIDPAllowedForDomain(idpType string, domain Domain) bool {
 }
 
 // RequiresEmailForBinding 是否需要邮箱来绑定身份（用于 Platform 域的 OAuth 登录）
-// GitHub/Google 等需要通过邮箱来查找/绑定 staff 身份
+// GitHub/Google/通用 OIDC/SAML 等需要通过邮箱来查找/绑定 staff 身份
 func RequiresEmailForBinding(idpType string) bool {
-	return idpType == TypeGithub || idpType == TypeGoogle
+	return idpType == TypeGithub || idpType == TypeGoogle || IsOIDCConnection(idpType) || IsSAMLConnection(idpType)
 }
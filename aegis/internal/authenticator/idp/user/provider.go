@@ -5,10 +5,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
-
-	"golang.org/x/crypto/bcrypt"
+	"time"
 
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/password"
 	"github.com/heliannuuthus/aegis/internal/types"
 	"github.com/heliannuuthus/aegis/models"
 	"github.com/heliannuuthus/aegis/rpc/hermes"
@@ -23,16 +23,19 @@ type credential struct {
 	Email        string
 	Picture      string
 	Status       int8
+	LockedUntil  *time.Time
 }
 
 // Provider C 端用户账号密码 Provider
 type Provider struct {
-	hermes *hermes.Client
+	hermes    *hermes.Client
+	passwords *password.Service // 登录成功后升级旧哈希
 }
 
-func NewProvider(hermesClient *hermes.Client) *Provider {
+func NewProvider(hermesClient *hermes.Client, passwords *password.Service) *Provider {
 	return &Provider{
-		hermes: hermesClient,
+		hermes:    hermesClient,
+		passwords: passwords,
 	}
 }
 
@@ -93,7 +96,7 @@ func (*Provider) Prepare() *types.ConnectionConfig {
 }
 
 // loginByPassword 密码验证
-func (p *Provider) loginByPassword(ctx context.Context, identifier, password string) (*models.TUserInfo, error) {
+func (p *Provider) loginByPassword(ctx context.Context, identifier, plain string) (*models.TUserInfo, error) {
 	cred, err := p.getCredential(ctx, identifier)
 	if err != nil {
 		logger.Warnf("[user] 用户不存在 - Identifier: %s, Error: %v", maskIdentifier(identifier), err)
@@ -105,15 +108,28 @@ func (p *Provider) loginByPassword(ctx context.Context, identifier, password str
 		return nil, errors.New("user is disabled")
 	}
 
+	if cred.LockedUntil != nil && cred.LockedUntil.After(time.Now()) {
+		logger.Warnf("[user] 用户已锁定 - Identifier: %s, LockedUntil: %v", maskIdentifier(identifier), *cred.LockedUntil)
+		return nil, idp.ErrUserLocked
+	}
+
 	if cred.PasswordHash == "" {
 		logger.Warnf("[user] 用户未设置密码 - Identifier: %s", maskIdentifier(identifier))
 		return nil, errors.New("password not set, please use other login methods")
 	}
 
-	if err := bcrypt.CompareHashAndPassword([]byte(cred.PasswordHash), []byte(password)); err != nil {
+	ok, rehash, err := password.Verify(cred.PasswordHash, plain)
+	if err != nil {
+		logger.Errorf("[user] 密码哈希无法校验 - OpenID: %s, Error: %v", cred.OpenID, err)
+		return nil, errors.New("invalid credentials")
+	}
+	if !ok {
 		logger.Warnf("[user] 密码错误 - Identifier: %s", maskIdentifier(identifier))
 		return nil, errors.New("invalid credentials")
 	}
+	if rehash {
+		p.passwords.UpgradeHash(ctx, cred.OpenID, plain)
+	}
 
 	logger.Infof("[user] 登录成功 - Identifier: %s, OpenID: %s", maskIdentifier(identifier), cred.OpenID)
 
@@ -139,6 +155,7 @@ func (p *Provider) getCredential(ctx context.Context, identifier string) (*crede
 		Email:        stringValue(user.Email),
 		Picture:      stringValue(user.Picture),
 		Status:       user.Status,
+		LockedUntil:  user.LockedUntil,
 	}, nil
 }
 
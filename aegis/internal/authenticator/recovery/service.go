@@ -0,0 +1,157 @@
+// Package recovery implements one-time MFA recovery codes.
+package recovery
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base32"
+	"encoding/hex"
+	"fmt"
+	"strings"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+const (
+	defaultRecoveryCodeLabel = "恢复码"
+	credentialIDPrefix       = "rc_"
+	credentialIDLength       = 16
+	codeLength               = 10 // 编码后字符数（约 50 bit 熵）
+	codeGroupSize            = 5  // 展示时每组字符数，组间以 - 分隔
+)
+
+var codeEncoding = base32.StdEncoding.WithPadding(base32.NoPadding)
+
+// Service owns recovery code generation and consumption.
+//
+// 每个恢复码在 t_user_credential 中单独存一行：credential_id 为随机 ID，secret 为
+// sha256(credential_id:code) 的十六进制摘要。恢复码本身是高熵随机串，按 credential_id 加盐的摘要足以抵御离线枚举。
+type Service struct {
+	credentials *hermes.Client
+}
+
+func NewService(credentials *hermes.Client) *Service {
+	return &Service{
+		credentials: credentials,
+	}
+}
+
+// Generate 生成一组新的恢复码，旧的恢复码全部作废；明文只在此处返回一次
+//
+// 删除旧恢复码与写入新恢复码在 hermes 的同一事务中完成，避免中途失败导致用户没有可用恢复码。
+func (s *Service) Generate(ctx context.Context, openid string) ([]string, error) {
+	count := config.GetMFARecoveryCodeCount()
+	codes := make([]string, 0, count)
+	credentials := make([]models.UserCredential, 0, count)
+	for range count {
+		code, err := generateCode()
+		if err != nil {
+			return nil, err
+		}
+		credentialID := credentialIDPrefix + helpers.GenerateID(credentialIDLength)
+		credentials = append(credentials, models.UserCredential{
+			OpenID:       openid,
+			CredentialID: &credentialID,
+			Type:         string(models.CredentialTypeRecoveryCode),
+			Label:        defaultRecoveryCodeLabel,
+			Enabled:      true,
+			Secret:       hashCode(credentialID, code),
+		})
+		codes = append(codes, code)
+	}
+
+	if err := s.credentials.ReplaceUserCredentialsByType(ctx, openid, string(models.CredentialTypeRecoveryCode), credentials); err != nil {
+		return nil, fmt.Errorf("保存恢复码失败: %w", err)
+	}
+
+	logger.Infof("[RecoveryCode] 恢复码已生成 - OpenID: %s, Count: %d", openid, count)
+	return codes, nil
+}
+
+// Remaining 返回用户剩余可用的恢复码数量
+func (s *Service) Remaining(ctx context.Context, openid string) (int, error) {
+	creds, err := s.credentials.ListUserCredentialsByType(ctx, openid, string(models.CredentialTypeRecoveryCode))
+	if err != nil {
+		return 0, fmt.Errorf("query recovery codes: %w", err)
+	}
+	remaining := 0
+	for i := range creds {
+		if creds[i].Enabled {
+			remaining++
+		}
+	}
+	return remaining, nil
+}
+
+// VerifyCode 校验并消费恢复码，每个恢复码只能成功一次
+func (s *Service) VerifyCode(ctx context.Context, openid, code string) (bool, error) {
+	code = normalizeCode(code)
+	if code == "" || openid == "" {
+		return false, nil
+	}
+
+	creds, err := s.credentials.ListUserCredentialsByType(ctx, openid, string(models.CredentialTypeRecoveryCode))
+	if err != nil {
+		return false, fmt.Errorf("query recovery codes: %w", err)
+	}
+	for i := range creds {
+		cred := &creds[i]
+		if !cred.Enabled || cred.CredentialID == nil || !matchCode(*cred.CredentialID, code, cred.Secret) {
+			continue
+		}
+
+		// 删除即消费：只有成功删除该行的请求算作使用成功，删除失败时恢复码保持有效
+		consumed, err := s.credentials.ConsumeCredential(ctx, openid, *cred.CredentialID)
+		if err != nil {
+			return false, fmt.Errorf("consume recovery code: %w", err)
+		}
+		if !consumed {
+			logger.Warnf("[RecoveryCode] 恢复码重复使用 - OpenID: %s", openid)
+			return false, nil
+		}
+		logger.Infof("[RecoveryCode] 恢复码验证成功 - OpenID: %s", openid)
+		return true, nil
+	}
+
+	logger.Debugf("[RecoveryCode] 验证失败 - OpenID: %s", openid)
+	return false, nil
+}
+
+// generateCode 生成形如 abcde-fghij 的恢复码
+func generateCode() (string, error) {
+	raw := make([]byte, 7)
+	if _, err := rand.Read(raw); err != nil {
+		return "", fmt.Errorf("生成恢复码失败: %w", err)
+	}
+	encoded := strings.ToLower(codeEncoding.EncodeToString(raw))[:codeLength]
+	return encoded[:codeGroupSize] + "-" + encoded[codeGroupSize:], nil
+}
+
+// normalizeCode 忽略大小写、分隔符与空白
+func normalizeCode(code string) string {
+	return strings.Map(func(r rune) rune {
+		switch {
+		case r == '-' || r == ' ' || r == '\t':
+			return -1
+		case r >= 'A' && r <= 'Z':
+			return r + ('a' - 'A')
+		default:
+			return r
+		}
+	}, strings.TrimSpace(code))
+}
+
+func hashCode(credentialID, code string) string {
+	sum := sha256.Sum256([]byte(credentialID + ":" + normalizeCode(code)))
+	return hex.EncodeToString(sum[:])
+}
+
+func matchCode(credentialID, code, digest string) bool {
+	return subtle.ConstantTimeCompare([]byte(hashCode(credentialID, code)), []byte(digest)) == 1
+}
@@ -0,0 +1,42 @@
+package recovery
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestGenerateCodeFormat(t *testing.T) {
+	code, err := generateCode()
+	if err != nil {
+		t.Fatalf("generateCode() error = %v", err)
+	}
+	if len(code) != codeLength+1 || code[codeGroupSize] != '-' {
+		t.Fatalf("generateCode() = %q, want %d chars grouped by -", code, codeLength)
+	}
+	if code != strings.ToLower(code) {
+		t.Fatalf("generateCode() = %q, want lower case", code)
+	}
+}
+
+func TestMatchCode(t *testing.T) {
+	digest := hashCode("rc_1", "abcde-fghij")
+
+	tests := []struct {
+		name         string
+		credentialID string
+		code         string
+		want         bool
+	}{
+		{name: "exact", credentialID: "rc_1", code: "abcde-fghij", want: true},
+		{name: "upper case without separator", credentialID: "rc_1", code: " ABCDEFGHIJ ", want: true},
+		{name: "wrong code", credentialID: "rc_1", code: "abcde-fghik", want: false},
+		{name: "other credential", credentialID: "rc_2", code: "abcde-fghij", want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := matchCode(tt.credentialID, tt.code, digest); got != tt.want {
+				t.Fatalf("matchCode() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
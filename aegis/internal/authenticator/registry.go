@@ -3,6 +3,7 @@ package authenticator
 
 import (
 	"context"
+	"strings"
 	"sync"
 
 	"github.com/heliannuuthus/aegis/internal/types"
@@ -55,11 +56,15 @@ type Exchanger interface {
 	Exchange(ctx context.Context, code string) (principal string, err error)
 }
 
+// Factory 按 connection 名称创建 Authenticator（用于名称不固定的可配置连接，如 oidc-{name}）
+type Factory func(connection string) Authenticator
+
 // Registry 全局认证器注册表
 // 统一管理所有 Connection 类型：IDP、VChan、MFA
 type Registry struct {
 	mu             sync.RWMutex
 	authenticators map[string]Authenticator
+	factories      map[string]Factory // connection 前缀 → Factory
 }
 
 // 全局 Registry 实例
@@ -74,6 +79,7 @@ func GlobalRegistry() *Registry {
 func NewRegistry() *Registry {
 	r := &Registry{
 		authenticators: make(map[string]Authenticator),
+		factories:      make(map[string]Factory),
 	}
 	globalRegistry = r
 	return r
@@ -88,19 +94,32 @@ func (r *Registry) Register(a Authenticator) {
 	r.authenticators[a.Type()] = a
 }
 
-// Get 获取 Authenticator
+// RegisterFactory 注册前缀 Factory：未精确注册的 connection 以 prefix 开头时由 factory 创建
+// 创建结果不写入注册表（connection 名称来自请求，不应无限增长），Factory 应保证创建开销很小
+func (r *Registry) RegisterFactory(prefix string, factory Factory) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.factories[prefix] = factory
+}
+
+// Get 获取 Authenticator（精确注册优先，其次按前缀 Factory 创建）
 func (r *Registry) Get(connection string) (Authenticator, bool) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
-	a, ok := r.authenticators[connection]
-	return a, ok
+	if a, ok := r.authenticators[connection]; ok {
+		return a, true
+	}
+	for prefix, factory := range r.factories {
+		if len(connection) > len(prefix) && strings.HasPrefix(connection, prefix) {
+			return factory(connection), true
+		}
+	}
+	return nil, false
 }
 
 // Has 检查是否已注册指定的 Authenticator
 func (r *Registry) Has(connection string) bool {
-	r.mu.RLock()
-	defer r.mu.RUnlock()
-	_, ok := r.authenticators[connection]
+	_, ok := r.Get(connection)
 	return ok
 }
 
@@ -143,8 +162,14 @@ func (r *Registry) Summary() map[string]any {
 		all = append(all, t)
 	}
 
+	prefixes := make([]string, 0, len(r.factories))
+	for prefix := range r.factories {
+		prefixes = append(prefixes, prefix+"*")
+	}
+
 	return map[string]any{
 		"authenticators": all,
+		"factories":      prefixes,
 		"count":          len(r.authenticators),
 	}
 }
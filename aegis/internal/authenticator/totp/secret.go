@@ -0,0 +1,66 @@
+package totp
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/aegis/config"
+)
+
+// storedSecret TOTP 凭证 secret 字段的内容：密钥及绑定时写入 otpauth URI 的参数
+// 验证时使用凭证自身的参数，修改 mfa.totp.* 只影响之后的绑定
+type storedSecret struct {
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm"`
+	Digits    int    `json:"digits"`
+	Period    uint   `json:"period"`
+}
+
+// parseStoredSecret 解析凭证 secret
+// 早期凭证只保存 Base32 密钥，绑定时使用的是默认参数（SHA1 / 6 位 / 30 秒）
+func parseStoredSecret(raw string) (*storedSecret, error) {
+	if !strings.HasPrefix(raw, "{") {
+		return &storedSecret{
+			Secret:    raw,
+			Algorithm: config.DefaultMFATOTPAlgorithm,
+			Digits:    config.DefaultMFATOTPDigits,
+			Period:    config.DefaultMFATOTPPeriod,
+		}, nil
+	}
+	var stored storedSecret
+	if err := json.Unmarshal([]byte(raw), &stored); err != nil {
+		return nil, fmt.Errorf("parse totp secret: %w", err)
+	}
+	if err := stored.validate(); err != nil {
+		return nil, err
+	}
+	return &stored, nil
+}
+
+func (s *storedSecret) validate() error {
+	if s.Secret == "" || s.Algorithm == "" || s.Digits == 0 || s.Period == 0 {
+		return errors.New("totp secret is incomplete")
+	}
+	return nil
+}
+
+func (s *storedSecret) serialize() (string, error) {
+	data, err := json.Marshal(s)
+	if err != nil {
+		return "", fmt.Errorf("serialize totp secret: %w", err)
+	}
+	return string(data), nil
+}
+
+// params 凭证参数加上当前配置的容忍窗口
+func (s *storedSecret) params(skew uint) *config.TOTPConfig {
+	return &config.TOTPConfig{
+		Period:    s.Period,
+		Digits:    s.Digits,
+		Algorithm: s.Algorithm,
+		Skew:      skew,
+	}
+}
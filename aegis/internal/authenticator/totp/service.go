@@ -3,22 +3,28 @@ package totp
 import (
 	"context"
 	"crypto/rand"
+	"crypto/subtle"
 	"encoding/base32"
 	"errors"
 	"fmt"
 	"net/url"
 	"time"
 
+	"github.com/pquerna/otp"
 	pquerna_totp "github.com/pquerna/otp/totp"
 
+	"github.com/heliannuuthus/aegis/config"
 	"github.com/heliannuuthus/aegis/internal/cache"
 	"github.com/heliannuuthus/aegis/models"
 	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/helpers"
 	"github.com/heliannuuthus/pkg/logger"
 )
 
 const (
-	defaultTOTPLabel = "身份验证器 App"
+	defaultTOTPLabel   = "身份验证器 App"
+	credentialIDPrefix = "totp_"
+	credentialIDLength = 16
 )
 
 // Service owns TOTP enrollment and code verification.
@@ -66,10 +72,14 @@ func (s *Service) BeginEnrollment(ctx context.Context, openid, appName string) (
 		issuer = "Helios"
 	}
 
+	cfg := config.GetTOTPConfig()
 	session := &cache.TOTPEnrollmentSession{
-		OpenID: openid,
-		Secret: secret,
-		Label:  defaultTOTPLabel,
+		OpenID:    openid,
+		Secret:    secret,
+		Algorithm: cfg.Algorithm,
+		Digits:    cfg.Digits,
+		Period:    cfg.Period,
+		Label:     defaultTOTPLabel,
 	}
 	uid, err := s.cache.SaveTOTPEnrollmentSession(ctx, session)
 	if err != nil {
@@ -79,7 +89,7 @@ func (s *Service) BeginEnrollment(ctx context.Context, openid, appName string) (
 	return &Enrollment{
 		UID:        uid,
 		Secret:     secret,
-		OTPAuthURI: otpauthURI(issuer, openid, secret),
+		OTPAuthURI: otpauthURI(issuer, openid, secret, cfg),
 	}, nil
 }
 
@@ -92,21 +102,33 @@ func (s *Service) ConfirmEnrollment(ctx context.Context, openid, uid, code strin
 		return errors.New("TOTP 注册会话不存在")
 	}
 
-	if session.Secret == "" {
+	// 使用发起绑定时写入 otpauth URI 的参数校验，并随凭证一起保存
+	stored := &storedSecret{Secret: session.Secret, Algorithm: session.Algorithm, Digits: session.Digits, Period: session.Period}
+	if err := stored.validate(); err != nil {
 		return errors.New("TOTP 注册会话数据无效")
 	}
-	if !pquerna_totp.Validate(code, session.Secret) {
+	cfg := stored.params(config.GetTOTPConfig().Skew)
+	now := time.Now()
+	step, ok, err := matchStep(stored.Secret, code, now, cfg)
+	if err != nil {
+		return err
+	} else if !ok {
 		return errors.New("验证码错误")
 	}
+	secret, err := stored.serialize()
+	if err != nil {
+		return err
+	}
 
-	now := time.Now()
+	credentialID := credentialIDPrefix + helpers.GenerateID(credentialIDLength)
 	credential := &models.UserCredential{
-		OpenID:     openid,
-		Type:       string(models.CredentialTypeTOTP),
-		Label:      session.Label,
-		Enabled:    true,
-		LastUsedAt: &now,
-		Secret:     session.Secret,
+		OpenID:       openid,
+		CredentialID: &credentialID,
+		Type:         string(models.CredentialTypeTOTP),
+		Label:        session.Label,
+		Enabled:      true,
+		LastUsedAt:   &now,
+		Secret:       secret,
 	}
 	if credential.Label == "" {
 		credential.Label = defaultTOTPLabel
@@ -114,6 +136,9 @@ func (s *Service) ConfirmEnrollment(ctx context.Context, openid, uid, code strin
 	if err := s.credentials.CreateCredential(ctx, credential); err != nil {
 		return fmt.Errorf("保存 TOTP 凭证失败: %w", err)
 	}
+	if err := s.acceptEnrollmentStep(ctx, openid, credentialID, step, cfg); err != nil {
+		return err
+	}
 	if err := s.cache.DeleteTOTPEnrollmentSession(ctx, uid); err != nil {
 		logger.Warnf("[Credential] 删除 TOTP 注册会话失败 - UID: %s, err: %v", uid, err)
 	}
@@ -122,6 +147,26 @@ func (s *Service) ConfirmEnrollment(ctx context.Context, openid, uid, code strin
 	return nil
 }
 
+// acceptEnrollmentStep 记录绑定时通过的时间步，使绑定用的验证码不能在容忍窗口内再次用于认证
+// 记录失败时删除刚创建的凭证，由用户重新绑定
+func (s *Service) acceptEnrollmentStep(ctx context.Context, openid, credentialID string, step uint64, cfg *config.TOTPConfig) error {
+	created, err := s.credentials.GetCredentialByID(ctx, credentialID)
+	if err == nil {
+		var accepted bool
+		accepted, err = s.cache.AcceptTOTPStep(ctx, created.ID, step, stepRetention(cfg))
+		if err == nil && !accepted {
+			err = errors.New("totp step already accepted")
+		}
+	}
+	if err == nil {
+		return nil
+	}
+	if delErr := s.credentials.DeleteCredential(ctx, openid, credentialID); delErr != nil {
+		logger.Warnf("[Credential] 回滚 TOTP 凭证失败 - OpenID: %s, err: %v", openid, delErr)
+	}
+	return fmt.Errorf("记录 TOTP 时间步失败: %w", err)
+}
+
 func (s *Service) VerifyCode(ctx context.Context, openid, code string) (bool, error) {
 	if code == "" || openid == "" {
 		return false, nil
@@ -131,20 +176,104 @@ func (s *Service) VerifyCode(ctx context.Context, openid, code string) (bool, er
 	if err != nil {
 		return false, fmt.Errorf("query totp credentials: %w", err)
 	}
+	skew := config.GetTOTPConfig().Skew
+	now := time.Now()
 	for i := range creds {
-		if !isActiveTOTPCredential(&creds[i]) {
+		cred := &creds[i]
+		if !isActiveTOTPCredential(cred) {
+			continue
+		}
+		stored, err := parseStoredSecret(cred.Secret)
+		if err != nil {
+			logger.Warnf("[TOTP] 凭证数据无效 - OpenID: %s, ID: %d, err: %v", openid, cred.ID, err)
 			continue
 		}
-		if pquerna_totp.Validate(code, creds[i].Secret) {
-			logger.Infof("[TOTP] 验证成功 - OpenID: %s", openid)
-			return true, nil
+		cfg := stored.params(skew)
+		step, ok, err := matchStep(stored.Secret, code, now, cfg)
+		if err != nil {
+			return false, err
+		}
+		if !ok {
+			continue
+		}
+
+		// 同一凭证的时间步只增不减：拒绝重放以及早于最近一次验证的验证码
+		accepted, err := s.cache.AcceptTOTPStep(ctx, cred.ID, step, stepRetention(cfg))
+		if err != nil {
+			return false, err
+		}
+		if !accepted {
+			logger.Warnf("[TOTP] 验证码重放 - OpenID: %s, Step: %d", openid, step)
+			return false, nil
 		}
+		s.touch(ctx, cred, now)
+		logger.Infof("[TOTP] 验证成功 - OpenID: %s", openid)
+		return true, nil
 	}
 
 	logger.Debugf("[TOTP] 验证失败 - OpenID: %s", openid)
 	return false, nil
 }
 
+// touch 更新凭证最后使用时间（失败不影响验证结果）
+func (s *Service) touch(ctx context.Context, cred *models.UserCredential, usedAt time.Time) {
+	if cred.CredentialID == nil {
+		logger.Warnf("[TOTP] 凭证缺少 credential_id，跳过更新最后使用时间 - OpenID: %s, ID: %d", cred.OpenID, cred.ID)
+		return
+	}
+	if err := s.credentials.PatchCredential(ctx, *cred.CredentialID, map[string]any{"last_used_at": usedAt}); err != nil {
+		logger.Warnf("[TOTP] 更新最后使用时间失败 - OpenID: %s, Error: %v", cred.OpenID, err)
+	}
+}
+
+// matchStep 在 [-skew, +skew] 时间步窗口内比对验证码，返回命中的时间步
+func matchStep(secret, code string, now time.Time, cfg *config.TOTPConfig) (uint64, bool, error) {
+	opts, err := validateOpts(cfg)
+	if err != nil {
+		return 0, false, err
+	}
+	if len(code) != cfg.Digits {
+		return 0, false, nil
+	}
+
+	period := time.Duration(cfg.Period) * time.Second
+	skew := int(cfg.Skew)
+	for offset := -skew; offset <= skew; offset++ {
+		at := now.Add(time.Duration(offset) * period)
+		expected, err := pquerna_totp.GenerateCodeCustom(secret, at, opts)
+		if err != nil {
+			return 0, false, fmt.Errorf("generate totp code: %w", err)
+		}
+		if subtle.ConstantTimeCompare([]byte(expected), []byte(code)) == 1 {
+			return uint64(at.Unix()) / uint64(cfg.Period), true, nil
+		}
+	}
+	return 0, false, nil
+}
+
+func validateOpts(cfg *config.TOTPConfig) (pquerna_totp.ValidateOpts, error) {
+	opts := pquerna_totp.ValidateOpts{
+		Period: cfg.Period,
+		Digits: otp.Digits(cfg.Digits),
+	}
+	switch cfg.Algorithm {
+	case "SHA1":
+		opts.Algorithm = otp.AlgorithmSHA1
+	case "SHA256":
+		opts.Algorithm = otp.AlgorithmSHA256
+	case "SHA512":
+		opts.Algorithm = otp.AlgorithmSHA512
+	default:
+		return opts, fmt.Errorf("unsupported totp algorithm: %s", cfg.Algorithm)
+	}
+	return opts, nil
+}
+
+// stepRetention 时间步记录的保留时长：覆盖整个容忍窗口即可
+func stepRetention(cfg *config.TOTPConfig) time.Duration {
+	return time.Duration(2*cfg.Skew+2) * time.Duration(cfg.Period) * time.Second
+}
+
 func isActiveTOTPCredential(c *models.UserCredential) bool {
 	if c.Type != string(models.CredentialTypeTOTP) {
 		return false
@@ -163,12 +292,15 @@ func generateTOTPSecret() (string, error) {
 	return base32.StdEncoding.WithPadding(base32.NoPadding).EncodeToString(secretBytes), nil
 }
 
-func otpauthURI(issuer, openid, secret string) string {
+func otpauthURI(issuer, openid, secret string, cfg *config.TOTPConfig) string {
 	return fmt.Sprintf(
-		"otpauth://totp/%s:%s?secret=%s&issuer=%s&algorithm=SHA1&digits=6&period=30",
+		"otpauth://totp/%s:%s?secret=%s&issuer=%s&algorithm=%s&digits=%d&period=%d",
 		url.PathEscape(issuer),
 		url.PathEscape(openid),
 		secret,
 		url.QueryEscape(issuer),
+		cfg.Algorithm,
+		cfg.Digits,
+		cfg.Period,
 	)
 }
@@ -0,0 +1,87 @@
+package totp
+
+import (
+	"testing"
+	"time"
+
+	pquerna_totp "github.com/pquerna/otp/totp"
+
+	"github.com/heliannuuthus/aegis/config"
+)
+
+const testSecret = "JBSWY3DPEHPK3PXPJBSWY3DPEHPK3PXP"
+
+func TestMatchStep(t *testing.T) {
+	cfg := &config.TOTPConfig{Period: 30, Digits: 6, Algorithm: "SHA256", Skew: 1}
+	opts, err := validateOpts(cfg)
+	if err != nil {
+		t.Fatalf("validateOpts() error = %v", err)
+	}
+	now := time.Unix(1_700_000_000, 0)
+	codeAt := func(at time.Time) string {
+		code, err := pquerna_totp.GenerateCodeCustom(testSecret, at, opts)
+		if err != nil {
+			t.Fatalf("GenerateCodeCustom() error = %v", err)
+		}
+		return code
+	}
+
+	tests := []struct {
+		name     string
+		code     string
+		wantStep uint64
+		wantOK   bool
+	}{
+		{name: "current step", code: codeAt(now), wantStep: 1_700_000_000 / 30, wantOK: true},
+		{name: "previous step within skew", code: codeAt(now.Add(-30 * time.Second)), wantStep: 1_700_000_000/30 - 1, wantOK: true},
+		{name: "outside skew", code: codeAt(now.Add(-90 * time.Second)), wantOK: false},
+		{name: "wrong length", code: "12345", wantOK: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			step, ok, err := matchStep(testSecret, tt.code, now, cfg)
+			if err != nil {
+				t.Fatalf("matchStep() error = %v", err)
+			}
+			if ok != tt.wantOK || (ok && step != tt.wantStep) {
+				t.Fatalf("matchStep() = (%d, %v), want (%d, %v)", step, ok, tt.wantStep, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestValidateOptsRejectsUnknownAlgorithm(t *testing.T) {
+	if _, err := validateOpts(&config.TOTPConfig{Period: 30, Digits: 6, Algorithm: "MD5"}); err == nil {
+		t.Fatal("validateOpts() error = nil, want unsupported algorithm")
+	}
+}
+
+func TestParseStoredSecret(t *testing.T) {
+	legacy, err := parseStoredSecret(testSecret)
+	if err != nil {
+		t.Fatalf("parseStoredSecret(legacy) error = %v", err)
+	}
+	if legacy.Secret != testSecret || legacy.Algorithm != "SHA1" || legacy.Digits != 6 || legacy.Period != 30 {
+		t.Fatalf("parseStoredSecret(legacy) = %+v, want default parameters", legacy)
+	}
+
+	enrolled := &storedSecret{Secret: testSecret, Algorithm: "SHA512", Digits: 8, Period: 60}
+	raw, err := enrolled.serialize()
+	if err != nil {
+		t.Fatalf("serialize() error = %v", err)
+	}
+	parsed, err := parseStoredSecret(raw)
+	if err != nil {
+		t.Fatalf("parseStoredSecret() error = %v", err)
+	}
+	if *parsed != *enrolled {
+		t.Fatalf("parseStoredSecret() = %+v, want %+v", parsed, enrolled)
+	}
+	if got := parsed.params(1); got.Period != 60 || got.Digits != 8 || got.Algorithm != "SHA512" || got.Skew != 1 {
+		t.Fatalf("params() = %+v, want stored parameters with configured skew", got)
+	}
+
+	if _, err := parseStoredSecret(`{"secret":"` + testSecret + `"}`); err == nil {
+		t.Fatal("parseStoredSecret(incomplete) error = nil, want error")
+	}
+}
@@ -0,0 +1,22 @@
+package authorize
+
+import (
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// narrowAuthorizationDetails 按 token 请求中的 authorization_details 收窄已授予的授权详情（RFC 9396 §6.1）
+// 未携带时沿用已授予的全部授权详情；携带时每一条都须被某条已授予的授权详情覆盖
+func narrowAuthorizationDetails(granted, requested tokendef.AuthorizationDetails) (tokendef.AuthorizationDetails, error) {
+	if len(requested) == 0 {
+		return granted, nil
+	}
+	if err := requested.Validate(); err != nil {
+		return nil, autherrors.NewInvalidAuthorizationDetails(err.Error())
+	}
+	details, err := granted.Narrow(requested)
+	if err != nil {
+		return nil, autherrors.NewInvalidAuthorizationDetails(err.Error())
+	}
+	return details, nil
+}
@@ -0,0 +1,138 @@
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/token"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// relationWildcard 应用-服务关系通配符，表示允许该服务的任意 scope
+const relationWildcard = "*"
+
+// ExchangeClientCredentials client_credentials 授权（M2M）
+// clientID 取自已验证的 CT，请求中的 client_id 仅做一致性校验
+// 单 audience（audience 字段）返回扁平 TokenResponse，多 audience（audiences 字段）返回 keyed MultiAudienceTokenResponse
+func (s *Service) ExchangeClientCredentials(ctx context.Context, clientID string, req *MultiAudienceTokenRequest) (any, error) {
+	if req.ClientID != "" && req.ClientID != clientID {
+		return nil, autherrors.NewInvalidGrant("client_id mismatch")
+	}
+
+	appWithKey, err := s.cache.GetApplication(ctx, clientID)
+	if err != nil {
+		return nil, autherrors.NewClientNotFoundf("application not found: %s", clientID)
+	}
+	app := &appWithKey.Application
+	if app.DPoPBoundAccessTokens && req.DPoPJKT == "" {
+		return nil, autherrors.NewInvalidDPoPProof("DPoP proof required for this client")
+	}
+
+	if req.Audience != "" {
+		if len(req.Audiences) > 0 {
+			return nil, autherrors.NewInvalidRequest("audience and audiences are mutually exclusive")
+		}
+		resp, err := s.generateServiceAccessTokens(ctx, app, map[string]*AudienceScope{req.Audience: {Scope: req.Scope}}, req.DPoPJKT)
+		if err != nil {
+			return nil, err
+		}
+		return resp[req.Audience], nil
+	}
+
+	if len(req.Audiences) == 0 {
+		return nil, autherrors.NewInvalidRequest("no audiences specified")
+	}
+	return s.generateServiceAccessTokens(ctx, app, req.Audiences, req.DPoPJKT)
+}
+
+// generateServiceAccessTokens 为每个 audience 签发独立的 SAT（不签发 refresh_token），jkt 非空时绑定 DPoP 密钥
+func (s *Service) generateServiceAccessTokens(
+	ctx context.Context, app *models.Application, audiences map[string]*AudienceScope, jkt string,
+) (MultiAudienceTokenResponse, error) {
+	relations, err := s.cache.GetAppServiceRelations(ctx, app.AppID)
+	if err != nil {
+		return nil, autherrors.NewServerError("check relation failed")
+	}
+	allowed := make(map[string][]string, len(relations))
+	for _, rel := range relations {
+		allowed[rel.ServiceID] = append(allowed[rel.ServiceID], rel.Relation)
+	}
+
+	resp := make(MultiAudienceTokenResponse, len(audiences))
+	for audience, audienceScope := range audiences {
+		rels, ok := allowed[audience]
+		if !ok {
+			return nil, autherrors.NewAccessDeniedf("application %s has no access to service %s", app.AppID, audience)
+		}
+
+		requested := helpers.ParseScopes(audienceScope.GetScope())
+		granted := grantServiceScopes(requested, rels)
+		if len(requested) > 0 && len(granted) == 0 {
+			return nil, autherrors.NewInvalidScopef("no requested scope is granted for service %s", audience)
+		}
+
+		svc, err := s.cache.GetService(ctx, audience)
+		if err != nil {
+			return nil, autherrors.NewServiceNotFoundf("service not found: %s", audience)
+		}
+
+		tokenResp, err := s.generateServiceAccessToken(ctx, app, &svc.Service, helpers.JoinScopes(granted), jkt)
+		if err != nil {
+			return nil, fmt.Errorf("generate token for audience %s: %w", audience, err)
+		}
+		resp[audience] = tokenResp
+	}
+
+	logger.Infof("[Token] client_credentials 签发 SAT - client_id: %s, audiences: %d", app.AppID, len(resp))
+	return resp, nil
+}
+
+// generateServiceAccessToken 签发 SAT（域密钥签名，无 sub），jkt 非空时写入 cnf.jkt
+func (s *Service) generateServiceAccessToken(ctx context.Context, app *models.Application, svc *models.Service, scope, jkt string) (*TokenResponse, error) {
+	if svc.AccessTokenExpiresIn == 0 {
+		return nil, autherrors.NewInvalidRequestf("access_token_expires_in not configured for service %s", svc.ServiceID)
+	}
+	accessExpiresIn := time.Duration(svc.AccessTokenExpiresIn) * time.Second
+
+	sat := token.NewClaimsBuilder().
+		Issuer(s.tokenSvc.GetIssuer()).
+		ClientID(app.AppID).
+		Audience(svc.ServiceID).
+		ExpiresIn(accessExpiresIn).
+		Build(token.NewServiceAccessTokenBuilder().Scope(scope).Confirmation(jkt))
+
+	accessToken, err := s.tokenSvc.Issue(ctx, sat)
+	if err != nil {
+		return nil, fmt.Errorf("issue token failed: %w", err)
+	}
+
+	tokenType := tokendef.TokenTypeBearer
+	if jkt != "" {
+		tokenType = dpop.TokenType
+	}
+
+	return &TokenResponse{
+		AccessToken: accessToken,
+		TokenType:   tokenType,
+		ExpiresIn:   int(accessExpiresIn.Seconds()),
+		Scope:       scope,
+	}, nil
+}
+
+// grantServiceScopes 计算请求 scope 与应用-服务关系的交集
+// 关系包含通配符时授予全部请求 scope
+func grantServiceScopes(requested, relations []string) []string {
+	for _, rel := range relations {
+		if strings.TrimSpace(rel) == relationWildcard {
+			return helpers.ScopeIntersection(requested, requested)
+		}
+	}
+	return helpers.ScopeIntersection(requested, relations)
+}
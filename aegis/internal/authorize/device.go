@@ -0,0 +1,256 @@
+package authorize
+
+import (
+	"context"
+	"crypto/rand"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/pkg/accessctl"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// userCodeAlphabet user_code 字符集（RFC 8628 §6.1：去除元音和易混淆字符）
+const userCodeAlphabet = "BCDFGHJKLMNPQRSTVWXZ"
+
+// userCodeLength user_code 长度（展示为 XXXX-XXXX）
+const userCodeLength = 8
+
+// userCodeMaxAttempts user_code 冲突时的最大重试次数
+const userCodeMaxAttempts = 3
+
+// slowDownStep 每次 slow_down 后最小轮询间隔增加的秒数（RFC 8628 §3.5）
+const slowDownStep = 5
+
+// CreateDeviceAuthorization 创建设备授权（RFC 8628 §3.1）
+// 调用前需由 handler 完成应用、audience 关系校验
+func (s *Service) CreateDeviceAuthorization(ctx context.Context, req *DeviceAuthorizationRequest) (*DeviceAuthorizationResponse, error) {
+	expiresIn := config.GetDeviceCodeExpiresIn()
+	interval := config.GetDevicePollInterval()
+	now := time.Now()
+
+	d := &cache.DeviceAuthorization{
+		DeviceCode: types.GenerateAuthorizationCode(),
+		ClientID:   req.ClientID,
+		Audience:   req.Audience,
+		Scope:      req.Scope,
+		Status:     cache.DeviceAuthorizationPending,
+		Interval:   int(interval.Seconds()),
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(expiresIn),
+	}
+
+	for attempt := 1; ; attempt++ {
+		userCode, err := generateUserCode()
+		if err != nil {
+			return nil, fmt.Errorf("generate user code: %w", err)
+		}
+		d.UserCode = userCode
+
+		err = s.cache.SaveDeviceAuthorization(ctx, d)
+		if err == nil {
+			break
+		}
+		if !errors.Is(err, cache.ErrUserCodeConflict) || attempt >= userCodeMaxAttempts {
+			return nil, fmt.Errorf("save device authorization: %w", err)
+		}
+	}
+
+	logger.Infof("[Device] 创建设备授权 - client_id: %s, audience: %s", req.ClientID, req.Audience)
+
+	verificationURI := deviceVerificationURI()
+	display := formatUserCode(d.UserCode)
+	return &DeviceAuthorizationResponse{
+		DeviceCode:              d.DeviceCode,
+		UserCode:                display,
+		VerificationURI:         verificationURI,
+		VerificationURIComplete: verificationURI + "?user_code=" + url.QueryEscape(display),
+		ExpiresIn:               int(expiresIn.Seconds()),
+		Interval:                d.Interval,
+	}, nil
+}
+
+// LookupDeviceAuthorization 验证页通过 user_code 查找待授权的设备授权
+// 按 IP（ctx 中的 remoteIP）与会话分别统计输错次数，任一维度达到阈值后返回 429，限制 user_code 猜测
+func (s *Service) LookupDeviceAuthorization(ctx context.Context, userCode, sessionID string) (*cache.DeviceAuthorization, error) {
+	policies := deviceVerifyPolicies(helpers.RemoteIPFrom(ctx), sessionID)
+	for _, p := range policies {
+		if s.ac.Failures(ctx, p) >= p.Threshold {
+			logger.Warnf("[Device] user_code 错误次数达到阈值 - Key: %s", p.Key)
+			return nil, autherrors.NewTooManyRequests(int(p.Window.Seconds()))
+		}
+	}
+
+	d, err := s.cache.GetDeviceAuthorizationByUserCode(ctx, normalizeUserCode(userCode))
+	if err != nil || d.Status != cache.DeviceAuthorizationPending {
+		for _, p := range policies {
+			s.ac.Strike(ctx, p)
+		}
+		return nil, autherrors.NewInvalidRequest("invalid or expired user_code")
+	}
+	return d, nil
+}
+
+// deviceVerifyPolicies 构建 user_code 错误计数策略（IP 维度、会话维度，缺失的维度跳过）
+func deviceVerifyPolicies(remoteIP, sessionID string) []*accessctl.Policy {
+	threshold := config.GetDeviceVerifyFailThreshold()
+	window := config.GetDeviceVerifyFailWindow()
+
+	policies := make([]*accessctl.Policy, 0, 2)
+	if remoteIP != "" {
+		policies = append(policies, accessctl.NewPolicy(types.RateLimitKeyPrefixDeviceVerifyIP+remoteIP).FailWindow(window).ThrottleAt(threshold))
+	}
+	if sessionID != "" {
+		policies = append(policies, accessctl.NewPolicy(types.RateLimitKeyPrefixDeviceVerifySession+sessionID).FailWindow(window).ThrottleAt(threshold))
+	}
+	return policies
+}
+
+// ApproveDeviceAuthorization 用户在验证页完成认证后，将授权码绑定到设备授权
+func (s *Service) ApproveDeviceAuthorization(ctx context.Context, flow *types.AuthFlow, authCode *cache.AuthorizationCode) error {
+	userCode := flow.DeviceUserCode()
+	if userCode == "" {
+		return autherrors.NewFlowInvalid("not a device authorization flow")
+	}
+	if err := s.cache.ApproveDeviceAuthorization(ctx, userCode, authCode.Code); err != nil {
+		logger.Warnf("[Device] 绑定授权码失败 - FlowID: %s, Error: %v", flow.ID, err)
+		return autherrors.NewInvalidRequest("invalid or expired user_code")
+	}
+	logger.Infof("[Device] 用户已授权设备 - FlowID: %s, client_id: %s", flow.ID, flow.Request.ClientID)
+	return nil
+}
+
+// DenyDeviceAuthorization 用户在同意页拒绝授权设备
+func (s *Service) DenyDeviceAuthorization(ctx context.Context, flow *types.AuthFlow) error {
+	userCode := flow.DeviceUserCode()
+	if userCode == "" {
+		return autherrors.NewFlowInvalid("not a device authorization flow")
+	}
+	if err := s.cache.DenyDeviceAuthorization(ctx, userCode); err != nil {
+		logger.Warnf("[Device] 标记拒绝失败 - FlowID: %s, Error: %v", flow.ID, err)
+		return autherrors.NewInvalidRequest("invalid or expired user_code")
+	}
+	logger.Infof("[Device] 用户拒绝授权设备 - FlowID: %s, client_id: %s", flow.ID, flow.Request.ClientID)
+	return nil
+}
+
+// exchangeDeviceCode device_code 轮询换取 token（RFC 8628 §3.4）
+// 未授权返回 authorization_pending，轮询过快返回 slow_down，用户拒绝返回 access_denied，过期返回 expired_token
+// 两次轮询间隔小于设备授权的最小轮询间隔时返回 slow_down，并将该间隔增加 5 秒保存（RFC 8628 §3.5），此后按新间隔限制
+func (s *Service) exchangeDeviceCode(ctx context.Context, req *TokenRequest) (*TokenResponse, error) {
+	if req.DeviceCode == "" || req.ClientID == "" {
+		return nil, autherrors.NewInvalidRequest("device_code and client_id are required")
+	}
+
+	d, err := s.cache.GetDeviceAuthorization(ctx, req.DeviceCode)
+	if err != nil {
+		return nil, autherrors.NewExpiredToken("device code expired or not found")
+	}
+	if d.ClientID != req.ClientID {
+		return nil, autherrors.NewInvalidGrant("client_id mismatch")
+	}
+
+	window := (time.Duration(d.Interval) * time.Second).String()
+	result, err := s.throttler.Allow(ctx, cache.DevicePollKey(d.DeviceCode), map[string]int{window: 1})
+	if err != nil {
+		return nil, autherrors.NewServerError("device poll throttle failed")
+	}
+	if !result.Allowed {
+		interval, err := s.cache.SlowDownDeviceAuthorization(ctx, d.DeviceCode, slowDownStep)
+		if err != nil {
+			logger.Warnf("[Device] 增大轮询间隔失败: %v", err)
+		} else {
+			logger.Infof("[Device] 轮询过快，间隔增大至 %ds - client_id: %s", interval, d.ClientID)
+		}
+		return nil, autherrors.NewSlowDown("polling too frequently")
+	}
+
+	if d.IsDenied() {
+		if err := s.cache.DelDeviceAuthorization(ctx, d); err != nil {
+			logger.Warnf("[Device] 清理设备授权失败: %v", err)
+		}
+		return nil, autherrors.NewDeviceAccessDenied("user denied the authorization request")
+	}
+	if !d.IsApproved() {
+		return nil, autherrors.NewAuthorizationPending("user has not yet completed authorization")
+	}
+
+	authCode, err := s.cache.GetAuthCode(ctx, d.AuthCode)
+	if err != nil {
+		return nil, autherrors.NewExpiredToken("device authorization expired")
+	}
+
+	flowData, err := s.cache.GetAuthFlow(ctx, authCode.FlowID)
+	if err != nil {
+		return nil, autherrors.NewFlowNotFound("session not found")
+	}
+	var flow types.AuthFlow
+	if err := json.Unmarshal(flowData, &flow); err != nil {
+		return nil, fmt.Errorf("unmarshal flow failed: %w", err)
+	}
+	if flow.Request.ClientID != d.ClientID {
+		return nil, autherrors.NewInvalidGrant("client_id mismatch")
+	}
+
+	resp, err := s.generateTokens(ctx, &flow, req.DPoPJKT, req.AuthorizationDetails)
+	if err != nil {
+		return nil, err
+	}
+
+	s.asyncCleanupFlow(ctx, authCode.FlowID)
+	if err := s.cache.DelDeviceAuthorization(ctx, d); err != nil {
+		logger.Warnf("[Device] 清理设备授权失败: %v", err)
+	}
+
+	return resp, nil
+}
+
+// deviceVerificationURI 验证页绝对地址
+func deviceVerificationURI() string {
+	endpoint := config.GetEndpointDevice()
+	if strings.HasPrefix(endpoint, "/") {
+		return strings.TrimRight(config.GetEndpoint(), "/") + endpoint
+	}
+	return endpoint
+}
+
+// generateUserCode 生成 user_code（存储格式，无分隔符）
+func generateUserCode() (string, error) {
+	var sb strings.Builder
+	base := big.NewInt(int64(len(userCodeAlphabet)))
+	for range userCodeLength {
+		n, err := rand.Int(rand.Reader, base)
+		if err != nil {
+			return "", err
+		}
+		sb.WriteByte(userCodeAlphabet[n.Int64()])
+	}
+	return sb.String(), nil
+}
+
+// formatUserCode 展示格式 XXXX-XXXX
+func formatUserCode(code string) string {
+	half := len(code) / 2
+	return code[:half] + "-" + code[half:]
+}
+
+// normalizeUserCode 归一化用户输入（忽略大小写、分隔符和空白）
+func normalizeUserCode(input string) string {
+	return strings.Map(func(r rune) rune {
+		if r == '-' || r == ' ' {
+			return -1
+		}
+		return r
+	}, strings.ToUpper(strings.TrimSpace(input)))
+}
@@ -0,0 +1,277 @@
+package authorize
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/pkg/accessctl"
+	"github.com/heliannuuthus/pkg/helpers"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+	"github.com/heliannuuthus/pkg/throttle"
+)
+
+// deviceRedis 进程内 Redis 替身：字符串与节流用的有序集合（仅保存 score）
+// Eval 按脚本内容识别 user_code 写入、slow_down 及 pkg/throttle 的 allow / record / count 脚本
+type deviceRedis struct {
+	pkgredis.Client
+	mu      sync.Mutex
+	strings map[string]string
+	zsets   map[string][]int64
+}
+
+func newDeviceRedis() *deviceRedis {
+	return &deviceRedis{strings: make(map[string]string), zsets: make(map[string][]int64)}
+}
+
+func (r *deviceRedis) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strings[key] = value.(string)
+	return nil
+}
+
+func (r *deviceRedis) Get(_ context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.strings[key]
+	if !ok {
+		return "", pkgredis.ErrNil
+	}
+	return value, nil
+}
+
+func (r *deviceRedis) Del(_ context.Context, keys ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		delete(r.strings, key)
+		delete(r.zsets, key)
+	}
+	return nil
+}
+
+// advance 模拟时间流逝：所有节流记录提前 d
+func (r *deviceRedis) advance(d time.Duration) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for key, scores := range r.zsets {
+		for i := range scores {
+			scores[i] -= d.Milliseconds()
+		}
+		r.zsets[key] = scores
+	}
+}
+
+func (r *deviceRedis) Eval(_ context.Context, script string, keys []string, args ...any) (any, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	key := keys[0]
+	switch {
+	case strings.Contains(script, `"NX"`):
+		if _, ok := r.strings[key]; ok {
+			return nil, pkgredis.ErrNil
+		}
+		r.strings[key] = args[0].(string)
+		return "OK", nil
+	case strings.Contains(script, "cjson"):
+		return r.slowDown(key, argInt(args[0]))
+	case strings.Contains(script, "local member = ARGV[2]"):
+		return r.allow(key, args), nil
+	case strings.Contains(script, "local member = ARGV[3]"):
+		r.trim(key, argInt(args[0]))
+		r.zsets[key] = append(r.zsets[key], argInt(args[1]))
+		return int64(len(r.zsets[key])), nil
+	case strings.Contains(script, "ZCARD"):
+		r.trim(key, argInt(args[0]))
+		return int64(len(r.zsets[key])), nil
+	}
+	return nil, errors.New("unsupported script")
+}
+
+// allow 单窗口的 allowScript：窗口内记录数未达上限时写入
+func (r *deviceRedis) allow(key string, args []any) []any {
+	now, windowStart, limit := argInt(args[0]), argInt(args[3]), argInt(args[4])
+	r.trim(key, windowStart)
+	if int64(len(r.zsets[key])) >= limit {
+		return []any{int64(0), int64(1), r.zsets[key][0]}
+	}
+	r.zsets[key] = append(r.zsets[key], now)
+	return []any{int64(1), int64(-1), int64(0)}
+}
+
+func (r *deviceRedis) trim(key string, windowStart int64) {
+	kept := r.zsets[key][:0]
+	for _, score := range r.zsets[key] {
+		if score > windowStart {
+			kept = append(kept, score)
+		}
+	}
+	r.zsets[key] = kept
+}
+
+func (r *deviceRedis) slowDown(key string, step int64) (any, error) {
+	data, ok := r.strings[key]
+	if !ok {
+		return nil, pkgredis.ErrNil
+	}
+	var d map[string]any
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		return nil, err
+	}
+	interval := int64(d["interval"].(float64)) + step
+	d["interval"] = interval
+	encoded, err := json.Marshal(d)
+	if err != nil {
+		return nil, err
+	}
+	r.strings[key] = string(encoded)
+	return interval, nil
+}
+
+func argInt(v any) int64 {
+	n, err := strconv.ParseInt(fmt.Sprint(v), 10, 64)
+	if err != nil {
+		panic(err)
+	}
+	return n
+}
+
+func newDeviceService(t *testing.T) (*Service, *deviceRedis) {
+	t.Helper()
+	redis := newDeviceRedis()
+	cm := cache.NewManager(nil, redis)
+	t.Cleanup(cm.Close)
+	throttler := throttle.NewThrottler(redis)
+	return &Service{cache: cm, throttler: throttler, ac: accessctl.NewManager(throttler)}, redis
+}
+
+func saveDevice(t *testing.T, s *Service, deviceCode, userCode string, status cache.DeviceAuthorizationStatus) {
+	t.Helper()
+	now := time.Now()
+	err := s.cache.SaveDeviceAuthorization(context.Background(), &cache.DeviceAuthorization{
+		DeviceCode: deviceCode,
+		UserCode:   userCode,
+		ClientID:   "tv-app",
+		Audience:   "svc",
+		Status:     status,
+		Interval:   5,
+		CreatedAt:  now,
+		ExpiresAt:  now.Add(10 * time.Minute),
+	})
+	if err != nil {
+		t.Fatalf("SaveDeviceAuthorization() error = %v", err)
+	}
+}
+
+func pollDevice(s *Service, deviceCode, clientID string) error {
+	_, err := s.exchangeDeviceCode(context.Background(), &TokenRequest{DeviceCode: deviceCode, ClientID: clientID})
+	return err
+}
+
+func TestExchangeDeviceCodeSlowDown(t *testing.T) {
+	s, redis := newDeviceService(t)
+	saveDevice(t, s, "dc-1", "BCDFGHJK", cache.DeviceAuthorizationPending)
+
+	steps := []struct {
+		name         string
+		advance      time.Duration
+		wantCode     string
+		wantInterval int
+	}{
+		{name: "first poll", wantCode: autherrors.CodeAuthorizationPending, wantInterval: 5},
+		{name: "too soon", advance: time.Second, wantCode: autherrors.CodeSlowDown, wantInterval: 10},
+		// 原间隔已过，但新间隔（10s）内仍视为过快
+		{name: "within raised interval", advance: 6 * time.Second, wantCode: autherrors.CodeSlowDown, wantInterval: 15},
+		{name: "after raised interval", advance: 16 * time.Second, wantCode: autherrors.CodeAuthorizationPending, wantInterval: 15},
+	}
+	for _, step := range steps {
+		redis.advance(step.advance)
+		if code := authErrorCode(pollDevice(s, "dc-1", "tv-app")); code != step.wantCode {
+			t.Fatalf("%s: exchangeDeviceCode() code = %q, want %q", step.name, code, step.wantCode)
+		}
+		d, err := s.cache.GetDeviceAuthorization(context.Background(), "dc-1")
+		if err != nil {
+			t.Fatalf("%s: GetDeviceAuthorization() error = %v", step.name, err)
+		}
+		if d.Interval != step.wantInterval || d.Status != cache.DeviceAuthorizationPending || d.UserCode != "BCDFGHJK" {
+			t.Fatalf("%s: stored device authorization = %+v, want interval %d", step.name, d, step.wantInterval)
+		}
+	}
+}
+
+func TestExchangeDeviceCodeRejects(t *testing.T) {
+	s, _ := newDeviceService(t)
+	saveDevice(t, s, "dc-pending", "BCDFGHJK", cache.DeviceAuthorizationPending)
+	saveDevice(t, s, "dc-denied", "LMNPQRST", cache.DeviceAuthorizationDenied)
+
+	tests := []struct {
+		name       string
+		deviceCode string
+		clientID   string
+		wantCode   string
+	}{
+		{name: "missing client_id", deviceCode: "dc-pending", wantCode: autherrors.CodeInvalidRequest},
+		{name: "unknown device code", deviceCode: "dc-unknown", clientID: "tv-app", wantCode: autherrors.CodeExpiredToken},
+		{name: "other client", deviceCode: "dc-pending", clientID: "other-app", wantCode: autherrors.CodeInvalidGrant},
+		{name: "denied by user", deviceCode: "dc-denied", clientID: "tv-app", wantCode: autherrors.CodeAccessDenied},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if code := authErrorCode(pollDevice(s, tt.deviceCode, tt.clientID)); code != tt.wantCode {
+				t.Fatalf("exchangeDeviceCode() code = %q, want %q", code, tt.wantCode)
+			}
+		})
+	}
+
+	if _, err := s.cache.GetDeviceAuthorization(context.Background(), "dc-denied"); !errors.Is(err, cache.ErrDeviceCodeNotFound) {
+		t.Fatalf("denied device authorization was not removed, error = %v", err)
+	}
+}
+
+func TestLookupDeviceAuthorizationThrottlesGuesses(t *testing.T) {
+	s, _ := newDeviceService(t)
+	saveDevice(t, s, "dc-1", "BCDFGHJK", cache.DeviceAuthorizationPending)
+	attacker := helpers.WithRemoteIP(context.Background(), "203.0.113.7")
+
+	for i := range 10 {
+		_, err := s.LookupDeviceAuthorization(attacker, fmt.Sprintf("WRONG%03d", i), "session-1")
+		if code := authErrorCode(err); code != autherrors.CodeInvalidRequest {
+			t.Fatalf("guess %d: LookupDeviceAuthorization() code = %q, want %q", i, code, autherrors.CodeInvalidRequest)
+		}
+	}
+
+	tests := []struct {
+		name      string
+		ctx       context.Context
+		sessionID string
+		wantCode  string
+	}{
+		{name: "same ip", ctx: attacker, wantCode: autherrors.CodeRateLimited},
+		{name: "same session from another ip", ctx: helpers.WithRemoteIP(context.Background(), "198.51.100.1"), sessionID: "session-1", wantCode: autherrors.CodeRateLimited},
+		{name: "another ip and session", ctx: helpers.WithRemoteIP(context.Background(), "198.51.100.1"), sessionID: "session-2"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			d, err := s.LookupDeviceAuthorization(tt.ctx, "bcdf-ghjk", tt.sessionID)
+			if tt.wantCode != "" {
+				if code := authErrorCode(err); code != tt.wantCode {
+					t.Fatalf("LookupDeviceAuthorization() code = %q, want %q", code, tt.wantCode)
+				}
+				return
+			}
+			if err != nil || d.DeviceCode != "dc-1" {
+				t.Fatalf("LookupDeviceAuthorization() = %+v, %v", d, err)
+			}
+		})
+	}
+}
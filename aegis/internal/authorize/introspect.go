@@ -0,0 +1,128 @@
+package authorize
+
+import (
+	"context"
+	"slices"
+	"strings"
+
+	"github.com/heliannuuthus/aegis/internal/token"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// TokenTypeHint 常量（RFC 7662 §2.1）
+const (
+	TokenTypeHintAccessToken  = "access_token"
+	TokenTypeHintRefreshToken = "refresh_token"
+)
+
+// Introspect Token 自省（RFC 7662）
+// callerID 为调用方 CT 的 client_id，只对 audience 为调用方的 access token 验签并解密 sub；
+// refresh_token 仅对其所属应用或 audience 可见，撤销/过期均视为 inactive
+func (s *Service) Introspect(ctx context.Context, callerID string, req *IntrospectionRequest) *IntrospectionResponse {
+	if req.TokenTypeHint == TokenTypeHintRefreshToken || !isPasetoToken(req.Token) {
+		return s.introspectRefreshToken(ctx, callerID, req.Token)
+	}
+	return s.introspectAccessToken(ctx, callerID, req.Token)
+}
+
+func (s *Service) introspectAccessToken(ctx context.Context, callerID, tokenString string) *IntrospectionResponse {
+	inactive := &IntrospectionResponse{Active: false}
+
+	// 先按未验签的 aud 过滤，避免为非调用方的 token 解密 sub
+	unverified, err := tokendef.UnsafeParse(tokenString)
+	if err != nil {
+		return inactive
+	}
+	if aud, err := tokendef.GetAudience(unverified); err != nil || aud != callerID {
+		return inactive
+	}
+
+	t, err := s.tokenSvc.Verify(ctx, tokenString)
+	if err != nil {
+		logger.Debugf("[Introspect] token 验证失败 - caller: %s, error: %v", callerID, err)
+		return inactive
+	}
+	if t.IsExpired() || t.Audience() != callerID {
+		return inactive
+	}
+	if at, ok := t.(tokendef.AccessToken); ok && s.IsAccessTokenRevoked(ctx, at) {
+		return inactive
+	}
+
+	resp := &IntrospectionResponse{
+		Active:    true,
+		ClientID:  t.ClientID(),
+		Aud:       t.Audience(),
+		Iss:       t.Issuer(),
+		Exp:       t.ExpiresAt().Unix(),
+		Iat:       t.IssuedAt().Unix(),
+		TokenType: tokendef.TokenTypeBearer,
+	}
+
+	switch v := t.(type) {
+	case *token.UserAccessToken:
+		resp.Sub = v.OpenID()
+		resp.Scope = joinScopeSet(v.Scopes())
+		resp.Act = v.Actor()
+		if authTime := v.AuthTime(); !authTime.IsZero() {
+			resp.AuthTime = authTime.Unix()
+		}
+		resp.AuthorizationDetails = v.AuthorizationDetails()
+		if jkt := v.JKT(); jkt != "" {
+			resp.TokenType = dpop.TokenType
+			resp.Cnf = &Cnf{JKT: jkt}
+		}
+	case *token.ServiceAccessToken:
+		resp.Scope = joinScopeSet(v.Scopes())
+		if jkt := v.JKT(); jkt != "" {
+			resp.TokenType = dpop.TokenType
+			resp.Cnf = &Cnf{JKT: jkt}
+		}
+	default:
+		// CT / Challenge / SSO 等不对外自省
+		return inactive
+	}
+
+	return resp
+}
+
+func (s *Service) introspectRefreshToken(ctx context.Context, callerID, tokenValue string) *IntrospectionResponse {
+	rt, err := s.cache.GetRefreshToken(ctx, tokenValue)
+	if err != nil {
+		// 不存在、过期、已撤销均为 inactive
+		return &IntrospectionResponse{Active: false}
+	}
+	if rt.ClientID != callerID && rt.Audience != callerID {
+		return &IntrospectionResponse{Active: false}
+	}
+
+	return &IntrospectionResponse{
+		Active:    true,
+		Scope:     rt.Scope,
+		ClientID:  rt.ClientID,
+		Sub:       rt.OpenID,
+		Aud:       rt.Audience,
+		Exp:       rt.ExpiresAt.Unix(),
+		Iat:       rt.CreatedAt.Unix(),
+		TokenType: TokenTypeHintRefreshToken,
+
+		AuthorizationDetails: rt.AuthorizationDetails,
+	}
+}
+
+// isPasetoToken 判断是否为 PASETO v4.public token（refresh_token 为不透明 hex 串）
+func isPasetoToken(s string) bool {
+	return strings.HasPrefix(s, tokendef.PasetoVersion+"."+tokendef.PasetoPurpose+".")
+}
+
+// joinScopeSet 将 scope 集合按字典序拼接为空格分隔字符串
+func joinScopeSet(set map[string]struct{}) string {
+	scopes := make([]string, 0, len(set))
+	for sc := range set {
+		scopes = append(scopes, sc)
+	}
+	slices.Sort(scopes)
+	return strings.Join(scopes, " ")
+}
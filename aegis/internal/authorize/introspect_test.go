@@ -0,0 +1,233 @@
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/token"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
+	"github.com/heliannuuthus/pkg/aegis/utilities/key"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// introspectRedis 在 deviceRedis 基础上补充吊销列表与 refresh token 用到的 hash / 集合
+type introspectRedis struct {
+	*deviceRedis
+	hashes map[string]map[string]string
+}
+
+func (r *introspectRedis) HSet(_ context.Context, key string, values ...any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes[key] == nil {
+		r.hashes[key] = make(map[string]string)
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		r.hashes[key][values[i].(string)] = fmt.Sprint(values[i+1])
+	}
+	return nil
+}
+
+func (r *introspectRedis) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := make(map[string]string, len(r.hashes[key]))
+	for field, value := range r.hashes[key] {
+		values[field] = value
+	}
+	return values, nil
+}
+
+func (r *introspectRedis) HDel(_ context.Context, key string, fields ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, field := range fields {
+		delete(r.hashes[key], field)
+	}
+	return nil
+}
+
+func (*introspectRedis) SAdd(context.Context, string, ...any) error { return nil }
+
+func (*introspectRedis) Expire(context.Context, string, time.Duration) error { return nil }
+
+func (*introspectRedis) TTL(context.Context, string) (time.Duration, error) { return 0, nil }
+
+// newIntrospectService 使用真实的 token.Service：所有应用共用一把域签名密钥，所有服务共用一把加密密钥
+func newIntrospectService(t *testing.T) *Service {
+	t.Helper()
+	cm := cache.NewManager(nil, &introspectRedis{deviceRedis: newDeviceRedis(), hashes: make(map[string]map[string]string)})
+	t.Cleanup(cm.Close)
+
+	domainKey := paseto.NewV4AsymmetricSecretKey()
+	serviceKey := paseto.NewV4SymmetricKey()
+	domainSign := key.SingleOf(func(context.Context, string) ([]byte, error) { return domainKey.ExportBytes(), nil })
+	domainVerify := key.SingleOf(func(context.Context, string) ([]byte, error) { return domainKey.Public().ExportBytes(), nil })
+	serviceKeys := key.SingleOf(func(context.Context, string) ([]byte, error) { return serviceKey.ExportBytes(), nil })
+	appVerify := key.SingleOf(func(context.Context, string) ([]byte, error) { return nil, key.ErrNotFound })
+
+	return &Service{cache: cm, tokenSvc: token.NewService(cm, domainSign, domainVerify, serviceKeys, appVerify)}
+}
+
+// issueUserAccessToken 签发 web-app 为 openid-1 申请的 service-a UAT
+func issueUserAccessToken(t *testing.T, s *Service, expiresIn time.Duration, jkt string) string {
+	t.Helper()
+	uat := tokendef.NewClaimsBuilder().
+		Issuer("aegis").
+		ClientID("web-app").
+		Audience("service-a").
+		ExpiresIn(expiresIn).
+		Build(tokendef.NewUserAccessTokenBuilder().OpenID("openid-1").Scope("openid profile").Confirmation(jkt))
+	tokenString, err := s.tokenSvc.Issue(context.Background(), uat)
+	if err != nil {
+		t.Fatalf("Issue() error = %v", err)
+	}
+	return tokenString
+}
+
+func introspect(s *Service, callerID, tokenString string) *IntrospectionResponse {
+	return s.Introspect(context.Background(), callerID, &IntrospectionRequest{Token: tokenString})
+}
+
+func TestIntrospectActiveAccessToken(t *testing.T) {
+	s := newIntrospectService(t)
+
+	resp := introspect(s, "service-a", issueUserAccessToken(t, s, time.Hour, ""))
+	if !resp.Active || resp.Sub != "openid-1" || resp.Scope != "openid profile" || resp.ClientID != "web-app" || resp.Aud != "service-a" {
+		t.Fatalf("Introspect() = %+v, want active token of openid-1", resp)
+	}
+	if resp.TokenType != tokendef.TokenTypeBearer || resp.Cnf != nil {
+		t.Fatalf("Introspect() token_type = %q, cnf = %+v, want unbound bearer token", resp.TokenType, resp.Cnf)
+	}
+}
+
+func TestIntrospectDPoPBoundAccessToken(t *testing.T) {
+	s := newIntrospectService(t)
+
+	resp := introspect(s, "service-a", issueUserAccessToken(t, s, time.Hour, "jkt-1"))
+	if !resp.Active || resp.TokenType != dpop.TokenType || resp.Cnf == nil || resp.Cnf.JKT != "jkt-1" {
+		t.Fatalf("Introspect() = %+v, want active DPoP token bound to jkt-1", resp)
+	}
+}
+
+func TestIntrospectInactiveAccessToken(t *testing.T) {
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		callerID string
+		prepare  func(t *testing.T, s *Service) string
+	}{
+		{
+			name:     "expired",
+			callerID: "service-a",
+			prepare: func(t *testing.T, s *Service) string {
+				return issueUserAccessToken(t, s, -time.Minute, "")
+			},
+		},
+		{
+			name:     "revoked by jti",
+			callerID: "service-a",
+			prepare: func(t *testing.T, s *Service) string {
+				tokenString := issueUserAccessToken(t, s, time.Hour, "")
+				if err := s.RevokeToken(ctx, tokenString); err != nil {
+					t.Fatalf("RevokeToken() error = %v", err)
+				}
+				return tokenString
+			},
+		},
+		{
+			name:     "revoked by subject",
+			callerID: "service-a",
+			prepare: func(t *testing.T, s *Service) string {
+				tokenString := issueUserAccessToken(t, s, time.Hour, "")
+				// 签发时间按秒截断，吊销时间取其后一秒以覆盖该 token
+				if err := s.cache.RevokeSubjectAudience(ctx, "openid-1", "service-a", time.Now().Add(time.Second)); err != nil {
+					t.Fatalf("RevokeSubjectAudience() error = %v", err)
+				}
+				return tokenString
+			},
+		},
+		{
+			name:     "foreign audience",
+			callerID: "service-b",
+			prepare: func(t *testing.T, s *Service) string {
+				return issueUserAccessToken(t, s, time.Hour, "")
+			},
+		},
+		{
+			name:     "caller is the issuing client",
+			callerID: "web-app",
+			prepare: func(t *testing.T, s *Service) string {
+				return issueUserAccessToken(t, s, time.Hour, "jkt-1")
+			},
+		},
+		{
+			name:     "malformed",
+			callerID: "service-a",
+			prepare: func(*testing.T, *Service) string {
+				return tokendef.PasetoVersion + "." + tokendef.PasetoPurpose + ".garbage"
+			},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := newIntrospectService(t)
+			// inactive 响应不得携带任何 token 细节
+			if resp := introspect(s, tt.callerID, tt.prepare(t, s)); !reflect.DeepEqual(resp, &IntrospectionResponse{Active: false}) {
+				t.Fatalf("Introspect() = %+v, want bare inactive response", resp)
+			}
+		})
+	}
+}
+
+func TestIntrospectRefreshTokenVisibility(t *testing.T) {
+	s := newIntrospectService(t)
+	now := time.Now()
+	err := s.cache.SetRefreshToken(context.Background(), &cache.RefreshToken{
+		Token:     "rt-1",
+		OpenID:    "openid-1",
+		ClientID:  "web-app",
+		Audience:  "service-a",
+		Scope:     "openid offline_access",
+		ExpiresAt: now.Add(time.Hour),
+		CreatedAt: now,
+	})
+	if err != nil {
+		t.Fatalf("SetRefreshToken() error = %v", err)
+	}
+
+	tests := []struct {
+		name       string
+		callerID   string
+		wantActive bool
+	}{
+		{name: "owning client", callerID: "web-app", wantActive: true},
+		{name: "audience", callerID: "service-a", wantActive: true},
+		{name: "unrelated caller", callerID: "service-b"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := introspect(s, tt.callerID, "rt-1")
+			if !tt.wantActive {
+				if !reflect.DeepEqual(resp, &IntrospectionResponse{Active: false}) {
+					t.Fatalf("Introspect() = %+v, want bare inactive response", resp)
+				}
+				return
+			}
+			if !resp.Active || resp.Sub != "openid-1" || resp.TokenType != TokenTypeHintRefreshToken {
+				t.Fatalf("Introspect() = %+v, want active refresh token of openid-1", resp)
+			}
+		})
+	}
+
+	if resp := introspect(s, "web-app", "rt-unknown"); resp.Active {
+		t.Fatalf("Introspect() of unknown refresh token = %+v, want inactive", resp)
+	}
+}
@@ -0,0 +1,66 @@
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// RevokeToken 撤销 token（RFC 7009）
+// refresh_token 标记撤销；access token 验签后按 jti 加入其 audience 的吊销列表，无效 token 静默忽略
+func (s *Service) RevokeToken(ctx context.Context, tokenValue string) error {
+	if !isPasetoToken(tokenValue) {
+		return s.cache.DelRefreshToken(ctx, tokenValue)
+	}
+
+	t, err := s.tokenSvc.Verify(ctx, tokenValue)
+	if err != nil {
+		return fmt.Errorf("verify token: %w", err)
+	}
+	at, ok := t.(tokendef.AccessToken)
+	if !ok || t.Type() == tokendef.TokenTypeCT {
+		return fmt.Errorf("%w: %s", tokendef.ErrUnsupportedToken, t.Type())
+	}
+
+	if err := s.cache.RevokeAccessToken(ctx, at.Audience(), at.JTI(), at.ExpiresAt()); err != nil {
+		return err
+	}
+	logger.Infof("[Revoke] 吊销 access token - audience: %s, client_id: %s, jti: %s", at.Audience(), at.ClientID(), at.JTI())
+	return nil
+}
+
+// RevokeSubject 吊销用户此前签发的全部 access token，撤销其全部 refresh token，
+// 并结束用户参与的 SSO 会话（后端通道通知各参与应用）
+// 用于登出所有设备、用户被禁用等场景
+func (s *Service) RevokeSubject(ctx context.Context, openid string) error {
+	if err := s.cache.RevokeSubject(ctx, openid, time.Now()); err != nil {
+		return err
+	}
+	logger.Infof("[Revoke] 吊销用户全部 access token - openid: %s", openid)
+	s.logoutSvc.EndSubjectSessions(ctx, openid)
+	return s.cache.DelUserRefreshTokens(ctx, openid)
+}
+
+// IssueRevocationList 为 audience 签发吊销列表（资源服务按 Cache-Control 轮询）
+func (s *Service) IssueRevocationList(ctx context.Context, audience string) (string, error) {
+	list, err := s.cache.GetRevocationList(ctx, audience)
+	if err != nil {
+		return "", err
+	}
+	// 列表 token 的有效期留出一个轮询周期的余量，避免资源服务刷新失败时立即失效
+	return s.tokenSvc.IssueRevocationList(ctx, audience, list, 2*config.GetRevocationListMaxAge())
+}
+
+// IsAccessTokenRevoked 检查 access token 是否在吊销列表中（列表不可用时视为未吊销）
+func (s *Service) IsAccessTokenRevoked(ctx context.Context, at tokendef.AccessToken) bool {
+	list, err := s.cache.GetRevocationList(ctx, at.Audience())
+	if err != nil {
+		logger.Warnf("[Revoke] 获取吊销列表失败: %v", err)
+		return false
+	}
+	return list.IsRevoked(at)
+}
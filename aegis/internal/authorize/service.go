@@ -16,24 +16,31 @@ import (
 	"github.com/heliannuuthus/aegis/config"
 	autherrors "github.com/heliannuuthus/aegis/errors"
 	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/logout"
 	"github.com/heliannuuthus/aegis/internal/token"
 	"github.com/heliannuuthus/aegis/internal/types"
 	"github.com/heliannuuthus/aegis/internal/user"
 	"github.com/heliannuuthus/aegis/models"
 	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/accessctl"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
 	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
 	"github.com/heliannuuthus/pkg/async"
 	"github.com/heliannuuthus/pkg/helpers"
 	"github.com/heliannuuthus/pkg/logger"
+	"github.com/heliannuuthus/pkg/throttle"
 )
 
 // Service 授权服务
 type Service struct {
-	cache    *cache.Manager
-	hermes   *hermes.Client
-	userSvc  *user.Service
-	tokenSvc *token.Service
-	pool     *async.Pool
+	cache     *cache.Manager
+	hermes    *hermes.Client
+	userSvc   *user.Service
+	tokenSvc  *token.Service
+	logoutSvc *logout.Service
+	pool      *async.Pool
+	throttler *throttle.Throttler
+	ac        *accessctl.Manager
 
 	// 配置
 	authCodeExpiresIn time.Duration
@@ -97,7 +104,10 @@ func NewService(
 	hermesClient *hermes.Client,
 	userSvc *user.Service,
 	tokenSvc *token.Service,
+	logoutSvc *logout.Service,
 	pool *async.Pool,
+	throttler *throttle.Throttler,
+	ac *accessctl.Manager,
 	authCodeExpiresIn time.Duration,
 ) *Service {
 	return &Service{
@@ -105,7 +115,10 @@ func NewService(
 		hermes:            hermesClient,
 		userSvc:           userSvc,
 		tokenSvc:          tokenSvc,
+		logoutSvc:         logoutSvc,
 		pool:              pool,
+		throttler:         throttler,
+		ac:                ac,
 		authCodeExpiresIn: defaultDuration(authCodeExpiresIn, 5*time.Minute),
 	}
 }
@@ -200,13 +213,17 @@ func (s *Service) GenerateAuthCode(ctx context.Context, flow *types.AuthFlow) (*
 
 // ==================== Token 交换 ====================
 
-// ExchangeToken 单 audience 授权码换取 Token
+// ExchangeToken 单 audience Token 交换（authorization_code / refresh_token / token-exchange / device_code）
 func (s *Service) ExchangeToken(ctx context.Context, req *TokenRequest) (*TokenResponse, error) {
 	switch req.GrantType {
 	case GrantTypeAuthorizationCode:
 		return s.exchangeAuthorizationCode(ctx, req)
 	case GrantTypeRefreshToken:
 		return s.refreshToken(ctx, req)
+	case GrantTypeTokenExchange:
+		return s.exchangeDelegatedToken(ctx, req)
+	case GrantTypeDeviceCode:
+		return s.exchangeDeviceCode(ctx, req)
 	default:
 		return nil, autherrors.NewInvalidRequestf("unsupported grant type: %s", req.GrantType)
 	}
@@ -221,7 +238,10 @@ func (s *Service) ExchangeAuthCodeForm(ctx context.Context, req *TokenRequest) (
 	}
 
 	if len(flow.Request.Audiences) > 0 {
-		resp, err := s.exchangeMultiAudienceAuthCode(ctx, flow)
+		if len(req.AuthorizationDetails) > 0 {
+			return nil, autherrors.NewInvalidAuthorizationDetails("authorization_details is not supported for multi-audience token requests")
+		}
+		resp, err := s.exchangeMultiAudienceAuthCode(ctx, flow, req.DPoPJKT)
 		if err != nil {
 			return nil, err
 		}
@@ -229,7 +249,7 @@ func (s *Service) ExchangeAuthCodeForm(ctx context.Context, req *TokenRequest) (
 		return resp, nil
 	}
 
-	resp, err := s.generateTokens(ctx, flow)
+	resp, err := s.generateTokens(ctx, flow, req.DPoPJKT, req.AuthorizationDetails)
 	if err != nil {
 		return nil, err
 	}
@@ -239,11 +259,15 @@ func (s *Service) ExchangeAuthCodeForm(ctx context.Context, req *TokenRequest) (
 
 // ==================== 多 Audience Token 交换 ====================
 
-// ExchangeMultiAudienceToken 多 audience token 交换（仅 authorization_code）
+// ExchangeMultiAudienceToken 多 audience token 交换（authorization_code）
 // refresh_token 场景下每个 audience 独立刷新，走单 audience 的 ExchangeToken
+// client_credentials 需要先验证 CT，走 ExchangeClientCredentials
 func (s *Service) ExchangeMultiAudienceToken(ctx context.Context, req *MultiAudienceTokenRequest) (MultiAudienceTokenResponse, error) {
 	switch req.GrantType {
 	case GrantTypeAuthorizationCode:
+		if req.Code == "" || req.ClientID == "" {
+			return nil, autherrors.NewInvalidRequest("code and client_id are required")
+		}
 		return s.exchangeMultiAudienceAuthorizationCode(ctx, req)
 	default:
 		return nil, autherrors.NewInvalidRequestf("unsupported grant type for multi-audience: %s (use single-audience refresh_token instead)", req.GrantType)
@@ -366,7 +390,7 @@ func (s *Service) resolveAuthCodeFlow(ctx context.Context, req *TokenRequest) (*
 	return &flow, authCode.FlowID, nil
 }
 
-func (s *Service) exchangeMultiAudienceAuthCode(ctx context.Context, flow *types.AuthFlow) (MultiAudienceTokenResponse, error) {
+func (s *Service) exchangeMultiAudienceAuthCode(ctx context.Context, flow *types.AuthFlow, jkt string) (MultiAudienceTokenResponse, error) {
 	if flow.User == nil || flow.User.OpenID == "" {
 		return nil, autherrors.NewServerError("failed to resolve user subject")
 	}
@@ -374,7 +398,7 @@ func (s *Service) exchangeMultiAudienceAuthCode(ctx context.Context, flow *types
 	if err != nil {
 		return nil, err
 	}
-	resp, err := s.generateMultiAudienceTokens(ctx, flow, audiences)
+	resp, err := s.generateMultiAudienceTokens(ctx, flow, audiences, jkt)
 	if err != nil {
 		return nil, err
 	}
@@ -443,7 +467,7 @@ func (s *Service) exchangeAuthorizationCode(ctx context.Context, req *TokenReque
 	logger.Infof("[Token] 阶段3 通过, 进入签发 token")
 
 	// 6. 生成 Token
-	resp, err := s.generateTokens(ctx, &flow)
+	resp, err := s.generateTokens(ctx, &flow, req.DPoPJKT, req.AuthorizationDetails)
 	if err != nil {
 		return nil, err
 	}
@@ -463,16 +487,22 @@ func (s *Service) asyncCleanupFlow(ctx context.Context, flowID string) {
 }
 
 func (s *Service) refreshToken(ctx context.Context, req *TokenRequest) (*TokenResponse, error) {
-	// 1. 获取 refresh token
+	// 1. 获取 refresh token（已轮换的 token 再次出示视为泄露，撤销整个 family）
 	rt, err := s.cache.GetRefreshToken(ctx, req.RefreshToken)
 	if err != nil {
+		if errors.Is(err, cache.ErrRefreshTokenReused) {
+			s.revokeRefreshTokenFamily(ctx, req.RefreshToken)
+		}
 		return nil, autherrors.NewInvalidGrant("invalid refresh token")
 	}
 
-	// 2. 验证 client_id
+	// 2. 验证 client_id；DPoP 绑定的 refresh token 须由同一密钥出示（RFC 9449 §5）
 	if req.ClientID != rt.ClientID {
 		return nil, autherrors.NewInvalidGrant("client_id mismatch")
 	}
+	if rt.JKT != "" && rt.JKT != req.DPoPJKT {
+		return nil, autherrors.NewInvalidDPoPProof("refresh token is bound to a different DPoP key")
+	}
 
 	now := time.Now()
 	if rt.MaxExpiresAt != nil && now.After(*rt.MaxExpiresAt) {
@@ -487,6 +517,13 @@ func (s *Service) refreshToken(ctx context.Context, req *TokenRequest) (*TokenRe
 	if err != nil {
 		return nil, fmt.Errorf("user not found: %w", err)
 	}
+	if !user.IsActive() {
+		// 用户已被禁用：吊销其全部 token，后续刷新与已签发的 access token 一并失效
+		if err := s.RevokeSubject(ctx, rt.OpenID); err != nil {
+			logger.Warnf("[Token] 吊销禁用用户 token 失败 - openid: %s, Error: %v", rt.OpenID, err)
+		}
+		return nil, autherrors.NewInvalidGrant("user is disabled")
+	}
 
 	app, err := s.cache.GetApplication(ctx, rt.ClientID)
 	if err != nil {
@@ -498,9 +535,14 @@ func (s *Service) refreshToken(ctx context.Context, req *TokenRequest) (*TokenRe
 		return nil, fmt.Errorf("service not found: %w", err)
 	}
 
-	// 4. 沉寂延长：更新 rt.ExpiresAt，不超过绝对过期
+	// 4. 轮换：签发同 family 的新 token；否则沉寂延长：更新 rt.ExpiresAt，不超过绝对过期
 	sliding := uintSecToDuration(app.RefreshTokenExpiresIn)
-	if sliding > 0 {
+	if app.RefreshTokenRotation {
+		rt, err = s.rotateRefreshToken(ctx, rt, sliding)
+		if err != nil {
+			return nil, err
+		}
+	} else if sliding > 0 {
 		newExpiresAt := now.Add(sliding)
 		if rt.MaxExpiresAt != nil && newExpiresAt.After(*rt.MaxExpiresAt) {
 			newExpiresAt = *rt.MaxExpiresAt
@@ -511,8 +553,12 @@ func (s *Service) refreshToken(ctx context.Context, req *TokenRequest) (*TokenRe
 		}
 	}
 
-	// 5. 生成新的 access token
-	tokenResp, err := s.generateAccessToken(ctx, &app.Application, &svc.Service, user, rt.OpenID, rt.Scope)
+	// 5. 生成新的 access token（authorization_details 可按 refresh token 的授予范围收窄）
+	details, err := narrowAuthorizationDetails(rt.AuthorizationDetails, req.AuthorizationDetails)
+	if err != nil {
+		return nil, err
+	}
+	tokenResp, err := s.generateAccessToken(ctx, &app.Application, &svc.Service, user, rt.OpenID, rt.Scope, req.DPoPJKT, rt.AuthTime, details)
 	if err != nil {
 		return nil, err
 	}
@@ -520,16 +566,81 @@ func (s *Service) refreshToken(ctx context.Context, req *TokenRequest) (*TokenRe
 	return tokenResp, nil
 }
 
-// generateTokens 生成 token（用于授权码交换）
-func (s *Service) generateTokens(ctx context.Context, flow *types.AuthFlow) (*TokenResponse, error) {
+// rotateRefreshToken 轮换 refresh token：新 token 继承 family、scope 与绝对过期时间
+func (s *Service) rotateRefreshToken(ctx context.Context, current *cache.RefreshToken, sliding time.Duration) (*cache.RefreshToken, error) {
+	tokenValue, err := generateRefreshTokenValue()
+	if err != nil {
+		return nil, fmt.Errorf("generate refresh token: %w", err)
+	}
+
+	now := time.Now()
+	expiresAt := current.ExpiresAt
+	if sliding > 0 {
+		expiresAt = now.Add(sliding)
+	}
+	if current.MaxExpiresAt != nil && expiresAt.After(*current.MaxExpiresAt) {
+		expiresAt = *current.MaxExpiresAt
+	}
+
+	next := &cache.RefreshToken{
+		Token:                tokenValue,
+		OpenID:               current.OpenID,
+		ClientID:             current.ClientID,
+		Audience:             current.Audience,
+		Scope:                current.Scope,
+		ExpiresAt:            expiresAt,
+		MaxExpiresAt:         current.MaxExpiresAt,
+		JKT:                  current.JKT,
+		AuthTime:             current.AuthTime,
+		AuthorizationDetails: current.AuthorizationDetails,
+		CreatedAt:            now,
+	}
+	if err := s.cache.RotateRefreshToken(ctx, current, next); err != nil {
+		if errors.Is(err, cache.ErrRefreshTokenReused) {
+			s.revokeRefreshTokenFamily(ctx, current.Token)
+			return nil, autherrors.NewInvalidGrant("invalid refresh token")
+		}
+		return nil, fmt.Errorf("rotate refresh token: %w", err)
+	}
+	return next, nil
+}
+
+// revokeRefreshTokenFamily 检测到 refresh token 重用，撤销整个 family（RFC 9700 §4.14.2），
+// 并将 family 的用户写入其 audience 的吊销列表，此前由该 family 换取的 access token 一并失效
+func (s *Service) revokeRefreshTokenFamily(ctx context.Context, tokenValue string) {
+	rt, err := s.cache.DelRefreshTokenFamily(ctx, tokenValue)
+	if rt == nil {
+		logger.Errorf("[Token] refresh token 重用，读取 family 失败 - Error: %v", err)
+		return
+	}
+	if err != nil {
+		logger.Errorf("[Token] refresh token 重用，撤销 family 失败 - family: %s, Error: %v", rt.FamilyID, err)
+	}
+	if err := s.cache.RevokeSubjectAudience(ctx, rt.OpenID, rt.Audience, time.Now()); err != nil {
+		logger.Errorf("[Token] refresh token 重用，吊销 access token 失败 - openid: %s, audience: %s, Error: %v", rt.OpenID, rt.Audience, err)
+		return
+	}
+	logger.Warnf("[Revoke] 检测到 refresh token 重用，已撤销 family 并吊销 access token - family: %s, openid: %s, client_id: %s, audience: %s",
+		rt.FamilyID, rt.OpenID, rt.ClientID, rt.Audience)
+}
+
+// generateTokens 生成 token（用于授权码交换），jkt 非空时 access token 与 refresh token 绑定 DPoP 密钥；
+// requested 非空时 access token 仅携带收窄后的 authorization_details，refresh token 保留完整授予范围
+func (s *Service) generateTokens(ctx context.Context, flow *types.AuthFlow, jkt string, requested tokendef.AuthorizationDetails) (*TokenResponse, error) {
 	scope := strings.Join(flow.GrantedScopes, " ")
 
 	if flow.User == nil || flow.User.OpenID == "" {
 		return nil, autherrors.NewServerError("failed to resolve user subject")
 	}
 
+	granted := flow.AuthorizationDetails[flow.Service.ServiceID]
+	details, err := narrowAuthorizationDetails(granted, requested)
+	if err != nil {
+		return nil, err
+	}
+
 	// 签发 access token
-	tokenResp, err := s.generateAccessToken(ctx, flow.Application, flow.Service, flow.User, flow.User.OpenID, scope)
+	tokenResp, err := s.generateAccessToken(ctx, flow.Application, flow.Service, flow.User, flow.User.OpenID, scope, jkt, flow.AuthTime, details)
 	if err != nil {
 		return nil, err
 	}
@@ -548,7 +659,7 @@ func (s *Service) generateTokens(ctx context.Context, flow *types.AuthFlow) (*To
 
 	// 如果 scope 包含 offline_access，生成 refresh token
 	if helpers.ContainsScope(flow.GrantedScopes, ScopeOfflineAccess) {
-		rt, err := s.createRefreshToken(ctx, flow, scope)
+		rt, err := s.createRefreshToken(ctx, flow, scope, jkt, granted)
 		if err != nil {
 			return nil, err
 		}
@@ -565,7 +676,13 @@ func (s *Service) generateAccessToken(
 	user *models.UserWithDecrypted,
 	sub string,
 	scope string,
+	jkt string,
+	authTime time.Time,
+	details tokendef.AuthorizationDetails,
 ) (*TokenResponse, error) {
+	if app.DPoPBoundAccessTokens && jkt == "" {
+		return nil, autherrors.NewInvalidDPoPProof("DPoP proof required for this client")
+	}
 	if svc.AccessTokenExpiresIn == 0 {
 		return nil, autherrors.NewInvalidRequestf("access_token_expires_in not configured for service %s", svc.ServiceID)
 	}
@@ -575,7 +692,10 @@ func (s *Service) generateAccessToken(
 	scopes := parseScopeSet(scope)
 	uatBuilder := token.NewUserAccessTokenBuilder().
 		Scope(scope).
-		OpenID(sub)
+		OpenID(sub).
+		Confirmation(jkt).
+		AuthTime(authTime).
+		AuthorizationDetails(details)
 
 	if scopes[ScopeProfile] {
 		uatBuilder.Nickname(user.GetNickname()).Picture(user.GetPicture())
@@ -594,16 +714,25 @@ func (s *Service) generateAccessToken(
 		ExpiresIn(accessExpiresIn).
 		Build(uatBuilder)
 
+	if err := s.cache.TrackSubjectAudience(ctx, sub, svc.ServiceID); err != nil {
+		return nil, fmt.Errorf("track subject audience failed: %w", err)
+	}
 	accessToken, err := s.tokenSvc.Issue(ctx, uat)
 	if err != nil {
 		return nil, fmt.Errorf("issue token failed: %w", err)
 	}
 
+	tokenType := tokendef.TokenTypeBearer
+	if jkt != "" {
+		tokenType = dpop.TokenType
+	}
+
 	return &TokenResponse{
-		AccessToken: accessToken,
-		TokenType:   tokendef.TokenTypeBearer,
-		ExpiresIn:   int(accessExpiresIn.Seconds()),
-		Scope:       scope,
+		AccessToken:          accessToken,
+		TokenType:            tokenType,
+		ExpiresIn:            int(accessExpiresIn.Seconds()),
+		Scope:                scope,
+		AuthorizationDetails: details,
 	}, nil
 }
 
@@ -612,7 +741,9 @@ func (s *Service) generateAccessToken(
 func (s *Service) generateIDToken(ctx context.Context, flow *types.AuthFlow) (string, error) {
 	scopes := parseScopeSet(strings.Join(flow.GrantedScopes, " "))
 
-	idtBuilder := token.NewIDTokenBuilder()
+	idtBuilder := token.NewIDTokenBuilder().
+		AuthTime(flow.AuthTime).
+		SessionID(flow.SessionID)
 
 	if scopes[ScopeProfile] {
 		idtBuilder.Nickname(flow.User.GetNickname()).Picture(flow.User.GetPicture())
@@ -635,7 +766,7 @@ func (s *Service) generateIDToken(ctx context.Context, flow *types.AuthFlow) (st
 	return s.tokenSvc.Issue(ctx, idt)
 }
 
-func (s *Service) createRefreshToken(ctx context.Context, flow *types.AuthFlow, scope string) (*cache.RefreshToken, error) {
+func (s *Service) createRefreshToken(ctx context.Context, flow *types.AuthFlow, scope, jkt string, details tokendef.AuthorizationDetails) (*cache.RefreshToken, error) {
 	if flow.Application.RefreshTokenExpiresIn == 0 {
 		return nil, autherrors.NewInvalidRequestf("refresh_token_expires_in not configured for application %s", flow.Application.AppID)
 	}
@@ -655,13 +786,19 @@ func (s *Service) createRefreshToken(ctx context.Context, flow *types.AuthFlow,
 
 	now := time.Now()
 	rt := &cache.RefreshToken{
-		Token:     tokenValue,
-		OpenID:    flow.User.OpenID,
-		ClientID:  flow.Application.AppID,
-		Audience:  flow.Service.ServiceID,
-		Scope:     scope,
-		ExpiresAt: now.Add(refreshExpiresIn),
-		CreatedAt: now,
+		Token:                tokenValue,
+		OpenID:               flow.User.OpenID,
+		ClientID:             flow.Application.AppID,
+		Audience:             flow.Service.ServiceID,
+		Scope:                scope,
+		ExpiresAt:            now.Add(refreshExpiresIn),
+		JKT:                  jkt,
+		AuthTime:             flow.AuthTime,
+		AuthorizationDetails: details,
+		CreatedAt:            now,
+	}
+	if flow.Application.RefreshTokenRotation {
+		rt.FamilyID = tokenValue
 	}
 	if absoluteExpiresIn > 0 {
 		maxAt := now.Add(absoluteExpiresIn)
@@ -742,7 +879,7 @@ func (s *Service) exchangeMultiAudienceAuthorizationCode(ctx context.Context, re
 	}
 
 	// 7. 为每个 audience 签发独立的 token
-	resp, err := s.generateMultiAudienceTokens(ctx, &flow, audiences)
+	resp, err := s.generateMultiAudienceTokens(ctx, &flow, audiences, req.DPoPJKT)
 	if err != nil {
 		return nil, err
 	}
@@ -802,7 +939,7 @@ func resolveAudiences(reqAudiences map[string]*AudienceScope, flowAudiences map[
 
 // generateMultiAudienceTokens 为多个 audience 生成独立的 token
 func (s *Service) generateMultiAudienceTokens(
-	ctx context.Context, flow *types.AuthFlow, audiences map[string]*AudienceScope,
+	ctx context.Context, flow *types.AuthFlow, audiences map[string]*AudienceScope, jkt string,
 ) (MultiAudienceTokenResponse, error) {
 	resp := make(MultiAudienceTokenResponse, len(audiences))
 
@@ -826,9 +963,10 @@ func (s *Service) generateMultiAudienceTokens(
 		}
 
 		scope := audienceScope.GetScope()
+		details := flow.AuthorizationDetails[audience]
 
 		// 签发 access token
-		tokenResp, err := s.generateAccessToken(ctx, flow.Application, &svc.Service, flow.User, flow.User.OpenID, scope)
+		tokenResp, err := s.generateAccessToken(ctx, flow.Application, &svc.Service, flow.User, flow.User.OpenID, scope, jkt, flow.AuthTime, details)
 		if err != nil {
 			return nil, fmt.Errorf("generate token for audience %s: %w", audience, err)
 		}
@@ -836,7 +974,7 @@ func (s *Service) generateMultiAudienceTokens(
 		// 如果 scope 包含 offline_access，签发独立的 refresh token
 		scopes := strings.Fields(scope)
 		if helpers.ContainsScope(scopes, ScopeOfflineAccess) {
-			rtValue, err := s.createRefreshTokenForAudience(ctx, flow.User.OpenID, flow.Application, &svc.Service, scope)
+			rtValue, err := s.createRefreshTokenForAudience(ctx, flow.User.OpenID, flow.Application, &svc.Service, scope, jkt, flow.AuthTime, details)
 			if err != nil {
 				return nil, fmt.Errorf("create refresh token for audience %s: %w", audience, err)
 			}
@@ -851,7 +989,8 @@ func (s *Service) generateMultiAudienceTokens(
 
 // createRefreshTokenForAudience 为指定 audience 创建 refresh token（应用控制 refresh_token 有效期）
 func (s *Service) createRefreshTokenForAudience(
-	ctx context.Context, openID string, app *models.Application, svc *models.Service, scope string,
+	ctx context.Context, openID string, app *models.Application, svc *models.Service, scope, jkt string, authTime time.Time,
+	details tokendef.AuthorizationDetails,
 ) (string, error) {
 	if app.RefreshTokenExpiresIn == 0 {
 		return "", autherrors.NewInvalidRequestf("refresh_token_expires_in not configured for application %s", app.AppID)
@@ -870,13 +1009,19 @@ func (s *Service) createRefreshTokenForAudience(
 
 	now := time.Now()
 	rt := &cache.RefreshToken{
-		Token:     tokenValue,
-		OpenID:    openID,
-		ClientID:  app.AppID,
-		Audience:  svc.ServiceID,
-		Scope:     scope,
-		ExpiresAt: now.Add(refreshExpiresIn),
-		CreatedAt: now,
+		Token:                tokenValue,
+		OpenID:               openID,
+		ClientID:             app.AppID,
+		Audience:             svc.ServiceID,
+		Scope:                scope,
+		ExpiresAt:            now.Add(refreshExpiresIn),
+		JKT:                  jkt,
+		AuthTime:             authTime,
+		AuthorizationDetails: details,
+		CreatedAt:            now,
+	}
+	if app.RefreshTokenRotation {
+		rt.FamilyID = tokenValue
 	}
 	if absoluteExpiresIn > 0 {
 		maxAt := now.Add(absoluteExpiresIn)
@@ -0,0 +1,216 @@
+package authorize
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/token"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// exchangeDelegatedToken token-exchange 授权（RFC 8693）
+// subject_token 为用户 UAT，actor_token 为调用方应用的 CT
+// 调用方应用须与 subject_token 的 audience（持有 token 的服务）及目标服务都存在关系；
+// 为目标 audience 签发降权 UAT，act 记录调用方应用，scope 只能收窄不能扩大
+func (s *Service) exchangeDelegatedToken(ctx context.Context, req *TokenRequest) (*TokenResponse, error) {
+	if req.SubjectToken == "" || req.ActorToken == "" || req.Audience == "" {
+		return nil, autherrors.NewInvalidRequest("subject_token, actor_token and audience are required")
+	}
+	if req.SubjectTokenType != TokenTypeURIAccessToken {
+		return nil, autherrors.NewInvalidRequestf("unsupported subject_token_type: %s", req.SubjectTokenType)
+	}
+	if req.ActorTokenType != "" && req.ActorTokenType != TokenTypeURIAccessToken {
+		return nil, autherrors.NewInvalidRequestf("unsupported actor_token_type: %s", req.ActorTokenType)
+	}
+	if req.RequestedTokenType != "" && req.RequestedTokenType != TokenTypeURIAccessToken {
+		return nil, autherrors.NewInvalidRequestf("unsupported requested_token_type: %s", req.RequestedTokenType)
+	}
+
+	// 1. 验证调用方 CT
+	actorToken, err := s.tokenSvc.Verify(ctx, req.ActorToken)
+	if err != nil {
+		logger.Warnf("[Token] token-exchange actor_token 验证失败: %v", err)
+		return nil, autherrors.NewInvalidClient("invalid actor_token")
+	}
+	actor, ok := actorToken.(*tokendef.ClientToken)
+	if !ok {
+		return nil, autherrors.NewInvalidClient("actor_token must be a client token")
+	}
+	actorID := actor.ClientID()
+	if req.ClientID != "" && req.ClientID != actorID {
+		return nil, autherrors.NewInvalidGrant("client_id mismatch")
+	}
+
+	// 2. 验证用户 UAT
+	subjectToken, err := s.tokenSvc.Verify(ctx, req.SubjectToken)
+	if err != nil {
+		logger.Warnf("[Token] token-exchange subject_token 验证失败: %v", err)
+		return nil, autherrors.NewInvalidGrant("invalid subject_token")
+	}
+	subject, err := checkSubjectToken(ctx, subjectToken, s.cache.GetRevocationList)
+	if err != nil {
+		return nil, err
+	}
+
+	// 3. 调用方应用必须与 subject_token 的 audience 及目标服务存在关系
+	appWithKey, err := s.cache.GetApplication(ctx, actorID)
+	if err != nil {
+		return nil, autherrors.NewClientNotFoundf("application not found: %s", actorID)
+	}
+	if appWithKey.DPoPBoundAccessTokens && req.DPoPJKT == "" {
+		return nil, autherrors.NewInvalidDPoPProof("DPoP proof required for this client")
+	}
+	relations, err := s.cache.GetAppServiceRelations(ctx, actorID)
+	if err != nil {
+		return nil, autherrors.NewServerError("check relation failed")
+	}
+	rels, err := exchangeRelations(relations, actorID, subject.Audience(), req.Audience)
+	if err != nil {
+		return nil, err
+	}
+
+	// 4. scope = 请求 scope ∩ subject scope ∩ 应用-服务关系
+	scope, err := narrowExchangeScope(subject, req.Scope, rels, req.Audience)
+	if err != nil {
+		return nil, err
+	}
+
+	// 5. 有效期不超过服务配置，也不超过 subject_token 剩余有效期
+	svc, err := s.cache.GetService(ctx, req.Audience)
+	if err != nil {
+		return nil, autherrors.NewServiceNotFoundf("service not found: %s", req.Audience)
+	}
+	if svc.AccessTokenExpiresIn == 0 {
+		return nil, autherrors.NewInvalidRequestf("access_token_expires_in not configured for service %s", svc.ServiceID)
+	}
+	expiresIn := time.Duration(svc.AccessTokenExpiresIn) * time.Second
+	if remaining := time.Until(subject.ExpiresAt()); remaining < expiresIn {
+		expiresIn = remaining.Truncate(time.Second)
+	}
+	if expiresIn <= 0 {
+		return nil, autherrors.NewInvalidGrant("subject_token expired")
+	}
+
+	// 用户信息按收窄后的 scope 过滤（与 generateAccessToken 一致）
+	scopes := parseScopeSet(scope)
+	uatBuilder := token.NewUserAccessTokenBuilder().
+		Scope(scope).
+		OpenID(subject.OpenID()).
+		AuthTime(subject.AuthTime()).
+		Actor(appWithKey.AppID).
+		Confirmation(req.DPoPJKT)
+	if scopes[ScopeProfile] {
+		uatBuilder.Nickname(subject.Nickname()).Picture(subject.Picture())
+	}
+	if scopes[ScopeEmail] {
+		uatBuilder.Email(subject.Email())
+	}
+	if scopes[ScopePhone] {
+		uatBuilder.Phone(subject.Phone())
+	}
+	uat := token.NewClaimsBuilder().
+		Issuer(s.tokenSvc.GetIssuer()).
+		ClientID(subject.ClientID()).
+		Audience(svc.ServiceID).
+		ExpiresIn(expiresIn).
+		Build(uatBuilder)
+
+	if err := s.cache.TrackSubjectAudience(ctx, subject.OpenID(), svc.ServiceID); err != nil {
+		return nil, fmt.Errorf("track subject audience failed: %w", err)
+	}
+	accessToken, err := s.tokenSvc.Issue(ctx, uat)
+	if err != nil {
+		return nil, fmt.Errorf("issue token failed: %w", err)
+	}
+
+	logger.Infof("[Token] token-exchange 签发代理 UAT - actor: %s, from: %s, to: %s, scope: %s",
+		actorID, subject.Audience(), svc.ServiceID, scope)
+
+	tokenType := tokendef.TokenTypeBearer
+	if req.DPoPJKT != "" {
+		tokenType = dpop.TokenType
+	}
+
+	return &TokenResponse{
+		AccessToken:     accessToken,
+		TokenType:       tokenType,
+		ExpiresIn:       int(expiresIn.Seconds()),
+		Scope:           scope,
+		IssuedTokenType: TokenTypeURIAccessToken,
+	}, nil
+}
+
+// revocationLister 按 audience 获取吊销列表
+type revocationLister func(ctx context.Context, audience string) (*tokendef.RevocationList, error)
+
+// checkSubjectToken subject_token 须为已识别用户的 UAT、未绑定 DPoP、未被吊销；
+// 与资源服务不同，吊销列表不可用时拒绝兑换
+func checkSubjectToken(ctx context.Context, t tokendef.Token, revocations revocationLister) (*tokendef.UserAccessToken, error) {
+	subject, ok := t.(*tokendef.UserAccessToken)
+	if !ok || !subject.Identified() {
+		return nil, autherrors.NewInvalidGrant("subject_token must be a user access token")
+	}
+	// DPoP 绑定的 token 只能由持有私钥的客户端使用，不能脱离绑定兑换为 Bearer token
+	if subject.JKT() != "" {
+		return nil, autherrors.NewInvalidGrant("sender-constrained subject_token cannot be exchanged")
+	}
+	list, err := revocations(ctx, subject.Audience())
+	if err != nil {
+		logger.Warnf("[Token] token-exchange 获取吊销列表失败: %v", err)
+		return nil, autherrors.NewServerError("check revocation failed")
+	}
+	if list.IsRevoked(subject) {
+		return nil, autherrors.NewInvalidGrant("subject_token has been revoked")
+	}
+	return subject, nil
+}
+
+// exchangeRelations 校验调用方应用与 subject_token 的 audience（持有 token 的服务）存在关系，
+// 返回其与目标服务的关系；任一关系缺失时拒绝，防止应用兑换与其无关的服务收到的用户 token
+func exchangeRelations(relations []models.ApplicationServiceRelation, actorID, subjectAudience, targetAudience string) ([]string, error) {
+	var holder bool
+	var rels []string
+	for _, rel := range relations {
+		if rel.ServiceID == subjectAudience {
+			holder = true
+		}
+		if rel.ServiceID == targetAudience {
+			rels = append(rels, rel.Relation)
+		}
+	}
+	if !holder {
+		return nil, autherrors.NewInvalidGrant(fmt.Sprintf("application %s is not related to subject_token audience %s", actorID, subjectAudience))
+	}
+	if len(rels) == 0 {
+		return nil, autherrors.NewAccessDeniedf("application %s has no access to service %s", actorID, targetAudience)
+	}
+	return rels, nil
+}
+
+// narrowExchangeScope scope = 请求 scope ∩ subject scope ∩ 应用-服务关系，只能收窄不能扩大
+func narrowExchangeScope(subject *tokendef.UserAccessToken, requestedScope string, rels []string, audience string) (string, error) {
+	subjectScopes := make([]string, 0, len(subject.Scopes()))
+	for sc := range subject.Scopes() {
+		subjectScopes = append(subjectScopes, sc)
+	}
+	slices.Sort(subjectScopes)
+	requested := subjectScopes
+	if requestedScope != "" {
+		requested = helpers.ScopeIntersection(helpers.ParseScopes(requestedScope), subjectScopes)
+		if len(requested) == 0 {
+			return "", autherrors.NewInvalidScope("requested scope exceeds subject_token scope")
+		}
+	}
+	granted := grantServiceScopes(requested, rels)
+	if len(requested) > 0 && len(granted) == 0 {
+		return "", autherrors.NewInvalidScopef("no requested scope is granted for service %s", audience)
+	}
+	return helpers.JoinScopes(granted), nil
+}
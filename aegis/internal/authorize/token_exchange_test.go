@@ -0,0 +1,145 @@
+package authorize
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/models"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// userAccessToken 用户前端应用 web-app 为用户申请的 service-a UAT
+func userAccessToken(scope, jkt string) *tokendef.UserAccessToken {
+	return tokendef.NewClaimsBuilder().
+		Issuer("aegis").
+		ClientID("web-app").
+		Audience("service-a").
+		ExpiresIn(time.Hour).
+		Build(tokendef.NewUserAccessTokenBuilder().OpenID("openid-1").Scope(scope).Confirmation(jkt)).(*tokendef.UserAccessToken)
+}
+
+func revocations(list *tokendef.RevocationList) revocationLister {
+	return func(context.Context, string) (*tokendef.RevocationList, error) { return list, nil }
+}
+
+// service-a 的后端应用 app-a 可访问 service-a 与 service-b
+var exchangeRelationFixtures = []models.ApplicationServiceRelation{
+	{AppID: "app-a", ServiceID: "service-a", Relation: "*"},
+	{AppID: "app-a", ServiceID: "service-b", Relation: "openid"},
+	{AppID: "app-a", ServiceID: "service-b", Relation: "profile"},
+}
+
+func authErrorCode(err error) string {
+	var authErr *autherrors.AuthError
+	if errors.As(err, &authErr) {
+		return authErr.Code
+	}
+	return ""
+}
+
+func TestExchangeAllowsRelatedActor(t *testing.T) {
+	subject, err := checkSubjectToken(context.Background(), userAccessToken("openid profile email", ""), revocations(tokendef.NewRevocationList()))
+	if err != nil {
+		t.Fatalf("checkSubjectToken() error = %v", err)
+	}
+	rels, err := exchangeRelations(exchangeRelationFixtures, "app-a", subject.Audience(), "service-b")
+	if err != nil {
+		t.Fatalf("exchangeRelations() error = %v", err)
+	}
+	scope, err := narrowExchangeScope(subject, "", rels, "service-b")
+	if err != nil {
+		t.Fatalf("narrowExchangeScope() error = %v", err)
+	}
+	if scope != "openid profile" {
+		t.Fatalf("scope = %q, want %q", scope, "openid profile")
+	}
+}
+
+func TestExchangeRejectsUnrelatedActor(t *testing.T) {
+	tests := []struct {
+		name      string
+		relations []models.ApplicationServiceRelation
+		wantCode  string
+	}{
+		{
+			name:      "not related to subject audience",
+			relations: []models.ApplicationServiceRelation{{AppID: "app-x", ServiceID: "service-b", Relation: "*"}},
+			wantCode:  autherrors.CodeInvalidGrant,
+		},
+		{
+			name:      "not related to target audience",
+			relations: []models.ApplicationServiceRelation{{AppID: "app-x", ServiceID: "service-a", Relation: "*"}},
+			wantCode:  autherrors.CodeAccessDenied,
+		},
+		{name: "no relations", wantCode: autherrors.CodeInvalidGrant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := exchangeRelations(tt.relations, "app-x", "service-a", "service-b")
+			if code := authErrorCode(err); code != tt.wantCode {
+				t.Fatalf("exchangeRelations() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestExchangeRejectsSubjectToken(t *testing.T) {
+	revoked := tokendef.NewRevocationList()
+	revoked.Subjects["openid-1"] = time.Now().Add(time.Second)
+	unavailable := func(context.Context, string) (*tokendef.RevocationList, error) {
+		return nil, errors.New("redis down")
+	}
+
+	tests := []struct {
+		name     string
+		token    tokendef.Token
+		lister   revocationLister
+		wantCode string
+	}{
+		{name: "revoked", token: userAccessToken("openid", ""), lister: revocations(revoked), wantCode: autherrors.CodeInvalidGrant},
+		{name: "revocation list unavailable", token: userAccessToken("openid", ""), lister: unavailable, wantCode: autherrors.CodeServerError},
+		{name: "dpop bound", token: userAccessToken("openid", "jkt"), lister: revocations(tokendef.NewRevocationList()), wantCode: autherrors.CodeInvalidGrant},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			_, err := checkSubjectToken(context.Background(), tt.token, tt.lister)
+			if code := authErrorCode(err); code != tt.wantCode {
+				t.Fatalf("checkSubjectToken() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestNarrowExchangeScope(t *testing.T) {
+	subject := userAccessToken("openid profile", "")
+	tests := []struct {
+		name      string
+		requested string
+		rels      []string
+		want      string
+		wantCode  string
+	}{
+		{name: "subset", requested: "profile", rels: []string{"*"}, want: "profile"},
+		{name: "cannot widen", requested: "profile email", rels: []string{"*"}, want: "profile"},
+		{name: "only wider scope", requested: "email", rels: []string{"*"}, wantCode: autherrors.CodeInvalidScope},
+		{name: "limited by relation", requested: "", rels: []string{"openid"}, want: "openid"},
+		{name: "relation grants none", requested: "profile", rels: []string{"openid"}, wantCode: autherrors.CodeInvalidScope},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := narrowExchangeScope(subject, tt.requested, tt.rels, "service-b")
+			if tt.wantCode != "" {
+				if code := authErrorCode(err); code != tt.wantCode {
+					t.Fatalf("narrowExchangeScope() error = %v, want code %s", err, tt.wantCode)
+				}
+				return
+			}
+			if err != nil || got != tt.want {
+				t.Fatalf("narrowExchangeScope() = %q, %v, want %q", got, err, tt.want)
+			}
+		})
+	}
+}
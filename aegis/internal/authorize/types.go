@@ -1,25 +1,69 @@
 package authorize
 
+import tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+
 // ==================== 单 audience（标准 OAuth2 form 请求）====================
 
 // TokenRequest 标准 OAuth2 Token 请求（application/x-www-form-urlencoded）
 type TokenRequest struct {
-	GrantType    string `form:"grant_type" binding:"required,oneof=authorization_code refresh_token"`
+	GrantType    string `form:"grant_type" binding:"required,oneof=authorization_code refresh_token urn:ietf:params:oauth:grant-type:token-exchange urn:ietf:params:oauth:grant-type:device_code"`
 	Code         string `form:"code"`          // authorization_code 时必填
 	RedirectURI  string `form:"redirect_uri"`  // authorization_code 时必填
 	ClientID     string `form:"client_id"`     // 必填
 	CodeVerifier string `form:"code_verifier"` // PKCE 验证器
 	RefreshToken string `form:"refresh_token"` // refresh_token grant 时必填
+	DeviceCode   string `form:"device_code"`   // device_code grant 时必填
+
+	// token-exchange（RFC 8693）
+	SubjectToken       string `form:"subject_token"`        // 被代理用户的 UAT
+	SubjectTokenType   string `form:"subject_token_type"`   // 仅支持 access_token
+	ActorToken         string `form:"actor_token"`          // 调用方 CT，缺省时取 Authorization 头
+	ActorTokenType     string `form:"actor_token_type"`     // 可选，仅支持 access_token
+	RequestedTokenType string `form:"requested_token_type"` // 可选，仅支持 access_token
+	Audience           string `form:"audience"`             // 目标服务 service_id
+	Scope              string `form:"scope"`                // 可选，不得超出 subject_token 的 scope
+
+	// RAR（RFC 9396 §6.1）：可选，按已授予的 authorization_details 收窄本次 access token 的权限（由 handler 解析 JSON）
+	AuthorizationDetails tokendef.AuthorizationDetails `form:"-"`
+
+	DPoPJKT string `form:"-"` // 已校验的 DPoP proof 公钥指纹（由 handler 从 DPoP 头解析）
 }
 
 // TokenResponse 标准 OAuth2 Token 响应（单 audience）
 type TokenResponse struct {
-	AccessToken  string `json:"access_token"`
-	IDToken      string `json:"id_token,omitempty"`      // scope 包含 openid 时返回
-	RefreshToken string `json:"refresh_token,omitempty"` // 只有 offline_access 时返回
-	TokenType    string `json:"token_type"`
-	ExpiresIn    int    `json:"expires_in"`
-	Scope        string `json:"scope"` // 实际授予的 scope
+	AccessToken     string `json:"access_token"`
+	IDToken         string `json:"id_token,omitempty"`      // scope 包含 openid 时返回
+	RefreshToken    string `json:"refresh_token,omitempty"` // 只有 offline_access 时返回
+	TokenType       string `json:"token_type"`
+	ExpiresIn       int    `json:"expires_in"`
+	Scope           string `json:"scope"`                       // 实际授予的 scope
+	IssuedTokenType string `json:"issued_token_type,omitempty"` // 仅 token-exchange 返回
+
+	AuthorizationDetails tokendef.AuthorizationDetails `json:"authorization_details,omitempty"` // 本次 access token 携带的授权详情（RFC 9396 §7）
+}
+
+// ==================== 设备授权（RFC 8628）====================
+
+// DeviceAuthorizationRequest 设备授权请求（POST /auth/device/code）
+type DeviceAuthorizationRequest struct {
+	ClientID string `form:"client_id" binding:"required"`
+	Audience string `form:"audience" binding:"required"`
+	Scope    string `form:"scope"`
+}
+
+// DeviceAuthorizationResponse 设备授权响应
+type DeviceAuthorizationResponse struct {
+	DeviceCode              string `json:"device_code"`
+	UserCode                string `json:"user_code"`
+	VerificationURI         string `json:"verification_uri"`
+	VerificationURIComplete string `json:"verification_uri_complete"`
+	ExpiresIn               int    `json:"expires_in"`
+	Interval                int    `json:"interval"`
+}
+
+// DeviceVerifyRequest 验证页提交 user_code（POST /auth/device/verify）
+type DeviceVerifyRequest struct {
+	UserCode string `json:"user_code" form:"user_code" binding:"required"`
 }
 
 // ==================== 多 audience（JSON 请求）====================
@@ -29,15 +73,18 @@ type AudienceScope struct {
 	Scope string `json:"scope"`
 }
 
-// MultiAudienceTokenRequest 多 audience Token 请求（application/json，仅 authorization_code）
+// MultiAudienceTokenRequest 多 audience Token 请求（application/json，authorization_code / client_credentials）
 // refresh_token 场景下每个 audience 独立刷新，走单 audience 的 TokenRequest
 type MultiAudienceTokenRequest struct {
-	GrantType    string                    `json:"grant_type" binding:"required,oneof=authorization_code"`
-	Code         string                    `json:"code" binding:"required"`
+	GrantType    string                    `json:"grant_type" binding:"required,oneof=authorization_code client_credentials"`
+	Code         string                    `json:"code,omitempty"` // authorization_code 时必填
 	RedirectURI  string                    `json:"redirect_uri,omitempty"`
-	ClientID     string                    `json:"client_id" binding:"required"`
+	ClientID     string                    `json:"client_id,omitempty"` // authorization_code 时必填；client_credentials 取自 CT
 	CodeVerifier string                    `json:"code_verifier,omitempty"`
+	Audience     string                    `json:"audience,omitempty"`  // client_credentials 单 audience
+	Scope        string                    `json:"scope,omitempty"`     // client_credentials 单 audience 的 scope
 	Audiences    map[string]*AudienceScope `json:"audiences,omitempty"` // 可选，优先使用授权阶段存储在 flow 中的 audiences
+	DPoPJKT      string                    `json:"-"`                   // 已校验的 DPoP proof 公钥指纹（由 handler 从 DPoP 头解析）
 }
 
 // GetScope 获取 audience 的 scope
@@ -58,8 +105,14 @@ type MultiAudienceTokenResponse map[string]*TokenResponse
 const (
 	GrantTypeAuthorizationCode = "authorization_code"
 	GrantTypeRefreshToken      = "refresh_token"
+	GrantTypeClientCredentials = "client_credentials"
+	GrantTypeTokenExchange     = "urn:ietf:params:oauth:grant-type:token-exchange"
+	GrantTypeDeviceCode        = "urn:ietf:params:oauth:grant-type:device_code"
 )
 
+// TokenTypeURIAccessToken RFC 8693 token type 标识
+const TokenTypeURIAccessToken = "urn:ietf:params:oauth:token-type:access_token"
+
 // Scope 常量
 const (
 	ScopeOpenID        = "openid"
@@ -68,3 +121,35 @@ const (
 	ScopePhone         = "phone"
 	ScopeOfflineAccess = "offline_access"
 )
+
+// ==================== Introspection（RFC 7662）====================
+
+// IntrospectionRequest Token 自省请求（POST /auth/introspect）
+type IntrospectionRequest struct {
+	Token         string `form:"token" binding:"required"`
+	TokenTypeHint string `form:"token_type_hint"` // access_token / refresh_token
+}
+
+// IntrospectionResponse Token 自省响应
+// 无效、过期、已撤销或不属于调用方的 token 仅返回 active=false
+type IntrospectionResponse struct {
+	Active    bool   `json:"active"`
+	Scope     string `json:"scope,omitempty"`
+	ClientID  string `json:"client_id,omitempty"`
+	Sub       string `json:"sub,omitempty"` // 仅 UAT / refresh_token 返回 OpenID
+	Aud       string `json:"aud,omitempty"`
+	Iss       string `json:"iss,omitempty"`
+	Exp       int64  `json:"exp,omitempty"`
+	Iat       int64  `json:"iat,omitempty"`
+	TokenType string `json:"token_type,omitempty"`
+	Act       string `json:"act,omitempty"`       // 代理签发的 UAT 返回调用方应用
+	AuthTime  int64  `json:"auth_time,omitempty"` // UAT 对应的用户认证时间
+	Cnf       *Cnf   `json:"cnf,omitempty"`       // DPoP 绑定的 UAT 返回公钥指纹（RFC 9449 §6.2）
+
+	AuthorizationDetails tokendef.AuthorizationDetails `json:"authorization_details,omitempty"` // 授予的授权详情（RFC 9396 §9.2）
+}
+
+// Cnf 确认声明（RFC 7800）
+type Cnf struct {
+	JKT string `json:"jkt"`
+}
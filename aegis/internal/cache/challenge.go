@@ -2,11 +2,13 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
 	"github.com/heliannuuthus/aegis/config"
 	"github.com/heliannuuthus/aegis/internal/types"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
 )
 
 // ==================== Challenge（Redis 临时会话）====================
@@ -74,3 +76,40 @@ func (cm *Manager) DeleteOTP(ctx context.Context, key string) error {
 	prefix := config.GetCacheKeyPrefix("otp")
 	return cm.redis.Del(ctx, prefix+key)
 }
+
+// ClaimChallengeToken 标记 ChallengeToken 已使用，仅首次成功，保证一次性凭证不被重放
+// ttl 为 token 剩余有效期，token 过期后标记随之失效
+func (cm *Manager) ClaimChallengeToken(ctx context.Context, jti string, ttl time.Duration) (bool, error) {
+	if jti == "" {
+		return false, fmt.Errorf("challenge token jti is required")
+	}
+	if ttl <= 0 {
+		return false, nil
+	}
+	key := config.GetCacheKeyPrefix("challenge_token_used") + jti
+	if _, err := cm.redis.Eval(ctx, claimRefreshTokenScript, []string{key}, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim challenge token: %w", err)
+	}
+	return true, nil
+}
+
+// ClaimRequestObject 标记请求对象（JAR）已使用，仅首次成功；ttl 为请求对象剩余有效期
+func (cm *Manager) ClaimRequestObject(ctx context.Context, clientID, jti string, ttl time.Duration) (bool, error) {
+	if clientID == "" || jti == "" {
+		return false, fmt.Errorf("request object client_id and jti are required")
+	}
+	if ttl <= 0 {
+		return false, nil
+	}
+	key := config.GetCacheKeyPrefix("request_object_used") + clientID + ":" + jti
+	if _, err := cm.redis.Eval(ctx, claimRefreshTokenScript, []string{key}, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim request object: %w", err)
+	}
+	return true, nil
+}
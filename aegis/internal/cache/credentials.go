@@ -2,6 +2,7 @@ package cache
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"time"
 
@@ -10,12 +11,16 @@ import (
 
 	"github.com/heliannuuthus/aegis/config"
 	"github.com/heliannuuthus/pkg/helpers"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
 )
 
 type TOTPEnrollmentSession struct {
-	OpenID string `json:"openid"`
-	Secret string `json:"secret"`
-	Label  string `json:"label"`
+	OpenID    string `json:"openid"`
+	Secret    string `json:"secret"`
+	Algorithm string `json:"algorithm"`
+	Digits    int    `json:"digits"`
+	Period    uint   `json:"period"`
+	Label     string `json:"label"`
 }
 
 func (cm *Manager) SaveTOTPEnrollmentSession(ctx context.Context, session *TOTPEnrollmentSession) (string, error) {
@@ -124,3 +129,24 @@ func (cm *Manager) DeleteWebAuthnCeremony(ctx context.Context, ceremonyID string
 func (cm *Manager) webAuthnCeremonyKey(ceremonyID string) string {
 	return config.GetCacheKeyPrefix("webauthn-ceremony") + ceremonyID
 }
+
+// acceptTOTPStepScript 仅当时间步大于已记录的时间步时写入，保证同一验证码（及更早的验证码）只能通过一次
+const acceptTOTPStepScript = `
+local last = redis.call("GET", KEYS[1])
+if last and tonumber(last) >= tonumber(ARGV[1]) then
+	return false
+end
+return redis.call("SET", KEYS[1], ARGV[1], "PX", ARGV[2])
+`
+
+// AcceptTOTPStep 记录凭证最近一次通过验证的时间步；时间步不晚于已记录值（重放）时返回 false
+func (cm *Manager) AcceptTOTPStep(ctx context.Context, credentialID uint, step uint64, ttl time.Duration) (bool, error) {
+	key := fmt.Sprintf("%s%d", config.GetCacheKeyPrefix("totp_step"), credentialID)
+	if _, err := cm.redis.Eval(ctx, acceptTOTPStepScript, []string{key}, step, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("accept totp step: %w", err)
+	}
+	return true, nil
+}
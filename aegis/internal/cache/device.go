@@ -0,0 +1,181 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/aegis/config"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+)
+
+// ==================== DeviceAuthorization（Redis）====================
+
+// DeviceAuthorizationStatus 设备授权状态
+type DeviceAuthorizationStatus string
+
+const (
+	DeviceAuthorizationPending  DeviceAuthorizationStatus = "pending"  // 等待用户在验证页完成认证
+	DeviceAuthorizationApproved DeviceAuthorizationStatus = "approved" // 用户已完成授权，授权码已绑定
+	DeviceAuthorizationDenied   DeviceAuthorizationStatus = "denied"   // 用户在同意页拒绝授权
+)
+
+// DeviceAuthorization 设备授权（RFC 8628）
+// device_code 由设备持有用于轮询，user_code 由用户在验证页输入
+type DeviceAuthorization struct {
+	DeviceCode string                    `json:"device_code"`
+	UserCode   string                    `json:"user_code"`
+	ClientID   string                    `json:"client_id"`
+	Audience   string                    `json:"audience"`
+	Scope      string                    `json:"scope,omitempty"`
+	Status     DeviceAuthorizationStatus `json:"status"`
+	AuthCode   string                    `json:"auth_code,omitempty"` // 用户授权后绑定的授权码
+	Interval   int                       `json:"interval"`            // 最小轮询间隔（秒）
+	CreatedAt  time.Time                 `json:"created_at"`
+	ExpiresAt  time.Time                 `json:"expires_at"`
+}
+
+// IsApproved 用户是否已完成授权
+func (d *DeviceAuthorization) IsApproved() bool {
+	return d.Status == DeviceAuthorizationApproved && d.AuthCode != ""
+}
+
+// IsDenied 用户是否已拒绝授权
+func (d *DeviceAuthorization) IsDenied() bool {
+	return d.Status == DeviceAuthorizationDenied
+}
+
+// setUserCodeScript user_code 仅在不存在时写入，避免与未过期的设备授权冲突
+const setUserCodeScript = `return redis.call("SET", KEYS[1], ARGV[1], "NX", "PX", ARGV[2])`
+
+// SaveDeviceAuthorization 保存设备授权
+// user_code 已被占用时返回 ErrUserCodeConflict，调用方应重新生成
+func (cm *Manager) SaveDeviceAuthorization(ctx context.Context, d *DeviceAuthorization) error {
+	ttl := time.Until(d.ExpiresAt)
+	if ttl <= 0 {
+		return fmt.Errorf("device authorization already expired, ExpiresAt: %v", d.ExpiresAt)
+	}
+
+	if _, err := cm.redis.Eval(ctx, setUserCodeScript, []string{userCodeKey(d.UserCode)}, d.DeviceCode, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return ErrUserCodeConflict
+		}
+		return fmt.Errorf("save user code: %w", err)
+	}
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	return cm.redis.Set(ctx, deviceCodeKey(d.DeviceCode), string(data), ttl)
+}
+
+// GetDeviceAuthorization 通过 device_code 获取设备授权
+func (cm *Manager) GetDeviceAuthorization(ctx context.Context, deviceCode string) (*DeviceAuthorization, error) {
+	data, err := cm.redis.Get(ctx, deviceCodeKey(deviceCode))
+	if err != nil {
+		return nil, ErrDeviceCodeNotFound
+	}
+
+	var d DeviceAuthorization
+	if err := json.Unmarshal([]byte(data), &d); err != nil {
+		return nil, err
+	}
+	if time.Now().After(d.ExpiresAt) {
+		return nil, ErrDeviceCodeNotFound
+	}
+	return &d, nil
+}
+
+// GetDeviceAuthorizationByUserCode 通过 user_code 获取设备授权
+func (cm *Manager) GetDeviceAuthorizationByUserCode(ctx context.Context, userCode string) (*DeviceAuthorization, error) {
+	deviceCode, err := cm.redis.Get(ctx, userCodeKey(userCode))
+	if err != nil {
+		return nil, ErrDeviceCodeNotFound
+	}
+	return cm.GetDeviceAuthorization(ctx, deviceCode)
+}
+
+// ApproveDeviceAuthorization 将授权码绑定到设备授权（用户在验证页完成认证后调用）
+// user_code 随即失效，不可重复使用
+func (cm *Manager) ApproveDeviceAuthorization(ctx context.Context, userCode, authCode string) error {
+	return cm.completeDeviceAuthorization(ctx, userCode, DeviceAuthorizationApproved, authCode)
+}
+
+// DenyDeviceAuthorization 标记设备授权被用户拒绝，设备轮询将收到 access_denied
+// user_code 随即失效，不可重复使用
+func (cm *Manager) DenyDeviceAuthorization(ctx context.Context, userCode string) error {
+	return cm.completeDeviceAuthorization(ctx, userCode, DeviceAuthorizationDenied, "")
+}
+
+func (cm *Manager) completeDeviceAuthorization(ctx context.Context, userCode string, status DeviceAuthorizationStatus, authCode string) error {
+	d, err := cm.GetDeviceAuthorizationByUserCode(ctx, userCode)
+	if err != nil {
+		return err
+	}
+	if d.Status != DeviceAuthorizationPending {
+		return ErrDeviceCodeNotFound
+	}
+
+	d.Status = status
+	d.AuthCode = authCode
+
+	data, err := json.Marshal(d)
+	if err != nil {
+		return err
+	}
+	if err := cm.redis.Set(ctx, deviceCodeKey(d.DeviceCode), string(data), time.Until(d.ExpiresAt)); err != nil {
+		return fmt.Errorf("update device authorization: %w", err)
+	}
+	return cm.redis.Del(ctx, userCodeKey(userCode))
+}
+
+// slowDownDeviceScript 原子地增大设备授权的最小轮询间隔，保留原 TTL，不覆盖并发写入的授权状态
+// KEYS[1] = device_code key；ARGV[1] = 增加的秒数；返回增大后的间隔（秒）
+const slowDownDeviceScript = `
+local data = redis.call("GET", KEYS[1])
+if not data then
+    return nil
+end
+local d = cjson.decode(data)
+d.interval = d.interval + tonumber(ARGV[1])
+redis.call("SET", KEYS[1], cjson.encode(d), "KEEPTTL")
+return d.interval
+`
+
+// SlowDownDeviceAuthorization 设备轮询过快时增大并保存最小轮询间隔（RFC 8628 §3.5），返回新的间隔（秒）
+func (cm *Manager) SlowDownDeviceAuthorization(ctx context.Context, deviceCode string, step int) (int, error) {
+	result, err := cm.redis.Eval(ctx, slowDownDeviceScript, []string{deviceCodeKey(deviceCode)}, step)
+	if err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return 0, ErrDeviceCodeNotFound
+		}
+		return 0, fmt.Errorf("slow down device authorization: %w", err)
+	}
+	interval, ok := result.(int64)
+	if !ok {
+		return 0, fmt.Errorf("unexpected slow down result: %v", result)
+	}
+	return int(interval), nil
+}
+
+// DelDeviceAuthorization 删除设备授权（token 签发后调用）
+func (cm *Manager) DelDeviceAuthorization(ctx context.Context, d *DeviceAuthorization) error {
+	return cm.redis.Del(ctx, deviceCodeKey(d.DeviceCode), userCodeKey(d.UserCode), DevicePollKey(d.DeviceCode))
+}
+
+// DevicePollKey 设备轮询节流 key
+func DevicePollKey(deviceCode string) string {
+	return config.GetCacheKeyPrefix("device_poll") + deviceCode
+}
+
+func deviceCodeKey(deviceCode string) string {
+	return config.GetCacheKeyPrefix("device_code") + deviceCode
+}
+
+func userCodeKey(userCode string) string {
+	return config.GetCacheKeyPrefix("user_code") + userCode
+}
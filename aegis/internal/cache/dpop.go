@@ -0,0 +1,36 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+)
+
+// ==================== DPoP Proof 防重放（Redis）====================
+
+// dpopReplayCache 基于 Redis 的 DPoP proof jti 防重放存储，多实例共享
+type dpopReplayCache struct {
+	cm *Manager
+}
+
+// DPoPReplayCache 返回 DPoP proof jti 防重放存储
+func (cm *Manager) DPoPReplayCache() dpop.ReplayCache {
+	return &dpopReplayCache{cm: cm}
+}
+
+// Claim 实现 dpop.ReplayCache，jti 已存在时返回 false
+func (r *dpopReplayCache) Claim(ctx context.Context, jti string, ttl time.Duration) (bool, error) {
+	key := config.GetCacheKeyPrefix("dpop_jti") + jti
+	if _, err := r.cm.redis.Eval(ctx, claimRefreshTokenScript, []string{key}, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim dpop jti: %w", err)
+	}
+	return true, nil
+}
@@ -21,8 +21,11 @@ var (
 	ErrRefreshTokenNotFound = errors.New("refresh token not found")
 	ErrRefreshTokenExpired  = errors.New("refresh token expired")
 	ErrRefreshTokenRevoked  = errors.New("refresh token revoked")
+	ErrRefreshTokenReused   = errors.New("refresh token reused")
 	ErrUserNotFound         = errors.New("user not found")
 	ErrOTPNotFound          = errors.New("otp not found")
+	ErrDeviceCodeNotFound   = errors.New("device code not found")
+	ErrUserCodeConflict     = errors.New("user code conflict")
 )
 
 // Manager 缓存管理器
@@ -47,6 +50,9 @@ type Manager struct {
 	// Challenge 配置缓存：service_id:type -> *ServiceChallengeSetting
 	challengeConfigCache *ristretto.Cache[string, *models.ServiceChallengeSetting]
 
+	// 授权详情类型缓存：service_id -> []ServiceAuthorizationDetailType
+	authzDetailTypeCache *ristretto.Cache[string, []models.ServiceAuthorizationDetailType]
+
 	// SSO 密钥缓存（派生后的密钥，走 ristretto TTL 自动过期）
 	ssoKeyCache *ristretto.Cache[string, *Keys]
 
@@ -83,6 +89,7 @@ func NewManager(client *hermes.Client, redis pkgredis.Client) *Manager {
 		domainIDPConfigCache: newConfiguredCache[[]*models.DomainIDPConfig]("domain-idp-config"),
 		appIDPConfigCache:    newConfiguredCache[[]*models.ApplicationIDPConfig]("app-idp-config"),
 		challengeConfigCache: newConfiguredCache[*models.ServiceChallengeSetting]("challenge-config"),
+		authzDetailTypeCache: newConfiguredCache[[]models.ServiceAuthorizationDetailType]("authorization-detail-type"),
 		ssoKeyCache:          newCache[*Keys]("sso", 10, 1, 64),
 	}
 }
@@ -101,5 +108,6 @@ func (cm *Manager) Close() {
 	cm.domainIDPConfigCache.Close()
 	cm.appIDPConfigCache.Close()
 	cm.challengeConfigCache.Close()
+	cm.authzDetailTypeCache.Close()
 	cm.ssoKeyCache.Close()
 }
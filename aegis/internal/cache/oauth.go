@@ -16,7 +16,8 @@ import (
 // ErrOAuthTransactionNotFound indicates an invalid, expired, or already-consumed state.
 var ErrOAuthTransactionNotFound = errors.New("oauth transaction not found")
 
-const consumeOAuthTransactionScript = `
+// consumeScript 原子读取并删除一次性 key（OAuth state、PAR request_uri）
+const consumeScript = `
 local value = redis.call("GET", KEYS[1])
 if not value then
   return nil
@@ -42,7 +43,7 @@ func (cm *Manager) ConsumeOAuthTransaction(ctx context.Context, state string) (*
 	if state == "" {
 		return nil, ErrOAuthTransactionNotFound
 	}
-	value, err := cm.redis.Eval(ctx, consumeOAuthTransactionScript, []string{oauthTransactionKey(state)})
+	value, err := cm.redis.Eval(ctx, consumeScript, []string{oauthTransactionKey(state)})
 	if err != nil {
 		if errors.Is(err, pkgredis.ErrNil) {
 			return nil, ErrOAuthTransactionNotFound
@@ -70,3 +71,21 @@ func (cm *Manager) ConsumeOAuthTransaction(ctx context.Context, state string) (*
 func oauthTransactionKey(state string) string {
 	return config.GetCacheKeyPrefix("oauth_state") + state
 }
+
+// ClaimSAMLAssertion 标记 SAML 断言已使用，仅首次成功；ttl 为断言剩余有效期，过期后断言本身已无法通过校验
+func (cm *Manager) ClaimSAMLAssertion(ctx context.Context, connection, assertionID string, ttl time.Duration) (bool, error) {
+	if connection == "" || assertionID == "" {
+		return false, fmt.Errorf("saml connection and assertion id are required")
+	}
+	if ttl <= 0 {
+		return false, nil
+	}
+	key := config.GetCacheKeyPrefix("saml_assertion_used") + connection + ":" + assertionID
+	if _, err := cm.redis.Eval(ctx, claimRefreshTokenScript, []string{key}, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return false, nil
+		}
+		return false, fmt.Errorf("claim saml assertion: %w", err)
+	}
+	return true, nil
+}
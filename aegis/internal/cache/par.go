@@ -0,0 +1,65 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/internal/types"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+)
+
+// ==================== Pushed Authorization Request（Redis）====================
+
+// ErrPushedAuthRequestNotFound request_uri 无效、已过期或已使用
+var ErrPushedAuthRequestNotFound = errors.New("pushed authorization request not found")
+
+// SavePushedAuthRequest 保存已校验的授权请求（RFC 9126），以 request_uri 标识索引
+func (cm *Manager) SavePushedAuthRequest(ctx context.Context, id string, req *types.AuthRequest, ttl time.Duration) error {
+	if id == "" || req == nil || ttl <= 0 {
+		return errors.New("pushed authorization request or ttl is invalid")
+	}
+	data, err := json.Marshal(req)
+	if err != nil {
+		return fmt.Errorf("marshal pushed authorization request: %w", err)
+	}
+	return cm.redis.Set(ctx, pushedAuthRequestKey(id), string(data), ttl)
+}
+
+// ConsumePushedAuthRequest 原子读取并删除授权请求，request_uri 仅可使用一次
+func (cm *Manager) ConsumePushedAuthRequest(ctx context.Context, id string) (*types.AuthRequest, error) {
+	if id == "" {
+		return nil, ErrPushedAuthRequestNotFound
+	}
+	value, err := cm.redis.Eval(ctx, consumeScript, []string{pushedAuthRequestKey(id)})
+	if err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return nil, ErrPushedAuthRequestNotFound
+		}
+		return nil, fmt.Errorf("consume pushed authorization request: %w", err)
+	}
+
+	var encoded []byte
+	switch v := value.(type) {
+	case string:
+		encoded = []byte(v)
+	case []byte:
+		encoded = v
+	default:
+		return nil, fmt.Errorf("consume pushed authorization request: unexpected redis value %T", value)
+	}
+
+	var req types.AuthRequest
+	if err := json.Unmarshal(encoded, &req); err != nil {
+		return nil, fmt.Errorf("unmarshal pushed authorization request: %w", err)
+	}
+	return &req, nil
+}
+
+func pushedAuthRequestKey(id string) string {
+	return config.GetCacheKeyPrefix("par") + id
+}
@@ -85,6 +85,16 @@ func (cm *Manager) GetApplication(ctx context.Context, appID string) (*Applicati
 	return result, nil
 }
 
+// InvalidateApplication 清除应用及其 IDP 配置的本地缓存（应用被修改或删除后调用）
+func (cm *Manager) InvalidateApplication(appID string) {
+	if cm.applicationCache != nil {
+		cm.applicationCache.Del(config.GetCacheKeyPrefix("application") + appID)
+	}
+	if cm.appIDPConfigCache != nil {
+		cm.appIDPConfigCache.Del(config.GetCacheKeyPrefix("app-idp-config") + appID)
+	}
+}
+
 // GetService 获取服务（带缓存，密钥已派生）
 func (cm *Manager) GetService(ctx context.Context, serviceID string) (*ServiceWithKey, error) {
 	cacheKey := config.GetCacheKeyPrefix("service") + serviceID
@@ -232,6 +242,38 @@ func (cm *Manager) ListApplicationIDPConfigs(ctx context.Context, appID string)
 	return configs, nil
 }
 
+// GetIDPConnectionConfig 获取应用所属域与应用自身对 connection 的连接参数（JSON，未配置时为 nil）
+// 合并规则由调用方决定（通常应用级覆盖域级）
+func (cm *Manager) GetIDPConnectionConfig(ctx context.Context, appID, connection string) (domainConfig, appConfig *string, err error) {
+	app, err := cm.GetApplication(ctx, appID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("get application: %w", err)
+	}
+
+	domainIDPs, err := cm.ListDomainIDPConfigs(ctx, app.DomainID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list domain idp configs: %w", err)
+	}
+	for _, c := range domainIDPs {
+		if c.IDPType == connection {
+			domainConfig = c.Config
+			break
+		}
+	}
+
+	appIDPs, err := cm.ListApplicationIDPConfigs(ctx, appID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("list application idp configs: %w", err)
+	}
+	for _, c := range appIDPs {
+		if c.Type == connection {
+			appConfig = c.Config
+			break
+		}
+	}
+	return domainConfig, appConfig, nil
+}
+
 // GetServiceChallengeSetting 获取服务的 Challenge 配置（带本地缓存）
 func (cm *Manager) GetServiceChallengeSetting(ctx context.Context, serviceID, challengeType string) (*models.ServiceChallengeSetting, error) {
 	cacheKey := serviceChallengeCacheKey(serviceID, challengeType)
@@ -263,6 +305,29 @@ func serviceChallengeCacheKey(serviceID, challengeType string) string {
 	return config.GetCacheKeyPrefix("service-challenge-setting") + serviceID + ":" + challengeType
 }
 
+// GetServiceAuthorizationDetailTypes 获取服务声明的授权详情类型（带本地缓存）
+func (cm *Manager) GetServiceAuthorizationDetailTypes(ctx context.Context, serviceID string) ([]models.ServiceAuthorizationDetailType, error) {
+	cacheKey := config.GetCacheKeyPrefix("authorization-detail-type") + serviceID
+
+	if cm.authzDetailTypeCache != nil {
+		if cached, ok := cm.authzDetailTypeCache.Get(cacheKey); ok {
+			return cached, nil
+		}
+	}
+
+	result, err := cm.client.ListServiceAuthorizationDetailTypes(ctx, serviceID)
+	if err != nil {
+		return nil, err
+	}
+
+	if cm.authzDetailTypeCache != nil {
+		ttl := config.GetCacheTTL("authorization-detail-type")
+		cm.authzDetailTypeCache.SetWithTTL(cacheKey, result, 1, ttl)
+	}
+
+	return result, nil
+}
+
 func (cm *Manager) GetIDPKey(ctx context.Context, appID, idpType string) (string, string, error) {
 	cacheKey := config.GetCacheKeyPrefix("idp-key") + appID + ":" + idpType
 
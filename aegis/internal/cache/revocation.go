@@ -0,0 +1,138 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// ==================== Access Token 吊销列表（Redis）====================
+
+// RevokeAccessToken 按 jti 吊销单个 access token，条目保留到 token 过期
+func (cm *Manager) RevokeAccessToken(ctx context.Context, audience, jti string, expiresAt time.Time) error {
+	ttl := time.Until(expiresAt)
+	if ttl <= 0 {
+		return nil
+	}
+
+	key := revokedJTIKey(audience)
+	if err := cm.redis.HSet(ctx, key, jti, expiresAt.Unix()); err != nil {
+		return fmt.Errorf("revoke access token: %w", err)
+	}
+	return cm.extendRevocationKey(ctx, key, ttl)
+}
+
+// TrackSubjectAudience 记录用户持有 audience 的 access token，签发 UAT 前调用
+// 按用户吊销时只写入这些 audience 的吊销列表，避免把其他租户的 openid 下发给无关服务
+func (cm *Manager) TrackSubjectAudience(ctx context.Context, openid, audience string) error {
+	key := subjectAudiencesKey(openid)
+	if err := cm.redis.SAdd(ctx, key, audience); err != nil {
+		return fmt.Errorf("track subject audience: %w", err)
+	}
+	return cm.extendRevocationKey(ctx, key, config.GetRevocationSubjectTTL())
+}
+
+// RevokeSubject 吊销用户在 before 之前签发的全部 access token（写入其持有 token 的各 audience 的吊销列表）
+// 条目保留 config.GetRevocationSubjectTTL()，应不短于最长的 access token 有效期
+func (cm *Manager) RevokeSubject(ctx context.Context, openid string, before time.Time) error {
+	audiences, err := cm.redis.SMembers(ctx, subjectAudiencesKey(openid))
+	if err != nil {
+		return fmt.Errorf("load subject audiences: %w", err)
+	}
+	for _, audience := range audiences {
+		if err := cm.RevokeSubjectAudience(ctx, openid, audience, before); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// RevokeSubjectAudience 吊销用户在 before 之前签发给 audience 的 access token（如 refresh token family 被判定泄露）
+func (cm *Manager) RevokeSubjectAudience(ctx context.Context, openid, audience string, before time.Time) error {
+	key := revokedSubjectKey(audience)
+	if err := cm.redis.HSet(ctx, key, openid, before.Unix()); err != nil {
+		return fmt.Errorf("revoke subject: %w", err)
+	}
+	return cm.extendRevocationKey(ctx, key, config.GetRevocationSubjectTTL())
+}
+
+// GetRevocationList 获取 audience 的吊销列表（顺带清理已失效条目）
+func (cm *Manager) GetRevocationList(ctx context.Context, audience string) (*tokendef.RevocationList, error) {
+	now := time.Now()
+	list := tokendef.NewRevocationList()
+
+	jtis, err := cm.loadRevocations(ctx, revokedJTIKey(audience), func(at time.Time) bool {
+		return now.After(at)
+	})
+	if err != nil {
+		return nil, err
+	}
+	list.JTIs = jtis
+
+	subjectTTL := config.GetRevocationSubjectTTL()
+	subjects, err := cm.loadRevocations(ctx, revokedSubjectKey(audience), func(at time.Time) bool {
+		return now.Sub(at) > subjectTTL
+	})
+	if err != nil {
+		return nil, err
+	}
+	list.Subjects = subjects
+
+	return list, nil
+}
+
+// loadRevocations 读取吊销 hash，删除 expired 判定为失效的条目
+func (cm *Manager) loadRevocations(ctx context.Context, key string, expired func(time.Time) bool) (map[string]time.Time, error) {
+	values, err := cm.redis.HGetAll(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("load revocations: %w", err)
+	}
+
+	result := make(map[string]time.Time, len(values))
+	var stale []string
+	for field, raw := range values {
+		unix, err := strconv.ParseInt(raw, 10, 64)
+		if err != nil {
+			stale = append(stale, field)
+			continue
+		}
+		at := time.Unix(unix, 0)
+		if expired(at) {
+			stale = append(stale, field)
+			continue
+		}
+		result[field] = at
+	}
+
+	if len(stale) > 0 {
+		if err := cm.redis.HDel(ctx, key, stale...); err != nil {
+			logger.Warnf("[Manager] prune revocations failed: %v", err)
+		}
+	}
+	return result, nil
+}
+
+// extendRevocationKey 吊销 hash 有效期只延长不缩短
+func (cm *Manager) extendRevocationKey(ctx context.Context, key string, ttl time.Duration) error {
+	if current, err := cm.redis.TTL(ctx, key); err != nil || current < ttl {
+		return cm.redis.Expire(ctx, key, ttl)
+	}
+	return nil
+}
+
+func revokedJTIKey(audience string) string {
+	return config.GetCacheKeyPrefix("revoked_jti") + audience
+}
+
+func revokedSubjectKey(audience string) string {
+	return config.GetCacheKeyPrefix("revoked_subject") + audience
+}
+
+func subjectAudiencesKey(openid string) string {
+	return config.GetCacheKeyPrefix("subject_audiences") + openid
+}
@@ -0,0 +1,104 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// ==================== 登录基线（Redis）====================
+
+const (
+	baselineDeviceField  = "device:"
+	baselineNetworkField = "network:"
+)
+
+// LoginBaseline 用户登录基线：已知设备与网络 → 最近一次登录完成时间
+type LoginBaseline struct {
+	Devices  map[string]time.Time
+	Networks map[string]time.Time
+}
+
+// Empty 是否尚无任何基线记录（首次登录或基线已过期）
+func (b *LoginBaseline) Empty() bool {
+	return len(b.Devices) == 0 && len(b.Networks) == 0
+}
+
+// KnownDevice 设备是否出现在基线中
+func (b *LoginBaseline) KnownDevice(deviceID string) bool {
+	_, ok := b.Devices[deviceID]
+	return deviceID != "" && ok
+}
+
+// KnownNetwork 网络是否出现在基线中
+func (b *LoginBaseline) KnownNetwork(network string) bool {
+	_, ok := b.Networks[network]
+	return network != "" && ok
+}
+
+// GetLoginBaseline 获取用户登录基线（顺带清理超过 config.GetMFARiskBaselineTTL() 的条目）
+func (cm *Manager) GetLoginBaseline(ctx context.Context, openid string) (*LoginBaseline, error) {
+	key := loginBaselineKey(openid)
+	values, err := cm.redis.HGetAll(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get login baseline: %w", err)
+	}
+
+	retention := config.GetMFARiskBaselineTTL()
+	baseline := &LoginBaseline{
+		Devices:  make(map[string]time.Time),
+		Networks: make(map[string]time.Time),
+	}
+	var stale []string
+	for field, raw := range values {
+		unix, err := strconv.ParseInt(raw, 10, 64)
+		seenAt := time.Unix(unix, 0)
+		if err != nil || time.Since(seenAt) > retention {
+			stale = append(stale, field)
+			continue
+		}
+		switch {
+		case strings.HasPrefix(field, baselineDeviceField):
+			baseline.Devices[strings.TrimPrefix(field, baselineDeviceField)] = seenAt
+		case strings.HasPrefix(field, baselineNetworkField):
+			baseline.Networks[strings.TrimPrefix(field, baselineNetworkField)] = seenAt
+		}
+	}
+
+	if len(stale) > 0 {
+		if err := cm.redis.HDel(ctx, key, stale...); err != nil {
+			logger.Warnf("[Manager] prune login baseline failed: %v", err)
+		}
+	}
+	return baseline, nil
+}
+
+// RecordLoginBaseline 将本次登录的设备与网络写入基线（空值忽略）
+func (cm *Manager) RecordLoginBaseline(ctx context.Context, openid, deviceID, network string) error {
+	now := strconv.FormatInt(time.Now().Unix(), 10)
+	values := make([]any, 0, 4)
+	if deviceID != "" {
+		values = append(values, baselineDeviceField+deviceID, now)
+	}
+	if network != "" {
+		values = append(values, baselineNetworkField+network, now)
+	}
+	if len(values) == 0 {
+		return nil
+	}
+
+	key := loginBaselineKey(openid)
+	if err := cm.redis.HSet(ctx, key, values...); err != nil {
+		return fmt.Errorf("record login baseline: %w", err)
+	}
+	return cm.redis.Expire(ctx, key, config.GetMFARiskBaselineTTL())
+}
+
+func loginBaselineKey(openid string) string {
+	return config.GetCacheKeyPrefix("login_baseline") + openid
+}
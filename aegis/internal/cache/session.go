@@ -0,0 +1,167 @@
+package cache
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// ==================== SSO 会话参与方（Redis）====================
+
+// takeSSOSessionScript 原子读取并删除会话参与方，保证同一会话只通知一次
+const takeSSOSessionScript = `
+local values = redis.call("HGETALL", KEYS[1])
+redis.call("DEL", KEYS[1])
+return values
+`
+
+// takeUserSSOSessionsScript 原子读取并删除用户的会话索引，避免并发登出重复通知或漏掉新写入的会话
+const takeUserSSOSessionsScript = `
+local sids = redis.call("SMEMBERS", KEYS[1])
+redis.call("DEL", KEYS[1])
+return sids
+`
+
+// AddSSOSessionParticipant 记录应用参与了 SSO 会话（appID → 该应用所在域的 OpenID），并建立用户到会话的索引
+func (cm *Manager) AddSSOSessionParticipant(ctx context.Context, sid, openid, appID string, ttl time.Duration) error {
+	key := ssoSessionKey(sid)
+	if err := cm.redis.HSet(ctx, key, appID, openid); err != nil {
+		return fmt.Errorf("add sso session participant: %w", err)
+	}
+	if err := cm.redis.Expire(ctx, key, ttl); err != nil {
+		return fmt.Errorf("expire sso session: %w", err)
+	}
+
+	userKey := ssoUserSessionKey(openid)
+	if err := cm.redis.SAdd(ctx, userKey, sid); err != nil {
+		return fmt.Errorf("index user sso session: %w", err)
+	}
+	return cm.redis.Expire(ctx, userKey, ttl)
+}
+
+// TakeSSOSessionParticipants 取出并删除 SSO 会话的参与方（appID → OpenID），会话不存在时返回空
+func (cm *Manager) TakeSSOSessionParticipants(ctx context.Context, sid string) (map[string]string, error) {
+	value, err := cm.redis.Eval(ctx, takeSSOSessionScript, []string{ssoSessionKey(sid)})
+	if err != nil {
+		return nil, fmt.Errorf("take sso session participants: %w", err)
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("take sso session participants: unexpected redis value %T", value)
+	}
+	participants := make(map[string]string, len(values)/2)
+	for i := 0; i+1 < len(values); i += 2 {
+		appID, _ := values[i].(string)
+		openid, _ := values[i+1].(string)
+		if appID != "" && openid != "" {
+			participants[appID] = openid
+		}
+	}
+	return participants, nil
+}
+
+// TakeUserSSOSessions 取出并删除用户参与过的 SSO 会话标识
+func (cm *Manager) TakeUserSSOSessions(ctx context.Context, openid string) ([]string, error) {
+	value, err := cm.redis.Eval(ctx, takeUserSSOSessionsScript, []string{ssoUserSessionKey(openid)})
+	if err != nil {
+		return nil, fmt.Errorf("take user sso sessions: %w", err)
+	}
+
+	values, ok := value.([]any)
+	if !ok {
+		return nil, fmt.Errorf("take user sso sessions: unexpected redis value %T", value)
+	}
+	sids := make([]string, 0, len(values))
+	for _, v := range values {
+		if sid, _ := v.(string); sid != "" {
+			sids = append(sids, sid)
+		}
+	}
+	return sids, nil
+}
+
+func ssoSessionKey(sid string) string {
+	return config.GetCacheKeyPrefix("sso_session") + sid
+}
+
+func ssoUserSessionKey(openid string) string {
+	return config.GetCacheKeyPrefix("sso_user_session") + openid
+}
+
+// ==================== 后端通道登出投递记录（Redis）====================
+
+// LogoutDeliveryStatus 投递状态
+type LogoutDeliveryStatus string
+
+const (
+	LogoutDeliveryPending   LogoutDeliveryStatus = "pending"   // 投递中（含重试等待）
+	LogoutDeliveryDelivered LogoutDeliveryStatus = "delivered" // 应用返回 2xx
+	LogoutDeliveryFailed    LogoutDeliveryStatus = "failed"    // 重试耗尽
+)
+
+// LogoutDelivery 后端通道登出通知投递记录（按应用保存，保留 config.GetBackchannelLogoutLogTTL()）
+type LogoutDelivery struct {
+	JTI        string               `json:"jti"`
+	SessionID  string               `json:"sid"`
+	OpenID     string               `json:"sub"`
+	URI        string               `json:"uri"`
+	Status     LogoutDeliveryStatus `json:"status"`
+	Attempts   int                  `json:"attempts"`
+	StatusCode int                  `json:"status_code,omitempty"` // 最近一次响应的 HTTP 状态码
+	Error      string               `json:"error,omitempty"`       // 最近一次失败原因
+	CreatedAt  time.Time            `json:"created_at"`
+	UpdatedAt  time.Time            `json:"updated_at"`
+}
+
+// SaveLogoutDelivery 写入（覆盖）应用的投递记录
+func (cm *Manager) SaveLogoutDelivery(ctx context.Context, appID string, d *LogoutDelivery) error {
+	data, err := json.Marshal(d)
+	if err != nil {
+		return fmt.Errorf("marshal logout delivery: %w", err)
+	}
+	key := logoutDeliveryKey(appID)
+	if err := cm.redis.HSet(ctx, key, d.JTI, string(data)); err != nil {
+		return fmt.Errorf("save logout delivery: %w", err)
+	}
+	return cm.redis.Expire(ctx, key, config.GetBackchannelLogoutLogTTL())
+}
+
+// ListLogoutDeliveries 列出应用的投递记录（按创建时间倒序，顺带清理过期条目）
+func (cm *Manager) ListLogoutDeliveries(ctx context.Context, appID string) ([]*LogoutDelivery, error) {
+	key := logoutDeliveryKey(appID)
+	values, err := cm.redis.HGetAll(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("list logout deliveries: %w", err)
+	}
+
+	retention := config.GetBackchannelLogoutLogTTL()
+	result := make([]*LogoutDelivery, 0, len(values))
+	var stale []string
+	for field, raw := range values {
+		var d LogoutDelivery
+		if err := json.Unmarshal([]byte(raw), &d); err != nil || time.Since(d.CreatedAt) > retention {
+			stale = append(stale, field)
+			continue
+		}
+		result = append(result, &d)
+	}
+
+	if len(stale) > 0 {
+		if err := cm.redis.HDel(ctx, key, stale...); err != nil {
+			logger.Warnf("[Manager] prune logout deliveries failed: %v", err)
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].CreatedAt.After(result[j].CreatedAt) })
+	return result, nil
+}
+
+func logoutDeliveryKey(appID string) string {
+	return config.GetCacheKeyPrefix("logout_delivery") + appID
+}
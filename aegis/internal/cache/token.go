@@ -9,6 +9,7 @@ import (
 	"github.com/go-json-experiment/json"
 
 	"github.com/heliannuuthus/aegis/config"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
 	"github.com/heliannuuthus/pkg/logger"
 	pkgredis "github.com/heliannuuthus/pkg/redis"
 )
@@ -90,7 +91,19 @@ type RefreshToken struct {
 	ExpiresAt    time.Time  `json:"expires_at"`               // 沉寂过期（每次使用可延长）
 	MaxExpiresAt *time.Time `json:"max_expires_at,omitempty"` // 绝对过期（从创建起，不可延长），nil=不限制
 	Revoked      bool       `json:"revoked"`
+	FamilyID     string     `json:"family_id,omitempty"` // 轮换 family（首个 token 标识），未启用轮换时为空
+	UsedAt       *time.Time `json:"used_at,omitempty"`   // 已被轮换的时间，再次出示视为重用
+	JKT          string     `json:"jkt,omitempty"`       // DPoP 公钥指纹，非空时刷新须出示同一密钥的 proof
+	AuthTime     time.Time  `json:"auth_time,omitempty"` // 用户认证时间，刷新签发的 access token 沿用
 	CreatedAt    time.Time  `json:"created_at"`
+
+	// 授予的授权详情（RAR），刷新签发的 access token 沿用或在此范围内收窄
+	AuthorizationDetails tokendef.AuthorizationDetails `json:"authorization_details,omitempty"`
+}
+
+// IsUsed 是否已被轮换
+func (rt *RefreshToken) IsUsed() bool {
+	return rt.UsedAt != nil
 }
 
 // SetRefreshToken 保存刷新令牌
@@ -112,6 +125,19 @@ func (cm *Manager) SetRefreshToken(ctx context.Context, token *RefreshToken) err
 		return err
 	}
 
+	// 添加到 family 集合，集合有效期只延长不缩短（覆盖 family 内最晚过期的成员）
+	if token.FamilyID != "" {
+		familyKey := refreshTokenFamilyKey(token.FamilyID)
+		if err := cm.redis.SAdd(ctx, familyKey, token.Token); err != nil {
+			return fmt.Errorf("add refresh token to family: %w", err)
+		}
+		if current, err := cm.redis.TTL(ctx, familyKey); err != nil || current < ttl {
+			if err := cm.redis.Expire(ctx, familyKey, ttl); err != nil {
+				return fmt.Errorf("extend refresh token family: %w", err)
+			}
+		}
+	}
+
 	// 添加到用户的 token 集合
 	return cm.redis.SAdd(ctx, userPrefix+token.OpenID, token.Token)
 }
@@ -138,9 +164,50 @@ func (cm *Manager) GetRefreshToken(ctx context.Context, token string) (*RefreshT
 		return nil, ErrRefreshTokenRevoked
 	}
 
+	if rt.IsUsed() {
+		return nil, ErrRefreshTokenReused
+	}
+
 	return &rt, nil
 }
 
+// claimRefreshTokenScript 标记 token 已使用，仅首次成功，保证同一 token 只能被轮换一次
+const claimRefreshTokenScript = `return redis.call("SET", KEYS[1], "1", "NX", "PX", ARGV[1])`
+
+// RotateRefreshToken 轮换刷新令牌：签发同 family 的新 token 并将旧 token 标记为已使用
+// 旧 token 已被轮换过（并发或重放）时返回 ErrRefreshTokenReused，调用方应撤销整个 family
+// 已使用的旧 token 保留到其过期，用于重用检测
+func (cm *Manager) RotateRefreshToken(ctx context.Context, current, next *RefreshToken) error {
+	ttl := time.Until(current.ExpiresAt)
+	if ttl <= 0 {
+		return ErrRefreshTokenExpired
+	}
+
+	usedKey := config.GetCacheKeyPrefix("refresh_token_used") + current.Token
+	if _, err := cm.redis.Eval(ctx, claimRefreshTokenScript, []string{usedKey}, ttl.Milliseconds()); err != nil {
+		if errors.Is(err, pkgredis.ErrNil) {
+			return ErrRefreshTokenReused
+		}
+		return fmt.Errorf("claim refresh token: %w", err)
+	}
+
+	if current.FamilyID == "" {
+		current.FamilyID = current.Token
+	}
+	next.FamilyID = current.FamilyID
+	if err := cm.SetRefreshToken(ctx, next); err != nil {
+		return fmt.Errorf("save rotated refresh token: %w", err)
+	}
+
+	now := time.Now()
+	current.UsedAt = &now
+	if err := cm.SetRefreshToken(ctx, current); err != nil {
+		// 使用标记已写入，旧 token 不会被再次轮换，仅记录
+		logger.Warnf("[Manager] mark refresh token used failed: %v", err)
+	}
+	return nil
+}
+
 // DelRefreshToken 撤销刷新令牌
 func (cm *Manager) DelRefreshToken(ctx context.Context, token string) error {
 	prefix := config.GetCacheKeyPrefix("refresh_token")
@@ -170,6 +237,41 @@ func (cm *Manager) DelRefreshToken(ctx context.Context, token string) error {
 	return cm.redis.Set(ctx, prefix+token, string(newData), remaining)
 }
 
+// DelRefreshTokenFamily 撤销 token 所在 family 的全部刷新令牌（重用检测触发）
+// 返回出示的 token 记录（含 family、用户、应用与 audience）；token 未启用轮换时仅撤销其自身
+func (cm *Manager) DelRefreshTokenFamily(ctx context.Context, token string) (*RefreshToken, error) {
+	prefix := config.GetCacheKeyPrefix("refresh_token")
+	data, err := cm.redis.Get(ctx, prefix+token)
+	if err != nil {
+		return nil, fmt.Errorf("get refresh token for family revocation: %w", err)
+	}
+
+	var rt RefreshToken
+	if err := json.Unmarshal([]byte(data), &rt); err != nil {
+		return nil, err
+	}
+	if rt.FamilyID == "" {
+		return &rt, cm.DelRefreshToken(ctx, token)
+	}
+
+	familyKey := refreshTokenFamilyKey(rt.FamilyID)
+	tokens, err := cm.redis.SMembers(ctx, familyKey)
+	if err != nil {
+		return &rt, fmt.Errorf("list refresh token family: %w", err)
+	}
+
+	for _, member := range tokens {
+		if err := cm.DelRefreshToken(ctx, member); err != nil {
+			// 已过期的成员无需撤销
+			logger.Debugf("[Manager] revoke family member skipped: %v", err)
+		}
+	}
+	if err := cm.redis.Del(ctx, familyKey); err != nil {
+		return &rt, fmt.Errorf("delete refresh token family: %w", err)
+	}
+	return &rt, nil
+}
+
 // DelUserRefreshTokens 撤销用户所有刷新令牌
 func (cm *Manager) DelUserRefreshTokens(ctx context.Context, openid string) error {
 	prefix := config.GetCacheKeyPrefix("user_token")
@@ -216,3 +318,7 @@ func (cm *Manager) ListRefreshTokens(ctx context.Context, openid, clientID strin
 
 	return result, nil
 }
+
+func refreshTokenFamilyKey(familyID string) string {
+	return config.GetCacheKeyPrefix("refresh_token_family") + familyID
+}
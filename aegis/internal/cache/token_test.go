@@ -0,0 +1,260 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+)
+
+// memoryRedis 进程内 Redis 替身：字符串、集合与 hash，Eval 仅支持 claimRefreshTokenScript（SET NX）
+type memoryRedis struct {
+	pkgredis.Client
+	mu      sync.Mutex
+	strings map[string]string
+	sets    map[string]map[string]bool
+	hashes  map[string]map[string]string
+}
+
+func newMemoryRedis() *memoryRedis {
+	return &memoryRedis{
+		strings: make(map[string]string),
+		sets:    make(map[string]map[string]bool),
+		hashes:  make(map[string]map[string]string),
+	}
+}
+
+func (r *memoryRedis) Set(_ context.Context, key string, value any, _ time.Duration) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.strings[key] = value.(string)
+	return nil
+}
+
+func (r *memoryRedis) Get(_ context.Context, key string) (string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	value, ok := r.strings[key]
+	if !ok {
+		return "", pkgredis.ErrNil
+	}
+	return value, nil
+}
+
+func (r *memoryRedis) Del(_ context.Context, keys ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, key := range keys {
+		delete(r.strings, key)
+		delete(r.sets, key)
+		delete(r.hashes, key)
+	}
+	return nil
+}
+
+func (r *memoryRedis) SAdd(_ context.Context, key string, members ...any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.sets[key] == nil {
+		r.sets[key] = make(map[string]bool)
+	}
+	for _, member := range members {
+		r.sets[key][member.(string)] = true
+	}
+	return nil
+}
+
+func (r *memoryRedis) SMembers(_ context.Context, key string) ([]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	members := make([]string, 0, len(r.sets[key]))
+	for member := range r.sets[key] {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (r *memoryRedis) HSet(_ context.Context, key string, values ...any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes[key] == nil {
+		r.hashes[key] = make(map[string]string)
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		r.hashes[key][values[i].(string)] = fmt.Sprint(values[i+1])
+	}
+	return nil
+}
+
+func (r *memoryRedis) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := make(map[string]string, len(r.hashes[key]))
+	for field, value := range r.hashes[key] {
+		values[field] = value
+	}
+	return values, nil
+}
+
+func (r *memoryRedis) HDel(_ context.Context, key string, fields ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, field := range fields {
+		delete(r.hashes[key], field)
+	}
+	return nil
+}
+
+func (*memoryRedis) Expire(context.Context, string, time.Duration) error { return nil }
+
+func (*memoryRedis) TTL(context.Context, string) (time.Duration, error) { return 0, nil }
+
+func (r *memoryRedis) Eval(_ context.Context, script string, keys []string, _ ...any) (any, error) {
+	if script != claimRefreshTokenScript {
+		return nil, errors.New("unsupported script")
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if _, ok := r.strings[keys[0]]; ok {
+		return nil, pkgredis.ErrNil
+	}
+	r.strings[keys[0]] = "1"
+	return "OK", nil
+}
+
+func newRefreshToken(token string) *RefreshToken {
+	return &RefreshToken{
+		Token:     token,
+		OpenID:    "openid-1",
+		ClientID:  "app",
+		Audience:  "svc",
+		ExpiresAt: time.Now().Add(time.Hour),
+		CreatedAt: time.Now(),
+	}
+}
+
+func TestRotateRefreshToken(t *testing.T) {
+	ctx := context.Background()
+	manager := &Manager{redis: newMemoryRedis()}
+	first := newRefreshToken("rt-1")
+	if err := manager.SetRefreshToken(ctx, first); err != nil {
+		t.Fatalf("SetRefreshToken() error = %v", err)
+	}
+
+	second := newRefreshToken("rt-2")
+	if err := manager.RotateRefreshToken(ctx, first, second); err != nil {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+	if second.FamilyID != "rt-1" {
+		t.Fatalf("rotated FamilyID = %q, want rt-1", second.FamilyID)
+	}
+	if _, err := manager.GetRefreshToken(ctx, "rt-1"); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("GetRefreshToken(old) error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+	got, err := manager.GetRefreshToken(ctx, "rt-2")
+	if err != nil {
+		t.Fatalf("GetRefreshToken(new) error = %v", err)
+	}
+	if got.FamilyID != "rt-1" || got.OpenID != "openid-1" {
+		t.Fatalf("GetRefreshToken(new) = %+v", got)
+	}
+
+	third := newRefreshToken("rt-3")
+	if err := manager.RotateRefreshToken(ctx, got, third); err != nil {
+		t.Fatalf("second RotateRefreshToken() error = %v", err)
+	}
+	if third.FamilyID != "rt-1" {
+		t.Fatalf("second rotation FamilyID = %q, want rt-1", third.FamilyID)
+	}
+}
+
+func TestRefreshTokenReuseRevokesFamily(t *testing.T) {
+	ctx := context.Background()
+	manager := &Manager{redis: newMemoryRedis()}
+	first := newRefreshToken("rt-1")
+	if err := manager.SetRefreshToken(ctx, first); err != nil {
+		t.Fatalf("SetRefreshToken() error = %v", err)
+	}
+	if err := manager.RotateRefreshToken(ctx, first, newRefreshToken("rt-2")); err != nil {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+
+	// 旧 token 被再次轮换视为重用
+	if err := manager.RotateRefreshToken(ctx, newRefreshToken("rt-1"), newRefreshToken("rt-x")); !errors.Is(err, ErrRefreshTokenReused) {
+		t.Fatalf("RotateRefreshToken(reused) error = %v, want %v", err, ErrRefreshTokenReused)
+	}
+
+	rt, err := manager.DelRefreshTokenFamily(ctx, "rt-1")
+	if err != nil {
+		t.Fatalf("DelRefreshTokenFamily() error = %v", err)
+	}
+	if rt.FamilyID != "rt-1" || rt.OpenID != "openid-1" || rt.ClientID != "app" || rt.Audience != "svc" {
+		t.Fatalf("DelRefreshTokenFamily() = %+v", rt)
+	}
+	for _, token := range []string{"rt-1", "rt-2"} {
+		if _, err := manager.GetRefreshToken(ctx, token); !errors.Is(err, ErrRefreshTokenRevoked) {
+			t.Fatalf("GetRefreshToken(%s) error = %v, want %v", token, err, ErrRefreshTokenRevoked)
+		}
+	}
+
+	// family 的 access token 经吊销列表失效
+	if err := manager.RevokeSubjectAudience(ctx, rt.OpenID, rt.Audience, time.Now()); err != nil {
+		t.Fatalf("RevokeSubjectAudience() error = %v", err)
+	}
+	list, err := manager.GetRevocationList(ctx, "svc")
+	if err != nil {
+		t.Fatalf("GetRevocationList() error = %v", err)
+	}
+	if _, ok := list.Subjects["openid-1"]; !ok {
+		t.Fatalf("revocation list subjects = %v, want openid-1", list.Subjects)
+	}
+}
+
+func TestRotateRefreshTokenConcurrentClaims(t *testing.T) {
+	ctx := context.Background()
+	manager := &Manager{redis: newMemoryRedis()}
+	if err := manager.SetRefreshToken(ctx, newRefreshToken("rt-1")); err != nil {
+		t.Fatalf("SetRefreshToken() error = %v", err)
+	}
+
+	const workers = 8
+	var (
+		wg      sync.WaitGroup
+		won     atomic.Int32
+		reused  atomic.Int32
+		start   = make(chan struct{})
+		unknown = make(chan error, workers)
+	)
+	for i := range workers {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			<-start
+			next := newRefreshToken("rt-next-" + strconv.Itoa(i))
+			switch err := manager.RotateRefreshToken(ctx, newRefreshToken("rt-1"), next); {
+			case err == nil:
+				won.Add(1)
+			case errors.Is(err, ErrRefreshTokenReused):
+				reused.Add(1)
+			default:
+				unknown <- err
+			}
+		}()
+	}
+	close(start)
+	wg.Wait()
+	close(unknown)
+
+	for err := range unknown {
+		t.Fatalf("RotateRefreshToken() error = %v", err)
+	}
+	if won.Load() != 1 || reused.Load() != workers-1 {
+		t.Fatalf("rotations won = %d, reused = %d, want 1 and %d", won.Load(), reused.Load(), workers-1)
+	}
+}
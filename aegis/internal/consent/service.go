@@ -0,0 +1,186 @@
+package consent
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// Service 用户授权同意服务
+//   - 授权记录持久化在 hermes（用户 + 应用 + 服务 唯一，scope 累计取并集）
+//   - 撤销应用授权时同步撤销该应用下用户的全部 refresh_token
+type Service struct {
+	cache  *cache.Manager
+	hermes *hermes.Client
+}
+
+func NewService(cache *cache.Manager, hermesClient *hermes.Client) *Service {
+	return &Service{
+		cache:  cache,
+		hermes: hermesClient,
+	}
+}
+
+// Requested 按服务拆分本次授权请求的 scope
+// 多 audience 请求：每个 audience 的 scope 与 granted 合并；否则全部归属 flow.Service
+func Requested(flow *types.AuthFlow, granted []string) map[string][]string {
+	requested := make(map[string][]string)
+	if len(flow.Request.Audiences) > 0 {
+		for aud, ras := range flow.Request.Audiences {
+			scopes := slices.Clone(granted)
+			if ras != nil {
+				for _, s := range strings.Fields(ras.Scope) {
+					if !slices.Contains(scopes, s) {
+						scopes = append(scopes, s)
+					}
+				}
+			}
+			requested[aud] = scopes
+		}
+		return requested
+	}
+	if flow.Service != nil {
+		requested[flow.Service.ServiceID] = slices.Clone(granted)
+	}
+	return requested
+}
+
+// Covered 判断用户已有授权是否覆盖本次请求的全部 scope
+func (s *Service) Covered(ctx context.Context, openid, appID string, requested map[string][]string) (bool, error) {
+	for serviceID, scopes := range requested {
+		consent, err := s.hermes.GetConsent(ctx, openid, appID, serviceID)
+		if err != nil {
+			return false, fmt.Errorf("get consent: %w", err)
+		}
+		if consent == nil {
+			return false, nil
+		}
+		if len(missing(consent.Scopes, scopes)) > 0 {
+			return false, nil
+		}
+	}
+	return true, nil
+}
+
+// Grant 记录用户对本次请求 scope 的授权
+func (s *Service) Grant(ctx context.Context, openid, appID string, requested map[string][]string) error {
+	for serviceID, scopes := range requested {
+		if _, err := s.hermes.SaveConsent(ctx, openid, appID, serviceID, scopes); err != nil {
+			return fmt.Errorf("save consent: %w", err)
+		}
+	}
+	logger.Infof("[Consent] 记录授权 - OpenID: %s, AppID: %s, Services: %d", openid, appID, len(requested))
+	return nil
+}
+
+// AppConsent 应用维度的授权汇总
+type AppConsent struct {
+	AppID     string            `json:"app_id"`
+	Name      string            `json:"name,omitempty"`
+	LogoURL   *string           `json:"logo_url,omitempty"`
+	Services  []*ServiceConsent `json:"services"`
+	UpdatedAt time.Time         `json:"updated_at"`
+}
+
+// ServiceConsent 单个服务的授权
+type ServiceConsent struct {
+	ServiceID string    `json:"service_id"`
+	Name      string    `json:"name,omitempty"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+// List 列出用户的授权（按应用聚合，补充应用和服务名称）
+func (s *Service) List(ctx context.Context, openid string) ([]*AppConsent, error) {
+	consents, err := s.hermes.ListConsents(ctx, openid)
+	if err != nil {
+		return nil, fmt.Errorf("list consents: %w", err)
+	}
+
+	byApp := make(map[string]*AppConsent)
+	for i := range consents {
+		c := &consents[i]
+		app, ok := byApp[c.AppID]
+		if !ok {
+			app = s.appConsent(ctx, c.AppID)
+			byApp[c.AppID] = app
+		}
+		app.Services = append(app.Services, s.serviceConsent(ctx, c))
+		if c.UpdatedAt.After(app.UpdatedAt) {
+			app.UpdatedAt = c.UpdatedAt
+		}
+	}
+
+	result := make([]*AppConsent, 0, len(byApp))
+	for _, app := range byApp {
+		result = append(result, app)
+	}
+	sort.Slice(result, func(i, j int) bool { return result[i].UpdatedAt.After(result[j].UpdatedAt) })
+	return result, nil
+}
+
+// Revoke 撤销用户对应用的全部授权，并撤销该应用下用户的 refresh_token
+func (s *Service) Revoke(ctx context.Context, openid, appID string) error {
+	if err := s.hermes.RevokeConsent(ctx, openid, appID); err != nil {
+		return fmt.Errorf("revoke consent: %w", err)
+	}
+
+	tokens, err := s.cache.ListRefreshTokens(ctx, openid, appID)
+	if err != nil {
+		return fmt.Errorf("list refresh tokens: %w", err)
+	}
+	for _, rt := range tokens {
+		if _, err := s.cache.DelRefreshTokenFamily(ctx, rt.Token); err != nil {
+			logger.Warnf("[Consent] 撤销 refresh_token 失败 - AppID: %s, Error: %v", appID, err)
+		}
+	}
+
+	logger.Infof("[Consent] 撤销授权 - OpenID: %s, AppID: %s, RefreshTokens: %d", openid, appID, len(tokens))
+	return nil
+}
+
+func (s *Service) appConsent(ctx context.Context, appID string) *AppConsent {
+	ac := &AppConsent{AppID: appID}
+	app, err := s.cache.GetApplication(ctx, appID)
+	if err != nil {
+		logger.Debugf("[Consent] 获取应用信息失败 - AppID: %s, Error: %v", appID, err)
+		return ac
+	}
+	ac.Name = app.Name
+	ac.LogoURL = app.LogoURL
+	return ac
+}
+
+func (s *Service) serviceConsent(ctx context.Context, c *models.UserConsent) *ServiceConsent {
+	sc := &ServiceConsent{
+		ServiceID: c.ServiceID,
+		Scopes:    c.Scopes,
+		CreatedAt: c.CreatedAt,
+		UpdatedAt: c.UpdatedAt,
+	}
+	if svc, err := s.cache.GetService(ctx, c.ServiceID); err == nil {
+		sc.Name = svc.Name
+	}
+	return sc
+}
+
+// missing 返回 requested 中未被 granted 覆盖的 scope
+func missing(granted, requested []string) []string {
+	var result []string
+	for _, s := range requested {
+		if !slices.Contains(granted, s) {
+			result = append(result, s)
+		}
+	}
+	return result
+}
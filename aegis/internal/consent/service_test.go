@@ -0,0 +1,61 @@
+package consent
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+)
+
+func TestRequested(t *testing.T) {
+	granted := []string{"openid", "profile"}
+
+	t.Run("single audience", func(t *testing.T) {
+		flow := &types.AuthFlow{
+			Request: &types.AuthRequest{},
+			Service: &models.Service{ServiceID: "iris"},
+		}
+		got := Requested(flow, granted)
+		if len(got) != 1 || !slices.Equal(got["iris"], granted) {
+			t.Fatalf("Requested() = %v", got)
+		}
+	})
+
+	t.Run("multi audience", func(t *testing.T) {
+		flow := &types.AuthFlow{
+			Request: &types.AuthRequest{Audiences: map[string]*types.RequestAudienceScope{
+				"iris":  {Scope: "profile iris:read"},
+				"chaos": nil,
+			}},
+		}
+		got := Requested(flow, granted)
+		if want := []string{"openid", "profile", "iris:read"}; !slices.Equal(got["iris"], want) {
+			t.Fatalf("Requested()[iris] = %v, want %v", got["iris"], want)
+		}
+		if !slices.Equal(got["chaos"], granted) {
+			t.Fatalf("Requested()[chaos] = %v, want %v", got["chaos"], granted)
+		}
+	})
+}
+
+func TestMissing(t *testing.T) {
+	tests := []struct {
+		name      string
+		granted   []string
+		requested []string
+		want      []string
+	}{
+		{name: "covered", granted: []string{"openid", "profile", "email"}, requested: []string{"openid", "email"}},
+		{name: "new scope", granted: []string{"openid"}, requested: []string{"openid", "phone"}, want: []string{"phone"}},
+		{name: "no grant", requested: []string{"openid"}, want: []string{"openid"}},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := missing(tt.granted, tt.requested); !slices.Equal(got, tt.want) {
+				t.Fatalf("missing() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
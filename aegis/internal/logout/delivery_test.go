@@ -0,0 +1,245 @@
+package logout
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/token"
+	"github.com/heliannuuthus/pkg/aegis/utilities/key"
+	"github.com/heliannuuthus/pkg/async"
+	pkgredis "github.com/heliannuuthus/pkg/redis"
+)
+
+// hashRedis 进程内 Redis 替身，仅支持投递记录用到的 hash 操作
+type hashRedis struct {
+	pkgredis.Client
+	mu     sync.Mutex
+	hashes map[string]map[string]string
+}
+
+func (r *hashRedis) HSet(_ context.Context, key string, values ...any) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	if r.hashes[key] == nil {
+		r.hashes[key] = make(map[string]string)
+	}
+	for i := 0; i+1 < len(values); i += 2 {
+		r.hashes[key][values[i].(string)] = fmt.Sprint(values[i+1])
+	}
+	return nil
+}
+
+func (r *hashRedis) HGetAll(_ context.Context, key string) (map[string]string, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	values := make(map[string]string, len(r.hashes[key]))
+	for field, value := range r.hashes[key] {
+		values[field] = value
+	}
+	return values, nil
+}
+
+func (r *hashRedis) HDel(_ context.Context, key string, fields ...string) error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	for _, field := range fields {
+		delete(r.hashes[key], field)
+	}
+	return nil
+}
+
+func (*hashRedis) Expire(context.Context, string, time.Duration) error { return nil }
+
+// logoutEndpoint 依次返回 statuses 中的状态码（用尽后重复最后一个），记录每次请求的时间
+type logoutEndpoint struct {
+	mu       sync.Mutex
+	statuses []int
+	hits     []time.Time
+}
+
+func (e *logoutEndpoint) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	status := e.statuses[min(len(e.hits), len(e.statuses)-1)]
+	e.hits = append(e.hits, time.Now())
+	w.WriteHeader(status)
+}
+
+func (e *logoutEndpoint) requests() []time.Time {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	return append([]time.Time(nil), e.hits...)
+}
+
+func newDeliveryService(t *testing.T, ctx context.Context, endpoint http.Handler, retryInterval time.Duration) (*Service, string) {
+	t.Helper()
+	srv := httptest.NewServer(endpoint)
+	t.Cleanup(srv.Close)
+	pool, err := async.NewPool(4)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	t.Cleanup(pool.Release)
+	cm := cache.NewManager(nil, &hashRedis{hashes: make(map[string]map[string]string)})
+	t.Cleanup(cm.Close)
+
+	return &Service{
+		ctx:           ctx,
+		cache:         cm,
+		pool:          pool,
+		client:        srv.Client(),
+		maxAttempts:   3,
+		retryInterval: retryInterval,
+	}, srv.URL
+}
+
+// startDelivery 执行首次投递（后续重试异步进行）
+func startDelivery(s *Service, uri string) {
+	now := time.Now()
+	d := &cache.LogoutDelivery{JTI: "jti-1", SessionID: "sid-1", OpenID: "openid-1", URI: uri, Status: cache.LogoutDeliveryPending, CreatedAt: now, UpdatedAt: now}
+	s.deliver(s.ctx, "app-1", d, "v4.public.token", 1, s.retryInterval)
+}
+
+// waitDelivery 等待投递记录离开 pending 状态
+func waitDelivery(t *testing.T, s *Service) *cache.LogoutDelivery {
+	t.Helper()
+	deadline := time.Now().Add(2 * time.Second)
+	for time.Now().Before(deadline) {
+		deliveries, err := s.Deliveries(context.Background(), "app-1")
+		if err != nil {
+			t.Fatalf("Deliveries() error = %v", err)
+		}
+		if len(deliveries) == 1 && deliveries[0].Status != cache.LogoutDeliveryPending {
+			return deliveries[0]
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	t.Fatal("delivery did not finish")
+	return nil
+}
+
+func TestIssueLogoutToken(t *testing.T) {
+	domainKey := paseto.NewV4AsymmetricSecretKey()
+	domainSign := key.SingleOf(func(context.Context, string) ([]byte, error) { return domainKey.ExportBytes(), nil })
+	noKey := key.SingleOf(func(context.Context, string) ([]byte, error) { return nil, key.ErrNotFound })
+	tokenSvc := token.NewService(nil, domainSign, noKey, noKey, noKey)
+	s := &Service{tokenSvc: tokenSvc}
+
+	lt, logoutToken, err := s.issueLogoutToken(context.Background(), "sid-1", "openid-1", "app-1")
+	if err != nil {
+		t.Fatalf("issueLogoutToken() error = %v", err)
+	}
+
+	parser := paseto.NewParser()
+	parser.AddRule(paseto.IssuedBy(tokenSvc.GetIssuer()), paseto.ForAudience("app-1"), paseto.Subject("openid-1"))
+	parsed, err := parser.ParseV4Public(domainKey.Public(), logoutToken, nil)
+	if err != nil {
+		t.Fatalf("ParseV4Public() error = %v", err)
+	}
+
+	var sid string
+	if err := parsed.Get(token.ClaimSessionID, &sid); err != nil || sid != "sid-1" {
+		t.Fatalf("sid = %q, %v, want sid-1", sid, err)
+	}
+	var events map[string]map[string]any
+	if err := parsed.Get(token.ClaimEvents, &events); err != nil {
+		t.Fatalf("events error = %v", err)
+	}
+	if _, ok := events[token.EventBackchannelLogout]; !ok || len(events) != 1 {
+		t.Fatalf("events = %v, want only %s", events, token.EventBackchannelLogout)
+	}
+	if jti, err := parsed.GetJti(); err != nil || jti == "" || jti != lt.JTI() {
+		t.Fatalf("jti = %q, %v, want %q", jti, err, lt.JTI())
+	}
+	iat, err := parsed.GetIssuedAt()
+	if err != nil {
+		t.Fatalf("iat error = %v", err)
+	}
+	if exp, err := parsed.GetExpiration(); err != nil || exp.Sub(iat) != logoutTokenExpiresIn {
+		t.Fatalf("exp - iat = %v, %v, want %v", exp.Sub(iat), err, logoutTokenExpiresIn)
+	}
+	// logout_token 不得携带 nonce（OIDC Back-Channel Logout §2.4）
+	var nonce string
+	if err := parsed.Get("nonce", &nonce); err == nil {
+		t.Fatalf("logout_token carries nonce %q", nonce)
+	}
+}
+
+func TestDeliverRetriesWithBackoff(t *testing.T) {
+	const interval = 20 * time.Millisecond
+	endpoint := &logoutEndpoint{statuses: []int{http.StatusServiceUnavailable, http.StatusTooManyRequests, http.StatusOK}}
+	s, uri := newDeliveryService(t, context.Background(), endpoint, interval)
+
+	startDelivery(s, uri)
+	d := waitDelivery(t, s)
+	if d.Status != cache.LogoutDeliveryDelivered || d.Attempts != 3 || d.StatusCode != http.StatusOK || d.Error != "" {
+		t.Fatalf("delivery = %+v, want delivered on attempt 3", d)
+	}
+
+	hits := endpoint.requests()
+	if len(hits) != 3 {
+		t.Fatalf("requests = %d, want 3", len(hits))
+	}
+	// 重试间隔逐次翻倍
+	if gap := hits[1].Sub(hits[0]); gap < interval {
+		t.Fatalf("first retry after %v, want >= %v", gap, interval)
+	}
+	if gap := hits[2].Sub(hits[1]); gap < 2*interval {
+		t.Fatalf("second retry after %v, want >= %v", gap, 2*interval)
+	}
+}
+
+func TestDeliverGivesUpAfterLastAttempt(t *testing.T) {
+	tests := []struct {
+		name         string
+		status       int
+		wantAttempts int
+	}{
+		{name: "server error until max attempts", status: http.StatusBadGateway, wantAttempts: 3},
+		{name: "rejected without retry", status: http.StatusBadRequest, wantAttempts: 1},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			endpoint := &logoutEndpoint{statuses: []int{tt.status}}
+			s, uri := newDeliveryService(t, context.Background(), endpoint, time.Millisecond)
+
+			startDelivery(s, uri)
+			d := waitDelivery(t, s)
+			if d.Status != cache.LogoutDeliveryFailed || d.Attempts != tt.wantAttempts || d.StatusCode != tt.status {
+				t.Fatalf("delivery = %+v, want failed after %d attempts", d, tt.wantAttempts)
+			}
+
+			// 放弃后不再有请求
+			time.Sleep(20 * time.Millisecond)
+			if hits := len(endpoint.requests()); hits != tt.wantAttempts {
+				t.Fatalf("requests = %d, want %d", hits, tt.wantAttempts)
+			}
+		})
+	}
+}
+
+func TestDeliverAbandonsRetryOnShutdown(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	endpoint := &logoutEndpoint{statuses: []int{http.StatusServiceUnavailable}}
+	s, uri := newDeliveryService(t, ctx, endpoint, time.Hour)
+
+	startDelivery(s, uri)
+	cancel()
+
+	d := waitDelivery(t, s)
+	if d.Status != cache.LogoutDeliveryFailed || d.Attempts != 1 || !strings.Contains(d.Error, "retry abandoned") {
+		t.Fatalf("delivery = %+v, want failed with abandoned retry", d)
+	}
+	if hits := len(endpoint.requests()); hits != 1 {
+		t.Fatalf("requests = %d, want 1", hits)
+	}
+}
@@ -0,0 +1,44 @@
+package logout
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	baseconfig "github.com/heliannuuthus/pkg/config"
+)
+
+// TestMain 以空配置加载 aegis 配置单例，缓存 key 前缀、issuer 等均取默认值
+func TestMain(m *testing.M) {
+	os.Exit(run(m))
+}
+
+func run(m *testing.M) int {
+	dir, err := os.MkdirTemp("", "aegis-logout-test")
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "create config dir: %v\n", err)
+		return 1
+	}
+	defer func() { _ = os.RemoveAll(dir) }()
+	if err := os.WriteFile(filepath.Join(dir, baseconfig.ConfigFile+".toml"), nil, 0o600); err != nil {
+		fmt.Fprintf(os.Stderr, "write config: %v\n", err)
+		return 1
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "get working directory: %v\n", err)
+		return 1
+	}
+	if err := os.Chdir(dir); err != nil {
+		fmt.Fprintf(os.Stderr, "chdir: %v\n", err)
+		return 1
+	}
+	baseconfig.LoadAegis()
+	if err := os.Chdir(wd); err != nil {
+		fmt.Fprintf(os.Stderr, "restore working directory: %v\n", err)
+		return 1
+	}
+	return m.Run()
+}
@@ -0,0 +1,269 @@
+package logout
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/netip"
+	"net/url"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/token"
+	"github.com/heliannuuthus/pkg/async"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// logoutTokenExpiresIn logout_token 有效期，覆盖全部重试窗口即可
+const logoutTokenExpiresIn = 5 * time.Minute
+
+// errNonPublicAddress 通知地址解析到了回环、链路本地或私有地址
+var errNonPublicAddress = errors.New("backchannel logout uri resolves to a non-public address")
+
+// Service 后端通道登出服务（OIDC Back-Channel Logout 1.0）
+//   - 应用在 SSO 会话中完成授权时记录为会话参与方
+//   - 会话结束（登出 / 吊销用户会话）时向配置了 backchannel_logout_uri 的参与方 POST 签名的 logout_token
+//   - 投递通过 async.Pool 异步执行，网络错误与 5xx 按指数退避重试（等待期间释放 worker），每次结果写入应用的投递记录
+//   - 投递尽力而为：待投递与等待重试的通知只保存在进程内，服务生命周期 ctx 结束后不再重试，对应记录标记为失败
+//   - 建立连接时校验解析后的 IP，默认拒绝回环、链路本地与私有地址（DNS 解析结果不可信，注册时的校验不足以防 SSRF）
+type Service struct {
+	ctx           context.Context // 服务生命周期，投递与重试均在其下执行
+	cache         *cache.Manager
+	tokenSvc      *token.Service
+	pool          *async.Pool
+	client        *http.Client
+	maxAttempts   int
+	retryInterval time.Duration
+}
+
+// NewService ctx 为服务生命周期（随进程退出取消），请求结束不影响已提交的投递
+func NewService(ctx context.Context, cache *cache.Manager, tokenSvc *token.Service, pool *async.Pool) *Service {
+	dialer := &net.Dialer{}
+	if !config.GetBackchannelLogoutAllowPrivateNetworks() {
+		dialer.Control = publicAddressOnly
+	}
+	transport := http.DefaultTransport.(*http.Transport).Clone()
+	transport.Proxy = nil // 经代理时校验的是代理地址而非通知地址
+	transport.DialContext = dialer.DialContext
+
+	return &Service{
+		ctx:      ctx,
+		cache:    cache,
+		tokenSvc: tokenSvc,
+		pool:     pool,
+		client: &http.Client{
+			Transport: transport,
+			Timeout:   config.GetBackchannelLogoutTimeout(),
+			// 通知端点不应重定向（OIDC Back-Channel Logout §2.8）
+			CheckRedirect: func(*http.Request, []*http.Request) error { return http.ErrUseLastResponse },
+		},
+		maxAttempts:   config.GetBackchannelLogoutMaxAttempts(),
+		retryInterval: config.GetBackchannelLogoutRetryInterval(),
+	}
+}
+
+// Track 记录应用参与了 SSO 会话（会话记录有效期与 SSO Token 一致）
+func (s *Service) Track(ctx context.Context, sid, openid, appID string) {
+	if sid == "" || openid == "" || appID == "" {
+		return
+	}
+	if err := s.cache.AddSSOSessionParticipant(ctx, sid, openid, appID, config.GetSSOTTL()); err != nil {
+		logger.Warnf("[Logout] 记录会话参与方失败 - SID: %s, AppID: %s, Error: %v", sid, appID, err)
+	}
+}
+
+// EndSession 结束 SSO 会话，通知全部参与的应用（同一会话只通知一次）
+func (s *Service) EndSession(ctx context.Context, sid string) {
+	if sid == "" {
+		return
+	}
+	participants, err := s.cache.TakeSSOSessionParticipants(ctx, sid)
+	if err != nil {
+		logger.Warnf("[Logout] 读取会话参与方失败 - SID: %s, Error: %v", sid, err)
+		return
+	}
+	for appID, openid := range participants {
+		s.notify(ctx, sid, openid, appID)
+	}
+	if len(participants) > 0 {
+		logger.Infof("[Logout] 结束 SSO 会话 - SID: %s, Apps: %d", sid, len(participants))
+	}
+}
+
+// EndSubjectSessions 结束用户参与过的全部 SSO 会话（登出、吊销用户会话）
+func (s *Service) EndSubjectSessions(ctx context.Context, openid string) {
+	sids, err := s.cache.TakeUserSSOSessions(ctx, openid)
+	if err != nil {
+		logger.Warnf("[Logout] 读取用户会话失败 - OpenID: %s, Error: %v", openid, err)
+		return
+	}
+	for _, sid := range sids {
+		s.EndSession(ctx, sid)
+	}
+}
+
+// Deliveries 列出应用的投递记录
+func (s *Service) Deliveries(ctx context.Context, appID string) ([]*cache.LogoutDelivery, error) {
+	return s.cache.ListLogoutDeliveries(ctx, appID)
+}
+
+// notify 为应用签发 logout_token 并提交异步投递，应用未配置 backchannel_logout_uri 时跳过
+func (s *Service) notify(ctx context.Context, sid, openid, appID string) {
+	app, err := s.cache.GetApplication(ctx, appID)
+	if err != nil {
+		logger.Warnf("[Logout] 获取应用失败 - AppID: %s, Error: %v", appID, err)
+		return
+	}
+	uri := app.GetBackchannelLogoutURI()
+	if uri == "" {
+		return
+	}
+
+	lt, logoutToken, err := s.issueLogoutToken(ctx, sid, openid, appID)
+	if err != nil {
+		logger.Errorf("[Logout] 签发 logout_token 失败 - AppID: %s, Error: %v", appID, err)
+		return
+	}
+
+	now := time.Now()
+	delivery := &cache.LogoutDelivery{
+		JTI:       lt.JTI(),
+		SessionID: sid,
+		OpenID:    openid,
+		URI:       uri,
+		Status:    cache.LogoutDeliveryPending,
+		CreatedAt: now,
+		UpdatedAt: now,
+	}
+	s.save(ctx, appID, delivery)
+
+	// 投递不随请求结束而取消，使用服务生命周期 ctx
+	s.pool.Go(func() {
+		s.deliver(s.ctx, appID, delivery, logoutToken, 1, s.retryInterval)
+	})
+}
+
+// issueLogoutToken 签发通知应用 appID 结束会话 sid 的 logout_token
+func (s *Service) issueLogoutToken(ctx context.Context, sid, openid, appID string) (*token.LogoutToken, string, error) {
+	lt := token.NewClaimsBuilder().
+		Issuer(s.tokenSvc.GetIssuer()).
+		Subject(openid).
+		Audience(appID).
+		ExpiresIn(logoutTokenExpiresIn).
+		Build(token.NewLogoutTokenBuilder().SessionID(sid)).(*token.LogoutToken)
+	logoutToken, err := s.tokenSvc.Issue(ctx, lt)
+	if err != nil {
+		return nil, "", err
+	}
+	return lt, logoutToken, nil
+}
+
+// deliver 执行一次投递，可重试的失败按指数退避安排下一次尝试，直至 maxAttempts
+func (s *Service) deliver(ctx context.Context, appID string, d *cache.LogoutDelivery, logoutToken string, attempt int, interval time.Duration) {
+	statusCode, err := s.post(ctx, d.URI, logoutToken)
+	d.Attempts = attempt
+	d.StatusCode = statusCode
+	d.UpdatedAt = time.Now()
+
+	if err == nil {
+		d.Status = cache.LogoutDeliveryDelivered
+		d.Error = ""
+		s.save(ctx, appID, d)
+		logger.Infof("[Logout] 通知投递成功 - AppID: %s, SID: %s, Attempts: %d", appID, d.SessionID, attempt)
+		return
+	}
+
+	d.Error = err.Error()
+	if attempt >= s.maxAttempts || !retryable(statusCode) || errors.Is(err, errNonPublicAddress) {
+		d.Status = cache.LogoutDeliveryFailed
+		s.save(ctx, appID, d)
+		logger.Warnf("[Logout] 通知投递失败 - AppID: %s, SID: %s, Attempts: %d, Error: %v", appID, d.SessionID, attempt, err)
+		return
+	}
+
+	s.save(ctx, appID, d)
+	s.retryAfter(ctx, interval, func(ctx context.Context) {
+		s.deliver(ctx, appID, d, logoutToken, attempt+1, interval*2)
+	}, func(cause error) {
+		s.abandon(ctx, appID, d, cause)
+	})
+}
+
+// retryAfter 延迟 delay 后将 fn 重新提交到任务池；等待期间不占用池中的 worker，ctx 结束时放弃重试并调用 giveUp
+func (s *Service) retryAfter(ctx context.Context, delay time.Duration, fn func(ctx context.Context), giveUp func(cause error)) {
+	timer := time.NewTimer(delay)
+	go func() {
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			giveUp(ctx.Err())
+		case <-timer.C:
+			s.pool.Go(func() { fn(ctx) })
+		}
+	}()
+}
+
+// abandon 服务停止时放弃等待重试的投递，记录标记为失败（ctx 已结束，写入记录使用独立的短超时）
+func (s *Service) abandon(ctx context.Context, appID string, d *cache.LogoutDelivery, cause error) {
+	d.Status = cache.LogoutDeliveryFailed
+	d.Error = fmt.Sprintf("retry abandoned: %v", cause)
+	d.UpdatedAt = time.Now()
+
+	saveCtx, cancel := context.WithTimeout(context.WithoutCancel(ctx), time.Second)
+	defer cancel()
+	s.save(saveCtx, appID, d)
+	logger.Warnf("[Logout] 放弃重试投递 - AppID: %s, SID: %s, Attempts: %d, Error: %v", appID, d.SessionID, d.Attempts, cause)
+}
+
+// post 以表单 POST logout_token，2xx 视为成功
+func (s *Service) post(ctx context.Context, uri, logoutToken string) (int, error) {
+	form := url.Values{"logout_token": {logoutToken}}
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, uri, strings.NewReader(form.Encode()))
+	if err != nil {
+		return 0, fmt.Errorf("create request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return 0, fmt.Errorf("post logout token: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	_, _ = io.Copy(io.Discard, io.LimitReader(resp.Body, 4096))
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return resp.StatusCode, fmt.Errorf("unexpected status: %d", resp.StatusCode)
+	}
+	return resp.StatusCode, nil
+}
+
+func (s *Service) save(ctx context.Context, appID string, d *cache.LogoutDelivery) {
+	if err := s.cache.SaveLogoutDelivery(ctx, appID, d); err != nil {
+		logger.Warnf("[Logout] 写入投递记录失败 - AppID: %s, Error: %v", appID, err)
+	}
+}
+
+// publicAddressOnly net.Dialer.Control：只允许连接公网地址（在 DNS 解析之后、建立连接之前执行）
+func publicAddressOnly(_, address string, _ syscall.RawConn) error {
+	host, _, err := net.SplitHostPort(address)
+	if err != nil {
+		return err
+	}
+	addr, err := netip.ParseAddr(host)
+	if err != nil || !helpers.IsPublicAddr(addr) {
+		return fmt.Errorf("%w: %s", errNonPublicAddress, host)
+	}
+	return nil
+}
+
+// retryable 网络错误、429 与 5xx 可重试；其余 4xx 表示应用拒绝了 logout_token，重试无意义
+func retryable(statusCode int) bool {
+	return statusCode == 0 || statusCode == http.StatusTooManyRequests || statusCode >= 500
+}
@@ -0,0 +1,109 @@
+package logout
+
+import (
+	"context"
+	"errors"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/heliannuuthus/pkg/async"
+)
+
+func TestPost(t *testing.T) {
+	var got string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if ct := r.Header.Get("Content-Type"); ct != "application/x-www-form-urlencoded" {
+			t.Errorf("Content-Type = %q", ct)
+		}
+		got = r.PostFormValue("logout_token")
+		if got == "rejected" {
+			w.WriteHeader(http.StatusBadRequest)
+		}
+	}))
+	defer srv.Close()
+
+	s := &Service{client: srv.Client()}
+
+	status, err := s.post(context.Background(), srv.URL, "v4.public.token")
+	if err != nil || status != http.StatusOK {
+		t.Fatalf("post() = %d, %v", status, err)
+	}
+	if got != "v4.public.token" {
+		t.Fatalf("logout_token = %q", got)
+	}
+
+	status, err = s.post(context.Background(), srv.URL, "rejected")
+	if err == nil || status != http.StatusBadRequest {
+		t.Fatalf("post() = %d, %v, want 400 error", status, err)
+	}
+}
+
+func TestPostRefusesNonPublicAddress(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(http.ResponseWriter, *http.Request) {
+		t.Error("request reached a loopback listener")
+	}))
+	defer srv.Close()
+
+	transport := srv.Client().Transport.(*http.Transport).Clone()
+	transport.DialContext = (&net.Dialer{Control: publicAddressOnly}).DialContext
+	s := &Service{client: &http.Client{Transport: transport}}
+
+	_, err := s.post(context.Background(), srv.URL, "v4.public.token")
+	if !errors.Is(err, errNonPublicAddress) {
+		t.Fatalf("post() error = %v, want errNonPublicAddress", err)
+	}
+}
+
+func TestRetryable(t *testing.T) {
+	tests := []struct {
+		status int
+		want   bool
+	}{
+		{status: 0, want: true},
+		{status: http.StatusTooManyRequests, want: true},
+		{status: http.StatusServiceUnavailable, want: true},
+		{status: http.StatusBadRequest, want: false},
+		{status: http.StatusNotImplemented, want: true},
+	}
+	for _, tt := range tests {
+		if got := retryable(tt.status); got != tt.want {
+			t.Errorf("retryable(%d) = %v, want %v", tt.status, got, tt.want)
+		}
+	}
+}
+
+func TestRetryAfter(t *testing.T) {
+	pool, err := async.NewPool(1)
+	if err != nil {
+		t.Fatalf("NewPool() error = %v", err)
+	}
+	defer pool.Release()
+	s := &Service{pool: pool}
+
+	fired := make(chan struct{}, 1)
+	gaveUp := make(chan error, 1)
+	giveUp := func(cause error) { gaveUp <- cause }
+	s.retryAfter(context.Background(), time.Millisecond, func(context.Context) { fired <- struct{}{} }, giveUp)
+	select {
+	case <-fired:
+	case <-time.After(time.Second):
+		t.Fatal("retry was not scheduled")
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	s.retryAfter(ctx, 10*time.Millisecond, func(context.Context) { fired <- struct{}{} }, giveUp)
+	select {
+	case <-fired:
+		t.Fatal("retry fired after context was canceled")
+	case cause := <-gaveUp:
+		if !errors.Is(cause, context.Canceled) {
+			t.Fatalf("giveUp cause = %v, want context.Canceled", cause)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("giveUp was not called after context was canceled")
+	}
+}
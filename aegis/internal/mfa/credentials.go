@@ -37,6 +37,8 @@ func (s *CredentialService) Status(ctx context.Context, openid string) (*models.
 			status.WebAuthnCount++
 		case models.CredentialTypePasskey:
 			status.PasskeyCount++
+		case models.CredentialTypeRecoveryCode:
+			status.RecoveryCodesRemaining++
 		}
 	}
 	return status, nil
@@ -50,7 +52,8 @@ func (s *CredentialService) List(ctx context.Context, openid string) ([]models.C
 
 	summaries := make([]models.CredentialSummary, 0, len(credentials))
 	for _, cred := range credentials {
-		if !credentialActiveInMFA(&cred) {
+		// 恢复码只在 MFAStatus 中汇总剩余数量，不逐条展示
+		if !credentialActiveInMFA(&cred) || models.CredentialType(cred.Type) == models.CredentialTypeRecoveryCode {
 			continue
 		}
 		summary := models.CredentialSummary{
@@ -4,6 +4,7 @@ import (
 	"context"
 	"net/http"
 
+	"github.com/heliannuuthus/aegis/internal/authenticator/recovery"
 	"github.com/heliannuuthus/aegis/internal/authenticator/totp"
 	"github.com/heliannuuthus/aegis/internal/authenticator/webauthn"
 	"github.com/heliannuuthus/aegis/internal/cache"
@@ -15,23 +16,34 @@ import (
 type Service struct {
 	credentials *CredentialService
 	totp        *totp.Service
+	recovery    *recovery.Service
 	webauthnSvc *webauthn.Service
 }
 
 func NewService(credentials *hermes.Client, cacheManager *cache.Manager, webauthnSvc *webauthn.Service) *Service {
 	credentialSvc := NewCredentialService(credentials)
 	totpSvc := totp.NewService(credentials, cacheManager)
+	recoverySvc := recovery.NewService(credentials)
 	return &Service{
 		credentials: credentialSvc,
 		totp:        totpSvc,
+		recovery:    recoverySvc,
 		webauthnSvc: webauthnSvc,
 	}
 }
 
+func (s *Service) Credentials() *CredentialService {
+	return s.credentials
+}
+
 func (s *Service) TOTP() *totp.Service {
 	return s.totp
 }
 
+func (s *Service) RecoveryCodes() *recovery.Service {
+	return s.recovery
+}
+
 func (s *Service) GetRPID() string {
 	return s.webauthnSvc.GetRPID()
 }
@@ -44,6 +56,11 @@ func (s *Service) ConfirmTOTPEnrollment(ctx context.Context, openid, uid, code s
 	return s.totp.ConfirmEnrollment(ctx, openid, uid, code)
 }
 
+// GenerateRecoveryCodes 生成一组新的恢复码（旧的恢复码作废）
+func (s *Service) GenerateRecoveryCodes(ctx context.Context, openid string) ([]string, error) {
+	return s.recovery.Generate(ctx, openid)
+}
+
 func (s *Service) UpdateCredential(ctx context.Context, openid, credType, credentialID string, updates map[string]any) error {
 	return s.credentials.Update(ctx, openid, credType, credentialID, updates)
 }
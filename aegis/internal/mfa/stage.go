@@ -0,0 +1,158 @@
+package mfa
+
+import (
+	"context"
+	"fmt"
+	"slices"
+	"time"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/risk"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// BizTypeMFA MFA 阶段第二因子 Challenge 的业务场景
+// 其他场景（forget_password、登录等）签发的 ChallengeToken 不能用于完成 MFA
+const BizTypeMFA = "mfa"
+
+// TokenVerifier ChallengeToken 验证能力
+type TokenVerifier interface {
+	Verify(ctx context.Context, tokenString string) (tokendef.Token, error)
+}
+
+// TokenClaimer ChallengeToken 一次性使用标记能力
+type TokenClaimer interface {
+	ClaimChallengeToken(ctx context.Context, jti string, ttl time.Duration) (bool, error)
+}
+
+// Stage 风险驱动的 MFA 阶段
+//
+// 授权完成后、签发授权码前评估风险；需要时从用户已登记的凭证中选出第二因子，
+// 用户通过 Challenge 完成验证后提交 ChallengeToken，校验通过才继续签发授权码。
+type Stage struct {
+	evaluator   risk.Evaluator
+	credentials *CredentialService
+	tokens      TokenVerifier
+	claims      TokenClaimer
+}
+
+func NewStage(evaluator risk.Evaluator, credentials *CredentialService, tokens TokenVerifier, claims TokenClaimer) *Stage {
+	return &Stage{
+		evaluator:   evaluator,
+		credentials: credentials,
+		tokens:      tokens,
+		claims:      claims,
+	}
+}
+
+// Evaluate 评估风险并选择第二因子，返回的 channels 为空表示无需 MFA
+// 风险等级为 blocked、或需要 MFA 但用户未登记任何可用因子时返回 access_denied（失败即拒绝）
+func (s *Stage) Evaluate(ctx context.Context, flow *types.AuthFlow, rc *risk.Context) (*risk.Assessment, []string, error) {
+	assessment, err := s.evaluator.Evaluate(ctx, rc)
+	if err != nil {
+		return nil, nil, fmt.Errorf("evaluate risk: %w", err)
+	}
+	if assessment.Level == risk.LevelBlocked {
+		logger.Warnf("[MFA] 风险评估拒绝登录 - FlowID: %s, OpenID: %s, Reason: %s", flow.ID, rc.UserID, assessment.Reason)
+		return assessment, nil, autherrors.NewAccessDenied("login blocked by risk policy")
+	}
+	if !assessment.RequireMFA {
+		return assessment, nil, nil
+	}
+
+	status, err := s.credentials.Status(ctx, rc.UserID)
+	if err != nil {
+		return nil, nil, fmt.Errorf("query mfa status: %w", err)
+	}
+	channels := SecondFactors(status, usedFactors(flow), authenticator.GlobalRegistry().Has)
+	if len(channels) == 0 {
+		logger.Warnf("[MFA] 风险评估要求 MFA 但用户无可用第二因子，拒绝登录 - FlowID: %s, OpenID: %s, Level: %s, Reason: %s",
+			flow.ID, rc.UserID, assessment.Level, assessment.Reason)
+		return assessment, nil, autherrors.NewAccessDenied("mfa required but no second factor is enrolled")
+	}
+	return assessment, channels, nil
+}
+
+// Complete 校验 MFA 阶段提交的 ChallengeToken：
+// mfa 业务场景、签发给当前应用、subject 为当前用户、channel_type 在允许列表中，且 jti 未被使用过（一次性）
+func (s *Stage) Complete(ctx context.Context, flow *types.AuthFlow, challengeToken string) error {
+	t, err := s.tokens.Verify(ctx, challengeToken)
+	if err != nil {
+		logger.Warnf("[MFA] challenge-token 验证失败 - FlowID: %s, Error: %v", flow.ID, err)
+		return autherrors.NewInvalidCredentials("invalid challenge token")
+	}
+	ct, ok := t.(*tokendef.ChallengeToken)
+	if !ok {
+		return autherrors.NewInvalidCredentials("proof is not a challenge token")
+	}
+	if ct.GetType() != BizTypeMFA {
+		return autherrors.NewInvalidCredentialsf("challenge token type %q is not allowed for mfa", ct.GetType())
+	}
+	if ct.ClientID() != flow.Application.AppID {
+		return autherrors.NewInvalidCredentials("challenge token was not issued to this client")
+	}
+	if ct.Subject() != flow.User.OpenID {
+		return autherrors.NewInvalidCredentials("challenge token subject does not match the user")
+	}
+	if !slices.Contains(flow.MFAAllowedChannels, string(ct.GetChannelType())) {
+		return autherrors.NewInvalidCredentialsf("channel type %q is not allowed for mfa", ct.GetChannelType())
+	}
+	claimed, err := s.claims.ClaimChallengeToken(ctx, ct.JTI(), time.Until(ct.ExpiresAt()))
+	if err != nil {
+		return autherrors.NewServerErrorf("claim challenge token failed: %v", err)
+	}
+	if !claimed {
+		return autherrors.NewInvalidCredentials("challenge token has already been used")
+	}
+	logger.Infof("[MFA] 第二因子验证通过 - FlowID: %s, OpenID: %s, Channel: %s", flow.ID, flow.User.OpenID, ct.GetChannelType())
+	return nil
+}
+
+// Record 登录完成后记录风险基线
+func (s *Stage) Record(ctx context.Context, rc *risk.Context) {
+	if err := s.evaluator.Record(ctx, rc); err != nil {
+		logger.Warnf("[MFA] 记录登录基线失败 - OpenID: %s, Error: %v", rc.UserID, err)
+	}
+}
+
+// SecondFactors 根据已登记凭证选出可用的第二因子（channel_type），排除主认证已使用的因子
+// 恢复码作为兜底因子，仅在用户登记了其他因子时提供
+func SecondFactors(status *models.MFAStatus, used []string, available func(string) bool) []string {
+	var enrolled []string
+	if status.TOTPEnabled {
+		enrolled = append(enrolled, string(types.ChannelTypeTOTP))
+	}
+	if status.WebAuthnCount+status.PasskeyCount > 0 {
+		enrolled = append(enrolled, string(types.ChannelTypeWebAuthn))
+	}
+	if len(enrolled) > 0 && status.RecoveryCodesRemaining > 0 {
+		enrolled = append(enrolled, string(types.ChannelTypeRecoveryCode))
+	}
+
+	channels := make([]string, 0, len(enrolled))
+	for _, channel := range enrolled {
+		if !slices.Contains(used, channel) && available(channel) {
+			channels = append(channels, channel)
+		}
+	}
+	return channels
+}
+
+// usedFactors 主认证已使用的因子：已验证的 factor connection，以及 passkey / webauthn 策略
+func usedFactors(flow *types.AuthFlow) []string {
+	var used []string
+	for name, cfg := range flow.ConnectionMap {
+		if cfg.Type == types.ConnTypeFactor && cfg.Verified {
+			used = append(used, name)
+		}
+	}
+	if flow.Connection == idp.TypePasskey || flow.GetExtra(types.ExtraKeyStrategy) == string(types.ChannelTypeWebAuthn) {
+		used = append(used, string(types.ChannelTypeWebAuthn))
+	}
+	return used
+}
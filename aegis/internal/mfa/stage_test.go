@@ -0,0 +1,99 @@
+package mfa
+
+import (
+	"context"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+type stubVerifier struct{ token tokendef.Token }
+
+func (v stubVerifier) Verify(context.Context, string) (tokendef.Token, error) { return v.token, nil }
+
+type memoryClaimer map[string]bool
+
+func (m memoryClaimer) ClaimChallengeToken(_ context.Context, jti string, _ time.Duration) (bool, error) {
+	if m[jti] {
+		return false, nil
+	}
+	m[jti] = true
+	return true, nil
+}
+
+func newChallengeToken(typ string) tokendef.Token {
+	return tokendef.NewClaimsBuilder().
+		Issuer("aegis").
+		ClientID("app").
+		Audience("app").
+		ExpiresIn(time.Minute).
+		Build(tokendef.NewChallengeTokenBuilder().Subject("openid-1").Type(typ).ChannelType(tokendef.ChannelType(types.ChannelTypeTOTP)))
+}
+
+func newMFAFlow() *types.AuthFlow {
+	return &types.AuthFlow{
+		Application:        &models.Application{AppID: "app"},
+		User:               &models.UserWithDecrypted{User: models.User{OpenID: "openid-1"}},
+		MFAAllowedChannels: []string{string(types.ChannelTypeTOTP)},
+	}
+}
+
+func TestCompleteConsumesChallengeToken(t *testing.T) {
+	stage := NewStage(nil, nil, stubVerifier{token: newChallengeToken(BizTypeMFA)}, memoryClaimer{})
+	flow := newMFAFlow()
+
+	if err := stage.Complete(context.Background(), flow, "token"); err != nil {
+		t.Fatalf("Complete() error = %v", err)
+	}
+	if err := stage.Complete(context.Background(), flow, "token"); err == nil {
+		t.Fatal("Complete() replay error = nil, want error")
+	}
+}
+
+func TestCompleteRejectsOtherChallengeTypes(t *testing.T) {
+	for _, typ := range []string{"forget_password", "login", "user:verify"} {
+		claims := memoryClaimer{}
+		stage := NewStage(nil, nil, stubVerifier{token: newChallengeToken(typ)}, claims)
+		if err := stage.Complete(context.Background(), newMFAFlow(), "token"); err == nil {
+			t.Fatalf("Complete(type=%q) error = nil, want error", typ)
+		}
+		if len(claims) != 0 {
+			t.Fatalf("Complete(type=%q) claimed the token", typ)
+		}
+	}
+}
+
+func TestSecondFactors(t *testing.T) {
+	all := func(string) bool { return true }
+	tests := []struct {
+		name      string
+		status    models.MFAStatus
+		used      []string
+		available func(string) bool
+		want      []string
+	}{
+		{name: "nothing enrolled", available: all, want: []string{}},
+		{name: "totp and passkey", status: models.MFAStatus{TOTPEnabled: true, PasskeyCount: 1}, available: all, want: []string{"totp", "webauthn"}},
+		{name: "primary factor excluded", status: models.MFAStatus{TOTPEnabled: true, WebAuthnCount: 2}, used: []string{"totp"}, available: all, want: []string{"webauthn"}},
+		{name: "recovery codes with totp", status: models.MFAStatus{TOTPEnabled: true, RecoveryCodesRemaining: 3}, available: all, want: []string{"totp", "recovery-code"}},
+		{name: "recovery codes alone", status: models.MFAStatus{RecoveryCodesRemaining: 3}, available: all, want: []string{}},
+		{
+			name:      "unregistered factor excluded",
+			status:    models.MFAStatus{TOTPEnabled: true, WebAuthnCount: 1},
+			available: func(channel string) bool { return channel == "totp" },
+			want:      []string{"totp"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := SecondFactors(&tt.status, tt.used, tt.available); !slices.Equal(got, tt.want) {
+				t.Fatalf("SecondFactors() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
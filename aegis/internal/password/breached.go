@@ -0,0 +1,84 @@
+package password
+
+import (
+	"bufio"
+	"crypto/sha1" // #nosec G505 -- 泄露密码库以 SHA-1 索引，仅用于查表，不用于存储密码
+	"encoding/hex"
+	"fmt"
+	"io"
+	"os"
+	"slices"
+	"strings"
+)
+
+const breachedPrefixLen = 5
+
+// BreachedList 离线泄露密码库
+//
+// 文件每行一个密码的 SHA-1（40 位十六进制，大小写不敏感），可带 ":count" 后缀，与 HIBP 导出格式一致。
+// 加载后按 5 位前缀分组（同 k-anonymity range 查询），查询时只比对同前缀的后缀，无需访问网络。
+type BreachedList struct {
+	ranges map[string][]string // 前缀 → 已排序的后缀
+	count  int
+}
+
+// LoadBreachedList 加载泄露密码库文件；path 为空时返回 nil（不做泄露检查）
+func LoadBreachedList(path string) (*BreachedList, error) {
+	if path == "" {
+		return nil, nil
+	}
+	f, err := os.Open(path) // #nosec G304 -- 路径来自部署配置
+	if err != nil {
+		return nil, fmt.Errorf("open breached password file: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	return parseBreachedList(f)
+}
+
+func parseBreachedList(r io.Reader) (*BreachedList, error) {
+	list := &BreachedList{ranges: make(map[string][]string)}
+	scanner := bufio.NewScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		entry := strings.TrimSpace(scanner.Text())
+		if entry == "" || strings.HasPrefix(entry, "#") {
+			continue
+		}
+		digest, _, _ := strings.Cut(entry, ":")
+		digest = strings.ToUpper(digest)
+		if len(digest) != sha1.Size*2 {
+			return nil, fmt.Errorf("breached password file line %d: invalid sha-1 %q", line, digest)
+		}
+		if _, err := hex.DecodeString(digest); err != nil {
+			return nil, fmt.Errorf("breached password file line %d: invalid sha-1 %q", line, digest)
+		}
+		prefix, suffix := digest[:breachedPrefixLen], digest[breachedPrefixLen:]
+		list.ranges[prefix] = append(list.ranges[prefix], suffix)
+		list.count++
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("read breached password file: %w", err)
+	}
+	for prefix := range list.ranges {
+		slices.Sort(list.ranges[prefix])
+	}
+	return list, nil
+}
+
+// Contains 判断密码是否在泄露密码库中；l 为 nil 时始终返回 false
+func (l *BreachedList) Contains(password string) bool {
+	if l == nil {
+		return false
+	}
+	sum := sha1.Sum([]byte(password)) // #nosec G401 -- 见 import 注释
+	digest := strings.ToUpper(hex.EncodeToString(sum[:]))
+	_, found := slices.BinarySearch(l.ranges[digest[:breachedPrefixLen]], digest[breachedPrefixLen:])
+	return found
+}
+
+// Len 返回泄露密码库中的条目数
+func (l *BreachedList) Len() int {
+	if l == nil {
+		return 0
+	}
+	return l.count
+}
@@ -0,0 +1,45 @@
+package password
+
+import (
+	"strings"
+	"testing"
+)
+
+func TestBreachedList(t *testing.T) {
+	// SHA-1("password") / SHA-1("123456")
+	input := strings.Join([]string{
+		"# HIBP export",
+		"5BAA61E4C9B93F3F0682250B6CF8331B7EE68FD8:9545824",
+		"7c4a8d09ca3762af61e59520943dc26494f8941b",
+		"",
+	}, "\n")
+
+	list, err := parseBreachedList(strings.NewReader(input))
+	if err != nil {
+		t.Fatalf("parseBreachedList() error = %v", err)
+	}
+	if list.Len() != 2 {
+		t.Fatalf("Len() = %d, want 2", list.Len())
+	}
+	for _, breached := range []string{"password", "123456"} {
+		if !list.Contains(breached) {
+			t.Errorf("Contains(%q) = false", breached)
+		}
+	}
+	if list.Contains("correct horse battery staple") {
+		t.Error("Contains() = true for unlisted password")
+	}
+}
+
+func TestBreachedListRejectsInvalidLine(t *testing.T) {
+	if _, err := parseBreachedList(strings.NewReader("not-a-hash\n")); err == nil {
+		t.Fatal("parseBreachedList() error = nil")
+	}
+}
+
+func TestNilBreachedList(t *testing.T) {
+	var list *BreachedList
+	if list.Contains("password") || list.Len() != 0 {
+		t.Fatal("nil list should not report breached passwords")
+	}
+}
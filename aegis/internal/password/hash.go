@@ -0,0 +1,113 @@
+package password
+
+import (
+	"crypto/rand"
+	"crypto/subtle"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"strings"
+
+	"golang.org/x/crypto/argon2"
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/heliannuuthus/aegis/config"
+)
+
+// PasswordHash 以 PHC 字符串格式保存，算法与参数随哈希一起存储：
+//
+//	$argon2id$v=19$m=65536,t=3,p=2$<salt>$<hash>
+//
+// 历史数据为 bcrypt（$2a$ / $2b$ / $2y$），仍可校验，登录成功时升级为 argon2id。
+const (
+	argon2idPrefix = "$argon2id$"
+	argon2SaltLen  = 16
+	argon2KeyLen   = 32
+)
+
+var (
+	errUnsupportedHash = errors.New("unsupported password hash format")
+	phcEncoding        = base64.RawStdEncoding
+)
+
+// Hash 以当前 argon2id 参数计算密码哈希
+func Hash(password string) (string, error) {
+	return hashArgon2id(password, config.GetPasswordArgon2Config())
+}
+
+// Verify 校验密码；rehash 为 true 表示哈希为旧格式或参数已调整，调用方应以 Hash 重新计算并保存
+func Verify(encoded, password string) (ok, rehash bool, err error) {
+	ok, stored, err := compare(encoded, password)
+	if err != nil || !ok {
+		return false, false, err
+	}
+	return true, needsRehash(stored, config.GetPasswordArgon2Config()), nil
+}
+
+// needsRehash stored 为 nil 表示 bcrypt 哈希
+func needsRehash(stored, current *config.Argon2Config) bool {
+	return stored == nil || *stored != *current
+}
+
+// compare 校验密码，返回 argon2id 哈希使用的参数（bcrypt 哈希返回 nil）
+func compare(encoded, password string) (bool, *config.Argon2Config, error) {
+	switch {
+	case strings.HasPrefix(encoded, argon2idPrefix):
+		params, salt, key, err := decodeArgon2id(encoded)
+		if err != nil {
+			return false, nil, err
+		}
+		computed := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, uint32(len(key)))
+		return subtle.ConstantTimeCompare(computed, key) == 1, params, nil
+	case isBcrypt(encoded):
+		if err := bcrypt.CompareHashAndPassword([]byte(encoded), []byte(password)); err != nil {
+			if errors.Is(err, bcrypt.ErrMismatchedHashAndPassword) {
+				return false, nil, nil
+			}
+			return false, nil, err
+		}
+		return true, nil, nil
+	default:
+		return false, nil, errUnsupportedHash
+	}
+}
+
+func hashArgon2id(password string, params *config.Argon2Config) (string, error) {
+	salt := make([]byte, argon2SaltLen)
+	if _, err := rand.Read(salt); err != nil {
+		return "", fmt.Errorf("generate salt: %w", err)
+	}
+	key := argon2.IDKey([]byte(password), salt, params.Iterations, params.Memory, params.Parallelism, argon2KeyLen)
+	return fmt.Sprintf("%sv=%d$m=%d,t=%d,p=%d$%s$%s",
+		argon2idPrefix, argon2.Version, params.Memory, params.Iterations, params.Parallelism,
+		phcEncoding.EncodeToString(salt), phcEncoding.EncodeToString(key)), nil
+}
+
+// decodeArgon2id 解析 $argon2id$v=19$m=..,t=..,p=..$salt$hash
+func decodeArgon2id(encoded string) (*config.Argon2Config, []byte, []byte, error) {
+	parts := strings.Split(encoded, "$")
+	if len(parts) != 6 {
+		return nil, nil, nil, errUnsupportedHash
+	}
+	var version int
+	if _, err := fmt.Sscanf(parts[2], "v=%d", &version); err != nil || version != argon2.Version {
+		return nil, nil, nil, fmt.Errorf("unsupported argon2 version %q", parts[2])
+	}
+	params := &config.Argon2Config{}
+	if _, err := fmt.Sscanf(parts[3], "m=%d,t=%d,p=%d", &params.Memory, &params.Iterations, &params.Parallelism); err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2 parameters: %w", err)
+	}
+	salt, err := phcEncoding.DecodeString(parts[4])
+	if err != nil {
+		return nil, nil, nil, fmt.Errorf("invalid argon2 salt: %w", err)
+	}
+	key, err := phcEncoding.DecodeString(parts[5])
+	if err != nil || len(key) == 0 {
+		return nil, nil, nil, errors.New("invalid argon2 hash")
+	}
+	return params, salt, key, nil
+}
+
+func isBcrypt(encoded string) bool {
+	return strings.HasPrefix(encoded, "$2a$") || strings.HasPrefix(encoded, "$2b$") || strings.HasPrefix(encoded, "$2y$")
+}
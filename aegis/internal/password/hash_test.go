@@ -0,0 +1,71 @@
+package password
+
+import (
+	"strings"
+	"testing"
+
+	"golang.org/x/crypto/bcrypt"
+
+	"github.com/heliannuuthus/aegis/config"
+)
+
+// testArgon2 测试用的低成本参数
+var testArgon2 = &config.Argon2Config{Memory: 1024, Iterations: 1, Parallelism: 1}
+
+func TestArgon2idRoundTrip(t *testing.T) {
+	encoded, err := hashArgon2id("correct horse", testArgon2)
+	if err != nil {
+		t.Fatalf("hashArgon2id() error = %v", err)
+	}
+	if !strings.HasPrefix(encoded, "$argon2id$v=19$m=1024,t=1,p=1$") {
+		t.Fatalf("hashArgon2id() = %q, want PHC string", encoded)
+	}
+
+	ok, stored, err := compare(encoded, "correct horse")
+	if err != nil || !ok {
+		t.Fatalf("compare(correct) = %v, %v", ok, err)
+	}
+	if needsRehash(stored, testArgon2) {
+		t.Fatal("needsRehash() = true for current parameters")
+	}
+	if !needsRehash(stored, &config.Argon2Config{Memory: 2048, Iterations: 1, Parallelism: 1}) {
+		t.Fatal("needsRehash() = false after parameter change")
+	}
+
+	if ok, _, err := compare(encoded, "wrong horse"); err != nil || ok {
+		t.Fatalf("compare(wrong) = %v, %v", ok, err)
+	}
+}
+
+func TestCompareLegacyBcrypt(t *testing.T) {
+	legacy, err := bcrypt.GenerateFromPassword([]byte("legacy-secret"), bcrypt.MinCost)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	ok, stored, err := compare(string(legacy), "legacy-secret")
+	if err != nil || !ok {
+		t.Fatalf("compare(bcrypt) = %v, %v", ok, err)
+	}
+	if !needsRehash(stored, testArgon2) {
+		t.Fatal("bcrypt hash should be upgraded")
+	}
+	if ok, _, err := compare(string(legacy), "other"); err != nil || ok {
+		t.Fatalf("compare(bcrypt, wrong) = %v, %v", ok, err)
+	}
+}
+
+func TestCompareRejectsMalformedHash(t *testing.T) {
+	for _, encoded := range []string{
+		"",
+		"plaintext",
+		"$argon2i$v=19$m=1024,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=16$m=1024,t=1,p=1$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=1024$c2FsdA$aGFzaA",
+		"$argon2id$v=19$m=1024,t=1,p=1$c2FsdA$",
+	} {
+		if _, _, err := compare(encoded, "password"); err == nil {
+			t.Errorf("compare(%q) error = nil", encoded)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+package password
+
+import (
+	"context"
+	"slices"
+
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// 密码历史在 t_user_credential 中每个旧密码存一行：type 为 password_history，secret 为旧密码的哈希。
+// enabled 恒为 false，不参与 MFA 状态与凭证列表。
+const (
+	historyCredentialIDPrefix = "ph_"
+	historyCredentialIDLength = 16
+	historyLabel              = "历史密码"
+)
+
+// listHistory 按创建时间倒序返回用户的密码历史
+func (s *Service) listHistory(ctx context.Context, openid string) ([]models.UserCredential, error) {
+	history, err := s.hermes.ListUserCredentialsByType(ctx, openid, string(models.CredentialTypePasswordHistory))
+	if err != nil {
+		return nil, err
+	}
+	slices.SortFunc(history, func(a, b models.UserCredential) int {
+		return b.CreatedAt.Compare(a.CreatedAt)
+	})
+	return history, nil
+}
+
+// reused 判断新密码是否与最近 limit 个密码（当前密码 + limit-1 条历史）之一相同
+func reused(password, current string, history []models.UserCredential, limit int) bool {
+	hashes := make([]string, 0, limit)
+	if current != "" {
+		hashes = append(hashes, current)
+	}
+	for i := range history {
+		if len(hashes) >= limit {
+			break
+		}
+		hashes = append(hashes, history[i].Secret)
+	}
+	for _, hash := range hashes {
+		if ok, _, err := compare(hash, password); err == nil && ok {
+			return true
+		}
+	}
+	return false
+}
+
+// recordHistory 将被替换的密码哈希写入历史，并只保留最近 limit-1 条（当前密码不计入历史）
+// 历史记录失败不影响已完成的密码修改，只记录日志
+func (s *Service) recordHistory(ctx context.Context, openid, previous string, history []models.UserCredential, limit int) {
+	if limit > 1 {
+		credentialID := historyCredentialIDPrefix + helpers.GenerateID(historyCredentialIDLength)
+		credential := &models.UserCredential{
+			OpenID:       openid,
+			CredentialID: &credentialID,
+			Type:         string(models.CredentialTypePasswordHistory),
+			Label:        historyLabel,
+			Secret:       previous,
+		}
+		if err := s.hermes.CreateCredential(ctx, credential); err != nil {
+			logger.Warnf("[Password] 记录密码历史失败 - OpenID: %s, Error: %v", openid, err)
+			return
+		}
+	}
+
+	keep := max(limit-2, 0) // 新写入的一条占用一个名额
+	for i := keep; i < len(history); i++ {
+		if history[i].CredentialID == nil {
+			continue
+		}
+		if err := s.hermes.DeleteCredential(ctx, openid, *history[i].CredentialID); err != nil {
+			logger.Warnf("[Password] 清理密码历史失败 - OpenID: %s, Error: %v", openid, err)
+		}
+	}
+}
@@ -0,0 +1,39 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/heliannuuthus/aegis/models"
+)
+
+func TestReused(t *testing.T) {
+	hash := func(plain string) string {
+		encoded, err := hashArgon2id(plain, testArgon2)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return encoded
+	}
+	current := hash("current-pass")
+	history := []models.UserCredential{{Secret: hash("previous-1")}, {Secret: hash("previous-2")}}
+
+	tests := []struct {
+		name     string
+		password string
+		limit    int
+		want     bool
+	}{
+		{name: "current password", password: "current-pass", limit: 1, want: true},
+		{name: "within history", password: "previous-1", limit: 2, want: true},
+		{name: "beyond limit", password: "previous-2", limit: 2, want: false},
+		{name: "whole history", password: "previous-2", limit: 3, want: true},
+		{name: "new password", password: "brand-new", limit: 3, want: false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := reused(tt.password, current, history, tt.limit); got != tt.want {
+				t.Fatalf("reused() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package password
+
+import (
+	"strings"
+	"unicode"
+	"unicode/utf8"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/models"
+)
+
+// 密码策略未通过的原因（weak_password 错误 data.reason）
+const (
+	ReasonMinLength          = "min_length"
+	ReasonMaxLength          = "max_length"
+	ReasonCharacterClasses   = "character_classes"
+	ReasonContainsIdentifier = "contains_identifier"
+	ReasonBreached           = "breached"
+	ReasonReused             = "reused"
+)
+
+// minIdentifierLen 过短的用户名 / 邮箱前缀不参与包含检查，避免误伤
+const minIdentifierLen = 3
+
+// checkPolicy 校验长度、字符类别与是否包含用户标识
+func checkPolicy(policy *config.PasswordPolicy, password string, identifiers []string) error {
+	length := utf8.RuneCountInString(password)
+	if length < policy.MinLength {
+		return autherrors.NewWeakPassword(ReasonMinLength, "password is too short")
+	}
+	if policy.MaxLength > 0 && length > policy.MaxLength {
+		return autherrors.NewWeakPassword(ReasonMaxLength, "password is too long")
+	}
+	if characterClasses(password) < policy.CharacterClasses {
+		return autherrors.NewWeakPassword(ReasonCharacterClasses, "password does not contain enough character classes")
+	}
+	if policy.DisallowIdentifier {
+		lower := strings.ToLower(password)
+		for _, identifier := range identifiers {
+			if utf8.RuneCountInString(identifier) >= minIdentifierLen && strings.Contains(lower, strings.ToLower(identifier)) {
+				return autherrors.NewWeakPassword(ReasonContainsIdentifier, "password must not contain username or email")
+			}
+		}
+	}
+	return nil
+}
+
+// characterClasses 统计密码包含的字符类别数：小写、大写、数字、其他符号
+func characterClasses(password string) int {
+	var lower, upper, digit, other bool
+	for _, r := range password {
+		switch {
+		case unicode.IsLower(r):
+			lower = true
+		case unicode.IsUpper(r):
+			upper = true
+		case unicode.IsDigit(r):
+			digit = true
+		default:
+			other = true
+		}
+	}
+	count := 0
+	for _, present := range []bool{lower, upper, digit, other} {
+		if present {
+			count++
+		}
+	}
+	return count
+}
+
+// userIdentifiers 密码中不允许出现的用户标识：用户名、邮箱及邮箱前缀
+func userIdentifiers(user *models.UserWithDecrypted) []string {
+	var identifiers []string
+	if user.Username != nil && *user.Username != "" {
+		identifiers = append(identifiers, *user.Username)
+	}
+	if user.Email != nil && *user.Email != "" {
+		identifiers = append(identifiers, *user.Email)
+		if local, _, ok := strings.Cut(*user.Email, "@"); ok && local != "" {
+			identifiers = append(identifiers, local)
+		}
+	}
+	return identifiers
+}
@@ -0,0 +1,67 @@
+package password
+
+import (
+	"testing"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/models"
+)
+
+func TestCheckPolicy(t *testing.T) {
+	policy := &config.PasswordPolicy{MinLength: 8, MaxLength: 16, CharacterClasses: 3, DisallowIdentifier: true}
+	identifiers := []string{"alice", "alice@example.com", "al"}
+
+	tests := []struct {
+		name       string
+		password   string
+		wantReason string
+	}{
+		{name: "valid", password: "Tr0ub4dor"},
+		{name: "too short", password: "Ab1!", wantReason: ReasonMinLength},
+		{name: "too long", password: "Abcdefgh12345678!", wantReason: ReasonMaxLength},
+		{name: "multibyte counted as characters", password: "密码密码密码密码", wantReason: ReasonCharacterClasses},
+		{name: "not enough classes", password: "abcdefgh12", wantReason: ReasonCharacterClasses},
+		{name: "contains username", password: "xAlice2024!", wantReason: ReasonContainsIdentifier},
+		{name: "short identifier ignored", password: "Pal1ndrome", wantReason: ""},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := checkPolicy(policy, tt.password, identifiers)
+			if tt.wantReason == "" {
+				if err != nil {
+					t.Fatalf("checkPolicy() error = %v", err)
+				}
+				return
+			}
+			authErr := autherrors.ToAuthError(err)
+			if authErr.Code != autherrors.CodeWeakPassword || authErr.Data["reason"] != tt.wantReason {
+				t.Fatalf("checkPolicy() = %v (%v), want reason %s", authErr, authErr.Data, tt.wantReason)
+			}
+		})
+	}
+}
+
+func TestCheckPolicyAllowsIdentifierWhenDisabled(t *testing.T) {
+	policy := &config.PasswordPolicy{MinLength: 8}
+	if err := checkPolicy(policy, "alice-password", []string{"alice"}); err != nil {
+		t.Fatalf("checkPolicy() error = %v", err)
+	}
+}
+
+func TestUserIdentifiers(t *testing.T) {
+	username, email := "bob", "bob.smith@example.com"
+	user := &models.UserWithDecrypted{User: models.User{Username: &username, Email: &email}}
+
+	got := userIdentifiers(user)
+	want := []string{"bob", "bob.smith@example.com", "bob.smith"}
+	if len(got) != len(want) {
+		t.Fatalf("userIdentifiers() = %v, want %v", got, want)
+	}
+	for i := range want {
+		if got[i] != want[i] {
+			t.Fatalf("userIdentifiers() = %v, want %v", got, want)
+		}
+	}
+}
@@ -0,0 +1,230 @@
+// Package password 实现账号密码（user / staff IDP）的哈希、密码策略与自助重置。
+//
+// 设置密码（修改 / 重置）统一经过 Service.SetPassword：按用户所在域的策略校验长度、字符类别、
+// 是否包含用户标识、是否在离线泄露密码库中、是否与最近使用过的密码重复，再以 argon2id 保存。
+//
+// 重置前的身份确认复用 Challenge：用户以 forget_password 场景完成 email-code / sms-code 验证，
+// 拿到 ChallengeToken 后连同新密码提交。ChallengeToken 仅可使用一次。
+package password
+
+import (
+	"context"
+	"slices"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+	"github.com/heliannuuthus/pkg/logger"
+	"github.com/heliannuuthus/pkg/mail"
+)
+
+// BizTypeForgetPassword 密码重置的 Challenge 业务场景
+const BizTypeForgetPassword = "forget_password"
+
+// resetChannels 可用于确认身份的验证方式
+var resetChannels = []types.ChannelType{types.ChannelTypeEmailOTP, types.ChannelTypeSmsOTP}
+
+// TokenVerifier ChallengeToken 验证能力
+type TokenVerifier interface {
+	Verify(ctx context.Context, tokenString string) (tokendef.Token, error)
+}
+
+// SubjectRevoker 吊销用户全部 token 并结束其会话
+type SubjectRevoker interface {
+	RevokeSubject(ctx context.Context, openid string) error
+}
+
+// Service 密码服务
+type Service struct {
+	hermes   *hermes.Client
+	cache    *cache.Manager
+	tokens   TokenVerifier
+	revoker  SubjectRevoker
+	mail     *mail.Sender
+	breached *BreachedList
+}
+
+// NewService 创建密码服务
+// mailSender 为 nil 时不发送密码已更改通知，breached 为 nil 时不做泄露密码检查
+func NewService(hermesClient *hermes.Client, cacheManager *cache.Manager, tokens TokenVerifier, revoker SubjectRevoker, mailSender *mail.Sender, breached *BreachedList) *Service {
+	return &Service{
+		hermes:   hermesClient,
+		cache:    cacheManager,
+		tokens:   tokens,
+		revoker:  revoker,
+		mail:     mailSender,
+		breached: breached,
+	}
+}
+
+// Change 修改密码；已设置过密码的用户须提供正确的旧密码
+func (s *Service) Change(ctx context.Context, openid, oldPassword, newPassword string) error {
+	user, err := s.hermes.GetUserByOpenID(ctx, openid)
+	if err != nil {
+		return autherrors.NewUserNotFound("user not found")
+	}
+	if user.PasswordHash != nil && *user.PasswordHash != "" {
+		if oldPassword == "" {
+			return autherrors.NewInvalidRequest("old password is required")
+		}
+		ok, _, err := Verify(*user.PasswordHash, oldPassword)
+		if err != nil {
+			return autherrors.NewServerErrorf("verify password failed: %v", err)
+		}
+		if !ok {
+			return autherrors.NewInvalidCredentials("old password is incorrect")
+		}
+	}
+
+	identities, err := s.hermes.ListUserIdentities(ctx, openid)
+	if err != nil {
+		return autherrors.NewServerErrorf("list identities failed: %v", err)
+	}
+	return s.SetPassword(ctx, user, userDomain(identities), newPassword)
+}
+
+// Reset 使用 forget_password 场景的 ChallengeToken 重置 connection（user / staff）账号的密码
+// 发起重置的应用取自当前 AuthFlow（由 /auth/authorize 校验过的登录会话），ChallengeToken 必须签发给该应用；
+// 成功后吊销用户全部 token、结束其会话，并向用户邮箱发送密码已更改通知
+func (s *Service) Reset(ctx context.Context, flow *types.AuthFlow, connection, challengeToken, newPassword string) error {
+	if flow.Application == nil {
+		return autherrors.NewFlowInvalid("flow has no application")
+	}
+	if connection != idp.TypeUser && connection != idp.TypeStaff {
+		return autherrors.NewInvalidRequestf("connection %s does not support password reset", connection)
+	}
+
+	ct, err := s.verifyChallengeToken(ctx, flow.Application.AppID, challengeToken)
+	if err != nil {
+		return err
+	}
+
+	user, identity, err := idp.ResolveUserIdentity(ctx, s.hermes, connection, ct.Subject())
+	if err != nil {
+		logger.Warnf("[Password] 重置密码未找到用户 - Connection: %s, Channel: %s", connection, ct.GetChannelType())
+		return autherrors.NewUserNotFound("user not found")
+	}
+
+	domain := identity.Domain
+	if domain == "" {
+		domain = string(idp.GetDomain(connection))
+	}
+	if err := s.SetPassword(ctx, user, domain, newPassword); err != nil {
+		return err
+	}
+	logger.Infof("[Password] 密码已重置 - OpenID: %s, Connection: %s, Channel: %s", user.OpenID, connection, ct.GetChannelType())
+
+	// 密码已更新，以下步骤失败只记录日志
+	if err := s.revoker.RevokeSubject(ctx, user.OpenID); err != nil {
+		logger.Errorf("[Password] 吊销用户 token 失败 - OpenID: %s, Error: %v", user.OpenID, err)
+	}
+	if s.mail != nil && user.Email != nil && *user.Email != "" {
+		if err := s.mail.SendPasswordChanged(ctx, *user.Email); err != nil {
+			logger.Warnf("[Password] 发送密码已更改通知失败 - OpenID: %s, Error: %v", user.OpenID, err)
+		}
+	}
+	return nil
+}
+
+// SetPassword 按 domain 的密码策略校验新密码，通过后以 argon2id 保存并记录密码历史
+func (s *Service) SetPassword(ctx context.Context, user *models.UserWithDecrypted, domain, newPassword string) error {
+	policy := config.GetPasswordPolicy(domain)
+	if err := checkPolicy(policy, newPassword, userIdentifiers(user)); err != nil {
+		return err
+	}
+	if s.breached.Contains(newPassword) {
+		return autherrors.NewWeakPassword(ReasonBreached, "password has appeared in a data breach")
+	}
+
+	current := ""
+	if user.PasswordHash != nil {
+		current = *user.PasswordHash
+	}
+	var history []models.UserCredential
+	if policy.History > 0 {
+		var err error
+		if history, err = s.listHistory(ctx, user.OpenID); err != nil {
+			return autherrors.NewServerErrorf("load password history failed: %v", err)
+		}
+		if reused(newPassword, current, history, policy.History) {
+			return autherrors.NewWeakPassword(ReasonReused, "password has been used recently")
+		}
+	}
+
+	hash, err := Hash(newPassword)
+	if err != nil {
+		return autherrors.NewServerErrorf("hash password failed: %v", err)
+	}
+	if err := s.hermes.PatchUser(ctx, user.OpenID, map[string]any{"password_hash": hash}); err != nil {
+		return autherrors.NewServerErrorf("update password failed: %v", err)
+	}
+	s.cache.InvalidateUser(ctx, user.OpenID)
+
+	if policy.History > 0 && current != "" {
+		s.recordHistory(ctx, user.OpenID, current, history, policy.History)
+	}
+	return nil
+}
+
+// UpgradeHash 账号密码登录成功后将旧格式（bcrypt）或旧参数的哈希重新计算为当前 argon2id 哈希并清除用户缓存
+// 密码本身未变，不校验策略也不记入历史；失败只记录日志，不影响登录
+func (s *Service) UpgradeHash(ctx context.Context, openid, plain string) {
+	hash, err := Hash(plain)
+	if err != nil {
+		logger.Warnf("[Password] 密码哈希升级失败 - OpenID: %s, Error: %v", openid, err)
+		return
+	}
+	if err := s.hermes.PatchUser(ctx, openid, map[string]any{"password_hash": hash}); err != nil {
+		logger.Warnf("[Password] 密码哈希升级失败 - OpenID: %s, Error: %v", openid, err)
+		return
+	}
+	s.cache.InvalidateUser(ctx, openid)
+	logger.Infof("[Password] 密码哈希已升级 - OpenID: %s", openid)
+}
+
+// verifyChallengeToken 校验 ChallengeToken：forget_password 场景、email-code / sms-code 验证方式、未使用过
+func (s *Service) verifyChallengeToken(ctx context.Context, clientID, challengeToken string) (*tokendef.ChallengeToken, error) {
+	t, err := s.tokens.Verify(ctx, challengeToken)
+	if err != nil {
+		logger.Warnf("[Password] challenge-token 验证失败 - Error: %v", err)
+		return nil, autherrors.NewInvalidCredentials("invalid challenge token")
+	}
+	ct, ok := t.(*tokendef.ChallengeToken)
+	if !ok {
+		return nil, autherrors.NewInvalidCredentials("proof is not a challenge token")
+	}
+	if ct.ClientID() != clientID {
+		return nil, autherrors.NewInvalidCredentials("challenge token was not issued to this client")
+	}
+	if ct.GetType() != BizTypeForgetPassword {
+		return nil, autherrors.NewInvalidCredentialsf("challenge token type %q is not valid for password reset", ct.GetType())
+	}
+	if !slices.Contains(resetChannels, ct.GetChannelType()) {
+		return nil, autherrors.NewInvalidCredentialsf("channel type %q is not allowed for password reset", ct.GetChannelType())
+	}
+
+	claimed, err := s.cache.ClaimChallengeToken(ctx, ct.JTI(), time.Until(ct.ExpiresAt()))
+	if err != nil {
+		return nil, autherrors.NewServerErrorf("claim challenge token failed: %v", err)
+	}
+	if !claimed {
+		return nil, autherrors.NewInvalidCredentials("challenge token has already been used")
+	}
+	return ct, nil
+}
+
+// userDomain 用户所在域取自 global 身份，缺省为 consumer
+func userDomain(identities models.Identities) string {
+	for _, identity := range identities {
+		if identity.IDP == idp.TypeGlobal && identity.Domain != "" {
+			return identity.Domain
+		}
+	}
+	return string(idp.DomainConsumer)
+}
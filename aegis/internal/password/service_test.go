@@ -0,0 +1,69 @@
+package password
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/types"
+	"github.com/heliannuuthus/aegis/models"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+type stubVerifier struct {
+	token tokendef.Token
+	err   error
+}
+
+func (v stubVerifier) Verify(context.Context, string) (tokendef.Token, error) {
+	return v.token, v.err
+}
+
+func challengeToken(typ string, channelType tokendef.ChannelType) tokendef.Token {
+	return tokendef.NewClaimsBuilder().
+		Issuer("aegis").
+		ClientID("app").
+		Audience("svc").
+		ExpiresIn(time.Minute).
+		Build(tokendef.NewChallengeTokenBuilder().Subject("user@example.com").Type(typ).ChannelType(channelType))
+}
+
+func flowFor(appID string) *types.AuthFlow {
+	return &types.AuthFlow{Application: &models.Application{AppID: appID}}
+}
+
+func TestResetRejectsBeforeClaim(t *testing.T) {
+	tests := []struct {
+		name       string
+		clientID   string
+		connection string
+		verifier   stubVerifier
+		wantCode   string
+	}{
+		{name: "unsupported connection", connection: "github", verifier: stubVerifier{token: challengeToken(BizTypeForgetPassword, tokendef.ChannelTypeEmailOTP)}, wantCode: autherrors.CodeInvalidRequest},
+		{name: "invalid token", connection: idp.TypeUser, verifier: stubVerifier{err: errors.New("bad signature")}, wantCode: autherrors.CodeInvalidCredentials},
+		{name: "wrong biz type", connection: idp.TypeUser, verifier: stubVerifier{token: challengeToken("login", tokendef.ChannelTypeEmailOTP)}, wantCode: autherrors.CodeInvalidCredentials},
+		{name: "wrong channel", connection: idp.TypeStaff, verifier: stubVerifier{token: challengeToken(BizTypeForgetPassword, tokendef.ChannelTypeCaptcha)}, wantCode: autherrors.CodeInvalidCredentials},
+		{name: "presented in another client's flow", clientID: "other-app", connection: idp.TypeUser, verifier: stubVerifier{token: challengeToken(BizTypeForgetPassword, tokendef.ChannelTypeEmailOTP)}, wantCode: autherrors.CodeInvalidCredentials},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			clientID := tt.clientID
+			if clientID == "" {
+				clientID = "app"
+			}
+			svc := NewService(nil, nil, tt.verifier, nil, nil, nil)
+			err := svc.Reset(context.Background(), flowFor(clientID), tt.connection, "token", "new-password")
+			if err == nil {
+				t.Fatal("Reset() error = nil")
+			}
+			if got := autherrors.ToAuthError(err).Code; got != tt.wantCode {
+				t.Fatalf("Reset() code = %s, want %s", got, tt.wantCode)
+			}
+		})
+	}
+}
@@ -0,0 +1,183 @@
+package registration
+
+import (
+	"fmt"
+	"net/netip"
+	"net/url"
+	"slices"
+	"strings"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/helpers"
+)
+
+// ClientMetadata 客户端元数据（RFC 7591 §2），未知字段按规范忽略
+type ClientMetadata struct {
+	ClientName                         string      `json:"client_name"`
+	LogoURI                            string      `json:"logo_uri,omitempty"`
+	RedirectURIs                       []string    `json:"redirect_uris,omitempty"`
+	PostLogoutRedirectURIs             []string    `json:"post_logout_redirect_uris,omitempty"`
+	BackchannelLogoutURI               string      `json:"backchannel_logout_uri,omitempty"`
+	DPoPBoundAccessTokens              bool        `json:"dpop_bound_access_tokens,omitempty"`
+	RequirePushedAuthorizationRequests bool        `json:"require_pushed_authorization_requests,omitempty"`
+	AllowedOrigins                     []string    `json:"allowed_origins,omitempty"` // aegis 扩展：CORS 白名单
+	IDPConfigs                         []IDPConfig `json:"idp_configs,omitempty"`     // aegis 扩展：可用的登录方式
+}
+
+// UpdateRequest 客户端配置更新请求（RFC 7592 §2.2），client_id 须与注册信息一致
+type UpdateRequest struct {
+	ClientID string `json:"client_id"`
+	ClientMetadata
+}
+
+// IDPConfig 应用 IDP 配置（aegis 扩展元数据）
+type IDPConfig struct {
+	Type     string `json:"type"`
+	Priority int    `json:"priority,omitempty"`
+	Strategy string `json:"strategy,omitempty"` // 逗号分隔的认证方式（如 password,webauthn）
+}
+
+// ClientInformation 注册结果（RFC 7591 §3.2.1 / RFC 7592 §3）
+type ClientInformation struct {
+	ClientID                string `json:"client_id"`
+	ClientSecret            string `json:"client_secret,omitempty"`            // 应用 seed（Base64URL），仅注册时返回一次，用于签发 CT
+	ClientSecretExpiresAt   *int64 `json:"client_secret_expires_at,omitempty"` // 0 表示不过期
+	ClientIDIssuedAt        int64  `json:"client_id_issued_at"`
+	RegistrationAccessToken string `json:"registration_access_token,omitempty"` // 仅注册时返回一次
+	RegistrationClientURI   string `json:"registration_client_uri"`
+	ClientMetadata
+}
+
+// Validate 校验元数据（RFC 7591 §3.2.2）
+func (m *ClientMetadata) Validate() *autherrors.AuthError {
+	if strings.TrimSpace(m.ClientName) == "" {
+		return autherrors.NewInvalidClientMetadata("client_name is required")
+	}
+	for _, uri := range m.RedirectURIs {
+		if err := validateClientURI(uri); err != nil {
+			return autherrors.NewInvalidRedirectURI(fmt.Sprintf("redirect_uris: %v", err))
+		}
+	}
+	for _, uri := range m.PostLogoutRedirectURIs {
+		if err := validateClientURI(uri); err != nil {
+			return autherrors.NewInvalidClientMetadata(fmt.Sprintf("post_logout_redirect_uris: %v", err))
+		}
+	}
+	if m.LogoURI != "" {
+		if err := validateClientURI(m.LogoURI); err != nil {
+			return autherrors.NewInvalidClientMetadata(fmt.Sprintf("logo_uri: %v", err))
+		}
+	}
+	if m.BackchannelLogoutURI != "" {
+		if err := validateClientURI(m.BackchannelLogoutURI); err != nil {
+			return autherrors.NewInvalidClientMetadata(fmt.Sprintf("backchannel_logout_uri: %v", err))
+		}
+	}
+	for _, origin := range m.AllowedOrigins {
+		u, err := url.Parse(origin)
+		if err != nil || u.Scheme == "" || u.Host == "" || (u.Path != "" && u.Path != "/") || u.RawQuery != "" || u.Fragment != "" {
+			return autherrors.NewInvalidClientMetadata(fmt.Sprintf("allowed_origins: %q is not an origin", origin))
+		}
+	}
+	seen := make([]string, 0, len(m.IDPConfigs))
+	for i, cfg := range m.IDPConfigs {
+		if cfg.Type == "" {
+			return autherrors.NewInvalidClientMetadata(fmt.Sprintf("idp_configs[%d].type is required", i))
+		}
+		if slices.Contains(seen, cfg.Type) {
+			return autherrors.NewInvalidClientMetadata(fmt.Sprintf("idp_configs[%d]: duplicate type %q", i, cfg.Type))
+		}
+		seen = append(seen, cfg.Type)
+	}
+	return nil
+}
+
+// validateClientURI 客户端自助提交的 URI：绝对 https URI、不含 fragment（RFC 6749 §3.1.2），
+// 且不得指向回环、链路本地、私有地址或内网主机名（aegis 会向 backchannel_logout_uri 等地址发起请求）
+func validateClientURI(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil {
+		return fmt.Errorf("%q is not a valid uri", raw)
+	}
+	if u.Scheme == "" || u.Host == "" {
+		return fmt.Errorf("%q must be an absolute uri", raw)
+	}
+	if u.Fragment != "" {
+		return fmt.Errorf("%q must not contain a fragment", raw)
+	}
+	if u.Scheme != "https" {
+		return fmt.Errorf("%q must use https", raw)
+	}
+	if internalHost(u.Hostname()) {
+		return fmt.Errorf("%q must not point to a loopback, link-local or private host", raw)
+	}
+	return nil
+}
+
+// internalHost 非公网 IP 字面量，或 localhost、单标签、.local / .internal 等内网主机名
+func internalHost(host string) bool {
+	host = strings.TrimSuffix(strings.ToLower(host), ".")
+	if addr, err := netip.ParseAddr(host); err == nil {
+		return !helpers.IsPublicAddr(addr)
+	}
+	if !strings.Contains(host, ".") {
+		return true
+	}
+	for _, suffix := range []string{".localhost", ".local", ".internal"} {
+		if strings.HasSuffix(host, suffix) {
+			return true
+		}
+	}
+	return false
+}
+
+// toApplication 转换为应用可写元数据
+func (m *ClientMetadata) toApplication() *models.ApplicationMetadata {
+	return &models.ApplicationMetadata{
+		Name:                      m.ClientName,
+		LogoURL:                   optionalString(m.LogoURI),
+		AllowedRedirectURIs:       m.RedirectURIs,
+		AllowedOrigins:            m.AllowedOrigins,
+		AllowedLogoutURIs:         m.PostLogoutRedirectURIs,
+		BackchannelLogoutURI:      optionalString(m.BackchannelLogoutURI),
+		DPoPBoundAccessTokens:     m.DPoPBoundAccessTokens,
+		RequirePushedAuthRequests: m.RequirePushedAuthorizationRequests,
+	}
+}
+
+// metadataFromApplication 由应用及其 IDP 配置还原客户端元数据
+func metadataFromApplication(app *models.Application, configs []*models.ApplicationIDPConfig) ClientMetadata {
+	meta := ClientMetadata{
+		ClientName:                         app.Name,
+		LogoURI:                            derefString(app.LogoURL),
+		RedirectURIs:                       app.GetAllowedRedirectURIs(),
+		PostLogoutRedirectURIs:             app.GetAllowedLogoutURIs(),
+		BackchannelLogoutURI:               derefString(app.BackchannelLogoutURI),
+		DPoPBoundAccessTokens:              app.DPoPBoundAccessTokens,
+		RequirePushedAuthorizationRequests: app.RequirePushedAuthRequests,
+		AllowedOrigins:                     app.GetAllowedOrigins(),
+	}
+	for _, cfg := range configs {
+		meta.IDPConfigs = append(meta.IDPConfigs, IDPConfig{
+			Type:     cfg.Type,
+			Priority: cfg.Priority,
+			Strategy: derefString(cfg.Strategy),
+		})
+	}
+	return meta
+}
+
+func optionalString(s string) *string {
+	if s == "" {
+		return nil
+	}
+	return &s
+}
+
+func derefString(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
@@ -0,0 +1,294 @@
+// Package registration 实现动态客户端注册（RFC 7591）与注册管理（RFC 7592）。
+//
+// 注册端点使用按域配置的初始访问令牌保护；应用、IDP 配置与应用密钥均通过 hermes 创建，
+// 注册成功后签发 registration_access_token（摘要随应用持久化在 hermes），用于后续读取、整体更新与删除该客户端。
+package registration
+
+import (
+	"context"
+	"crypto/sha256"
+	"crypto/subtle"
+	"encoding/base64"
+	"encoding/hex"
+	"errors"
+	"slices"
+	"strings"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	"github.com/heliannuuthus/aegis/config"
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/aegis/rpc/hermes"
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// registrationAccessTokenLength registration_access_token 长度
+const registrationAccessTokenLength = 43
+
+// Service 动态客户端注册服务
+type Service struct {
+	cache  *cache.Manager
+	hermes *hermes.Client
+}
+
+func NewService(cache *cache.Manager, hermesClient *hermes.Client) *Service {
+	return &Service{
+		cache:  cache,
+		hermes: hermesClient,
+	}
+}
+
+// AuthorizeInitialAccess 校验初始访问令牌，返回令牌所属的域
+func (s *Service) AuthorizeInitialAccess(token string) (string, bool) {
+	return matchInitialAccessToken(config.GetRegistrationInitialAccessTokens(), token)
+}
+
+// matchInitialAccessToken 在 domain_id -> 令牌摘要 配置中查找 token 所属的域
+func matchInitialAccessToken(tokens map[string][]string, token string) (string, bool) {
+	if token == "" {
+		return "", false
+	}
+	digest := hashToken(token)
+	for domainID, hashes := range tokens {
+		for _, h := range hashes {
+			if subtle.ConstantTimeCompare([]byte(strings.ToLower(h)), []byte(digest)) == 1 {
+				return domainID, true
+			}
+		}
+	}
+	return "", false
+}
+
+// Register 注册客户端：创建应用（同时写入 registration_access_token 摘要）、应用密钥与 IDP 配置，任一步失败时删除已创建的应用
+func (s *Service) Register(ctx context.Context, domainID string, meta *ClientMetadata) (*ClientInformation, *autherrors.AuthError) {
+	if authErr := meta.Validate(); authErr != nil {
+		return nil, authErr
+	}
+	if authErr := s.checkIDPTypes(ctx, domainID, meta.IDPConfigs); authErr != nil {
+		return nil, authErr
+	}
+
+	token := helpers.GenerateID(registrationAccessTokenLength)
+	tokenHash := hashToken(token)
+	appMeta := meta.toApplication()
+	appMeta.RegistrationTokenHash = &tokenHash
+	app, err := s.hermes.CreateApplication(ctx, domainID, appMeta)
+	if err != nil {
+		logger.Errorf("[Registration] 创建应用失败 - DomainID: %s, Error: %v", domainID, err)
+		return nil, autherrors.NewServerError("create client failed")
+	}
+
+	seed, err := s.hermes.CreateKey(ctx, models.KeyOwnerApplication, app.AppID)
+	if err != nil {
+		logger.Errorf("[Registration] 创建应用密钥失败 - ClientID: %s, Error: %v", app.AppID, err)
+		s.rollback(ctx, app.AppID)
+		return nil, autherrors.NewServerError("create client key failed")
+	}
+
+	for i := range meta.IDPConfigs {
+		if _, err := s.hermes.CreateApplicationIDPConfig(ctx, idpConfigModel(app.AppID, &meta.IDPConfigs[i])); err != nil {
+			logger.Errorf("[Registration] 创建应用 IDP 配置失败 - ClientID: %s, Type: %s, Error: %v", app.AppID, meta.IDPConfigs[i].Type, err)
+			s.rollback(ctx, app.AppID)
+			return nil, idpConfigError(meta.IDPConfigs[i].Type, err)
+		}
+	}
+
+	configs, err := s.hermes.ListApplicationIDPConfigs(ctx, app.AppID)
+	if err != nil {
+		logger.Warnf("[Registration] 获取应用 IDP 配置失败 - ClientID: %s, Error: %v", app.AppID, err)
+	}
+	info := s.information(app, configs)
+	info.ClientSecret = base64.RawURLEncoding.EncodeToString(seed)
+	info.ClientSecretExpiresAt = new(int64)
+	info.RegistrationAccessToken = token
+	logger.Infof("[Registration] 客户端注册成功 - DomainID: %s, ClientID: %s", domainID, app.AppID)
+	return info, nil
+}
+
+// Authenticate 校验 registration_access_token（RFC 7592 §2），客户端不存在、非动态注册与令牌错误不作区分
+func (s *Service) Authenticate(ctx context.Context, clientID, token string) (*models.Application, *autherrors.AuthError) {
+	if clientID == "" || token == "" {
+		return nil, autherrors.NewInvalidToken("missing registration access token")
+	}
+	app, err := s.hermes.GetApplication(ctx, clientID)
+	if err != nil {
+		if status.Code(err) != codes.NotFound {
+			logger.Errorf("[Registration] 获取应用失败 - ClientID: %s, Error: %v", clientID, err)
+			return nil, autherrors.NewServerError("query client registration failed")
+		}
+		return nil, autherrors.NewInvalidToken("invalid registration access token")
+	}
+	if app.RegistrationTokenHash == nil ||
+		subtle.ConstantTimeCompare([]byte(*app.RegistrationTokenHash), []byte(hashToken(token))) != 1 {
+		return nil, autherrors.NewInvalidToken("invalid registration access token")
+	}
+	return app, nil
+}
+
+// Get 读取客户端当前配置（RFC 7592 §2.1）
+func (s *Service) Get(ctx context.Context, app *models.Application) (*ClientInformation, *autherrors.AuthError) {
+	configs, err := s.hermes.ListApplicationIDPConfigs(ctx, app.AppID)
+	if err != nil {
+		logger.Errorf("[Registration] 获取应用 IDP 配置失败 - ClientID: %s, Error: %v", app.AppID, err)
+		return nil, autherrors.NewServerError("query client failed")
+	}
+	return s.information(app, configs), nil
+}
+
+// Update 以请求元数据整体替换客户端配置（RFC 7592 §2.2），请求中缺省的字段视为清空
+// 写入前校验全部元数据与 IDP 类型；应用已更新但 IDP 配置同步中途失败时返回 server_error 并说明部分完成，
+// 客户端以相同请求重试即可收敛（整体替换是幂等的）
+func (s *Service) Update(ctx context.Context, current *models.Application, req *UpdateRequest) (*ClientInformation, *autherrors.AuthError) {
+	if req.ClientID != current.AppID {
+		return nil, autherrors.NewInvalidRequest("client_id does not match the registration")
+	}
+	meta := &req.ClientMetadata
+	if authErr := meta.Validate(); authErr != nil {
+		return nil, authErr
+	}
+	if authErr := s.checkIDPTypes(ctx, current.DomainID, meta.IDPConfigs); authErr != nil {
+		return nil, authErr
+	}
+
+	app, err := s.hermes.UpdateApplication(ctx, current.AppID, meta.toApplication())
+	if err != nil {
+		logger.Errorf("[Registration] 更新应用失败 - ClientID: %s, Error: %v", current.AppID, err)
+		return nil, autherrors.NewServerError("update client failed")
+	}
+	s.cache.InvalidateApplication(current.AppID)
+
+	if authErr := s.syncIDPConfigs(ctx, current.AppID, meta.IDPConfigs); authErr != nil {
+		logger.Errorf("[Registration] 应用已更新但 IDP 配置同步未完成 - ClientID: %s, Error: %v", current.AppID, authErr)
+		if authErr.Code == autherrors.CodeInvalidClientMetadata {
+			return nil, authErr
+		}
+		return nil, autherrors.NewServerError("client metadata was updated but idp_configs were only partially synced, retry the update")
+	}
+	logger.Infof("[Registration] 客户端配置已更新 - ClientID: %s", current.AppID)
+	return s.Get(ctx, app)
+}
+
+// Delete 注销客户端（RFC 7592 §2.3）：删除应用及其关联数据，registration_access_token 摘要随应用删除而失效
+func (s *Service) Delete(ctx context.Context, app *models.Application) *autherrors.AuthError {
+	if err := s.hermes.DeleteApplication(ctx, app.AppID); err != nil {
+		logger.Errorf("[Registration] 删除应用失败 - ClientID: %s, Error: %v", app.AppID, err)
+		return autherrors.NewServerError("delete client failed")
+	}
+	s.cache.InvalidateApplication(app.AppID)
+	logger.Infof("[Registration] 客户端已注销 - ClientID: %s", app.AppID)
+	return nil
+}
+
+// checkIDPTypes 校验请求的 IDP 类型均已在应用所属域中配置，避免写入应用后才被 hermes 拒绝
+func (s *Service) checkIDPTypes(ctx context.Context, domainID string, desired []IDPConfig) *autherrors.AuthError {
+	if len(desired) == 0 {
+		return nil
+	}
+	configs, err := s.hermes.ListDomainIDPConfigs(ctx, domainID)
+	if err != nil {
+		logger.Errorf("[Registration] 获取域 IDP 配置失败 - DomainID: %s, Error: %v", domainID, err)
+		return autherrors.NewServerError("query domain idp configs failed")
+	}
+	if idpType, ok := unconfiguredIDPType(configs, desired); ok {
+		return autherrors.NewInvalidClientMetadata("idp_configs: " + idpType + " is not configured for the domain")
+	}
+	return nil
+}
+
+// unconfiguredIDPType 返回第一个未在域中配置的 IDP 类型
+func unconfiguredIDPType(domainConfigs []*models.DomainIDPConfig, desired []IDPConfig) (string, bool) {
+	for _, cfg := range desired {
+		if !slices.ContainsFunc(domainConfigs, func(d *models.DomainIDPConfig) bool { return d.IDPType == cfg.Type }) {
+			return cfg.Type, true
+		}
+	}
+	return "", false
+}
+
+// syncIDPConfigs 将应用 IDP 配置同步为 desired：删除多余、更新已有、创建缺失
+func (s *Service) syncIDPConfigs(ctx context.Context, appID string, desired []IDPConfig) *autherrors.AuthError {
+	current, err := s.hermes.ListApplicationIDPConfigs(ctx, appID)
+	if err != nil {
+		logger.Errorf("[Registration] 获取应用 IDP 配置失败 - ClientID: %s, Error: %v", appID, err)
+		return autherrors.NewServerError("query client idp configs failed")
+	}
+	existing := make([]string, 0, len(current))
+	for _, cfg := range current {
+		if slices.ContainsFunc(desired, func(d IDPConfig) bool { return d.Type == cfg.Type }) {
+			existing = append(existing, cfg.Type)
+			continue
+		}
+		if err := s.hermes.DeleteApplicationIDPConfig(ctx, appID, cfg.Type); err != nil {
+			logger.Errorf("[Registration] 删除应用 IDP 配置失败 - ClientID: %s, Type: %s, Error: %v", appID, cfg.Type, err)
+			return autherrors.NewServerError("update client idp configs failed")
+		}
+	}
+	for i := range desired {
+		cfg := idpConfigModel(appID, &desired[i])
+		if slices.Contains(existing, cfg.Type) {
+			if _, err := s.hermes.UpdateApplicationIDPConfig(ctx, cfg); err != nil {
+				logger.Errorf("[Registration] 更新应用 IDP 配置失败 - ClientID: %s, Type: %s, Error: %v", appID, cfg.Type, err)
+				return idpConfigError(cfg.Type, err)
+			}
+			continue
+		}
+		if _, err := s.hermes.CreateApplicationIDPConfig(ctx, cfg); err != nil {
+			logger.Errorf("[Registration] 创建应用 IDP 配置失败 - ClientID: %s, Type: %s, Error: %v", appID, cfg.Type, err)
+			return idpConfigError(cfg.Type, err)
+		}
+	}
+	return nil
+}
+
+// idpConfigError 将 hermes 拒绝 IDP 配置的原因返回给客户端（如域未启用该 IDP），其余失败视为服务端错误
+func idpConfigError(idpType string, err error) *autherrors.AuthError {
+	var se interface{ GRPCStatus() *status.Status }
+	if !errors.As(err, &se) {
+		return autherrors.NewServerError("save client idp config failed")
+	}
+	switch st := se.GRPCStatus(); st.Code() {
+	case codes.InvalidArgument, codes.FailedPrecondition, codes.AlreadyExists:
+		return autherrors.NewInvalidClientMetadata("idp_configs: " + idpType + ": " + st.Message())
+	default:
+		return autherrors.NewServerError("save client idp config failed")
+	}
+}
+
+// rollback 注册中途失败时删除已创建的应用（hermes 级联删除密钥与 IDP 配置）
+func (s *Service) rollback(ctx context.Context, appID string) {
+	if err := s.hermes.DeleteApplication(ctx, appID); err != nil {
+		logger.Errorf("[Registration] 回滚删除应用失败 - ClientID: %s, Error: %v", appID, err)
+	}
+}
+
+func (s *Service) information(app *models.Application, configs []*models.ApplicationIDPConfig) *ClientInformation {
+	return &ClientInformation{
+		ClientID:              app.AppID,
+		ClientIDIssuedAt:      app.CreatedAt.Unix(),
+		RegistrationClientURI: ClientURI(app.AppID),
+		ClientMetadata:        metadataFromApplication(app, configs),
+	}
+}
+
+// ClientURI 返回客户端配置端点地址（registration_client_uri）
+func ClientURI(clientID string) string {
+	return strings.TrimRight(config.GetIssuer(), "/") + "/auth/register/" + clientID
+}
+
+func idpConfigModel(appID string, cfg *IDPConfig) *models.ApplicationIDPConfig {
+	return &models.ApplicationIDPConfig{
+		AppID:    appID,
+		Type:     cfg.Type,
+		Priority: cfg.Priority,
+		Strategy: optionalString(cfg.Strategy),
+	}
+}
+
+func hashToken(token string) string {
+	sum := sha256.Sum256([]byte(token))
+	return hex.EncodeToString(sum[:])
+}
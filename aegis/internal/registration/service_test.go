@@ -0,0 +1,161 @@
+package registration
+
+import (
+	"fmt"
+	"testing"
+
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
+	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/models"
+)
+
+func TestMatchInitialAccessToken(t *testing.T) {
+	tokens := map[string][]string{
+		"consumer": {hashToken("consumer-token")},
+		"platform": {"DEADBEEF", hashToken("platform-token")},
+	}
+
+	if domainID, ok := matchInitialAccessToken(tokens, "platform-token"); !ok || domainID != "platform" {
+		t.Fatalf("matchInitialAccessToken() = %q, %v, want platform, true", domainID, ok)
+	}
+	for _, token := range []string{"", "unknown", hashToken("consumer-token")} {
+		if _, ok := matchInitialAccessToken(tokens, token); ok {
+			t.Fatalf("matchInitialAccessToken(%q) matched", token)
+		}
+	}
+}
+
+func TestClientMetadataValidate(t *testing.T) {
+	tests := []struct {
+		name     string
+		meta     ClientMetadata
+		wantCode string
+	}{
+		{
+			name: "valid",
+			meta: ClientMetadata{
+				ClientName:     "partner",
+				RedirectURIs:   []string{"https://partner.example.com/callback"},
+				AllowedOrigins: []string{"https://partner.example.com"},
+				IDPConfigs:     []IDPConfig{{Type: "user", Strategy: "password"}, {Type: "github"}},
+			},
+		},
+		{
+			name: "public uris",
+			meta: ClientMetadata{
+				ClientName:           "partner",
+				LogoURI:              "https://203.0.113.10/logo.png",
+				BackchannelLogoutURI: "https://partner.example.com/logout",
+			},
+		},
+		{name: "missing name", meta: ClientMetadata{}, wantCode: autherrors.CodeInvalidClientMetadata},
+		{
+			name:     "relative redirect uri",
+			meta:     ClientMetadata{ClientName: "partner", RedirectURIs: []string{"/callback"}},
+			wantCode: autherrors.CodeInvalidRedirectURI,
+		},
+		{
+			name:     "redirect uri with fragment",
+			meta:     ClientMetadata{ClientName: "partner", RedirectURIs: []string{"https://partner.example.com/cb#x"}},
+			wantCode: autherrors.CodeInvalidRedirectURI,
+		},
+		{
+			name:     "plain http redirect uri",
+			meta:     ClientMetadata{ClientName: "partner", RedirectURIs: []string{"http://partner.example.com/callback"}},
+			wantCode: autherrors.CodeInvalidRedirectURI,
+		},
+		{
+			name:     "loopback redirect uri",
+			meta:     ClientMetadata{ClientName: "partner", RedirectURIs: []string{"https://127.0.0.1/callback"}},
+			wantCode: autherrors.CodeInvalidRedirectURI,
+		},
+		{
+			name:     "localhost backchannel logout uri",
+			meta:     ClientMetadata{ClientName: "partner", BackchannelLogoutURI: "https://localhost/logout"},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "private backchannel logout uri",
+			meta:     ClientMetadata{ClientName: "partner", BackchannelLogoutURI: "https://10.0.0.8/logout"},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "link-local backchannel logout uri",
+			meta:     ClientMetadata{ClientName: "partner", BackchannelLogoutURI: "https://[fe80::1]/logout"},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "metadata endpoint logo uri",
+			meta:     ClientMetadata{ClientName: "partner", LogoURI: "https://169.254.169.254/latest/meta-data"},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "internal host name",
+			meta:     ClientMetadata{ClientName: "partner", BackchannelLogoutURI: "https://redis.internal/logout"},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "single-label host",
+			meta:     ClientMetadata{ClientName: "partner", PostLogoutRedirectURIs: []string{"https://intranet/"}},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "origin with path",
+			meta:     ClientMetadata{ClientName: "partner", AllowedOrigins: []string{"https://partner.example.com/app"}},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+		{
+			name:     "duplicate idp",
+			meta:     ClientMetadata{ClientName: "partner", IDPConfigs: []IDPConfig{{Type: "user"}, {Type: "user"}}},
+			wantCode: autherrors.CodeInvalidClientMetadata,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			err := tt.meta.Validate()
+			if tt.wantCode == "" {
+				if err != nil {
+					t.Fatalf("Validate() error = %v", err)
+				}
+				return
+			}
+			if err == nil || err.Code != tt.wantCode {
+				t.Fatalf("Validate() error = %v, want code %s", err, tt.wantCode)
+			}
+		})
+	}
+}
+
+func TestUnconfiguredIDPType(t *testing.T) {
+	domain := []*models.DomainIDPConfig{{IDPType: "user"}, {IDPType: "github"}}
+
+	if idpType, ok := unconfiguredIDPType(domain, []IDPConfig{{Type: "user"}, {Type: "github"}}); ok {
+		t.Fatalf("unconfiguredIDPType() = %q, want none", idpType)
+	}
+	if idpType, ok := unconfiguredIDPType(domain, []IDPConfig{{Type: "user"}, {Type: "oidc-acme"}}); !ok || idpType != "oidc-acme" {
+		t.Fatalf("unconfiguredIDPType() = %q, %v, want oidc-acme", idpType, ok)
+	}
+}
+
+func TestIDPConfigError(t *testing.T) {
+	rejected := fmt.Errorf("创建应用 IDP 配置失败: %w", status.Error(codes.FailedPrecondition, "idp not allowed: IDP github 未在域 consumer 中配置"))
+	authErr := idpConfigError("github", rejected)
+	if authErr.Code != autherrors.CodeInvalidClientMetadata {
+		t.Fatalf("idpConfigError() code = %s, want %s", authErr.Code, autherrors.CodeInvalidClientMetadata)
+	}
+	if want := "idp_configs: github: idp not allowed: IDP github 未在域 consumer 中配置"; authErr.Description != want {
+		t.Fatalf("idpConfigError() description = %q, want %q", authErr.Description, want)
+	}
+
+	for _, err := range []error{
+		fmt.Errorf("创建应用 IDP 配置失败: %w", status.Error(codes.Unavailable, "connection refused")),
+		fmt.Errorf("network down"),
+	} {
+		if authErr := idpConfigError("github", err); authErr.Code != autherrors.CodeServerError {
+			t.Fatalf("idpConfigError(%v) code = %s, want %s", err, authErr.Code, autherrors.CodeServerError)
+		}
+	}
+}
@@ -0,0 +1,137 @@
+package risk
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/pkg/accessctl"
+)
+
+var _ Evaluator = (*OfflineEvaluator)(nil)
+
+// NetworkResolver 将 IP 归并为网络标识（如 ASN），用于判断“新网络”
+type NetworkResolver interface {
+	Network(ip string) string
+}
+
+// PrefixResolver 以 IP 前缀近似网络归属：IPv4 取 /24，IPv6 取 /48
+type PrefixResolver struct{}
+
+// Network 返回 IP 所在前缀，无法解析时返回空
+func (PrefixResolver) Network(ip string) string {
+	parsed := net.ParseIP(ip)
+	if parsed == nil {
+		return ""
+	}
+	if v4 := parsed.To4(); v4 != nil {
+		return v4.Mask(net.CIDRMask(24, 32)).String() + "/24"
+	}
+	return parsed.Mask(net.CIDRMask(48, 128)).String() + "/48"
+}
+
+// OfflineEvaluator 基于本地信号的风险评估器
+//
+// 设备与网络基线保存在 Redis（登录完成后由 Record 写入），失败次数来自 accessctl 的登录失败计数。
+type OfflineEvaluator struct {
+	cache    *cache.Manager
+	ac       *accessctl.Manager
+	networks NetworkResolver
+}
+
+// NewOfflineEvaluator 创建离线风险评估器，networks 为 nil 时使用 PrefixResolver
+func NewOfflineEvaluator(cache *cache.Manager, ac *accessctl.Manager, networks NetworkResolver) *OfflineEvaluator {
+	if networks == nil {
+		networks = PrefixResolver{}
+	}
+	return &OfflineEvaluator{
+		cache:    cache,
+		ac:       ac,
+		networks: networks,
+	}
+}
+
+// Evaluate 汇总本地信号并计算风险等级
+func (e *OfflineEvaluator) Evaluate(ctx context.Context, rc *Context) (*Assessment, error) {
+	baseline, err := e.cache.GetLoginBaseline(ctx, rc.UserID)
+	if err != nil {
+		return nil, fmt.Errorf("load login baseline: %w", err)
+	}
+
+	s := Signals{
+		Failures:          e.ac.Failures(ctx, rc.FailurePolicy),
+		FailureThreshold:  config.GetMFARiskFailureThreshold(),
+		PhishingResistant: phishingResistant(rc.LoginMethod),
+	}
+	// 基线为空（首次登录或长期未登录已过期）时无从比较，设备与网络信号不计入
+	if !baseline.Empty() {
+		s.NewDevice = !baseline.KnownDevice(rc.DeviceID)
+		s.NewNetwork = !baseline.KnownNetwork(e.networks.Network(rc.IP))
+	}
+	if rc.LastLoginAt != nil {
+		s.Dormant = time.Since(*rc.LastLoginAt) > config.GetMFARiskDormantAfter()
+	}
+	return s.Assess(ParseLevel(config.GetMFARiskRequireLevel())), nil
+}
+
+// Record 将本次登录的设备与网络写入基线
+func (e *OfflineEvaluator) Record(ctx context.Context, rc *Context) error {
+	return e.cache.RecordLoginBaseline(ctx, rc.UserID, rc.DeviceID, e.networks.Network(rc.IP))
+}
+
+// Signals 离线风险信号
+type Signals struct {
+	NewDevice         bool // 新设备
+	NewNetwork        bool // 新网络
+	Dormant           bool // 休眠账号
+	Failures          int  // 近期登录失败次数
+	FailureThreshold  int  // 失败次数阈值
+	PhishingResistant bool // 主认证方式本身抗钓鱼（passkey / webauthn），降一级
+}
+
+// Assess 按规则计算风险等级：
+//   - 近期失败次数达到阈值 → high
+//   - 否则新设备 +1、新网络 +2、休眠账号 +1，累计 0/1/2/≥3 分别对应 none/low/medium/high
+//   - 主认证方式抗钓鱼时降一级
+//
+// 风险等级不低于 requireLevel 时要求 MFA（requireLevel 为 none 时始终要求）。
+func (s Signals) Assess(requireLevel Level) *Assessment {
+	var reasons []string
+	score := 0
+	if s.NewDevice {
+		score++
+		reasons = append(reasons, SignalNewDevice)
+	}
+	if s.NewNetwork {
+		score += 2
+		reasons = append(reasons, SignalNewNetwork)
+	}
+	if s.Dormant {
+		score++
+		reasons = append(reasons, SignalDormant)
+	}
+	if s.FailureThreshold > 0 && s.Failures >= s.FailureThreshold {
+		score = 3
+		reasons = append(reasons, SignalFailedAttempts)
+	}
+	if s.PhishingResistant && score > 0 {
+		score--
+	}
+
+	level := levelOrder[min(score, 3)]
+	return &Assessment{
+		Level:      level,
+		RequireMFA: level.AtLeast(requireLevel),
+		Reason:     strings.Join(reasons, ","),
+	}
+}
+
+// phishingResistant 主认证方式是否为 passkey / webauthn
+func phishingResistant(loginMethod string) bool {
+	return loginMethod == idp.TypePasskey || strings.HasSuffix(loginMethod, ":webauthn")
+}
@@ -0,0 +1,68 @@
+package risk
+
+import "testing"
+
+func TestSignalsAssess(t *testing.T) {
+	tests := []struct {
+		name        string
+		signals     Signals
+		wantLevel   Level
+		wantReason  string
+		wantRequire bool
+	}{
+		{name: "known device and network", signals: Signals{}, wantLevel: LevelNone},
+		{name: "new device", signals: Signals{NewDevice: true}, wantLevel: LevelLow, wantReason: SignalNewDevice},
+		{name: "new network", signals: Signals{NewNetwork: true}, wantLevel: LevelMedium, wantReason: SignalNewNetwork, wantRequire: true},
+		{
+			name:        "new device and network",
+			signals:     Signals{NewDevice: true, NewNetwork: true},
+			wantLevel:   LevelHigh,
+			wantReason:  SignalNewDevice + "," + SignalNewNetwork,
+			wantRequire: true,
+		},
+		{
+			name:        "failed attempts",
+			signals:     Signals{Failures: 3, FailureThreshold: 3},
+			wantLevel:   LevelHigh,
+			wantReason:  SignalFailedAttempts,
+			wantRequire: true,
+		},
+		{name: "failures below threshold", signals: Signals{Failures: 2, FailureThreshold: 3}, wantLevel: LevelNone},
+		{
+			name:       "passkey downgrades new network",
+			signals:    Signals{NewNetwork: true, PhishingResistant: true},
+			wantLevel:  LevelLow,
+			wantReason: SignalNewNetwork,
+		},
+		{
+			name:        "dormant account on new device",
+			signals:     Signals{NewDevice: true, Dormant: true},
+			wantLevel:   LevelMedium,
+			wantReason:  SignalNewDevice + "," + SignalDormant,
+			wantRequire: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := tt.signals.Assess(LevelMedium)
+			if got.Level != tt.wantLevel || got.Reason != tt.wantReason || got.RequireMFA != tt.wantRequire {
+				t.Fatalf("Assess() = %+v, want level %s, reason %q, require %v", got, tt.wantLevel, tt.wantReason, tt.wantRequire)
+			}
+		})
+	}
+}
+
+func TestPrefixResolverNetwork(t *testing.T) {
+	tests := map[string]string{
+		"203.0.113.42":        "203.0.113.0/24",
+		"2001:db8:abcd:12::1": "2001:db8:abcd::/48",
+		"::ffff:198.51.100.7": "198.51.100.0/24",
+		"not-an-ip":           "",
+	}
+	for ip, want := range tests {
+		if got := (PrefixResolver{}).Network(ip); got != want {
+			t.Fatalf("Network(%q) = %q, want %q", ip, got, want)
+		}
+	}
+}
@@ -0,0 +1,98 @@
+// Package risk 实现主认证后的风险评估，为 MFA 阶段提供决策依据。
+//
+// Evaluator 可插拔；默认实现 OfflineEvaluator 只依赖本地信号：
+// 新设备、新网络（IP 前缀或 ASN）、accessctl 记录的近期登录失败次数，以及距上次登录的时长。
+package risk
+
+import (
+	"context"
+	"time"
+
+	"github.com/heliannuuthus/pkg/accessctl"
+)
+
+// Level 风险等级
+type Level string
+
+const (
+	LevelNone    Level = "none"    // 无风险，直接签发
+	LevelLow     Level = "low"     // 低风险
+	LevelMedium  Level = "medium"  // 中风险
+	LevelHigh    Level = "high"    // 高风险
+	LevelBlocked Level = "blocked" // 极高风险，直接拒绝
+)
+
+// levelOrder 风险等级由低到高排列
+var levelOrder = []Level{LevelNone, LevelLow, LevelMedium, LevelHigh, LevelBlocked}
+
+func (l Level) rank() int {
+	for i, level := range levelOrder {
+		if level == l {
+			return i
+		}
+	}
+	return 0
+}
+
+// AtLeast 是否不低于给定等级
+func (l Level) AtLeast(other Level) bool {
+	return l.rank() >= other.rank()
+}
+
+// ParseLevel 解析配置中的风险等级，无法识别时返回 LevelMedium
+func ParseLevel(s string) Level {
+	for _, level := range levelOrder {
+		if string(level) == s {
+			return level
+		}
+	}
+	return LevelMedium
+}
+
+// 风险信号（写入 Assessment.Reason，审计用）
+const (
+	SignalNewDevice      = "new_device"      // 基线中没有该设备
+	SignalNewNetwork     = "new_network"     // 基线中没有该网络
+	SignalFailedAttempts = "failed_attempts" // 近期登录失败次数达到阈值
+	SignalDormant        = "dormant"         // 距上次登录过久
+)
+
+// Context 风险评估上下文（主认证与授权完成后构建）
+type Context struct {
+	UserID        string            // 用户 OpenID
+	ClientID      string            // 应用 ID
+	LoginMethod   string            // 主认证方式（connection，带 strategy 时为 connection:strategy）
+	IP            string            // 请求 IP
+	DeviceID      string            // 设备标识（设备 Cookie）
+	LastLoginAt   *time.Time        // 本次登录前的最后登录时间
+	FailurePolicy *accessctl.Policy // 近期登录失败计数策略（无登录标识时为 nil）
+}
+
+// Assessment 风险评估结果
+type Assessment struct {
+	Level      Level  // 风险等级
+	RequireMFA bool   // 是否需要追加第二因子
+	Reason     string // 命中的风险信号（逗号分隔）
+}
+
+// Evaluator 风险评估器
+type Evaluator interface {
+	// Evaluate 评估本次登录的风险
+	Evaluate(ctx context.Context, rc *Context) (*Assessment, error)
+
+	// Record 登录完成（含 MFA）后记录本次信号，作为后续评估的基线
+	Record(ctx context.Context, rc *Context) error
+}
+
+type deviceIDKey struct{}
+
+// WithDeviceID 将设备标识写入 context
+func WithDeviceID(ctx context.Context, deviceID string) context.Context {
+	return context.WithValue(ctx, deviceIDKey{}, deviceID)
+}
+
+// DeviceIDFrom 从 context 中读取设备标识
+func DeviceIDFrom(ctx context.Context) string {
+	deviceID, _ := ctx.Value(deviceIDKey{}).(string)
+	return deviceID
+}
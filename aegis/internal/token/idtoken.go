@@ -2,6 +2,7 @@ package token
 
 import (
 	"fmt"
+	"time"
 
 	"aidanwoods.dev/go-paseto"
 
@@ -19,17 +20,21 @@ const TokenTypeIDToken pkgtoken.TokenType = "id"
 // Contains basic user profile for frontend display only.
 type IDToken struct {
 	pkgtoken.Claims
-	nickname string
-	picture  string
-	nonce    string
+	nickname  string
+	picture   string
+	nonce     string
+	authTime  time.Time
+	sessionID string
 }
 
 // ==================== Builder ====================
 
 type IDTokenBuilder struct {
-	nickname string
-	picture  string
-	nonce    string
+	nickname  string
+	picture   string
+	nonce     string
+	authTime  time.Time
+	sessionID string
 }
 
 func NewIDTokenBuilder() *IDTokenBuilder {
@@ -51,12 +56,26 @@ func (b *IDTokenBuilder) Nonce(nonce string) *IDTokenBuilder {
 	return b
 }
 
+// AuthTime 设置用户完成认证的时间（OIDC auth_time）
+func (b *IDTokenBuilder) AuthTime(authTime time.Time) *IDTokenBuilder {
+	b.authTime = authTime
+	return b
+}
+
+// SessionID 设置 SSO 会话标识（OIDC sid），供 RP 关联后端通道登出通知
+func (b *IDTokenBuilder) SessionID(sessionID string) *IDTokenBuilder {
+	b.sessionID = sessionID
+	return b
+}
+
 func (b *IDTokenBuilder) Build(claims pkgtoken.Claims) pkgtoken.Token {
 	return &IDToken{
-		Claims:   claims,
-		nickname: b.nickname,
-		picture:  b.picture,
-		nonce:    b.nonce,
+		Claims:    claims,
+		nickname:  b.nickname,
+		picture:   b.picture,
+		nonce:     b.nonce,
+		authTime:  b.authTime,
+		sessionID: b.sessionID,
 	}
 }
 
@@ -86,6 +105,14 @@ func (t *IDToken) Build() (*paseto.Token, error) {
 			return nil, fmt.Errorf("set nonce: %w", err)
 		}
 	}
+	if !t.authTime.IsZero() {
+		pt.SetTime(pkgtoken.ClaimAuthTime, t.authTime)
+	}
+	if t.sessionID != "" {
+		if err := pt.Set(ClaimSessionID, t.sessionID); err != nil {
+			return nil, fmt.Errorf("set sid: %w", err)
+		}
+	}
 	return &pt, nil
 }
 
@@ -95,6 +122,10 @@ func (t *IDToken) GetNickname() string { return t.nickname }
 
 func (t *IDToken) GetPicture() string { return t.picture }
 
+func (t *IDToken) GetAuthTime() time.Time { return t.authTime }
+
+func (t *IDToken) GetSessionID() string { return t.sessionID }
+
 // ==================== Parse ====================
 
 func ParseIDToken(pasetoToken *paseto.Token) (*IDToken, error) {
@@ -113,9 +144,21 @@ func ParseIDToken(pasetoToken *paseto.Token) (*IDToken, error) {
 		picture = ""
 	}
 
+	authTime, err := pasetoToken.GetTime(pkgtoken.ClaimAuthTime)
+	if err != nil {
+		authTime = time.Time{}
+	}
+
+	var sessionID string
+	if err := pasetoToken.Get(ClaimSessionID, &sessionID); err != nil {
+		sessionID = ""
+	}
+
 	return &IDToken{
-		Claims:   claims,
-		nickname: nickname,
-		picture:  picture,
+		Claims:    claims,
+		nickname:  nickname,
+		picture:   picture,
+		authTime:  authTime,
+		sessionID: sessionID,
 	}, nil
 }
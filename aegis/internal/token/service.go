@@ -5,8 +5,10 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"aidanwoods.dev/go-paseto"
+	"github.com/go-json-experiment/json"
 
 	"github.com/heliannuuthus/aegis/config"
 	"github.com/heliannuuthus/aegis/internal/cache"
@@ -84,6 +86,17 @@ func (s *Service) Issue(ctx context.Context, t tokendef.Token) (string, error) {
 	return signer.Sign(ctx, pasetoToken)
 }
 
+// IssueRevocationList signs the revocation list for an audience.
+// The list is signed with the audience's domain key so resource servers verify it
+// with the same public keys they already fetch for access tokens.
+func (s *Service) IssueRevocationList(ctx context.Context, audience string, list *tokendef.RevocationList, expiresIn time.Duration) (string, error) {
+	pasetoToken, err := list.Build(s.issuer, audience, expiresIn)
+	if err != nil {
+		return "", fmt.Errorf("build revocation list: %w", err)
+	}
+	return s.domainSigner(audience).Sign(ctx, pasetoToken)
+}
+
 // ============= Verify =============
 
 // Verify verifies the token signature, detects type, parses claims,
@@ -147,6 +160,32 @@ func (s *Service) Verify(ctx context.Context, tokenString string) (Token, error)
 	return t, nil
 }
 
+// VerifyRequestObject 验证应用签名的请求对象（JAR，RFC 9101），返回全部 claims。
+// 使用应用密钥验签（与 CT 相同），要求 typ 为请求对象、iss 为 client_id、aud 为 aegis issuer。
+func (s *Service) VerifyRequestObject(ctx context.Context, clientID, requestObject string) (map[string]any, error) {
+	pasetoToken, err := s.appDecryptor.Verifier(clientID).Verify(ctx, requestObject)
+	if err != nil {
+		return nil, fmt.Errorf("verify signature: %w", err)
+	}
+
+	var typ string
+	if err := pasetoToken.Get(tokendef.ClaimType, &typ); err != nil || typ != tokendef.TypeRequestObject {
+		return nil, fmt.Errorf("%w: not a request object", tokendef.ErrUnsupportedToken)
+	}
+	if iss, err := pasetoToken.GetIssuer(); err != nil || iss != clientID {
+		return nil, errors.New("request object issuer mismatch")
+	}
+	if aud, err := pasetoToken.GetAudience(); err != nil || aud != s.issuer {
+		return nil, errors.New("request object audience mismatch")
+	}
+
+	var claims map[string]any
+	if err := json.Unmarshal(pasetoToken.ClaimsJSON(), &claims); err != nil {
+		return nil, fmt.Errorf("unmarshal request object: %w", err)
+	}
+	return claims, nil
+}
+
 // ============= Lazy Component Getters =============
 
 func (s *Service) domainSigner(clientID string) *Signer {
@@ -2,6 +2,7 @@ package token
 
 import (
 	"fmt"
+	"time"
 
 	"aidanwoods.dev/go-paseto"
 	"github.com/go-json-experiment/json"
@@ -14,6 +15,9 @@ const (
 	SSOAudience = "aegis"
 )
 
+// ClaimSessionID SSO 会话标识（OIDC sid），续期与合并身份时保持不变
+const ClaimSessionID = "sid"
+
 // SSOToken represents a single sign-on session token.
 // Claims are signed (public token), identities are encrypted into the sub field
 // as a nested v4.local token.
@@ -23,12 +27,16 @@ const (
 type SSOToken struct {
 	pkgtoken.Claims
 	identities map[string]string // domain → openID
+	authTime   time.Time         // 用户最近一次完成认证的时间（续期不变）
+	sessionID  string            // SSO 会话标识（续期不变）
 }
 
 // ==================== Builder ====================
 
 type SSOTokenBuilder struct {
 	identities map[string]string
+	authTime   time.Time
+	sessionID  string
 }
 
 func NewSSOTokenBuilder() *SSOTokenBuilder {
@@ -49,6 +57,18 @@ func (b *SSOTokenBuilder) Identities(identities map[string]string) *SSOTokenBuil
 	return b
 }
 
+// AuthTime 设置用户完成认证的时间
+func (b *SSOTokenBuilder) AuthTime(authTime time.Time) *SSOTokenBuilder {
+	b.authTime = authTime
+	return b
+}
+
+// SessionID 设置 SSO 会话标识
+func (b *SSOTokenBuilder) SessionID(sessionID string) *SSOTokenBuilder {
+	b.sessionID = sessionID
+	return b
+}
+
 func (b *SSOTokenBuilder) Build(claims pkgtoken.Claims) pkgtoken.Token {
 	cp := make(map[string]string, len(b.identities))
 	for k, v := range b.identities {
@@ -58,6 +78,8 @@ func (b *SSOTokenBuilder) Build(claims pkgtoken.Claims) pkgtoken.Token {
 	return &SSOToken{
 		Claims:     claims,
 		identities: cp,
+		authTime:   b.authTime,
+		sessionID:  b.sessionID,
 	}
 }
 
@@ -74,6 +96,14 @@ func (s *SSOToken) Build() (*paseto.Token, error) {
 	if err := s.SetStandardClaims(&t); err != nil {
 		return nil, fmt.Errorf("set standard claims: %w", err)
 	}
+	if !s.authTime.IsZero() {
+		t.SetTime(pkgtoken.ClaimAuthTime, s.authTime)
+	}
+	if s.sessionID != "" {
+		if err := t.Set(ClaimSessionID, s.sessionID); err != nil {
+			return nil, fmt.Errorf("set sid: %w", err)
+		}
+	}
 	return &t, nil
 }
 
@@ -105,6 +135,22 @@ func (s *SSOToken) GetOpenID(domain string) string {
 	return s.identities[domain]
 }
 
+// GetAuthTime 返回用户完成认证的时间；旧 token 未携带 auth_time 时退化为 iat
+func (s *SSOToken) GetAuthTime() time.Time {
+	if s.authTime.IsZero() {
+		return s.IssuedAt()
+	}
+	return s.authTime
+}
+
+// GetSessionID 返回 SSO 会话标识；旧 token 未携带 sid 时退化为 jti
+func (s *SSOToken) GetSessionID() string {
+	if s.sessionID == "" {
+		return s.JTI()
+	}
+	return s.sessionID
+}
+
 func (s *SSOToken) GetIdentities() map[string]string {
 	if s.identities == nil {
 		return nil
@@ -124,7 +170,19 @@ func ParseSSOToken(pasetoToken *paseto.Token) (*SSOToken, error) {
 		return nil, fmt.Errorf("parse claims: %w", err)
 	}
 
+	authTime, err := pasetoToken.GetTime(pkgtoken.ClaimAuthTime)
+	if err != nil {
+		authTime = time.Time{}
+	}
+
+	var sessionID string
+	if err := pasetoToken.Get(ClaimSessionID, &sessionID); err != nil {
+		sessionID = ""
+	}
+
 	return &SSOToken{
-		Claims: claims,
+		Claims:    claims,
+		authTime:  authTime,
+		sessionID: sessionID,
 	}, nil
 }
@@ -9,6 +9,7 @@ import (
 	"github.com/go-json-experiment/json"
 
 	"github.com/heliannuuthus/aegis/models"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
 	"github.com/heliannuuthus/pkg/binding"
 	"github.com/heliannuuthus/pkg/helpers"
 )
@@ -20,6 +21,7 @@ const (
 	FlowStateInitialized   FlowState = "initialized"   // 已初始化
 	FlowStateAuthenticated FlowState = "authenticated" // 已认证（用户已验证）
 	FlowStateAuthorized    FlowState = "authorized"    // 已授权（权限已计算）
+	FlowStateMFA           FlowState = "mfa"           // 待多因素验证（风险评估要求追加第二因子）
 	FlowStateCompleted     FlowState = "completed"     // 已完成（授权码已生成）
 	FlowStateFailed        FlowState = "failed"        // 已失败（发生错误）
 )
@@ -27,8 +29,10 @@ const (
 // Extra key 常量
 const (
 	ExtraKeyStrategy          = "strategy"
+	ExtraKeyPrincipal         = "principal"
 	ExtraKeyOAuthRedirectURI  = "oauth_redirect_uri"
 	ExtraKeyOAuthCodeVerifier = "oauth_code_verifier"
+	ExtraKeyOAuthNonce        = "oauth_nonce"
 )
 
 // AuthFlow 认证流程上下文
@@ -55,10 +59,23 @@ type AuthFlow struct {
 
 	// 认证结果
 	Identities models.Identities `json:"identities,omitempty"` // 用户全部身份绑定
+	AuthTime   time.Time         `json:"auth_time,omitempty"`  // 用户实际完成认证的时间（SSO 快速路径沿用 SSO 会话的认证时间）
+	SessionID  string            `json:"sid,omitempty"`        // 所属 SSO 会话标识（签发 SSO Token 或走 SSO 快速路径时写入）
 
 	// 授权结果
 	GrantedScopes []string `json:"granted_scopes,omitempty"`
 
+	// 按 audience 拆分的授权详情（authorize 阶段按服务声明的类型校验后写入，同意后随 access token 签发）
+	AuthorizationDetails map[string]tokendef.AuthorizationDetails `json:"authorization_details,omitempty"`
+
+	// MFA 阶段（风险评估要求追加第二因子时写入）
+	MFAAllowedChannels []string   `json:"mfa_allowed_channels,omitempty"` // 允许的第二因子 channel_type
+	MFAExpiresAt       time.Time  `json:"mfa_expires_at,omitzero"`        // MFA 阶段截止时间
+	MFAAttempts        int        `json:"mfa_attempts,omitempty"`         // 已失败的完成尝试次数
+	MFACompletedAt     *time.Time `json:"mfa_completed_at,omitempty"`     // 第二因子验证通过时间
+	RiskLevel          string     `json:"risk_level,omitempty"`           // 风险等级
+	RiskReason         string     `json:"risk_reason,omitempty"`          // 命中的风险信号
+
 	// 额外数据（不序列化，仅在当前请求生命周期内有效）
 	Extra map[string]string `json:"-"`
 
@@ -95,10 +112,14 @@ type AuthRequest struct {
 	Prompt    binding.SpaceDelimited `json:"prompt,omitempty" form:"prompt"`         // none, login, consent
 	Nonce     string                 `json:"nonce,omitempty" form:"nonce"`           // 防重放攻击
 	LoginHint string                 `json:"login_hint,omitempty" form:"login_hint"` // 登录提示（邮箱/手机）
+	MaxAge    *int                   `json:"max_age,omitempty" form:"max_age"`       // 最大认证时长（秒），SSO 会话认证时间超过后强制重新登录
 
 	// 多 audience 扩展（授权阶段指定，token 交换时使用）
 	Audiences map[string]*RequestAudienceScope `json:"audiences,omitempty" form:"-"`
 
+	// RAR 扩展（RFC 9396）：表单中为 JSON 字符串，由 handler 单独解析
+	AuthorizationDetails tokendef.AuthorizationDetails `json:"authorization_details,omitempty" form:"-"`
+
 	// 其他扩展参数 - 序列化时平铺到顶层
 	Params map[string]any `json:"-" form:"-"`
 }
@@ -120,8 +141,11 @@ var authRequestKnownFields = map[string]bool{
 	"prompt":     true,
 	"nonce":      true,
 	"login_hint": true,
+	"max_age":    true,
 	// 多 audience 扩展
 	"audiences": true,
+	// RAR 扩展
+	"authorization_details": true,
 }
 
 // Prompt 常量
@@ -262,6 +286,19 @@ func GenerateFlowID() string {
 	return helpers.GenerateID(16)
 }
 
+// GenerateSessionID 生成 SSO 会话标识
+func GenerateSessionID() string {
+	return helpers.GenerateID(32)
+}
+
+// RequestURIPrefix PAR 签发的 request_uri 前缀（RFC 9126 §2.2）
+const RequestURIPrefix = "urn:ietf:params:oauth:request_uri:"
+
+// GeneratePushedRequestID 生成 PAR request_uri 标识（32位 Base62）
+func GeneratePushedRequestID() string {
+	return helpers.GenerateID(32)
+}
+
 // GenerateAuthorizationCode 生成授权码（32位 Base62，约 62^32 ≈ 2.3×10^57 种可能）
 func GenerateAuthorizationCode() string {
 	return helpers.GenerateID(32)
@@ -306,6 +343,14 @@ func (f *AuthFlow) CanAuthenticate() bool {
 	return f.State == FlowStateInitialized && !f.IsExpired()
 }
 
+// DeviceUserCode 返回设备授权 flow 绑定的 user_code，非设备授权 flow 返回空
+func (f *AuthFlow) DeviceUserCode() string {
+	if f.Request == nil || f.Request.ResponseType != ResponseTypeDeviceCode {
+		return ""
+	}
+	return f.Request.GetString(RequestParamUserCode)
+}
+
 // SetConnectionMap 设置 ConnectionMap
 func (f *AuthFlow) SetConnectionMap(connMap map[string]*ConnectionConfig) {
 	f.ConnectionMap = connMap
@@ -349,10 +394,11 @@ func (f *AuthFlow) GetIdentity(connection string) *models.UserIdentity {
 	return f.Identities.FindByIDP(connection)
 }
 
-// SetAuthenticated 设置为已认证状态
+// SetAuthenticated 设置为已认证状态，认证时间记为当前时间
 func (f *AuthFlow) SetAuthenticated(user *models.UserWithDecrypted) {
 	f.State = FlowStateAuthenticated
 	f.User = user
+	f.AuthTime = time.Now()
 }
 
 // AllRequiredVerified 检查当前 Connection 的所有 Require 依赖是否已验证
@@ -380,6 +426,28 @@ func (f *AuthFlow) SetAuthorized(grantedScopes []string) {
 	f.GrantedScopes = grantedScopes
 }
 
+// RequireMFA 进入 MFA 阶段：授权结果保留，签发授权码前需完成第二因子
+func (f *AuthFlow) RequireMFA(channels []string, riskLevel, riskReason string, expiresIn time.Duration) {
+	f.State = FlowStateMFA
+	f.MFAAllowedChannels = channels
+	f.MFAExpiresAt = time.Now().Add(expiresIn)
+	f.MFAAttempts = 0
+	f.RiskLevel = riskLevel
+	f.RiskReason = riskReason
+}
+
+// MFAExpired MFA 阶段是否已超时
+func (f *AuthFlow) MFAExpired() bool {
+	return !f.MFAExpiresAt.IsZero() && time.Now().After(f.MFAExpiresAt)
+}
+
+// CompleteMFA 第二因子验证通过，回到已授权状态
+func (f *AuthFlow) CompleteMFA() {
+	now := time.Now()
+	f.MFACompletedAt = &now
+	f.State = FlowStateAuthorized
+}
+
 // SetCompleted 设置为已完成状态
 func (f *AuthFlow) SetCompleted() {
 	f.State = FlowStateCompleted
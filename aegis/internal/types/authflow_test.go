@@ -0,0 +1,51 @@
+package types
+
+import (
+	"testing"
+
+	"github.com/go-json-experiment/json"
+
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// PAR 以 JSON 存储授权请求，多 audience 与扩展参数须完整保留
+func TestAuthRequestJSONRoundTrip(t *testing.T) {
+	req := &AuthRequest{
+		ResponseType: "code",
+		ClientID:     "app",
+		RedirectURI:  "https://app.example/callback",
+		Audiences: map[string]*RequestAudienceScope{
+			"iris":  {Scope: "iris:read"},
+			"chaos": nil,
+		},
+		AuthorizationDetails: []tokendef.AuthorizationDetail{
+			{"type": "recipe", "actions": []any{"read"}},
+		},
+		Params: map[string]any{"ui_locales": "zh-CN"},
+	}
+
+	data, err := json.Marshal(req)
+	if err != nil {
+		t.Fatalf("marshal: %v", err)
+	}
+	var got AuthRequest
+	if err := json.Unmarshal(data, &got); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+
+	if got.ClientID != "app" || got.RedirectURI != req.RedirectURI {
+		t.Fatalf("standard fields lost: %+v", got)
+	}
+	if len(got.Audiences) != 2 || got.Audiences["iris"] == nil || got.Audiences["iris"].Scope != "iris:read" {
+		t.Fatalf("audiences = %v", got.Audiences)
+	}
+	if len(got.AuthorizationDetails) != 1 || got.AuthorizationDetails[0].Type() != "recipe" {
+		t.Fatalf("authorization_details = %v", got.AuthorizationDetails)
+	}
+	if got.GetString("ui_locales") != "zh-CN" {
+		t.Fatalf("params = %v", got.Params)
+	}
+	if _, ok := got.Params["audiences"]; ok {
+		t.Fatal("known field leaked into params")
+	}
+}
@@ -16,14 +16,15 @@ type ChannelType = token.ChannelType
 
 // 常量别名 - 从 pkg/aegis/token 导入
 const (
-	ChannelTypeCaptcha  = token.ChannelTypeCaptcha
-	ChannelTypeEmailOTP = token.ChannelTypeEmailOTP
-	ChannelTypeTOTP     = token.ChannelTypeTOTP
-	ChannelTypeSmsOTP   = token.ChannelTypeSmsOTP
-	ChannelTypeTgOTP    = token.ChannelTypeTgOTP
-	ChannelTypeWebAuthn = token.ChannelTypeWebAuthn
-	ChannelTypeWechatMP = token.ChannelTypeWechatMP
-	ChannelTypeAlipayMP = token.ChannelTypeAlipayMP
+	ChannelTypeCaptcha      = token.ChannelTypeCaptcha
+	ChannelTypeEmailOTP     = token.ChannelTypeEmailOTP
+	ChannelTypeTOTP         = token.ChannelTypeTOTP
+	ChannelTypeSmsOTP       = token.ChannelTypeSmsOTP
+	ChannelTypeTgOTP        = token.ChannelTypeTgOTP
+	ChannelTypeWebAuthn     = token.ChannelTypeWebAuthn
+	ChannelTypeRecoveryCode = token.ChannelTypeRecoveryCode
+	ChannelTypeWechatMP     = token.ChannelTypeWechatMP
+	ChannelTypeAlipayMP     = token.ChannelTypeAlipayMP
 )
 
 // ChallengeRequiredConfig 前置条件配置
@@ -41,10 +41,16 @@ const (
 // ==================== Rate Limit Key 前缀 ====================
 
 const (
-	RateLimitKeyPrefixCreate     = "rl:create:"    // Challenge 创建频率（channel 维度）
-	RateLimitKeyPrefixCreateIP   = "rl:create:ip:" // Challenge 创建频率（IP 维度）
-	RateLimitKeyPrefixVerifyFail = "rl:vfail:"     // 验证错误计数（channel 维度）
-	RateLimitKeyPrefixLoginFail  = "rl:login:"     // 登录失败计数
+	RateLimitKeyPrefixCreate      = "rl:create:"    // Challenge 创建频率（channel 维度）
+	RateLimitKeyPrefixCreateIP    = "rl:create:ip:" // Challenge 创建频率（IP 维度）
+	RateLimitKeyPrefixVerifyFail  = "rl:vfail:"     // 验证错误计数（channel 维度）
+	RateLimitKeyPrefixLoginFail   = "rl:login:"     // 登录失败计数
+	RateLimitKeyPrefixLoginLock   = "rl:lock:"      // 登录锁定失败计数（标识维度）
+	RateLimitKeyPrefixLoginLockIP = "rl:lock:ip:"   // 登录锁定失败计数（IP 维度）
+
+	RateLimitKeyPrefixDeviceVerifyIP      = "rl:device:ip:"      // 设备验证页 user_code 错误计数（IP 维度）
+	RateLimitKeyPrefixDeviceVerifySession = "rl:device:session:" // 设备验证页 user_code 错误计数（会话维度）
+	RateLimitKeyPrefixSmsOTPVerify        = "rl:otp:sms:"        // 短信验证码输错计数（challenge 维度）
 )
 
 // ==================== Subject Type ====================
@@ -59,10 +65,16 @@ const (
 
 const (
 	CacheKeyPrefixEmailOTP = "email-code:" // 邮件验证码 cache key 前缀
+	CacheKeyPrefixSmsOTP   = "sms-code:"   // 短信验证码 cache key 前缀
 )
 
 // ==================== OAuth ====================
 
 const (
-	ResponseTypeCode = "code" // OAuth 授权码模式
+	ResponseTypeCode       = "code"        // OAuth 授权码模式
+	ResponseTypeDeviceCode = "device_code" // 设备授权模式（RFC 8628），flow 由验证页创建
+)
+
+const (
+	RequestParamUserCode = "user_code" // 设备授权 flow 绑定的 user_code（AuthRequest 扩展参数）
 )
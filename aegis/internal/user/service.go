@@ -4,7 +4,11 @@ import (
 	"context"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+
 	autherrors "github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
 	"github.com/heliannuuthus/aegis/internal/cache"
 	"github.com/heliannuuthus/aegis/models"
 	"github.com/heliannuuthus/aegis/rpc/hermes"
@@ -66,6 +70,106 @@ func (s *Service) LinkIdentity(ctx context.Context, identity *models.UserIdentit
 	return s.hermes.CreateIdentity(ctx, identity)
 }
 
+// BindIdentity 为已登录用户绑定新的 IDP 身份
+// 用户须已有该 IDP 所属域的 global 身份；上游身份已绑定到其他用户，或用户已绑定同一 IDP 的其他账号时返回 identity_conflict；
+// 上游身份已绑定到当前用户时视为成功
+func (s *Service) BindIdentity(ctx context.Context, openid string, identity *models.UserIdentity) error {
+	owners, err := s.hermes.ListIdentitiesByIdentity(ctx, identity.Domain, identity.IDP, identity.TOpenID)
+	if err != nil {
+		return autherrors.NewServerErrorf("query identity owner failed: %v", err)
+	}
+	if bound := owners.FindByDomainAndIDP(identity.Domain, identity.IDP); bound != nil {
+		if bound.UID == openid {
+			return nil
+		}
+		return autherrors.NewIdentityConflict("identity is already bound to another user")
+	}
+
+	identities, err := s.hermes.ListUserIdentities(ctx, openid)
+	if err != nil {
+		return autherrors.NewServerErrorf("list identities failed: %v", err)
+	}
+	if identities.FindByDomainAndIDP(identity.Domain, idp.TypeGlobal) == nil {
+		return autherrors.NewInvalidRequestf("user has no account in domain %s", identity.Domain)
+	}
+	if identities.FindByDomainAndIDP(identity.Domain, identity.IDP) != nil {
+		return autherrors.NewIdentityConflict("another account of this idp is already bound, unbind it first")
+	}
+
+	identity.UID = openid
+	if err := s.hermes.CreateIdentity(ctx, identity); err != nil {
+		// 并发绑定时由唯一索引兜底
+		if status.Code(err) == codes.AlreadyExists {
+			return autherrors.NewIdentityConflict("identity is already bound to another user")
+		}
+		return autherrors.NewServerErrorf("bind identity failed: %v", err)
+	}
+	return nil
+}
+
+// UnbindIdentity 解绑用户的 IDP 身份
+// global 身份不可解绑；解绑后用户须至少保留一种可用登录方式（其他 IDP 身份、密码或已启用的通行密钥）
+func (s *Service) UnbindIdentity(ctx context.Context, openid, idpType string) error {
+	if idpType == idp.TypeGlobal {
+		return autherrors.NewInvalidRequest("global identity cannot be unbound")
+	}
+
+	identities, err := s.hermes.ListUserIdentities(ctx, openid)
+	if err != nil {
+		return autherrors.NewServerErrorf("list identities failed: %v", err)
+	}
+	identity := identities.FindByIDP(idpType)
+	if identity == nil {
+		return autherrors.NewNotFoundf("identity %s is not bound", idpType)
+	}
+
+	user, err := s.hermes.GetUserByOpenID(ctx, openid)
+	if err != nil {
+		return autherrors.NewServerErrorf("get user failed: %v", err)
+	}
+	credentials, err := s.hermes.ListUserCredentials(ctx, openid)
+	if err != nil {
+		return autherrors.NewServerErrorf("list credentials failed: %v", err)
+	}
+	hasPassword := user.PasswordHash != nil && *user.PasswordHash != ""
+	if !hasOtherLoginMethod(identities, idpType, hasPassword, credentials) {
+		return autherrors.NewInvalidRequest("cannot unbind the last login method")
+	}
+
+	if err := s.hermes.DeleteIdentity(ctx, identity.Domain, openid, idpType); err != nil {
+		if status.Code(err) == codes.NotFound {
+			return autherrors.NewNotFoundf("identity %s is not bound", idpType)
+		}
+		return autherrors.NewServerErrorf("unbind identity failed: %v", err)
+	}
+	return nil
+}
+
+// hasOtherLoginMethod 解绑 idpType 后是否仍有可用登录方式
+// 仅计入：其他 IDP 身份、设置了密码的账号密码身份（user / staff）、已启用的通行密钥；
+// 普通 WebAuthn 凭证与 TOTP 一样只能作为第二因素，不计入
+func hasOtherLoginMethod(identities models.Identities, idpType string, hasPassword bool, credentials []models.UserCredential) bool {
+	for _, identity := range identities {
+		switch identity.IDP {
+		case idpType, idp.TypeGlobal:
+			continue
+		case idp.TypeUser, idp.TypeStaff:
+			if hasPassword {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	for i := range credentials {
+		cred := &credentials[i]
+		if cred.Enabled && models.CredentialType(cred.Type) == models.CredentialTypePasskey {
+			return true
+		}
+	}
+	return false
+}
+
 // CreateUser 创建用户，返回全部身份
 func (s *Service) CreateUser(ctx context.Context, identity *models.UserIdentity, userInfo *models.TUserInfo) (models.Identities, error) {
 	newUser, err := s.hermes.CreateUser(ctx, identity, userInfo)
@@ -0,0 +1,61 @@
+package user
+
+import (
+	"testing"
+
+	"github.com/heliannuuthus/aegis/models"
+)
+
+func TestHasOtherLoginMethod(t *testing.T) {
+	identities := func(idps ...string) models.Identities {
+		ids := make(models.Identities, 0, len(idps))
+		for _, idp := range idps {
+			ids = append(ids, &models.UserIdentity{Domain: "platform", IDP: idp})
+		}
+		return ids
+	}
+
+	tests := []struct {
+		name        string
+		identities  models.Identities
+		hasPassword bool
+		credentials []models.UserCredential
+		want        bool
+	}{
+		{name: "another idp remains", identities: identities("global", "github", "google"), want: true},
+		{name: "only global remains", identities: identities("global", "github"), want: false},
+		{
+			name:        "enabled passkey remains",
+			identities:  identities("global", "github"),
+			credentials: []models.UserCredential{{Type: string(models.CredentialTypePasskey), Enabled: true}},
+			want:        true,
+		},
+		{
+			name:        "disabled passkey does not count",
+			identities:  identities("global", "github"),
+			credentials: []models.UserCredential{{Type: string(models.CredentialTypePasskey)}},
+			want:        false,
+		},
+		{
+			name:        "webauthn credential is a second factor",
+			identities:  identities("global", "github"),
+			credentials: []models.UserCredential{{Type: string(models.CredentialTypeWebAuthn), Enabled: true}},
+			want:        false,
+		},
+		{name: "password remains", identities: identities("global", "github", "user"), hasPassword: true, want: true},
+		{name: "password identity without password", identities: identities("global", "github", "user"), want: false},
+		{
+			name:        "totp is not a login method",
+			identities:  identities("global", "github"),
+			credentials: []models.UserCredential{{Type: string(models.CredentialTypeTOTP), Enabled: true}},
+			want:        false,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := hasOtherLoginMethod(tt.identities, "github", tt.hasPassword, tt.credentials); got != tt.want {
+				t.Fatalf("hasOtherLoginMethod() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
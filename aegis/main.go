@@ -2,8 +2,12 @@ package main
 
 import (
 	"context"
+	"errors"
 	"fmt"
+	"net/http"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/gin-gonic/gin"
@@ -62,8 +66,12 @@ func main() {
 	}
 	logger.Infof("[Auth] Redis 连接成功")
 
+	// 收到退出信号后停止接收请求，并放弃后台等待中的任务
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
 	cacheManager := cache.NewManager(client, redis)
-	aegisHandler, err := initializeAegis(client, cacheManager)
+	aegisHandler, err := initializeAegis(ctx, client, cacheManager)
 	if err != nil {
 		logger.Fatalf("初始化 aegis 失败: %v", err)
 	}
@@ -82,6 +90,8 @@ func main() {
 
 	aegisCORS := middleware.CORS(aegisHandler.CacheManager())
 
+	r.GET("/.well-known/openid-configuration", aegisHandler.Discovery)
+
 	authGroup := r.Group("/auth")
 	{
 		corsRoutes := []struct {
@@ -95,13 +105,21 @@ func main() {
 			{"POST", "/idps", aegisHandler.IDPs},
 			{"GET", "/binding", aegisHandler.GetIdentifyContext},
 			{"POST", "/binding", aegisHandler.ConfirmIdentify},
+			{"POST", "/consent", aegisHandler.Consent},
+			{"GET", "/mfa", aegisHandler.GetMFAContext},
+			{"POST", "/mfa/complete", aegisHandler.CompleteMFA},
 			{"POST", "/challenge", aegisHandler.InitiateChallenge},
 			{"POST", "/challenge/:cid", aegisHandler.ContinueChallenge},
+			{"POST", "/password/reset", aegisHandler.ResetPassword},
 			{"POST", "/token", aegisHandler.Token},
+			{"POST", "/device/code", aegisHandler.DeviceCode},
+			{"POST", "/device/verify", aegisHandler.DeviceVerify},
 			{"POST", "/revoke", aegisHandler.Revoke},
 			{"POST", "/logout", aegisHandler.Logout},
 			{"GET", "/logout", aegisHandler.LogoutGET},
 			{"GET", "/pubkeys", aegisHandler.PublicKeys},
+			{"GET", "/userinfo", aegisHandler.UserInfo},
+			{"POST", "/userinfo", aegisHandler.UserInfo},
 		}
 		registered := make(map[string]bool)
 		for _, route := range corsRoutes {
@@ -112,7 +130,17 @@ func main() {
 			}
 		}
 		authGroup.GET("/idps/:connection/callback", aegisHandler.OAuthCallback)
+		authGroup.POST("/idps/:connection/callback", aegisHandler.OAuthCallback)
+		authGroup.GET("/idps/:connection/metadata/:client_id", aegisHandler.SAMLMetadata)
+		authGroup.POST("/par", aegisHandler.PushedAuthorize)
+		authGroup.POST("/register", aegisHandler.RegisterClient)
+		authGroup.GET("/register/:client_id", aegisHandler.GetRegisteredClient)
+		authGroup.PUT("/register/:client_id", aegisHandler.UpdateRegisteredClient)
+		authGroup.DELETE("/register/:client_id", aegisHandler.DeleteRegisteredClient)
 		authGroup.POST("/check", aegisHandler.Check)
+		authGroup.POST("/introspect", aegisHandler.Introspect)
+		authGroup.GET("/revocations", aegisHandler.Revocations)
+		authGroup.GET("/backchannel-logout/deliveries", aegisHandler.LogoutDeliveries)
 	}
 
 	profile := aegisHandler.Profile()
@@ -120,6 +148,10 @@ func main() {
 	if err != nil {
 		logger.Fatalf("初始化 Iris 鉴权中间件失败: %v", err)
 	}
+	// 多实例共享 DPoP proof 防重放存储
+	guard.SetDPoPReplayCache(aegisHandler.CacheManager().DPoPReplayCache())
+	// htu 以配置的对外地址还原，不信任客户端可控的 X-Forwarded-* 头
+	guard.SetDPoPPublicURL(aegisconfig.GetIssuer())
 	userGroup := r.Group("/user")
 	{
 		userRoutes := []struct {
@@ -136,6 +168,8 @@ func main() {
 			{"POST", "/mfa/:uid", profile.CompleteMFA},
 			{"PATCH", "/mfa", profile.UpdateMFA},
 			{"DELETE", "/mfa", profile.DeleteMFA},
+			{"GET", "/consents", profile.ListConsents},
+			{"DELETE", "/consents/:app_id", profile.RevokeConsent},
 		}
 		registered := make(map[string]bool)
 		for _, route := range userRoutes {
@@ -148,10 +182,21 @@ func main() {
 	}
 
 	addr := fmt.Sprintf(":%d", config.GetServerPort())
+	srv := &http.Server{Addr: addr, Handler: r.Handler(), ReadHeaderTimeout: 10 * time.Second}
+	go func() {
+		<-ctx.Done()
+		shutdownCtx, cancel := context.WithTimeout(context.Background(), 10*time.Second)
+		defer cancel()
+		if err := srv.Shutdown(shutdownCtx); err != nil {
+			logger.Warnf("aegis 服务关闭失败: %v", err)
+		}
+	}()
+
 	logger.Infof("aegis 服务启动: %s", addr)
-	if err := r.Run(addr); err != nil {
+	if err := srv.ListenAndServe(); err != nil && !errors.Is(err, http.ErrServerClosed) {
 		logger.Fatalf("服务启动失败: %v", err)
 	}
+	logger.Info("aegis 服务已停止")
 }
 
 func initTokenManager(client *hermesrpc.Client) error {
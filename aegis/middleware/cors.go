@@ -16,7 +16,7 @@ import (
 const (
 	wildcard             = "*"
 	defaultAllowMethods  = "GET, POST, PUT, DELETE, OPTIONS, PATCH"
-	defaultAllowHeaders  = "Content-Type, Authorization, X-Requested-With"
+	defaultAllowHeaders  = "Content-Type, Authorization, X-Requested-With, DPoP"
 	defaultExposeHeaders = "Location"
 	defaultMaxAge        = "86400"
 
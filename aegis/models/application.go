@@ -25,10 +25,28 @@ type Application struct {
 	IDTokenExpiresIn              uint      `json:"id_token_expires_in"`
 	RefreshTokenExpiresIn         uint      `json:"refresh_token_expires_in"`
 	RefreshTokenAbsoluteExpiresIn uint      `json:"refresh_token_absolute_expires_in"`
+	RefreshTokenRotation          bool      `json:"refresh_token_rotation"`                // 刷新时轮换 refresh_token（旧 token 重用将撤销整个 family）
+	DPoPBoundAccessTokens         bool      `json:"dpop_bound_access_tokens"`              // 强制 DPoP 绑定 access token（RFC 9449）
+	BackchannelLogoutURI          *string   `json:"backchannel_logout_uri,omitempty"`      // 后端通道登出通知 URI（OIDC Back-Channel Logout）
+	RequirePushedAuthRequests     bool      `json:"require_pushed_authorization_requests"` // 强制通过 PAR 发起授权请求（RFC 9126）
+	RegistrationTokenHash         *string   `json:"-"`                                     // 动态注册的 registration_access_token 摘要（RFC 7592）
 	CreatedAt                     time.Time `json:"created_at"`
 	UpdatedAt                     time.Time `json:"updated_at"`
 }
 
+// ApplicationMetadata 创建/整体替换应用时的可写元数据（用于动态客户端注册）
+type ApplicationMetadata struct {
+	Name                      string
+	LogoURL                   *string
+	AllowedRedirectURIs       []string
+	AllowedOrigins            []string
+	AllowedLogoutURIs         []string
+	BackchannelLogoutURI      *string
+	DPoPBoundAccessTokens     bool
+	RequirePushedAuthRequests bool
+	RegistrationTokenHash     *string // 仅创建时写入
+}
+
 // ApplicationWithKey 带密钥的 Application（Main/Keys 不序列化到 API）
 type ApplicationWithKey struct {
 	Application
@@ -46,6 +64,7 @@ type ApplicationIDPConfig struct {
 	Delegate  *string   `json:"delegate,omitempty"`
 	Require   *string   `json:"require,omitempty"`
 	TAppID    *string   `json:"t_app_id,omitempty"`
+	Config    *string   `json:"config,omitempty"` // 连接参数（JSON），覆盖域 IDP 配置中的同名项
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
@@ -153,6 +172,14 @@ func (a *Application) ResolveLogoutRedirect(returnTo, referer string) (string, e
 	return "", errors.New("return_to or referer does not match allowed_logout_uris")
 }
 
+// GetBackchannelLogoutURI 返回后端通道登出通知 URI，未配置时返回空
+func (a *Application) GetBackchannelLogoutURI() string {
+	if a.BackchannelLogoutURI == nil {
+		return ""
+	}
+	return *a.BackchannelLogoutURI
+}
+
 // GetAllowedOrigins 解析允许的跨域源列表
 func (a *Application) GetAllowedOrigins() []string {
 	if a.AllowedOrigins == nil || *a.AllowedOrigins == "" {
@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// UserConsent 用户授权同意（从 proto 转换）
+// 同一用户 + 应用 + 服务 唯一，Scopes 为累计同意的 scope
+type UserConsent struct {
+	OpenID    string    `json:"openid"`
+	AppID     string    `json:"app_id"`
+	ServiceID string    `json:"service_id"`
+	Scopes    []string  `json:"scopes"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
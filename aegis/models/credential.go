@@ -6,9 +6,11 @@ import "time"
 type CredentialType string
 
 const (
-	CredentialTypeTOTP     CredentialType = "totp"
-	CredentialTypeWebAuthn CredentialType = "webauthn"
-	CredentialTypePasskey  CredentialType = "passkey"
+	CredentialTypeTOTP            CredentialType = "totp"
+	CredentialTypeWebAuthn        CredentialType = "webauthn"
+	CredentialTypePasskey         CredentialType = "passkey"
+	CredentialTypeRecoveryCode    CredentialType = "recovery_code"
+	CredentialTypePasswordHistory CredentialType = "password_history"
 )
 
 // UserCredential 用户安全凭证（从 proto 转换）
@@ -27,9 +29,10 @@ type UserCredential struct {
 
 // MFAStatus 用户 MFA 状态
 type MFAStatus struct {
-	TOTPEnabled   bool `json:"totp_enabled"`
-	WebAuthnCount int  `json:"webauthn_count"`
-	PasskeyCount  int  `json:"passkey_count"`
+	TOTPEnabled            bool `json:"totp_enabled"`
+	WebAuthnCount          int  `json:"webauthn_count"`
+	PasskeyCount           int  `json:"passkey_count"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
 }
 
 // TOTPSecret TOTP 凭证数据结构（存储在 secret JSON 中）
@@ -24,6 +24,7 @@ type DomainIDPConfig struct {
 	Priority  int       `json:"priority"`
 	Strategy  *string   `json:"strategy,omitempty"`
 	TAppID    string    `json:"t_app_id"`
+	Config    *string   `json:"config,omitempty"` // 连接参数（JSON），如通用 OIDC 的 issuer / endpoints / scopes / claim 映射
 	CreatedAt time.Time `json:"created_at"`
 	UpdatedAt time.Time `json:"updated_at"`
 }
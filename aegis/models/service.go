@@ -59,3 +59,14 @@ type ServiceChallengeSetting struct {
 	CreatedAt time.Time  `json:"created_at"`
 	UpdatedAt time.Time  `json:"updated_at"`
 }
+
+// ServiceAuthorizationDetailType 服务声明的授权详情类型（从 proto 转换）
+type ServiceAuthorizationDetailType struct {
+	ID          uint      `json:"_id"`
+	ServiceID   string    `json:"service_id"`
+	Type        string    `json:"type"`
+	Description *string   `json:"description,omitempty"`
+	JSONSchema  *string   `json:"json_schema,omitempty"` // JSON Schema 子集，约束除 type 以外的字段
+	CreatedAt   time.Time `json:"created_at"`
+	UpdatedAt   time.Time `json:"updated_at"`
+}
@@ -20,6 +20,7 @@ type User struct {
 	Phone         *string    `json:"-"`
 	PhoneCipher   *string    `json:"-"`
 	LastLoginAt   *time.Time `json:"last_login_at"`
+	LockedUntil   *time.Time `json:"locked_until,omitempty"`
 	CreatedAt     time.Time  `json:"created_at"`
 	UpdatedAt     time.Time  `json:"updated_at"`
 }
@@ -29,6 +30,11 @@ func (u *User) IsActive() bool {
 	return u.Status == 0
 }
 
+// IsLocked 用户是否处于登录失败锁定中
+func (u *User) IsLocked(now time.Time) bool {
+	return u.LockedUntil != nil && u.LockedUntil.After(now)
+}
+
 // UserIdentity 用户身份（IDP 绑定）
 type UserIdentity struct {
 	ID        uint      `json:"_id"`
@@ -4,16 +4,18 @@ import (
 	"bytes"
 	"context"
 	"encoding/base64"
-	stderrors "errors"
 	"io"
 	"net/http"
 
 	"github.com/gin-gonic/gin"
 	"github.com/go-json-experiment/json/jsontext"
-	"golang.org/x/crypto/bcrypt"
 
 	"github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/cache"
+	"github.com/heliannuuthus/aegis/internal/consent"
 	"github.com/heliannuuthus/aegis/internal/mfa"
+	"github.com/heliannuuthus/aegis/internal/password"
+	"github.com/heliannuuthus/aegis/internal/user"
 	"github.com/heliannuuthus/aegis/models"
 	"github.com/heliannuuthus/aegis/rpc/hermes"
 	"github.com/heliannuuthus/pkg/aegis/guard"
@@ -21,14 +23,22 @@ import (
 )
 
 type Handler struct {
-	hermes *hermes.Client
-	mfaSvc *mfa.Service
+	hermes      *hermes.Client
+	mfaSvc      *mfa.Service
+	consentSvc  *consent.Service
+	userSvc     *user.Service
+	passwordSvc *password.Service
+	cache       *cache.Manager
 }
 
-func NewHandler(hermesClient *hermes.Client, mfaSvc *mfa.Service) *Handler {
+func NewHandler(hermesClient *hermes.Client, mfaSvc *mfa.Service, consentSvc *consent.Service, userSvc *user.Service, passwordSvc *password.Service, cacheManager *cache.Manager) *Handler {
 	return &Handler{
-		hermes: hermesClient,
-		mfaSvc: mfaSvc,
+		hermes:      hermesClient,
+		mfaSvc:      mfaSvc,
+		consentSvc:  consentSvc,
+		userSvc:     userSvc,
+		passwordSvc: passwordSvc,
+		cache:       cacheManager,
 	}
 }
 
@@ -104,8 +114,8 @@ func (h *Handler) UpdateProfile(c *gin.Context) {
 	}
 
 	if hasPasswordUpdate {
-		if err := h.changePassword(ctx, openid, req.OldPassword, req.Password.Value()); err != nil {
-			h.writeError(c, errors.NewInvalidRequest(err.Error()))
+		if err := h.passwordSvc.Change(ctx, openid, req.OldPassword, req.Password.Value()); err != nil {
+			h.writeError(c, err)
 			return
 		}
 	}
@@ -149,22 +159,6 @@ func (h *Handler) ListIdentities(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"identities": resp})
 }
 
-func (h *Handler) BindIdentity(c *gin.Context) {
-	if guard.OpenID(c.Request.Context()) == "" {
-		h.writeError(c, errors.NewInvalidToken("not authenticated"))
-		return
-	}
-	h.writeError(c, errors.NewServerError("not implemented"))
-}
-
-func (h *Handler) UnbindIdentity(c *gin.Context) {
-	if guard.OpenID(c.Request.Context()) == "" {
-		h.writeError(c, errors.NewInvalidToken("not authenticated"))
-		return
-	}
-	h.writeError(c, errors.NewServerError("not implemented"))
-}
-
 func (h *Handler) GetMFAStatus(c *gin.Context) {
 	openid := guard.OpenID(c.Request.Context())
 	if openid == "" {
@@ -192,7 +186,7 @@ func (h *Handler) GetMFAStatus(c *gin.Context) {
 }
 
 type SetupMFARequest struct {
-	Type    string `json:"type" binding:"required,oneof=totp webauthn passkey"`
+	Type    string `json:"type" binding:"required,oneof=totp webauthn passkey recovery_code"`
 	AppName string `json:"app_name,omitempty"`
 }
 
@@ -228,6 +222,22 @@ func (h *Handler) SetupMFA(c *gin.Context) {
 	case models.CredentialTypeWebAuthn, models.CredentialTypePasskey:
 		h.beginWebAuthnRegistration(c, openid, req.Type)
 
+	case models.CredentialTypeRecoveryCode:
+		// 重新生成会作废现有恢复码，要求最近完成过认证
+		if err := requireRecentAuth(ctx); err != nil {
+			h.writeError(c, err)
+			return
+		}
+		codes, err := h.mfaSvc.GenerateRecoveryCodes(ctx, openid)
+		if err != nil {
+			h.writeError(c, errors.NewServerError(err.Error()))
+			return
+		}
+		c.JSON(http.StatusOK, gin.H{
+			"type":  "recovery_code",
+			"codes": codes,
+		})
+
 	default:
 		h.writeError(c, errors.NewInvalidRequest("unsupported credential type"))
 	}
@@ -270,7 +280,16 @@ func (h *Handler) CompleteMFA(c *gin.Context) {
 			h.writeError(c, errors.NewInvalidRequest(err.Error()))
 			return
 		}
-		c.JSON(http.StatusOK, gin.H{"type": "totp", "success": true})
+		recoveryCodes, err := h.initialRecoveryCodes(ctx, openid)
+		if err != nil {
+			h.writeError(c, errors.NewServerError(err.Error()))
+			return
+		}
+		body := gin.H{"type": "totp", "success": true}
+		if len(recoveryCodes) > 0 {
+			body["recovery_codes"] = recoveryCodes
+		}
+		c.JSON(http.StatusOK, body)
 
 	case models.CredentialTypeWebAuthn, models.CredentialTypePasskey:
 		h.finishWebAuthnRegistration(c, openid, req.Type, uid, req.Credential)
@@ -390,30 +409,47 @@ func (h *Handler) DeleteMFA(c *gin.Context) {
 	c.JSON(http.StatusOK, gin.H{"success": true})
 }
 
-func (h *Handler) writeError(c *gin.Context, err error) {
-	authErr := errors.ToAuthError(err)
-	c.JSON(authErr.HTTPStatus, authErr)
-}
+func (h *Handler) ListConsents(c *gin.Context) {
+	openid := guard.OpenID(c.Request.Context())
+	if openid == "" {
+		h.writeError(c, errors.NewInvalidToken("not authenticated"))
+		return
+	}
 
-func (h *Handler) changePassword(ctx context.Context, openid, oldPassword, newPassword string) error {
-	user, err := h.hermes.GetUserByOpenID(ctx, openid)
+	consents, err := h.consentSvc.List(c.Request.Context(), openid)
 	if err != nil {
-		return stderrors.New("user not found")
+		h.writeError(c, errors.NewServerError(err.Error()))
+		return
 	}
-	if user.PasswordHash != nil && *user.PasswordHash != "" {
-		if oldPassword == "" {
-			return stderrors.New("old password is required")
-		}
-		if err := bcrypt.CompareHashAndPassword([]byte(*user.PasswordHash), []byte(oldPassword)); err != nil {
-			return stderrors.New("old password is incorrect")
-		}
+
+	c.JSON(http.StatusOK, gin.H{"consents": consents})
+}
+
+// RevokeConsent 撤销对应用的全部授权（同时撤销该应用的 refresh_token）
+func (h *Handler) RevokeConsent(c *gin.Context) {
+	openid := guard.OpenID(c.Request.Context())
+	if openid == "" {
+		h.writeError(c, errors.NewInvalidToken("not authenticated"))
+		return
 	}
 
-	hash, err := bcrypt.GenerateFromPassword([]byte(newPassword), bcrypt.DefaultCost)
-	if err != nil {
-		return err
+	appID := c.Param("app_id")
+	if appID == "" {
+		h.writeError(c, errors.NewInvalidRequest("app_id is required"))
+		return
 	}
-	return h.hermes.PatchUser(ctx, openid, map[string]any{"password_hash": string(hash)})
+
+	if err := h.consentSvc.Revoke(c.Request.Context(), openid, appID); err != nil {
+		h.writeError(c, errors.NewServerError(err.Error()))
+		return
+	}
+
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+func (h *Handler) writeError(c *gin.Context, err error) {
+	authErr := errors.ToAuthError(err)
+	c.JSON(authErr.HTTPStatus, authErr)
 }
 
 func (h *Handler) beginWebAuthnRegistration(c *gin.Context, openID, credType string) {
@@ -436,6 +472,18 @@ func (h *Handler) beginWebAuthnRegistration(c *gin.Context, openID, credType str
 	})
 }
 
+// initialRecoveryCodes 首次完成 MFA 登记后生成恢复码；已有可用恢复码时返回 nil（重新生成请使用 recovery_code 类型）
+func (h *Handler) initialRecoveryCodes(ctx context.Context, openid string) ([]string, error) {
+	status, err := h.mfaSvc.Status(ctx, openid)
+	if err != nil {
+		return nil, err
+	}
+	if status.RecoveryCodesRemaining > 0 {
+		return nil, nil
+	}
+	return h.mfaSvc.GenerateRecoveryCodes(ctx, openid)
+}
+
 func (h *Handler) finishWebAuthnRegistration(c *gin.Context, openID, credType, uid string, credentialJSON jsontext.Value) {
 	if uid == "" {
 		h.writeError(c, errors.NewInvalidRequest("uid is required"))
@@ -452,9 +500,18 @@ func (h *Handler) finishWebAuthnRegistration(c *gin.Context, openID, credType, u
 		h.writeError(c, errors.NewInvalidRequest(err.Error()))
 		return
 	}
-	c.JSON(http.StatusOK, gin.H{
+	recoveryCodes, err := h.initialRecoveryCodes(c.Request.Context(), openID)
+	if err != nil {
+		h.writeError(c, errors.NewServerError(err.Error()))
+		return
+	}
+	body := gin.H{
 		"type":          credType,
 		"success":       true,
 		"credential_id": base64.RawURLEncoding.EncodeToString(credInfo.ID),
-	})
+	}
+	if len(recoveryCodes) > 0 {
+		body["recovery_codes"] = recoveryCodes
+	}
+	c.JSON(http.StatusOK, body)
 }
@@ -0,0 +1,198 @@
+package profile
+
+import (
+	"context"
+	stderrors "errors"
+	"io"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/aegis/errors"
+	"github.com/heliannuuthus/aegis/internal/authenticator"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp"
+	"github.com/heliannuuthus/aegis/models"
+	"github.com/heliannuuthus/pkg/aegis/guard"
+	"github.com/heliannuuthus/pkg/aegis/guard/requirement"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+// BindingCookie 身份绑定 Cookie：保存发起绑定时的 OAuth state，回调时校验是同一浏览器
+const BindingCookie = "aegis-identity-binding"
+
+// IdentityProvider 可用于绑定的 IDP（由 IDPAuthenticator 实现）
+type IdentityProvider interface {
+	Identify(ctx context.Context, clientID, proof string, oauthCtx *idp.OAuthLoginContext) (*models.UserIdentity, error)
+}
+
+type identityInitiator interface {
+	Initiate(ctx context.Context, initiation *idp.InitiateContext, strategy string) (*idp.InitiateResponse, error)
+}
+
+// ResolveIdentityProvider 查找可绑定的 IDP；global、账号密码与 Passkey 不通过绑定接口管理
+func ResolveIdentityProvider(connection string) (IdentityProvider, error) {
+	switch connection {
+	case idp.TypeGlobal, idp.TypeUser, idp.TypeStaff, idp.TypePasskey:
+		return nil, errors.NewInvalidRequestf("idp %s cannot be bound", connection)
+	}
+	auth, ok := authenticator.GlobalRegistry().Get(connection)
+	if !ok {
+		return nil, errors.NewInvalidRequestf("idp %s is not configured", connection)
+	}
+	provider, ok := auth.(IdentityProvider)
+	if !ok {
+		return nil, errors.NewInvalidRequestf("idp %s cannot be bound", connection)
+	}
+	return provider, nil
+}
+
+type BindIdentityRequest struct {
+	Proof string `json:"proof,omitempty"`
+}
+
+// BindIdentity POST /user/identities/:idp
+// 跳转类 IDP（GitHub / Google）返回上游授权地址，上游回调 /auth/idps/:connection/callback 时完成绑定；
+// 其他 IDP（如小程序）携带客户端获取的 proof，直接换取上游身份并绑定
+func (h *Handler) BindIdentity(c *gin.Context) {
+	ctx := c.Request.Context()
+	openid := guard.OpenID(ctx)
+	if openid == "" {
+		h.writeError(c, errors.NewInvalidToken("not authenticated"))
+		return
+	}
+	if err := requireRecentAuth(ctx); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	var req BindIdentityRequest
+	if err := c.ShouldBindJSON(&req); err != nil && !stderrors.Is(err, io.EOF) {
+		h.writeError(c, errors.NewInvalidRequest(err.Error()))
+		return
+	}
+
+	connection := c.Param("idp")
+	provider, err := ResolveIdentityProvider(connection)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	clientID := guard.AccessToken(ctx).ClientID()
+
+	if idp.IsOAuthRedirectConnection(connection) {
+		h.initiateIdentityBinding(c, openid, clientID, connection, provider)
+		return
+	}
+
+	if req.Proof == "" {
+		h.writeError(c, errors.NewInvalidRequest("proof is required"))
+		return
+	}
+	identity, err := provider.Identify(ctx, clientID, req.Proof, nil)
+	if err != nil {
+		h.writeError(c, err)
+		return
+	}
+	if err := h.userSvc.BindIdentity(ctx, openid, identity); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	logger.Infof("[Profile] 身份绑定成功 - OpenID: %s, IDP: %s", openid, connection)
+	c.JSON(http.StatusOK, gin.H{"idp": connection, "success": true})
+}
+
+// initiateIdentityBinding 创建绑定用的 OAuth 事务并返回上游授权地址
+func (h *Handler) initiateIdentityBinding(c *gin.Context, openid, clientID, connection string, provider IdentityProvider) {
+	ctx := c.Request.Context()
+
+	initiator, ok := provider.(identityInitiator)
+	if !ok {
+		h.writeError(c, errors.NewInvalidRequestf("idp %s does not support initiate", connection))
+		return
+	}
+	tx, err := idp.NewBindingTransaction(openid, clientID, connection, config.GetIDPRedirectURI(connection))
+	if err != nil {
+		h.writeError(c, errors.NewServerError("oauth transaction initialization failed"))
+		return
+	}
+	resp, err := initiator.Initiate(ctx, &idp.InitiateContext{Transaction: tx}, "")
+	if err != nil {
+		logger.Errorf("[Profile] 身份绑定初始化失败 - OpenID: %s, IDP: %s, Error: %v", openid, connection, err)
+		h.writeError(c, errors.NewServerErrorf("idp initiate failed: %v", err))
+		return
+	}
+	if resp.URL == "" {
+		h.writeError(c, errors.NewServerError("oauth authorization URL is empty"))
+		return
+	}
+	if err := h.cache.SaveOAuthTransaction(ctx, tx, config.GetOAuthStateExpiresIn()); err != nil {
+		h.writeError(c, errors.NewServerError("save oauth transaction failed"))
+		return
+	}
+	setBindingCookie(c, tx.State)
+	c.JSON(http.StatusOK, gin.H{"idp": connection, "url": resp.URL})
+}
+
+// UnbindIdentity DELETE /user/identities/:idp
+// 解绑后用户须至少保留一种可用登录方式
+func (h *Handler) UnbindIdentity(c *gin.Context) {
+	ctx := c.Request.Context()
+	openid := guard.OpenID(ctx)
+	if openid == "" {
+		h.writeError(c, errors.NewInvalidToken("not authenticated"))
+		return
+	}
+	if err := requireRecentAuth(ctx); err != nil {
+		h.writeError(c, err)
+		return
+	}
+
+	connection := c.Param("idp")
+	if err := h.userSvc.UnbindIdentity(ctx, openid, connection); err != nil {
+		h.writeError(c, err)
+		return
+	}
+	logger.Infof("[Profile] 身份解绑成功 - OpenID: %s, IDP: %s", openid, connection)
+	c.JSON(http.StatusOK, gin.H{"success": true})
+}
+
+// requireRecentAuth 绑定 / 解绑身份、重新生成恢复码要求用户最近完成过认证，否则客户端应以 max_age 重新发起授权
+func requireRecentAuth(ctx context.Context) error {
+	maxAge := config.GetIdentityReauthMaxAge()
+	if err := requirement.MaxAge(maxAge).Enforce(ctx); err != nil {
+		authErr := errors.NewLoginRequired("recent authentication required")
+		authErr.Data = map[string]any{"max_age": int(maxAge.Seconds())}
+		return authErr
+	}
+	return nil
+}
+
+func setBindingCookie(c *gin.Context, state string) {
+	cookie := &http.Cookie{ // #nosec G124 -- secure cookie flags default to true and are controlled by deployment config.
+		Name:     BindingCookie,
+		Value:    state,
+		MaxAge:   int(config.GetOAuthStateExpiresIn().Seconds()),
+		Path:     config.GetCookiePath(),
+		Domain:   config.GetCookieDomain(),
+		Secure:   config.GetCookieSecure(),
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	}
+	http.SetCookie(c.Writer, cookie)
+}
+
+// ClearBindingCookie 清除身份绑定 Cookie
+func ClearBindingCookie(c *gin.Context) {
+	cookie := &http.Cookie{ // #nosec G124 -- secure cookie flags default to true and must match the configured cookie for deletion.
+		Name:     BindingCookie,
+		Value:    "",
+		MaxAge:   -1,
+		Path:     config.GetCookiePath(),
+		Domain:   config.GetCookieDomain(),
+		Secure:   config.GetCookieSecure(),
+		HttpOnly: true,
+		SameSite: http.SameSiteNoneMode,
+	}
+	http.SetCookie(c.Writer, cookie)
+}
@@ -28,6 +28,7 @@ func domainIDPConfigFromProto(pb *hermesv1.DomainIDPConfig) *models.DomainIDPCon
 		IDPType:  pb.Type,
 		Priority: int(pb.Priority),
 		Strategy: pb.Strategy,
+		Config:   pb.Config,
 	}
 	if pb.CreatedAt != nil {
 		cfg.CreatedAt = pb.CreatedAt.AsTime()
@@ -52,6 +53,11 @@ func applicationFromProto(pb *hermesv1.Application) *models.Application {
 		IDTokenExpiresIn:              uint(pb.IdTokenExpiresIn),
 		RefreshTokenExpiresIn:         uint(pb.RefreshTokenExpiresIn),
 		RefreshTokenAbsoluteExpiresIn: uint(pb.RefreshTokenAbsoluteExpiresIn),
+		RefreshTokenRotation:          pb.RefreshTokenRotation,
+		DPoPBoundAccessTokens:         pb.DpopBoundAccessTokens,
+		BackchannelLogoutURI:          pb.BackchannelLogoutUri,
+		RequirePushedAuthRequests:     pb.RequirePushedAuthorizationRequests,
+		RegistrationTokenHash:         pb.RegistrationTokenHash,
 	}
 	app.AllowedRedirectURIs = marshalStringSlice(pb.AllowedRedirectUris)
 	app.AllowedOrigins = marshalStringSlice(pb.AllowedOrigins)
@@ -94,6 +100,23 @@ func serviceFromProto(pb *hermesv1.Service) *models.Service {
 	return svc
 }
 
+func authorizationDetailTypeFromProto(pb *hermesv1.ServiceAuthorizationDetailType) *models.ServiceAuthorizationDetailType {
+	t := &models.ServiceAuthorizationDetailType{
+		ID:          uint(pb.Id),
+		ServiceID:   pb.ServiceId,
+		Type:        pb.Type,
+		Description: pb.Description,
+		JSONSchema:  pb.JsonSchema,
+	}
+	if pb.CreatedAt != nil {
+		t.CreatedAt = pb.CreatedAt.AsTime()
+	}
+	if pb.UpdatedAt != nil {
+		t.UpdatedAt = pb.UpdatedAt.AsTime()
+	}
+	return t
+}
+
 func challengeSettingFromProto(pb *hermesv1.ServiceChallengeSetting) *models.ServiceChallengeSetting {
 	if pb == nil {
 		return nil
@@ -131,6 +154,7 @@ func idpConfigFromProto(pb *hermesv1.ApplicationIDPConfig) *models.ApplicationID
 		Strategy: pb.Strategy,
 		Delegate: pb.Delegate,
 		Require:  pb.Require,
+		Config:   pb.Config,
 	}
 }
 
@@ -202,6 +226,10 @@ func userFromProto(pb *hermesv1.User) *models.User {
 		t := pb.LastLoginAt.AsTime()
 		u.LastLoginAt = &t
 	}
+	if pb.LockedUntil != nil {
+		t := pb.LockedUntil.AsTime()
+		u.LockedUntil = &t
+	}
 	if pb.CreatedAt != nil {
 		u.CreatedAt = pb.CreatedAt.AsTime()
 	}
@@ -260,6 +288,25 @@ func credentialFromProto(pb *hermesv1.UserCredential) *models.UserCredential {
 	return c
 }
 
+func consentFromProto(pb *hermesv1.UserConsent) *models.UserConsent {
+	if pb == nil {
+		return nil
+	}
+	c := &models.UserConsent{
+		OpenID:    pb.Openid,
+		AppID:     pb.AppId,
+		ServiceID: pb.ServiceId,
+		Scopes:    pb.Scopes,
+	}
+	if pb.CreatedAt != nil {
+		c.CreatedAt = pb.CreatedAt.AsTime()
+	}
+	if pb.UpdatedAt != nil {
+		c.UpdatedAt = pb.UpdatedAt.AsTime()
+	}
+	return c
+}
+
 // ==================== helpers ====================
 
 func marshalStringSlice(s []string) *string {
@@ -273,3 +320,12 @@ func marshalStringSlice(s []string) *string {
 	str := string(b)
 	return &str
 }
+
+// stringOrEmpty 将 nil 转为空字符串指针，用于整体替换语义下清空可选字段
+func stringOrEmpty(s *string) *string {
+	if s == nil {
+		empty := ""
+		return &empty
+	}
+	return s
+}
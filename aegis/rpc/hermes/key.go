@@ -18,6 +18,18 @@ func (c *Client) GetKeys(ctx context.Context, ownerType, ownerID string) ([][]by
 	return resp.Keys, nil
 }
 
+// CreateKey 为尚无密钥的 owner 生成首个密钥，返回主密钥（48 字节 seed）
+func (c *Client) CreateKey(ctx context.Context, ownerType, ownerID string) ([]byte, error) {
+	resp, err := c.key.CreateKey(ctx, &hermesv1.CreateKeyRequest{
+		OwnerType: ownerType,
+		OwnerId:   ownerID,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建密钥失败: %w", err)
+	}
+	return resp.Main, nil
+}
+
 func (c *Client) GetIDPKey(ctx context.Context, appID, idpType string) (tAppID, tSecret string, err error) {
 	resp, err := c.key.ResolveIDPKey(ctx, &hermesv1.ResolveIDPKeyRequest{
 		AppId:   appID,
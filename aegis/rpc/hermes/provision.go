@@ -40,6 +40,51 @@ func (c *Client) GetApplication(ctx context.Context, appID string) (*models.Appl
 	return applicationFromProto(resp), nil
 }
 
+func (c *Client) CreateApplication(ctx context.Context, domainID string, meta *models.ApplicationMetadata) (*models.Application, error) {
+	resp, err := c.provision.CreateApplication(ctx, &hermesv1.CreateApplicationRequest{
+		DomainId:                           domainID,
+		Name:                               meta.Name,
+		LogoUrl:                            meta.LogoURL,
+		AllowedRedirectUris:                meta.AllowedRedirectURIs,
+		AllowedOrigins:                     meta.AllowedOrigins,
+		AllowedLogoutUris:                  meta.AllowedLogoutURIs,
+		BackchannelLogoutUri:               meta.BackchannelLogoutURI,
+		DpopBoundAccessTokens:              &meta.DPoPBoundAccessTokens,
+		RequirePushedAuthorizationRequests: &meta.RequirePushedAuthRequests,
+		RegistrationTokenHash:              meta.RegistrationTokenHash,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建应用失败: %w", err)
+	}
+	return applicationFromProto(resp), nil
+}
+
+// UpdateApplication 以 meta 整体替换应用元数据（未设置的可选字段置空）
+func (c *Client) UpdateApplication(ctx context.Context, appID string, meta *models.ApplicationMetadata) (*models.Application, error) {
+	resp, err := c.provision.UpdateApplication(ctx, &hermesv1.UpdateApplicationRequest{
+		AppId:                              appID,
+		Name:                               &meta.Name,
+		LogoUrl:                            stringOrEmpty(meta.LogoURL),
+		AllowedRedirectUris:                &hermesv1.OptionalStringList{Present: true, Values: meta.AllowedRedirectURIs},
+		AllowedOrigins:                     &hermesv1.OptionalStringList{Present: true, Values: meta.AllowedOrigins},
+		AllowedLogoutUris:                  &hermesv1.OptionalStringList{Present: true, Values: meta.AllowedLogoutURIs},
+		BackchannelLogoutUri:               stringOrEmpty(meta.BackchannelLogoutURI),
+		DpopBoundAccessTokens:              &meta.DPoPBoundAccessTokens,
+		RequirePushedAuthorizationRequests: &meta.RequirePushedAuthRequests,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("更新应用失败: %w", err)
+	}
+	return applicationFromProto(resp), nil
+}
+
+func (c *Client) DeleteApplication(ctx context.Context, appID string) error {
+	if _, err := c.provision.DeleteApplication(ctx, &hermesv1.DeleteApplicationRequest{AppId: appID}); err != nil {
+		return fmt.Errorf("删除应用失败: %w", err)
+	}
+	return nil
+}
+
 func (c *Client) ListApplicationIDPConfigs(ctx context.Context, appID string) ([]*models.ApplicationIDPConfig, error) {
 	resp, err := c.provision.GetApplicationIDPConfigs(ctx, &hermesv1.GetApplicationRequest{AppId: appID})
 	if err != nil {
@@ -52,6 +97,40 @@ func (c *Client) ListApplicationIDPConfigs(ctx context.Context, appID string) ([
 	return configs, nil
 }
 
+func (c *Client) CreateApplicationIDPConfig(ctx context.Context, cfg *models.ApplicationIDPConfig) (*models.ApplicationIDPConfig, error) {
+	resp, err := c.provision.CreateApplicationIDPConfig(ctx, &hermesv1.CreateApplicationIDPConfigRequest{
+		AppId:    cfg.AppID,
+		Type:     cfg.Type,
+		Priority: int32(cfg.Priority),
+		Strategy: cfg.Strategy,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("创建应用 IDP 配置失败: %w", err)
+	}
+	return idpConfigFromProto(resp), nil
+}
+
+func (c *Client) UpdateApplicationIDPConfig(ctx context.Context, cfg *models.ApplicationIDPConfig) (*models.ApplicationIDPConfig, error) {
+	priority := int32(cfg.Priority)
+	resp, err := c.provision.UpdateApplicationIDPConfig(ctx, &hermesv1.UpdateApplicationIDPConfigRequest{
+		AppId:    cfg.AppID,
+		Type:     cfg.Type,
+		Priority: &priority,
+		Strategy: stringOrEmpty(cfg.Strategy),
+	})
+	if err != nil {
+		return nil, fmt.Errorf("更新应用 IDP 配置失败: %w", err)
+	}
+	return idpConfigFromProto(resp), nil
+}
+
+func (c *Client) DeleteApplicationIDPConfig(ctx context.Context, appID, idpType string) error {
+	if _, err := c.provision.DeleteApplicationIDPConfig(ctx, &hermesv1.DeleteApplicationIDPConfigRequest{AppId: appID, Type: idpType}); err != nil {
+		return fmt.Errorf("删除应用 IDP 配置失败: %w", err)
+	}
+	return nil
+}
+
 // ==================== Service ====================
 
 func (c *Client) GetService(ctx context.Context, serviceID string) (*models.Service, error) {
@@ -72,3 +151,15 @@ func (c *Client) GetServiceChallengeSetting(ctx context.Context, serviceID, chal
 	}
 	return challengeSettingFromProto(resp), nil
 }
+
+func (c *Client) ListServiceAuthorizationDetailTypes(ctx context.Context, serviceID string) ([]models.ServiceAuthorizationDetailType, error) {
+	resp, err := c.provision.ListServiceAuthorizationDetailTypes(ctx, &hermesv1.GetServiceRequest{ServiceId: serviceID})
+	if err != nil {
+		return nil, fmt.Errorf("获取授权详情类型失败: %w", err)
+	}
+	types := make([]models.ServiceAuthorizationDetailType, 0, len(resp.Types))
+	for _, t := range resp.Types {
+		types = append(types, *authorizationDetailTypeFromProto(t))
+	}
+	return types, nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"time"
 
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/types/known/timestamppb"
 
 	"github.com/heliannuuthus/aegis/models"
@@ -93,6 +95,11 @@ func (c *Client) PatchUser(ctx context.Context, openid string, updates map[strin
 			pbReq.LastLoginAt = timestamppb.New(t)
 		}
 	}
+	if v, ok := updates["locked_until"]; ok {
+		if t, ok := v.(time.Time); ok {
+			pbReq.LockedUntil = timestamppb.New(t)
+		}
+	}
 	_, err := c.user.PatchUser(ctx, pbReq)
 	if err != nil {
 		return fmt.Errorf("更新用户失败: %w", err)
@@ -152,6 +159,15 @@ func (c *Client) CreateIdentity(ctx context.Context, identity *models.UserIdenti
 	return err
 }
 
+func (c *Client) DeleteIdentity(ctx context.Context, domain, openid, idp string) error {
+	_, err := c.user.RemoveIdentity(ctx, &hermesv1.RemoveIdentityRequest{
+		Domain: domain,
+		Openid: openid,
+		Idp:    idp,
+	})
+	return err
+}
+
 // ==================== Credential ====================
 
 func (c *Client) CreateCredential(ctx context.Context, cred *models.UserCredential) error {
@@ -184,6 +200,38 @@ func (c *Client) DeleteUserCredentialsByType(ctx context.Context, openid, credTy
 	return err
 }
 
+// ConsumeCredential 删除一次性凭证，仅首次删除成功返回 true
+func (c *Client) ConsumeCredential(ctx context.Context, openid, credentialID string) (bool, error) {
+	resp, err := c.user.ConsumeCredential(ctx, &hermesv1.DeleteCredentialRequest{
+		Openid:       openid,
+		CredentialId: credentialID,
+	})
+	if err != nil {
+		return false, err
+	}
+	return resp.Consumed, nil
+}
+
+// ReplaceUserCredentialsByType 在同一事务中替换用户指定类型的全部凭证
+func (c *Client) ReplaceUserCredentialsByType(ctx context.Context, openid, credType string, creds []models.UserCredential) error {
+	pbReq := &hermesv1.ReplaceCredentialsRequest{
+		Openid:      openid,
+		Type:        credType,
+		Credentials: make([]*hermesv1.CreateCredentialRequest, 0, len(creds)),
+	}
+	for i := range creds {
+		pbReq.Credentials = append(pbReq.Credentials, &hermesv1.CreateCredentialRequest{
+			Openid:       openid,
+			CredentialId: creds[i].CredentialID,
+			Type:         credType,
+			Secret:       creds[i].Secret,
+			Label:        creds[i].Label,
+		})
+	}
+	_, err := c.user.ReplaceUserCredentialsByType(ctx, pbReq)
+	return err
+}
+
 func (c *Client) GetOpenIDByCredentialID(ctx context.Context, credentialID string) (string, error) {
 	resp, err := c.user.GetOpenIDByCredentialID(ctx, &hermesv1.CredentialIDRequest{CredentialId: credentialID})
 	if err != nil {
@@ -264,3 +312,52 @@ func (c *Client) PatchCredential(ctx context.Context, credentialID string, updat
 	_, err := c.user.PatchCredential(ctx, pbReq)
 	return err
 }
+
+// ==================== Consent ====================
+
+// GetConsent 获取用户对应用访问指定服务的授权，不存在返回 (nil, nil)
+func (c *Client) GetConsent(ctx context.Context, openid, appID, serviceID string) (*models.UserConsent, error) {
+	resp, err := c.user.GetConsent(ctx, &hermesv1.GetConsentRequest{
+		Openid:    openid,
+		AppId:     appID,
+		ServiceId: serviceID,
+	})
+	if err != nil {
+		if status.Code(err) == codes.NotFound {
+			return nil, nil
+		}
+		return nil, err
+	}
+	return consentFromProto(resp), nil
+}
+
+func (c *Client) ListConsents(ctx context.Context, openid string) ([]models.UserConsent, error) {
+	resp, err := c.user.ListConsents(ctx, &hermesv1.OpenIDRequest{Openid: openid})
+	if err != nil {
+		return nil, err
+	}
+	consents := make([]models.UserConsent, 0, len(resp.Consents))
+	for _, pb := range resp.Consents {
+		consents = append(consents, *consentFromProto(pb))
+	}
+	return consents, nil
+}
+
+// SaveConsent 保存授权（hermes 侧与已有 scope 取并集）
+func (c *Client) SaveConsent(ctx context.Context, openid, appID, serviceID string, scopes []string) (*models.UserConsent, error) {
+	resp, err := c.user.SaveConsent(ctx, &hermesv1.SaveConsentRequest{
+		Openid:    openid,
+		AppId:     appID,
+		ServiceId: serviceID,
+		Scopes:    scopes,
+	})
+	if err != nil {
+		return nil, err
+	}
+	return consentFromProto(resp), nil
+}
+
+func (c *Client) RevokeConsent(ctx context.Context, openid, appID string) error {
+	_, err := c.user.RevokeConsent(ctx, &hermesv1.RevokeConsentRequest{Openid: openid, AppId: appID})
+	return err
+}
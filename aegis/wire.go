@@ -15,7 +15,9 @@ import (
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp/alipay"
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp/github"
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp/google"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp/oidc"
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp/passkey"
+	"github.com/heliannuuthus/aegis/internal/authenticator/idp/saml"
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp/staff"
 	"github.com/heliannuuthus/aegis/internal/authenticator/idp/tt"
 	idpuser "github.com/heliannuuthus/aegis/internal/authenticator/idp/user"
@@ -25,7 +27,12 @@ import (
 	"github.com/heliannuuthus/aegis/internal/authorize"
 	"github.com/heliannuuthus/aegis/internal/cache"
 	"github.com/heliannuuthus/aegis/internal/challenge"
+	"github.com/heliannuuthus/aegis/internal/consent"
+	"github.com/heliannuuthus/aegis/internal/logout"
 	internalmfa "github.com/heliannuuthus/aegis/internal/mfa"
+	"github.com/heliannuuthus/aegis/internal/password"
+	"github.com/heliannuuthus/aegis/internal/registration"
+	"github.com/heliannuuthus/aegis/internal/risk"
 	"github.com/heliannuuthus/aegis/internal/token"
 	"github.com/heliannuuthus/aegis/internal/user"
 	"github.com/heliannuuthus/aegis/profile"
@@ -35,10 +42,12 @@ import (
 	"github.com/heliannuuthus/pkg/async"
 	"github.com/heliannuuthus/pkg/logger"
 	"github.com/heliannuuthus/pkg/mail"
+	"github.com/heliannuuthus/pkg/sms"
 	"github.com/heliannuuthus/pkg/throttle"
 )
 
-func initializeAegis(hermesClient *hermes.Client, cacheManager *cache.Manager) (*auth.Handler, error) {
+// initializeAegis ctx 为进程生命周期，取消后后台任务（如后端通道登出重试）不再继续
+func initializeAegis(ctx context.Context, hermesClient *hermes.Client, cacheManager *cache.Manager) (*auth.Handler, error) {
 	if hermesClient == nil {
 		return nil, fmt.Errorf("hermes client is required")
 	}
@@ -59,6 +68,11 @@ func initializeAegis(hermesClient *hermes.Client, cacheManager *cache.Manager) (
 	}
 	logger.Info("[Auth] 邮件发送器初始化完成")
 
+	smsSender, err := initSMSSender(config.GetSMSConfig())
+	if err != nil {
+		return nil, err
+	}
+
 	webauthnSvc, captchaVerifier, err := initProviders(cacheManager, hermesClient)
 	if err != nil {
 		return nil, err
@@ -70,20 +84,30 @@ func initializeAegis(hermesClient *hermes.Client, cacheManager *cache.Manager) (
 
 	mfaSvc := internalmfa.NewService(hermesClient, cacheManager, webauthnSvc)
 
-	registry := initRegistry(hermesClient, cacheManager, emailSender, mfaSvc.TOTP(), webauthnSvc, captchaVerifier, ac, tokenSvc)
-
 	pool, err := async.NewPool(64)
 	if err != nil {
 		return nil, err
 	}
 
 	userService := user.NewService(cacheManager, hermesClient)
-	authenticateSvc := authenticate.NewService(cacheManager, ac)
-	authorizeSvc := authorize.NewService(cacheManager, hermesClient, userService, tokenSvc, pool, 5*time.Minute)
+	logoutSvc := logout.NewService(ctx, cacheManager, tokenSvc, pool)
+	authorizeSvc := authorize.NewService(cacheManager, hermesClient, userService, tokenSvc, logoutSvc, pool, throttler, ac, 5*time.Minute)
+	breached, err := initBreachedList()
+	if err != nil {
+		return nil, err
+	}
+	passwordSvc := password.NewService(hermesClient, cacheManager, tokenSvc, authorizeSvc, emailSender, breached)
+
+	registry := initRegistry(hermesClient, cacheManager, emailSender, smsSender, mfaSvc.TOTP(), mfaSvc.RecoveryCodes(), webauthnSvc, captchaVerifier, ac, tokenSvc, passwordSvc)
+
+	authenticateSvc := authenticate.NewService(cacheManager, ac, hermesClient, emailSender)
 	challengeSvc := challenge.NewService(cacheManager, registry)
-	profileHandler := profile.NewHandler(hermesClient, mfaSvc)
+	consentSvc := consent.NewService(cacheManager, hermesClient)
+	registrationSvc := registration.NewService(cacheManager, hermesClient)
+	mfaStage := internalmfa.NewStage(risk.NewOfflineEvaluator(cacheManager, ac, nil), mfaSvc.Credentials(), tokenSvc, cacheManager)
+	profileHandler := profile.NewHandler(hermesClient, mfaSvc, consentSvc, userService, passwordSvc, cacheManager)
 
-	handler := auth.NewHandler(authenticateSvc, authorizeSvc, challengeSvc, userService, cacheManager, tokenSvc, profileHandler, pool)
+	handler := auth.NewHandler(authenticateSvc, authorizeSvc, challengeSvc, consentSvc, logoutSvc, registrationSvc, mfaStage, passwordSvc, userService, cacheManager, tokenSvc, profileHandler, pool)
 	logger.Info("[Auth] 模块初始化完成")
 	return handler, nil
 }
@@ -184,7 +208,7 @@ func initProviders(cacheManager *cache.Manager, hermesClient *hermes.Client) (*w
 }
 
 // initRegistry 初始化全局 Registry（注册胶水层 Authenticator）
-func initRegistry(hermesClient *hermes.Client, cacheManager *cache.Manager, emailSender *mail.Sender, totpVerifier factor.TOTPVerifier, webauthnSvc *webauthn.Service, captchaVerifier captcha.Verifier, ac *accessctl.Manager, tokenVerifier authenticate.ChallengeTokenVerifier) *authenticator.Registry {
+func initRegistry(hermesClient *hermes.Client, cacheManager *cache.Manager, emailSender *mail.Sender, smsSender sms.Sender, totpVerifier factor.TOTPVerifier, recoveryVerifier factor.RecoveryCodeVerifier, webauthnSvc *webauthn.Service, captchaVerifier captcha.Verifier, ac *accessctl.Manager, tokenVerifier authenticate.ChallengeTokenVerifier, passwords *password.Service) *authenticator.Registry {
 	registry := authenticator.NewRegistry()
 
 	// ==================== IDP Authenticators ====================
@@ -199,8 +223,18 @@ func initRegistry(hermesClient *hermes.Client, cacheManager *cache.Manager, emai
 	registerIDP(github.NewProvider(cacheManager))
 	registerIDP(google.NewProvider(cacheManager))
 
-	registerIDP(idpuser.NewProvider(hermesClient))
-	registerIDP(staff.NewProvider(hermesClient))
+	// 通用 OIDC：oidc-{name} 按需创建，发现文档与 JWKS 缓存在所有连接间共享
+	oidcMetadata := oidc.NewMetadataCache()
+	registry.RegisterFactory(idp.TypeOIDCPrefix, func(connection string) authenticator.Authenticator {
+		return authenticate.NewIDPAuthenticator(oidc.NewProvider(connection, cacheManager, oidcMetadata))
+	})
+	// SAML 2.0：saml-{name} 按需创建，IdP 元数据 / 证书按应用从 IDP 配置解析
+	registry.RegisterFactory(idp.TypeSAMLPrefix, func(connection string) authenticator.Authenticator {
+		return authenticate.NewIDPAuthenticator(saml.NewProvider(connection, cacheManager))
+	})
+
+	registerIDP(idpuser.NewProvider(hermesClient, passwords))
+	registerIDP(staff.NewProvider(hermesClient, passwords))
 
 	registerIDP(passkey.NewProvider(webauthnSvc))
 	logger.Info("[Auth] Passkey IDP 注册完成")
@@ -213,8 +247,15 @@ func initRegistry(hermesClient *hermes.Client, cacheManager *cache.Manager, emai
 
 	registry.Register(authenticate.NewFactorAuthenticator(factor.NewEmailOTPProvider(emailSender, cacheManager), ac, tokenVerifier))
 
+	// 短信验证码因子仅在显式配置了 sms.provider 时注册
+	if smsSender != nil {
+		registry.Register(authenticate.NewFactorAuthenticator(factor.NewSmsOTPProvider(smsSender, cacheManager, hermesClient, ac), ac, tokenVerifier))
+	}
+
 	registry.Register(authenticate.NewFactorAuthenticator(factor.NewTOTPFactor(totpVerifier), ac, tokenVerifier))
 
+	registry.Register(authenticate.NewFactorAuthenticator(factor.NewRecoveryCodeFactor(recoveryVerifier), ac, tokenVerifier))
+
 	registry.Register(authenticate.NewFactorAuthenticator(factor.NewWebAuthnProvider(webauthnSvc, hermesClient), ac, tokenVerifier))
 
 	logger.Infof("[Auth] Registry 初始化完成: %v", registry.Summary())
@@ -257,3 +298,39 @@ func initMailSender() (*mail.Sender, error) {
 	logger.Infof("[Auth] 邮件连接池初始化成功: %s:%d", cfg.Host, cfg.Port)
 	return sender, nil
 }
+
+// initBreachedList 加载离线泄露密码库，未配置时返回 nil
+func initBreachedList() (*password.BreachedList, error) {
+	path := config.GetPasswordBreachedFile()
+	if path == "" {
+		logger.Warn("[Auth] 未配置泄露密码库，设置密码时不做泄露检查")
+		return nil, nil
+	}
+	list, err := password.LoadBreachedList(path)
+	if err != nil {
+		return nil, err
+	}
+	logger.Infof("[Auth] 泄露密码库加载完成: file=%s, entries=%d", path, list.Len())
+	return list, nil
+}
+
+// initSMSSender 初始化短信发送器
+func initSMSSender(cfg *config.SMSConfig) (sms.Sender, error) {
+	switch cfg.Provider {
+	case "":
+		logger.Warn("[Auth] 未配置 sms.provider，短信验证码因子不可用")
+		return nil, nil
+	case config.SMSProviderLog:
+		logger.Warnf("[Auth] 短信发送器为 log 模式，验证码不会真正发送: file=%q", cfg.File)
+		return sms.NewLogSender(cfg.File), nil
+	case config.SMSProviderHTTP:
+		var opts []sms.Option
+		if cfg.Timeout > 0 {
+			opts = append(opts, sms.WithTimeout(cfg.Timeout))
+		}
+		logger.Infof("[Auth] 短信网关初始化完成: %s", cfg.Endpoint)
+		return sms.NewHTTPSender(cfg.Endpoint, cfg.Token, opts...), nil
+	default:
+		return nil, fmt.Errorf("不支持的短信发送方式: %s", cfg.Provider)
+	}
+}
@@ -0,0 +1,34 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/heliannuuthus/aegis/config"
+	"github.com/heliannuuthus/pkg/sms"
+)
+
+func TestInitSMSSenderRequiresProvider(t *testing.T) {
+	tests := []struct {
+		name     string
+		provider string
+		wantErr  bool
+		check    func(sms.Sender) bool
+	}{
+		// 未显式配置时不创建发送器，短信验证码因子不注册
+		{name: "unset", check: func(s sms.Sender) bool { return s == nil }},
+		{name: "log", provider: config.SMSProviderLog, check: func(s sms.Sender) bool { _, ok := s.(*sms.LogSender); return ok }},
+		{name: "http", provider: config.SMSProviderHTTP, check: func(s sms.Sender) bool { _, ok := s.(*sms.HTTPSender); return ok }},
+		{name: "unknown", provider: "carrier-pigeon", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			sender, err := initSMSSender(&config.SMSConfig{Provider: tt.provider, Endpoint: "https://sms.example.com/send"})
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("initSMSSender() error = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.check != nil && !tt.check(sender) {
+				t.Fatalf("initSMSSender() = %T, unexpected sender for provider %q", sender, tt.provider)
+			}
+		})
+	}
+}
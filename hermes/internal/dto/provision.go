@@ -1,6 +1,8 @@
 package dto
 
 import (
+	"github.com/go-json-experiment/json"
+
 	"github.com/heliannuuthus/hermes/internal/models"
 	"github.com/heliannuuthus/pkg/patch"
 )
@@ -78,6 +80,7 @@ type ApplicationCreateRequest struct {
 	AppID                         string   `json:"app_id"`
 	Name                          string   `json:"name" binding:"required"`
 	Description                   string   `json:"description" binding:"required"`
+	LogoURL                       *string  `json:"logo_url"`
 	AllowedRedirectURIs           []string `json:"allowed_redirect_uris"`
 	AllowedOrigins                []string `json:"allowed_origins"`
 	AllowedLogoutURIs             []string `json:"allowed_logout_uris"`
@@ -85,6 +88,11 @@ type ApplicationCreateRequest struct {
 	IDTokenExpiresIn              *uint    `json:"id_token_expires_in"`
 	RefreshTokenExpiresIn         *uint    `json:"refresh_token_expires_in"`
 	RefreshTokenAbsoluteExpiresIn *uint    `json:"refresh_token_absolute_expires_in"`
+	RefreshTokenRotation          *bool    `json:"refresh_token_rotation"`
+	DPoPBoundAccessTokens         *bool    `json:"dpop_bound_access_tokens"`
+	BackchannelLogoutURI          *string  `json:"backchannel_logout_uri"`
+	RequirePushedAuthRequests     *bool    `json:"require_pushed_authorization_requests"`
+	RegistrationTokenHash         *string  `json:"-"` // 仅动态客户端注册（gRPC）设置
 }
 
 // ApplicationUpdateRequest 更新应用请求（JSON Merge Patch 语义）
@@ -98,6 +106,10 @@ type ApplicationUpdateRequest struct {
 	IDTokenExpiresIn              patch.Optional[uint]     `json:"id_token_expires_in"`
 	RefreshTokenExpiresIn         patch.Optional[uint]     `json:"refresh_token_expires_in"`
 	RefreshTokenAbsoluteExpiresIn patch.Optional[uint]     `json:"refresh_token_absolute_expires_in"`
+	RefreshTokenRotation          patch.Optional[bool]     `json:"refresh_token_rotation"`
+	DPoPBoundAccessTokens         patch.Optional[bool]     `json:"dpop_bound_access_tokens"`
+	BackchannelLogoutURI          patch.Optional[string]   `json:"backchannel_logout_uri"`
+	RequirePushedAuthRequests     patch.Optional[bool]     `json:"require_pushed_authorization_requests"`
 }
 
 // ApplicationResponse 应用（无 _id，allowed_redirect_uris/allowed_origins 为数组）
@@ -113,6 +125,10 @@ type ApplicationResponse struct {
 	IDTokenExpiresIn              uint     `json:"id_token_expires_in"`
 	RefreshTokenExpiresIn         uint     `json:"refresh_token_expires_in"`
 	RefreshTokenAbsoluteExpiresIn uint     `json:"refresh_token_absolute_expires_in"`
+	RefreshTokenRotation          bool     `json:"refresh_token_rotation"`
+	DPoPBoundAccessTokens         bool     `json:"dpop_bound_access_tokens"`
+	BackchannelLogoutURI          *string  `json:"backchannel_logout_uri,omitempty"`
+	RequirePushedAuthRequests     bool     `json:"require_pushed_authorization_requests"`
 	CreatedAt                     string   `json:"created_at"`
 	UpdatedAt                     string   `json:"updated_at"`
 }
@@ -130,6 +146,10 @@ func NewApplicationResponse(a *models.Application) ApplicationResponse {
 		IDTokenExpiresIn:              a.IDTokenExpiresIn,
 		RefreshTokenExpiresIn:         a.RefreshTokenExpiresIn,
 		RefreshTokenAbsoluteExpiresIn: a.RefreshTokenAbsoluteExpiresIn,
+		RefreshTokenRotation:          a.RefreshTokenRotation,
+		DPoPBoundAccessTokens:         a.DPoPBoundAccessTokens,
+		BackchannelLogoutURI:          a.BackchannelLogoutURI,
+		RequirePushedAuthRequests:     a.RequirePushedAuthRequests,
 		CreatedAt:                     FormatTime(a.CreatedAt),
 		UpdatedAt:                     FormatTime(a.UpdatedAt),
 	}
@@ -139,28 +159,31 @@ func NewApplicationResponse(a *models.Application) ApplicationResponse {
 
 // DomainIDPConfigCreateRequest 创建域 IDP 配置请求
 type DomainIDPConfigCreateRequest struct {
-	IDPType  string  `json:"idp_type" binding:"required"`
-	Priority int     `json:"priority"`
-	Strategy *string `json:"strategy,omitempty"`
-	TAppID   string  `json:"t_app_id" binding:"required"`
+	IDPType  string         `json:"idp_type" binding:"required"`
+	Priority int            `json:"priority"`
+	Strategy *string        `json:"strategy,omitempty"`
+	TAppID   string         `json:"t_app_id" binding:"required"`
+	Config   map[string]any `json:"config,omitempty"`
 }
 
 // DomainIDPConfigUpdateRequest 更新域 IDP 配置请求（JSON Merge Patch 语义）
 type DomainIDPConfigUpdateRequest struct {
-	Priority patch.Optional[int]    `json:"priority"`
-	Strategy patch.Optional[string] `json:"strategy"`
-	TAppID   patch.Optional[string] `json:"t_app_id"`
+	Priority patch.Optional[int]            `json:"priority"`
+	Strategy patch.Optional[string]         `json:"strategy"`
+	TAppID   patch.Optional[string]         `json:"t_app_id"`
+	Config   patch.Optional[map[string]any] `json:"config"`
 }
 
 // DomainIDPConfigResponse 域 IDP 配置（无 _id，t_secret 不暴露）
 type DomainIDPConfigResponse struct {
-	DomainID  string  `json:"domain_id"`
-	IDPType   string  `json:"idp_type"`
-	Priority  int     `json:"priority"`
-	Strategy  *string `json:"strategy,omitempty"`
-	TAppID    string  `json:"t_app_id"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	DomainID  string         `json:"domain_id"`
+	IDPType   string         `json:"idp_type"`
+	Priority  int            `json:"priority"`
+	Strategy  *string        `json:"strategy,omitempty"`
+	TAppID    string         `json:"t_app_id"`
+	Config    map[string]any `json:"config,omitempty"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
 }
 
 func NewDomainIDPConfigResponse(c *models.DomainIDPConfig) DomainIDPConfigResponse {
@@ -170,11 +193,24 @@ func NewDomainIDPConfigResponse(c *models.DomainIDPConfig) DomainIDPConfigRespon
 		Priority:  c.Priority,
 		Strategy:  c.Strategy,
 		TAppID:    c.TAppID,
+		Config:    unmarshalIDPConfig(c.Config),
 		CreatedAt: FormatTime(c.CreatedAt),
 		UpdatedAt: FormatTime(c.UpdatedAt),
 	}
 }
 
+// unmarshalIDPConfig 解析 IDP 连接参数，无法解析时视为未配置
+func unmarshalIDPConfig(raw *string) map[string]any {
+	if raw == nil {
+		return nil
+	}
+	var cfg map[string]any
+	if err := json.Unmarshal([]byte(*raw), &cfg); err != nil {
+		return nil
+	}
+	return cfg
+}
+
 // ==================== Service Challenge Setting ====================
 
 // ServiceChallengeSettingCreateRequest 创建服务 Challenge 配置请求
@@ -211,28 +247,83 @@ func NewServiceChallengeSettingResponse(s *models.ServiceChallengeSetting) Servi
 	}
 }
 
+// ServiceAuthorizationDetailTypeCreateRequest 创建服务授权详情类型请求
+type ServiceAuthorizationDetailTypeCreateRequest struct {
+	Type        string         `json:"type" binding:"required"`
+	Description *string        `json:"description,omitempty"`
+	JSONSchema  map[string]any `json:"json_schema,omitempty"`
+}
+
+// ServiceAuthorizationDetailTypeUpdateRequest 更新服务授权详情类型请求（JSON Merge Patch 语义）
+type ServiceAuthorizationDetailTypeUpdateRequest struct {
+	Description patch.Optional[string]         `json:"description"`
+	JSONSchema  patch.Optional[map[string]any] `json:"json_schema"`
+}
+
+// ServiceAuthorizationDetailTypeResponse 服务授权详情类型
+type ServiceAuthorizationDetailTypeResponse struct {
+	ServiceID   string         `json:"service_id"`
+	Type        string         `json:"type"`
+	Description *string        `json:"description,omitempty"`
+	JSONSchema  map[string]any `json:"json_schema,omitempty"`
+	CreatedAt   string         `json:"created_at"`
+	UpdatedAt   string         `json:"updated_at"`
+}
+
+func NewServiceAuthorizationDetailTypeResponse(t *models.ServiceAuthorizationDetailType) ServiceAuthorizationDetailTypeResponse {
+	resp := ServiceAuthorizationDetailTypeResponse{
+		ServiceID:   t.ServiceID,
+		Type:        t.Type,
+		Description: t.Description,
+		CreatedAt:   FormatTime(t.CreatedAt),
+		UpdatedAt:   FormatTime(t.UpdatedAt),
+	}
+	if t.JSONSchema != nil {
+		if err := json.Unmarshal([]byte(*t.JSONSchema), &resp.JSONSchema); err != nil {
+			resp.JSONSchema = nil
+		}
+	}
+	return resp
+}
+
 // ApplicationIDPConfigCreateRequest 创建应用 IDP 配置请求（idp 类型必须在应用所属域的 idp-configs 内）
 type ApplicationIDPConfigCreateRequest struct {
-	Type     string  `json:"type" binding:"required"`
-	Priority int     `json:"priority"`
-	Strategy *string `json:"strategy,omitempty"`
-	TAppID   *string `json:"t_app_id,omitempty"`
+	Type     string         `json:"type" binding:"required"`
+	Priority int            `json:"priority"`
+	Strategy *string        `json:"strategy,omitempty"`
+	TAppID   *string        `json:"t_app_id,omitempty"`
+	Config   map[string]any `json:"config,omitempty"`
 }
 
 // ApplicationIDPConfigUpdateRequest 更新应用 IDP 配置请求（JSON Merge Patch 语义）
 type ApplicationIDPConfigUpdateRequest struct {
-	Priority patch.Optional[int]    `json:"priority"`
-	Strategy patch.Optional[string] `json:"strategy"`
-	TAppID   patch.Optional[string] `json:"t_app_id"`
+	Priority patch.Optional[int]            `json:"priority"`
+	Strategy patch.Optional[string]         `json:"strategy"`
+	TAppID   patch.Optional[string]         `json:"t_app_id"`
+	Config   patch.Optional[map[string]any] `json:"config"`
 }
 
 // ApplicationIDPConfigResponse 应用 IDP 配置（无 _id，t_secret 不暴露）
 type ApplicationIDPConfigResponse struct {
-	AppID     string  `json:"app_id"`
-	Type      string  `json:"type"`
-	Priority  int     `json:"priority"`
-	Strategy  *string `json:"strategy,omitempty"`
-	TAppID    *string `json:"t_app_id,omitempty"`
-	CreatedAt string  `json:"created_at"`
-	UpdatedAt string  `json:"updated_at"`
+	AppID     string         `json:"app_id"`
+	Type      string         `json:"type"`
+	Priority  int            `json:"priority"`
+	Strategy  *string        `json:"strategy,omitempty"`
+	TAppID    *string        `json:"t_app_id,omitempty"`
+	Config    map[string]any `json:"config,omitempty"`
+	CreatedAt string         `json:"created_at"`
+	UpdatedAt string         `json:"updated_at"`
+}
+
+func NewApplicationIDPConfigResponse(c *models.ApplicationIDPConfig) ApplicationIDPConfigResponse {
+	return ApplicationIDPConfigResponse{
+		AppID:     c.AppID,
+		Type:      c.Type,
+		Priority:  c.Priority,
+		Strategy:  c.Strategy,
+		TAppID:    c.TAppID,
+		Config:    unmarshalIDPConfig(c.Config),
+		CreatedAt: FormatTime(c.CreatedAt),
+		UpdatedAt: FormatTime(c.UpdatedAt),
+	}
 }
@@ -6,6 +6,8 @@ import (
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
 	"gorm.io/gorm"
+
+	hermes "github.com/heliannuuthus/hermes/internal"
 )
 
 func toStatus(err error) error {
@@ -18,6 +20,12 @@ func toStatus(err error) error {
 	if errors.Is(err, gorm.ErrDuplicatedKey) {
 		return status.Error(codes.AlreadyExists, err.Error())
 	}
+	if errors.Is(err, hermes.ErrIDPNotAllowed) {
+		return status.Error(codes.FailedPrecondition, err.Error())
+	}
+	if errors.Is(err, hermes.ErrInvalidIDPConfig) {
+		return status.Error(codes.InvalidArgument, err.Error())
+	}
 	if st, ok := status.FromError(err); ok {
 		return st.Err()
 	}
@@ -56,6 +56,23 @@ func (s *keyServiceServer) RotateKey(ctx context.Context, req *hermesv1.RotateKe
 	return &emptypb.Empty{}, nil
 }
 
+func (s *keyServiceServer) CreateKey(ctx context.Context, req *hermesv1.CreateKeyRequest) (*hermesv1.KeySet, error) {
+	switch req.GetOwnerType() {
+	case models.KeyOwnerDomain, models.KeyOwnerApplication, models.KeyOwnerService:
+	default:
+		return nil, toStatus(fmt.Errorf("unknown owner_type: %s", req.GetOwnerType()))
+	}
+	keys, err := s.svc.IssueKey(ctx, req.GetOwnerType(), req.GetOwnerId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	result := &hermesv1.KeySet{Keys: keys}
+	if len(keys) > 0 {
+		result.Main = keys[0]
+	}
+	return result, nil
+}
+
 // ==================== IDP Key ====================
 
 func (s *keyServiceServer) ListIDPKeys(ctx context.Context, _ *emptypb.Empty) (*hermesv1.IDPKeyList, error) {
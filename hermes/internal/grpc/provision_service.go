@@ -143,6 +143,24 @@ func (s *provisionServiceServer) CreateApplication(ctx context.Context, req *her
 		v := uint(*req.RefreshTokenAbsoluteExpiresIn)
 		createReq.RefreshTokenAbsoluteExpiresIn = &v
 	}
+	if req.RefreshTokenRotation != nil {
+		createReq.RefreshTokenRotation = req.RefreshTokenRotation
+	}
+	if req.DpopBoundAccessTokens != nil {
+		createReq.DPoPBoundAccessTokens = req.DpopBoundAccessTokens
+	}
+	if req.BackchannelLogoutUri != nil {
+		createReq.BackchannelLogoutURI = req.BackchannelLogoutUri
+	}
+	if req.RequirePushedAuthorizationRequests != nil {
+		createReq.RequirePushedAuthRequests = req.RequirePushedAuthorizationRequests
+	}
+	if req.LogoUrl != nil {
+		createReq.LogoURL = req.LogoUrl
+	}
+	if req.RegistrationTokenHash != nil {
+		createReq.RegistrationTokenHash = req.RegistrationTokenHash
+	}
 
 	app, err := s.svc.CreateApplication(ctx, createReq)
 	if err != nil {
@@ -205,6 +223,10 @@ func (s *provisionServiceServer) UpdateApplication(ctx context.Context, req *her
 	if req.RefreshTokenAbsoluteExpiresIn != nil {
 		updateReq.RefreshTokenAbsoluteExpiresIn = optionalUintFromPtr32(req.RefreshTokenAbsoluteExpiresIn)
 	}
+	updateReq.RefreshTokenRotation = optionalFromPtr(req.RefreshTokenRotation)
+	updateReq.DPoPBoundAccessTokens = optionalFromPtr(req.DpopBoundAccessTokens)
+	updateReq.BackchannelLogoutURI = optionalFromPtr(req.BackchannelLogoutUri)
+	updateReq.RequirePushedAuthRequests = optionalFromPtr(req.RequirePushedAuthorizationRequests)
 
 	if err := s.svc.UpdateApplication(ctx, req.GetAppId(), updateReq); err != nil {
 		return nil, toStatus(err)
@@ -217,6 +239,13 @@ func (s *provisionServiceServer) UpdateApplication(ctx context.Context, req *her
 	return applicationToProto(app), nil
 }
 
+func (s *provisionServiceServer) DeleteApplication(ctx context.Context, req *hermesv1.DeleteApplicationRequest) (*emptypb.Empty, error) {
+	if err := s.svc.DeleteApplication(ctx, req.GetAppId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // ==================== Application IDP Config ====================
 
 func (s *provisionServiceServer) GetApplicationIDPConfigs(ctx context.Context, req *hermesv1.GetApplicationRequest) (*hermesv1.ApplicationIDPConfigList, error) {
@@ -359,6 +388,18 @@ func (s *provisionServiceServer) GetServiceChallengeSetting(ctx context.Context,
 	return challengeSettingToProto(cfg), nil
 }
 
+func (s *provisionServiceServer) ListServiceAuthorizationDetailTypes(ctx context.Context, req *hermesv1.GetServiceRequest) (*hermesv1.ServiceAuthorizationDetailTypeList, error) {
+	types, err := s.svc.ListServiceAuthorizationDetailTypes(ctx, req.GetServiceId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	list := make([]*hermesv1.ServiceAuthorizationDetailType, 0, len(types))
+	for i := range types {
+		list = append(list, authorizationDetailTypeToProto(&types[i]))
+	}
+	return &hermesv1.ServiceAuthorizationDetailTypeList{Types: list}, nil
+}
+
 // ==================== conversion helpers ====================
 
 func domainToProto(d *models.Domain) *hermesv1.Domain {
@@ -371,20 +412,25 @@ func domainToProto(d *models.Domain) *hermesv1.Domain {
 
 func applicationToProto(a *models.Application) *hermesv1.Application {
 	return &hermesv1.Application{
-		Id:                            safeUint32(a.ID),
-		DomainId:                      a.DomainID,
-		AppId:                         a.AppID,
-		Name:                          a.Name,
-		Description:                   a.Description,
-		LogoUrl:                       a.LogoURL,
-		AllowedRedirectUris:           a.GetAllowedRedirectURIs(),
-		AllowedOrigins:                a.GetAllowedOrigins(),
-		AllowedLogoutUris:             a.GetAllowedLogoutURIs(),
-		IdTokenExpiresIn:              safeUint32(a.IDTokenExpiresIn),
-		RefreshTokenExpiresIn:         safeUint32(a.RefreshTokenExpiresIn),
-		RefreshTokenAbsoluteExpiresIn: safeUint32(a.RefreshTokenAbsoluteExpiresIn),
-		CreatedAt:                     timestamppb.New(a.CreatedAt),
-		UpdatedAt:                     timestamppb.New(a.UpdatedAt),
+		Id:                                 safeUint32(a.ID),
+		DomainId:                           a.DomainID,
+		AppId:                              a.AppID,
+		Name:                               a.Name,
+		Description:                        a.Description,
+		LogoUrl:                            a.LogoURL,
+		AllowedRedirectUris:                a.GetAllowedRedirectURIs(),
+		AllowedOrigins:                     a.GetAllowedOrigins(),
+		AllowedLogoutUris:                  a.GetAllowedLogoutURIs(),
+		IdTokenExpiresIn:                   safeUint32(a.IDTokenExpiresIn),
+		RefreshTokenExpiresIn:              safeUint32(a.RefreshTokenExpiresIn),
+		RefreshTokenAbsoluteExpiresIn:      safeUint32(a.RefreshTokenAbsoluteExpiresIn),
+		RefreshTokenRotation:               a.RefreshTokenRotation,
+		DpopBoundAccessTokens:              a.DPoPBoundAccessTokens,
+		BackchannelLogoutUri:               a.BackchannelLogoutURI,
+		RequirePushedAuthorizationRequests: a.RequirePushedAuthRequests,
+		RegistrationTokenHash:              a.RegistrationTokenHash,
+		CreatedAt:                          timestamppb.New(a.CreatedAt),
+		UpdatedAt:                          timestamppb.New(a.UpdatedAt),
 	}
 }
 
@@ -405,6 +451,7 @@ func appIDPConfigToProto(cfg *models.ApplicationIDPConfig) *hermesv1.Application
 		Strategy:  cfg.Strategy,
 		Delegate:  delegate,
 		Require:   require,
+		Config:    cfg.Config,
 		CreatedAt: timestamppb.New(cfg.CreatedAt),
 		UpdatedAt: timestamppb.New(cfg.UpdatedAt),
 	}
@@ -427,6 +474,7 @@ func domainIDPConfigToProto(cfg *models.DomainIDPConfig) *hermesv1.DomainIDPConf
 		Strategy:  cfg.Strategy,
 		Delegate:  delegate,
 		Require:   require,
+		Config:    cfg.Config,
 		CreatedAt: timestamppb.New(cfg.CreatedAt),
 		UpdatedAt: timestamppb.New(cfg.UpdatedAt),
 	}
@@ -473,6 +521,18 @@ func challengeSettingToProto(cfg *models.ServiceChallengeSetting) *hermesv1.Serv
 	}
 }
 
+func authorizationDetailTypeToProto(t *models.ServiceAuthorizationDetailType) *hermesv1.ServiceAuthorizationDetailType {
+	return &hermesv1.ServiceAuthorizationDetailType{
+		Id:          safeUint32(t.ID),
+		ServiceId:   t.ServiceID,
+		Type:        t.Type,
+		Description: t.Description,
+		JsonSchema:  t.JSONSchema,
+		CreatedAt:   timestamppb.New(t.CreatedAt),
+		UpdatedAt:   timestamppb.New(t.UpdatedAt),
+	}
+}
+
 func optionalFromPtr[T any](p *T) patch.Optional[T] {
 	if p == nil {
 		return patch.Optional[T]{}
@@ -132,6 +132,9 @@ func (s *userServiceServer) PatchUser(ctx context.Context, req *hermesv1.PatchUs
 	if req.LastLoginAt != nil {
 		updates["last_login_at"] = req.GetLastLoginAt().AsTime()
 	}
+	if req.LockedUntil != nil {
+		updates["locked_until"] = req.GetLockedUntil().AsTime()
+	}
 
 	if len(updates) > 0 {
 		if err := s.svc.PatchUser(ctx, req.GetOpenid(), updates); err != nil {
@@ -184,6 +187,13 @@ func (s *userServiceServer) AddIdentity(ctx context.Context, req *hermesv1.AddId
 	return &emptypb.Empty{}, nil
 }
 
+func (s *userServiceServer) RemoveIdentity(ctx context.Context, req *hermesv1.RemoveIdentityRequest) (*emptypb.Empty, error) {
+	if err := s.svc.DeleteIdentity(ctx, req.GetDomain(), req.GetOpenid(), req.GetIdp()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (s *userServiceServer) GetPasswordCredential(ctx context.Context, req *hermesv1.GetPasswordCredentialRequest) (*hermesv1.PasswordStoreCredential, error) {
 	user, identity, err := s.resolvePasswordCredentialUser(ctx, req.GetIdp(), req.GetIdentifier())
 	if err != nil {
@@ -273,6 +283,33 @@ func (s *userServiceServer) DeleteUserCredentialsByType(ctx context.Context, req
 	return &emptypb.Empty{}, nil
 }
 
+func (s *userServiceServer) ConsumeCredential(ctx context.Context, req *hermesv1.DeleteCredentialRequest) (*hermesv1.ConsumeCredentialResponse, error) {
+	consumed, err := s.svc.ConsumeCredential(ctx, req.GetOpenid(), req.GetCredentialId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &hermesv1.ConsumeCredentialResponse{Consumed: consumed}, nil
+}
+
+func (s *userServiceServer) ReplaceUserCredentialsByType(ctx context.Context, req *hermesv1.ReplaceCredentialsRequest) (*emptypb.Empty, error) {
+	creds := make([]models.UserCredential, 0, len(req.GetCredentials()))
+	for _, c := range req.GetCredentials() {
+		cred := models.UserCredential{
+			Label:   c.GetLabel(),
+			Enabled: true,
+			Secret:  c.GetSecret(),
+		}
+		if id := c.GetCredentialId(); id != "" {
+			cred.CredentialID = &id
+		}
+		creds = append(creds, cred)
+	}
+	if err := s.svc.ReplaceUserCredentialsByType(ctx, req.GetOpenid(), req.GetType(), creds); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 func (s *userServiceServer) GetOpenIDByCredentialID(ctx context.Context, req *hermesv1.CredentialIDRequest) (*hermesv1.OpenIDResponse, error) {
 	openid, err := s.svc.GetOpenIDByCredentialID(ctx, req.GetCredentialId())
 	if err != nil {
@@ -281,6 +318,43 @@ func (s *userServiceServer) GetOpenIDByCredentialID(ctx context.Context, req *he
 	return &hermesv1.OpenIDResponse{Openid: openid}, nil
 }
 
+// ==================== Consent ====================
+
+func (s *userServiceServer) GetConsent(ctx context.Context, req *hermesv1.GetConsentRequest) (*hermesv1.UserConsent, error) {
+	consent, err := s.svc.GetConsent(ctx, req.GetOpenid(), req.GetAppId(), req.GetServiceId())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return consentToProto(consent), nil
+}
+
+func (s *userServiceServer) ListConsents(ctx context.Context, req *hermesv1.OpenIDRequest) (*hermesv1.UserConsentList, error) {
+	consents, err := s.svc.ListConsents(ctx, req.GetOpenid())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	out := make([]*hermesv1.UserConsent, 0, len(consents))
+	for i := range consents {
+		out = append(out, consentToProto(&consents[i]))
+	}
+	return &hermesv1.UserConsentList{Consents: out}, nil
+}
+
+func (s *userServiceServer) SaveConsent(ctx context.Context, req *hermesv1.SaveConsentRequest) (*hermesv1.UserConsent, error) {
+	consent, err := s.svc.SaveConsent(ctx, req.GetOpenid(), req.GetAppId(), req.GetServiceId(), req.GetScopes())
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return consentToProto(consent), nil
+}
+
+func (s *userServiceServer) RevokeConsent(ctx context.Context, req *hermesv1.RevokeConsentRequest) (*emptypb.Empty, error) {
+	if err := s.svc.RevokeConsent(ctx, req.GetOpenid(), req.GetAppId()); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
 // ==================== Group ====================
 
 func (s *userServiceServer) CreateGroup(ctx context.Context, req *hermesv1.CreateGroupRequest) (*hermesv1.Group, error) {
@@ -382,6 +456,9 @@ func userToProto(u *models.User) *hermesv1.User {
 	if u.LastLoginAt != nil {
 		pb.LastLoginAt = timestamppb.New(*u.LastLoginAt)
 	}
+	if u.LockedUntil != nil {
+		pb.LockedUntil = timestamppb.New(*u.LockedUntil)
+	}
 	if u.PasswordHash != nil {
 		pb.PasswordHash = u.PasswordHash
 	}
@@ -410,6 +487,17 @@ func identityToProto(i *models.UserIdentity) *hermesv1.UserIdentity {
 	return pb
 }
 
+func consentToProto(c *models.UserConsent) *hermesv1.UserConsent {
+	return &hermesv1.UserConsent{
+		Openid:    c.OpenID,
+		AppId:     c.AppID,
+		ServiceId: c.ServiceID,
+		Scopes:    c.Scopes(),
+		CreatedAt: timestamppb.New(c.CreatedAt),
+		UpdatedAt: timestamppb.New(c.UpdatedAt),
+	}
+}
+
 func identitiesToProto(identities models.Identities) *hermesv1.IdentityList {
 	out := make([]*hermesv1.UserIdentity, 0, len(identities))
 	for _, i := range identities {
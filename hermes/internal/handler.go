@@ -1,6 +1,7 @@
 package hermes
 
 import (
+	"errors"
 	"net/http"
 	"strconv"
 
@@ -164,6 +165,10 @@ func (h *Handler) UpdateDomainIDPConfig(c *gin.Context) {
 		return
 	}
 	if err := h.service.UpdateDomainIDPConfig(c.Request.Context(), domainID, idpType, &req); err != nil {
+		if errors.Is(err, ErrInvalidIDPConfig) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -499,15 +504,7 @@ func (h *Handler) ListApplicationIDPConfigs(c *gin.Context) {
 	}
 	resp := make([]dto.ApplicationIDPConfigResponse, 0, len(configs))
 	for _, cfg := range configs {
-		resp = append(resp, dto.ApplicationIDPConfigResponse{
-			AppID:     cfg.AppID,
-			Type:      cfg.Type,
-			Priority:  cfg.Priority,
-			Strategy:  cfg.Strategy,
-			TAppID:    cfg.TAppID,
-			CreatedAt: dto.FormatTime(cfg.CreatedAt),
-			UpdatedAt: dto.FormatTime(cfg.UpdatedAt),
-		})
+		resp = append(resp, dto.NewApplicationIDPConfigResponse(cfg))
 	}
 	c.JSON(http.StatusOK, resp)
 }
@@ -535,15 +532,7 @@ func (h *Handler) CreateApplicationIDPConfig(c *gin.Context) {
 		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, dto.ApplicationIDPConfigResponse{
-		AppID:     cfg.AppID,
-		Type:      cfg.Type,
-		Priority:  cfg.Priority,
-		Strategy:  cfg.Strategy,
-		TAppID:    cfg.TAppID,
-		CreatedAt: dto.FormatTime(cfg.CreatedAt),
-		UpdatedAt: dto.FormatTime(cfg.UpdatedAt),
-	})
+	c.JSON(http.StatusOK, dto.NewApplicationIDPConfigResponse(cfg))
 }
 
 // UpdateApplicationIDPConfig PATCH /hermes/domains/:domain_id/applications/:app_id/idp-configs/:idp_type
@@ -566,6 +555,10 @@ func (h *Handler) UpdateApplicationIDPConfig(c *gin.Context) {
 		return
 	}
 	if err := h.service.UpdateApplicationIDPConfig(c.Request.Context(), appID, idpType, &req); err != nil {
+		if errors.Is(err, ErrInvalidIDPConfig) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
@@ -663,6 +656,16 @@ func (h *Handler) DeleteGroup(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// UnlockUser POST /hermes/users/:openid/unlock（解除登录失败锁定）
+func (h *Handler) UnlockUser(c *gin.Context) {
+	openid := c.Param("openid")
+	if err := h.service.UnlockUser(c.Request.Context(), openid); err != nil {
+		c.JSON(http.StatusNotFound, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // ==================== Service Challenge Setting 相关 ====================
 
 // ListServiceChallengeSettings GET /hermes/domains/:domain_id/services/:service_id/challenge-settings
@@ -723,6 +726,66 @@ func (h *Handler) DeleteServiceChallengeSetting(c *gin.Context) {
 	c.Status(http.StatusNoContent)
 }
 
+// ==================== Service Authorization Detail Type 相关 ====================
+
+// ListServiceAuthorizationDetailTypes GET /hermes/domains/:domain_id/services/:service_id/authorization-detail-types
+func (h *Handler) ListServiceAuthorizationDetailTypes(c *gin.Context) {
+	serviceID := c.Param("service_id")
+	types, err := h.service.ListServiceAuthorizationDetailTypes(c.Request.Context(), serviceID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	resp := make([]dto.ServiceAuthorizationDetailTypeResponse, 0, len(types))
+	for i := range types {
+		resp = append(resp, dto.NewServiceAuthorizationDetailTypeResponse(&types[i]))
+	}
+	c.JSON(http.StatusOK, resp)
+}
+
+// CreateServiceAuthorizationDetailType POST /hermes/domains/:domain_id/services/:service_id/authorization-detail-types
+func (h *Handler) CreateServiceAuthorizationDetailType(c *gin.Context) {
+	serviceID := c.Param("service_id")
+	var req dto.ServiceAuthorizationDetailTypeCreateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	detailType, err := h.service.CreateServiceAuthorizationDetailType(c.Request.Context(), serviceID, &req)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, dto.NewServiceAuthorizationDetailTypeResponse(detailType))
+}
+
+// UpdateServiceAuthorizationDetailType PATCH /hermes/domains/:domain_id/services/:service_id/authorization-detail-types/:type
+func (h *Handler) UpdateServiceAuthorizationDetailType(c *gin.Context) {
+	serviceID := c.Param("service_id")
+	detailType := c.Param("type")
+	var req dto.ServiceAuthorizationDetailTypeUpdateRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if err := h.service.UpdateServiceAuthorizationDetailType(c.Request.Context(), serviceID, detailType, &req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, gin.H{"message": "更新成功"})
+}
+
+// DeleteServiceAuthorizationDetailType DELETE /hermes/domains/:domain_id/services/:service_id/authorization-detail-types/:type
+func (h *Handler) DeleteServiceAuthorizationDetailType(c *gin.Context) {
+	serviceID := c.Param("service_id")
+	detailType := c.Param("type")
+	if err := h.service.DeleteServiceAuthorizationDetailType(c.Request.Context(), serviceID, detailType); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Status(http.StatusNoContent)
+}
+
 // ==================== Relationship 相关 ====================
 
 // CreateRelationship POST /hermes/relationships
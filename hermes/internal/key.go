@@ -73,6 +73,26 @@ func (s *Service) RotateKey(ctx context.Context, ownerType, ownerID string, wind
 	})
 }
 
+// IssueKey 为尚无有效密钥的 owner 生成首个密钥并返回有效密钥（已解密）；已有密钥时应使用 RotateKey
+func (s *Service) IssueKey(ctx context.Context, ownerType, ownerID string) ([][]byte, error) {
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		var count int64
+		if err := tx.Model(&models.Key{}).
+			Where("owner_type = ? AND owner_id = ? AND (expired_at IS NULL OR expired_at > NOW())", ownerType, ownerID).
+			Count(&count).Error; err != nil {
+			return fmt.Errorf("查询密钥失败: %w", err)
+		}
+		if count > 0 {
+			return fmt.Errorf("%s %s 已存在有效密钥", ownerType, ownerID)
+		}
+		return s.CreateKey(tx, ownerType, ownerID)
+	})
+	if err != nil {
+		return nil, err
+	}
+	return s.getKeys(ctx, ownerType, ownerID)
+}
+
 // getKeys 获取指定 owner 的所有有效密钥（已解密），按 created_at DESC 排序
 func (s *Service) getKeys(ctx context.Context, ownerType, ownerID string) ([][]byte, error) {
 	var keys []models.Key
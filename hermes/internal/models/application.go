@@ -27,6 +27,11 @@ type Application struct {
 	IDTokenExpiresIn              uint    `gorm:"column:id_token_expires_in;not null;default:3600" json:"id_token_expires_in"`
 	RefreshTokenExpiresIn         uint    `gorm:"column:refresh_token_expires_in;not null;default:604800" json:"refresh_token_expires_in"`
 	RefreshTokenAbsoluteExpiresIn uint    `gorm:"column:refresh_token_absolute_expires_in;not null;default:0" json:"refresh_token_absolute_expires_in"`
+	RefreshTokenRotation          bool    `gorm:"column:refresh_token_rotation;not null;default:false" json:"refresh_token_rotation"`
+	DPoPBoundAccessTokens         bool    `gorm:"column:dpop_bound_access_tokens;not null;default:false" json:"dpop_bound_access_tokens"`
+	BackchannelLogoutURI          *string `gorm:"column:backchannel_logout_uri;size:512" json:"backchannel_logout_uri,omitempty"`
+	RequirePushedAuthRequests     bool    `gorm:"column:require_pushed_authorization_requests;not null;default:false" json:"require_pushed_authorization_requests"`
+	RegistrationTokenHash         *string `gorm:"column:registration_token_hash;size:64" json:"-"` // 动态注册的 registration_access_token 摘要（RFC 7592），仅经 gRPC 写入与读取
 	// 时间戳
 	CreatedAt time.Time `gorm:"column:created_at;not null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
@@ -46,6 +51,7 @@ type ApplicationIDPConfig struct {
 	Delegate  *string   `gorm:"column:delegate;size:256" json:"delegate,omitempty"`
 	Require   *string   `gorm:"column:require;size:256" json:"require,omitempty"`
 	TAppID    *string   `gorm:"column:t_app_id;size:256" json:"t_app_id,omitempty"`
+	Config    *string   `gorm:"column:config" json:"config,omitempty"` // 连接参数（JSON），覆盖域 IDP 配置中的同名项
 	CreatedAt time.Time `gorm:"column:created_at;not null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
 }
@@ -0,0 +1,46 @@
+package models
+
+import (
+	"strings"
+	"time"
+)
+
+// UserConsent 用户授权同意（用户 + 应用 + 服务 唯一，scope 空格分隔）
+type UserConsent struct {
+	ID        uint      `gorm:"primaryKey;autoIncrement;column:_id" json:"_id"`
+	OpenID    string    `gorm:"column:openid;size:64;not null;uniqueIndex:uk_openid_app_service" json:"openid"`
+	AppID     string    `gorm:"column:app_id;size:64;not null;uniqueIndex:uk_openid_app_service" json:"app_id"`
+	ServiceID string    `gorm:"column:service_id;size:32;not null;uniqueIndex:uk_openid_app_service" json:"service_id"`
+	Scope     string    `gorm:"column:scope;size:1024;not null;default:''" json:"scope"`
+	CreatedAt time.Time `gorm:"column:created_at;not null" json:"created_at"`
+	UpdatedAt time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
+}
+
+func (UserConsent) TableName() string { return "t_user_consent" }
+
+func (c UserConsent) PrimaryKey() uint { return c.ID }
+
+// Scopes 返回已授权的 scope 列表
+func (c *UserConsent) Scopes() []string {
+	return strings.Fields(c.Scope)
+}
+
+// MergeScopes 合并 scope（取并集，保持原有顺序）
+func (c *UserConsent) MergeScopes(scopes []string) {
+	existing := c.Scopes()
+	seen := make(map[string]struct{}, len(existing)+len(scopes))
+	for _, s := range existing {
+		seen[s] = struct{}{}
+	}
+	for _, s := range scopes {
+		if s == "" {
+			continue
+		}
+		if _, ok := seen[s]; ok {
+			continue
+		}
+		seen[s] = struct{}{}
+		existing = append(existing, s)
+	}
+	c.Scope = strings.Join(existing, " ")
+}
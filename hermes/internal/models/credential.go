@@ -8,9 +8,11 @@ import (
 type CredentialType string
 
 const (
-	CredentialTypeTOTP     CredentialType = "totp"
-	CredentialTypeWebAuthn CredentialType = "webauthn"
-	CredentialTypePasskey  CredentialType = "passkey"
+	CredentialTypeTOTP            CredentialType = "totp"
+	CredentialTypeWebAuthn        CredentialType = "webauthn"
+	CredentialTypePasskey         CredentialType = "passkey"
+	CredentialTypeRecoveryCode    CredentialType = "recovery_code"
+	CredentialTypePasswordHistory CredentialType = "password_history"
 )
 
 // UserCredential 用户安全凭证（Secret 不序列化到 API）
@@ -64,7 +66,8 @@ type CredentialSummary struct {
 
 // MFAStatus 用户 MFA 状态
 type MFAStatus struct {
-	TOTPEnabled   bool `json:"totp_enabled"`
-	WebAuthnCount int  `json:"webauthn_count"`
-	PasskeyCount  int  `json:"passkey_count"`
+	TOTPEnabled            bool `json:"totp_enabled"`
+	WebAuthnCount          int  `json:"webauthn_count"`
+	PasskeyCount           int  `json:"passkey_count"`
+	RecoveryCodesRemaining int  `json:"recovery_codes_remaining"`
 }
@@ -31,6 +31,7 @@ type DomainIDPConfig struct {
 	Delegate  *string   `gorm:"column:delegate;size:256" json:"delegate,omitempty"`
 	Require   *string   `gorm:"column:require;size:256" json:"require,omitempty"`
 	TAppID    string    `gorm:"column:t_app_id;size:256;not null" json:"t_app_id"`
+	Config    *string   `gorm:"column:config" json:"config,omitempty"` // 连接参数（JSON），如通用 OIDC 的 issuer / endpoints / scopes / claim 映射
 	CreatedAt time.Time `gorm:"column:created_at;not null" json:"created_at"`
 	UpdatedAt time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
 }
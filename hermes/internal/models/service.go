@@ -59,3 +59,17 @@ type ServiceChallengeSetting struct {
 }
 
 func (ServiceChallengeSetting) TableName() string { return "t_service_challenge_setting" }
+
+// ServiceAuthorizationDetailType 服务声明的授权详情类型（RAR，RFC 9396）
+// JSONSchema 约束该 type 的授权详情中除 type 以外的字段，为空时不校验字段
+type ServiceAuthorizationDetailType struct {
+	ID          uint      `gorm:"primaryKey;autoIncrement;column:_id" json:"_id"`
+	ServiceID   string    `gorm:"column:service_id;size:32;not null;index" json:"service_id"`
+	Type        string    `gorm:"column:type;size:64;not null" json:"type"`
+	Description *string   `gorm:"column:description;size:512" json:"description,omitempty"`
+	JSONSchema  *string   `gorm:"column:json_schema" json:"json_schema,omitempty"`
+	CreatedAt   time.Time `gorm:"column:created_at;not null" json:"created_at"`
+	UpdatedAt   time.Time `gorm:"column:updated_at;not null" json:"updated_at"`
+}
+
+func (ServiceAuthorizationDetailType) TableName() string { return "t_service_authorization_detail_type" }
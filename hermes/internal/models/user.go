@@ -18,7 +18,7 @@ type User struct {
 	OpenID        string  `json:"openid" gorm:"column:openid;size:64;not null;uniqueIndex"` // 用户标识（= global identity 的 t_openid）
 	Status        int8    `json:"status" gorm:"column:status;not null;default:0"`           // 0=active, 1=disabled
 	Username      *string `json:"-" gorm:"column:username;size:64;uniqueIndex"`             // 用户名（唯一）
-	PasswordHash  *string `json:"-" gorm:"column:password_hash;size:256"`                   // 密码哈希（bcrypt）
+	PasswordHash  *string `json:"-" gorm:"column:password_hash;size:256"`                   // 密码哈希（PHC 格式 argon2id，历史数据为 bcrypt）
 	Nickname      *string `json:"nickname" gorm:"column:nickname;size:128"`
 	Picture       *string `json:"picture" gorm:"column:picture;size:512"`
 	Email         *string `json:"email" gorm:"column:email;size:256;uniqueIndex"`
@@ -27,6 +27,7 @@ type User struct {
 	PhoneCipher   *string `json:"-" gorm:"column:phone_cipher;size:256"`     // 手机号密文
 	// 时间戳
 	LastLoginAt *time.Time `json:"last_login_at" gorm:"column:last_login_at"`
+	LockedUntil *time.Time `json:"locked_until" gorm:"column:locked_until"` // 登录失败锁定截止时间，为空或已过期表示未锁定
 	CreatedAt   time.Time  `json:"created_at" gorm:"column:created_at;not null"`
 	UpdatedAt   time.Time  `json:"updated_at" gorm:"column:updated_at;not null"`
 }
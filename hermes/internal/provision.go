@@ -2,6 +2,7 @@ package hermes
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"strings"
 
@@ -11,6 +12,7 @@ import (
 	"github.com/heliannuuthus/hermes/internal/dto"
 	"github.com/heliannuuthus/hermes/internal/models"
 	"github.com/heliannuuthus/hermes/internal/validation"
+	"github.com/heliannuuthus/pkg/aegis/utilities/schema"
 	"github.com/heliannuuthus/pkg/filter"
 	"github.com/heliannuuthus/pkg/helpers"
 	"github.com/heliannuuthus/pkg/pagination"
@@ -186,6 +188,9 @@ func (s *Service) DeleteService(ctx context.Context, serviceID string) error {
 		if err := tx.Where("service_id = ?", serviceID).Delete(&models.ServiceChallengeSetting{}).Error; err != nil {
 			return err
 		}
+		if err := tx.Where("service_id = ?", serviceID).Delete(&models.ServiceAuthorizationDetailType{}).Error; err != nil {
+			return err
+		}
 		if err := tx.Where("owner_type = ? AND owner_id = ?", models.KeyOwnerService, serviceID).Delete(&models.Key{}).Error; err != nil {
 			return err
 		}
@@ -228,6 +233,11 @@ func (s *Service) CreateApplication(ctx context.Context, req *dto.ApplicationCre
 	if err := validation.ValidateLogoutURIs(req.AllowedLogoutURIs); err != nil {
 		return nil, fmt.Errorf("allowed_logout_uris: %w", err)
 	}
+	if req.BackchannelLogoutURI != nil && *req.BackchannelLogoutURI != "" {
+		if err := validation.ValidateBackchannelLogoutURI(*req.BackchannelLogoutURI); err != nil {
+			return nil, fmt.Errorf("backchannel_logout_uri: %w", err)
+		}
+	}
 
 	allowedRedirectURIs := marshalOptionalStringSlice(req.AllowedRedirectURIs)
 	allowedOrigins := marshalOptionalStringSlice(req.AllowedOrigins)
@@ -239,9 +249,11 @@ func (s *Service) CreateApplication(ctx context.Context, req *dto.ApplicationCre
 		AppID:                         appID,
 		Name:                          req.Name,
 		Description:                   &desc,
+		LogoURL:                       req.LogoURL,
 		AllowedRedirectURIs:           allowedRedirectURIs,
 		AllowedOrigins:                allowedOrigins,
 		AllowedLogoutURIs:             allowedLogoutURIs,
+		BackchannelLogoutURI:          req.BackchannelLogoutURI,
 		IDTokenExpiresIn:              3600,
 		RefreshTokenExpiresIn:         604800,
 		RefreshTokenAbsoluteExpiresIn: 0,
@@ -255,6 +267,16 @@ func (s *Service) CreateApplication(ctx context.Context, req *dto.ApplicationCre
 	if req.RefreshTokenAbsoluteExpiresIn != nil {
 		app.RefreshTokenAbsoluteExpiresIn = *req.RefreshTokenAbsoluteExpiresIn
 	}
+	if req.RefreshTokenRotation != nil {
+		app.RefreshTokenRotation = *req.RefreshTokenRotation
+	}
+	if req.DPoPBoundAccessTokens != nil {
+		app.DPoPBoundAccessTokens = *req.DPoPBoundAccessTokens
+	}
+	if req.RequirePushedAuthRequests != nil {
+		app.RequirePushedAuthRequests = *req.RequirePushedAuthRequests
+	}
+	app.RegistrationTokenHash = req.RegistrationTokenHash
 
 	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
 		if err := tx.Create(app).Error; err != nil {
@@ -331,8 +353,18 @@ func (s *Service) UpdateApplication(ctx context.Context, appID string, req *dto.
 		patch.Field("id_token_expires_in", req.IDTokenExpiresIn),
 		patch.Field("refresh_token_expires_in", req.RefreshTokenExpiresIn),
 		patch.Field("refresh_token_absolute_expires_in", req.RefreshTokenAbsoluteExpiresIn),
+		patch.Field("refresh_token_rotation", req.RefreshTokenRotation),
+		patch.Field("dpop_bound_access_tokens", req.DPoPBoundAccessTokens),
+		patch.Field("backchannel_logout_uri", req.BackchannelLogoutURI),
+		patch.Field("require_pushed_authorization_requests", req.RequirePushedAuthRequests),
 	)
 
+	if req.BackchannelLogoutURI.HasValue() && req.BackchannelLogoutURI.Value() != "" {
+		if err := validation.ValidateBackchannelLogoutURI(req.BackchannelLogoutURI.Value()); err != nil {
+			return fmt.Errorf("backchannel_logout_uri: %w", err)
+		}
+	}
+
 	if err := applyOptionalURIList(updates, req.AllowedRedirectURIs, "redirect_uris", validation.ValidateRedirectURIs, "allowed_redirect_uris"); err != nil {
 		return err
 	}
@@ -403,12 +435,20 @@ func (s *Service) CreateDomainIDPConfig(ctx context.Context, domainID string, re
 	if _, err := s.getDomain(ctx, domainID); err != nil {
 		return nil, err
 	}
+	if err := validateIDPConfig(validation.ValidateDomainIDPConfig, req.IDPType, req.Config); err != nil {
+		return nil, err
+	}
+	connConfig, err := marshalIDPConfig(req.Config)
+	if err != nil {
+		return nil, err
+	}
 	cfg := &models.DomainIDPConfig{
 		DomainID: domainID,
 		IDPType:  req.IDPType,
 		Priority: req.Priority,
 		Strategy: req.Strategy,
 		TAppID:   req.TAppID,
+		Config:   connConfig,
 	}
 	if err := s.db.WithContext(ctx).Create(cfg).Error; err != nil {
 		return nil, fmt.Errorf("创建域 IDP 配置失败: %w", err)
@@ -423,6 +463,14 @@ func (s *Service) UpdateDomainIDPConfig(ctx context.Context, domainID, idpType s
 		patch.Field("strategy", req.Strategy),
 		patch.Field("t_app_id", req.TAppID),
 	)
+	if req.Config.HasValue() {
+		if err := validateIDPConfig(validation.ValidateDomainIDPConfig, idpType, req.Config.Value()); err != nil {
+			return err
+		}
+	}
+	if err := patchIDPConfig(updates, req.Config); err != nil {
+		return err
+	}
 	if len(updates) == 0 {
 		return nil
 	}
@@ -470,12 +518,20 @@ func (s *Service) CreateApplicationIDPConfig(ctx context.Context, appID string,
 	if err := s.ensureIDPAllowedForApplication(ctx, appID, req.Type); err != nil {
 		return nil, err
 	}
+	if err := validateIDPConfig(validation.ValidateApplicationIDPConfig, req.Type, req.Config); err != nil {
+		return nil, err
+	}
+	connConfig, err := marshalIDPConfig(req.Config)
+	if err != nil {
+		return nil, err
+	}
 	cfg := &models.ApplicationIDPConfig{
 		AppID:    appID,
 		Type:     req.Type,
 		Priority: req.Priority,
 		Strategy: req.Strategy,
 		TAppID:   req.TAppID,
+		Config:   connConfig,
 	}
 	if err := s.db.WithContext(ctx).Create(cfg).Error; err != nil {
 		return nil, fmt.Errorf("创建应用 IDP 配置失败: %w", err)
@@ -490,6 +546,14 @@ func (s *Service) UpdateApplicationIDPConfig(ctx context.Context, appID, idpType
 		patch.Field("strategy", req.Strategy),
 		patch.Field("t_app_id", req.TAppID),
 	)
+	if req.Config.HasValue() {
+		if err := validateIDPConfig(validation.ValidateApplicationIDPConfig, idpType, req.Config.Value()); err != nil {
+			return err
+		}
+	}
+	if err := patchIDPConfig(updates, req.Config); err != nil {
+		return err
+	}
 	if len(updates) == 0 {
 		return nil
 	}
@@ -504,6 +568,44 @@ func (s *Service) UpdateApplicationIDPConfig(ctx context.Context, appID, idpType
 	return nil
 }
 
+// validateIDPConfig 写入前校验 IDP 连接参数，校验失败返回 ErrInvalidIDPConfig
+func validateIDPConfig(validate func(string, map[string]any) error, idpType string, cfg map[string]any) error {
+	if err := validate(idpType, cfg); err != nil {
+		return fmt.Errorf("%w: %w", ErrInvalidIDPConfig, err)
+	}
+	return nil
+}
+
+// marshalIDPConfig 序列化 IDP 连接参数，空参数返回 nil
+func marshalIDPConfig(cfg map[string]any) (*string, error) {
+	if len(cfg) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(cfg)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 config 失败: %w", err)
+	}
+	s := string(data)
+	return &s, nil
+}
+
+// patchIDPConfig 将 config 的 JSON Merge Patch 写入 updates（null 清除，对象整体替换）
+func patchIDPConfig(updates map[string]any, cfg patch.Optional[map[string]any]) error {
+	if !cfg.IsPresent() {
+		return nil
+	}
+	if cfg.IsNull() {
+		updates["config"] = nil
+		return nil
+	}
+	connConfig, err := marshalIDPConfig(cfg.Value())
+	if err != nil {
+		return err
+	}
+	updates["config"] = connConfig
+	return nil
+}
+
 // DeleteApplicationIDPConfig 删除应用 IDP 配置
 func (s *Service) DeleteApplicationIDPConfig(ctx context.Context, appID, idpType string) error {
 	result := s.db.WithContext(ctx).Where("app_id = ? AND `type` = ?", appID, idpType).Delete(&models.ApplicationIDPConfig{})
@@ -608,6 +710,12 @@ func (s *Service) getDomain(ctx context.Context, domainID string) (*models.Domai
 	return &rec, nil
 }
 
+// ErrIDPNotAllowed 应用所属域未配置该 IDP
+var ErrIDPNotAllowed = errors.New("idp not allowed")
+
+// ErrInvalidIDPConfig IDP 连接参数未通过校验
+var ErrInvalidIDPConfig = errors.New("invalid idp config")
+
 func (s *Service) ensureIDPAllowedForApplication(ctx context.Context, appID, idpType string) error {
 	app, err := s.GetApplication(ctx, appID)
 	if err != nil {
@@ -622,5 +730,97 @@ func (s *Service) ensureIDPAllowedForApplication(ctx context.Context, appID, idp
 			return nil
 		}
 	}
-	return fmt.Errorf("IDP %s 未在域 %s 中配置", idpType, app.DomainID)
+	return fmt.Errorf("%w: IDP %s 未在域 %s 中配置", ErrIDPNotAllowed, idpType, app.DomainID)
+}
+
+// ==================== Service Authorization Detail Type 相关 ====================
+
+// ListServiceAuthorizationDetailTypes 获取服务声明的全部授权详情类型
+func (s *Service) ListServiceAuthorizationDetailTypes(ctx context.Context, serviceID string) ([]models.ServiceAuthorizationDetailType, error) {
+	var types []models.ServiceAuthorizationDetailType
+	if err := s.db.WithContext(ctx).Where("service_id = ?", serviceID).Order("`type`").Find(&types).Error; err != nil {
+		return nil, fmt.Errorf("获取授权详情类型列表失败: %w", err)
+	}
+	return types, nil
+}
+
+// CreateServiceAuthorizationDetailType 创建服务授权详情类型
+func (s *Service) CreateServiceAuthorizationDetailType(ctx context.Context, serviceID string, req *dto.ServiceAuthorizationDetailTypeCreateRequest) (*models.ServiceAuthorizationDetailType, error) {
+	if _, err := s.GetService(ctx, serviceID); err != nil {
+		return nil, err
+	}
+	jsonSchema, err := marshalDetailSchema(req.JSONSchema)
+	if err != nil {
+		return nil, err
+	}
+	detailType := &models.ServiceAuthorizationDetailType{
+		ServiceID:   serviceID,
+		Type:        req.Type,
+		Description: req.Description,
+		JSONSchema:  jsonSchema,
+	}
+	if err := s.db.WithContext(ctx).Create(detailType).Error; err != nil {
+		return nil, fmt.Errorf("创建授权详情类型失败: %w", err)
+	}
+	return detailType, nil
+}
+
+// UpdateServiceAuthorizationDetailType 更新服务授权详情类型（JSON Merge Patch 语义）
+func (s *Service) UpdateServiceAuthorizationDetailType(ctx context.Context, serviceID, detailType string, req *dto.ServiceAuthorizationDetailTypeUpdateRequest) error {
+	updates := patch.Collect(
+		patch.Field("description", req.Description),
+	)
+	if req.JSONSchema.IsPresent() {
+		if req.JSONSchema.IsNull() {
+			updates["json_schema"] = nil
+		} else {
+			jsonSchema, err := marshalDetailSchema(req.JSONSchema.Value())
+			if err != nil {
+				return err
+			}
+			updates["json_schema"] = jsonSchema
+		}
+	}
+	if len(updates) == 0 {
+		return nil
+	}
+	result := s.db.WithContext(ctx).Model(&models.ServiceAuthorizationDetailType{}).
+		Where("service_id = ? AND `type` = ?", serviceID, detailType).Updates(updates)
+	if result.Error != nil {
+		return fmt.Errorf("更新授权详情类型失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("授权详情类型不存在: service_id=%s, type=%s", serviceID, detailType)
+	}
+	return nil
+}
+
+// DeleteServiceAuthorizationDetailType 删除服务授权详情类型
+func (s *Service) DeleteServiceAuthorizationDetailType(ctx context.Context, serviceID, detailType string) error {
+	result := s.db.WithContext(ctx).
+		Where("service_id = ? AND `type` = ?", serviceID, detailType).
+		Delete(&models.ServiceAuthorizationDetailType{})
+	if result.Error != nil {
+		return fmt.Errorf("删除授权详情类型失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("授权详情类型不存在: service_id=%s, type=%s", serviceID, detailType)
+	}
+	return nil
+}
+
+// marshalDetailSchema 校验并序列化授权详情 schema，空 schema 返回 nil（不校验字段）
+func marshalDetailSchema(def map[string]any) (*string, error) {
+	if len(def) == 0 {
+		return nil, nil
+	}
+	data, err := json.Marshal(def)
+	if err != nil {
+		return nil, fmt.Errorf("序列化 json_schema 失败: %w", err)
+	}
+	if _, err := schema.Parse(data); err != nil {
+		return nil, err
+	}
+	s := string(data)
+	return &s, nil
 }
@@ -148,6 +148,18 @@ func (s *Service) CreateIdentity(ctx context.Context, identity *models.UserIdent
 	return s.db.WithContext(ctx).Create(identity).Error
 }
 
+// DeleteIdentity 解除用户在指定域下的 IDP 身份关联，不存在时返回 gorm.ErrRecordNotFound
+func (s *Service) DeleteIdentity(ctx context.Context, domain, openid, idpType string) error {
+	result := s.db.WithContext(ctx).Where("domain = ? AND uid = ? AND idp = ?", domain, openid, idpType).Delete(&models.UserIdentity{})
+	if result.Error != nil {
+		return result.Error
+	}
+	if result.RowsAffected == 0 {
+		return gorm.ErrRecordNotFound
+	}
+	return nil
+}
+
 // ==================== User Write ====================
 
 // CreateUser 创建用户及其身份关联（认证身份 + global 身份）
@@ -243,6 +255,20 @@ func (s *Service) PatchUser(ctx context.Context, openid string, updates map[stri
 	return s.db.WithContext(ctx).Model(&models.User{}).Where("openid = ?", openid).Updates(updates).Error
 }
 
+// UnlockUser 解除登录失败锁定
+// aegis 每次账号密码登录都直接向 hermes 读取锁定状态（不经用户缓存），锁定时已清零标识维度失败计数且锁定期内不再计数，
+// 因此只需清除 locked_until，解锁即时生效
+func (s *Service) UnlockUser(ctx context.Context, openid string) error {
+	result := s.db.WithContext(ctx).Model(&models.User{}).Where("openid = ?", openid).Update("locked_until", nil)
+	if result.Error != nil {
+		return fmt.Errorf("解除用户锁定失败: %w", result.Error)
+	}
+	if result.RowsAffected == 0 {
+		return fmt.Errorf("用户不存在: openid=%s", openid)
+	}
+	return nil
+}
+
 // ==================== WebAuthn 凭证管理 ====================
 
 // CreateCredential 创建凭证（TOTP 类型自动加密 Secret）
@@ -309,6 +335,39 @@ func (s *Service) DeleteUserCredentialsByType(ctx context.Context, openid, credT
 	return s.db.WithContext(ctx).Where("openid = ? AND type = ?", openid, credType).Delete(&models.UserCredential{}).Error
 }
 
+// ConsumeCredential 删除一次性凭证（如恢复码），仅首次删除成功返回 true；删除即消费，并发请求只有一个能成功
+func (s *Service) ConsumeCredential(ctx context.Context, openid, credentialID string) (bool, error) {
+	result := s.db.WithContext(ctx).Where("openid = ? AND credential_id = ?", openid, credentialID).Delete(&models.UserCredential{})
+	if result.Error != nil {
+		return false, result.Error
+	}
+	return result.RowsAffected == 1, nil
+}
+
+// ReplaceUserCredentialsByType 在同一事务中删除用户指定类型的全部凭证并写入新凭证
+func (s *Service) ReplaceUserCredentialsByType(ctx context.Context, openid, credType string, creds []models.UserCredential) error {
+	for i := range creds {
+		creds[i].OpenID = openid
+		creds[i].Type = credType
+		if models.CredentialType(credType) == models.CredentialTypeTOTP && creds[i].Secret != "" {
+			encrypted, err := s.encryptSecret(creds[i].Secret, openid)
+			if err != nil {
+				return fmt.Errorf("加密凭证失败: %w", err)
+			}
+			creds[i].Secret = encrypted
+		}
+	}
+	return s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		if err := tx.Where("openid = ? AND type = ?", openid, credType).Delete(&models.UserCredential{}).Error; err != nil {
+			return err
+		}
+		if len(creds) == 0 {
+			return nil
+		}
+		return tx.Create(&creds).Error
+	})
+}
+
 // GetOpenIDByCredentialID 根据凭证 ID 获取用户 OpenID
 func (s *Service) GetOpenIDByCredentialID(ctx context.Context, credentialID string) (string, error) {
 	var cred models.UserCredential
@@ -343,6 +402,61 @@ func (s *Service) getUserByEmail(ctx context.Context, email string) (*models.Use
 	return &user, nil
 }
 
+// ==================== Consent 相关 ====================
+
+// GetConsent 获取用户对应用访问指定服务的授权
+func (s *Service) GetConsent(ctx context.Context, openid, appID, serviceID string) (*models.UserConsent, error) {
+	var consent models.UserConsent
+	if err := s.db.WithContext(ctx).
+		Where("openid = ? AND app_id = ? AND service_id = ?", openid, appID, serviceID).
+		First(&consent).Error; err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+// ListConsents 列出用户的全部授权
+func (s *Service) ListConsents(ctx context.Context, openid string) ([]models.UserConsent, error) {
+	var consents []models.UserConsent
+	if err := s.db.WithContext(ctx).Where("openid = ?", openid).Order("app_id, service_id").Find(&consents).Error; err != nil {
+		return nil, fmt.Errorf("获取授权列表失败: %w", err)
+	}
+	return consents, nil
+}
+
+// SaveConsent 保存授权（与已有授权的 scope 取并集）
+func (s *Service) SaveConsent(ctx context.Context, openid, appID, serviceID string, scopes []string) (*models.UserConsent, error) {
+	var consent models.UserConsent
+	err := s.db.WithContext(ctx).Transaction(func(tx *gorm.DB) error {
+		err := tx.Where("openid = ? AND app_id = ? AND service_id = ?", openid, appID, serviceID).First(&consent).Error
+		switch {
+		case errors.Is(err, gorm.ErrRecordNotFound):
+			consent = models.UserConsent{OpenID: openid, AppID: appID, ServiceID: serviceID}
+			consent.MergeScopes(scopes)
+			if err := tx.Create(&consent).Error; err != nil {
+				return fmt.Errorf("创建授权失败: %w", err)
+			}
+			return nil
+		case err != nil:
+			return err
+		}
+		consent.MergeScopes(scopes)
+		if err := tx.Model(&consent).Update("scope", consent.Scope).Error; err != nil {
+			return fmt.Errorf("更新授权失败: %w", err)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &consent, nil
+}
+
+// RevokeConsent 撤销用户对应用的全部授权
+func (s *Service) RevokeConsent(ctx context.Context, openid, appID string) error {
+	return s.db.WithContext(ctx).Where("openid = ? AND app_id = ?", openid, appID).Delete(&models.UserConsent{}).Error
+}
+
 // ==================== Group 相关 ====================
 
 var groupFilters = filter.Whitelist{
@@ -0,0 +1,84 @@
+package validation
+
+import (
+	"errors"
+	"fmt"
+	"net/url"
+	"slices"
+	"strings"
+)
+
+// oidcTypePrefix 通用 OIDC 连接的 IDP 类型前缀（oidc-{name}）
+const oidcTypePrefix = "oidc-"
+
+// oidcDomainOnlyKeys 决定上游身份来源的 OIDC 参数，只能在域 IDP 配置中设置
+// 用户身份仅按 (connection, sub) 唯一，应用级覆盖这些参数会让另一个上游签发的同名 sub 登录到已有用户
+var oidcDomainOnlyKeys = []string{"issuer", "authorization_endpoint", "token_endpoint", "userinfo_endpoint", "jwks_uri"}
+
+// ValidateDomainIDPConfig 校验域 IDP 连接参数
+func ValidateDomainIDPConfig(idpType string, cfg map[string]any) error {
+	if !strings.HasPrefix(idpType, oidcTypePrefix) || len(cfg) == 0 {
+		return nil
+	}
+	endpoints := make(map[string]string, len(oidcDomainOnlyKeys))
+	for _, key := range oidcDomainOnlyKeys {
+		v, ok := cfg[key]
+		if !ok || v == nil {
+			continue
+		}
+		s, ok := v.(string)
+		if !ok {
+			return fmt.Errorf("%s 必须是字符串", key)
+		}
+		if s == "" {
+			continue
+		}
+		if err := validateUpstreamURL(s); err != nil {
+			return fmt.Errorf("%s %w", key, err)
+		}
+		endpoints[key] = s
+	}
+	if oidcScopeRequested(cfg) && endpoints["issuer"] == "" {
+		return errors.New("请求 openid scope 时必须配置 issuer")
+	}
+	if endpoints["issuer"] == "" && (endpoints["authorization_endpoint"] == "" || endpoints["token_endpoint"] == "") {
+		return errors.New("OIDC 连接参数缺少 issuer 或 authorization_endpoint / token_endpoint")
+	}
+	return nil
+}
+
+// ValidateApplicationIDPConfig 校验应用 IDP 连接参数：OIDC 连接不得覆盖 issuer / 端点 / JWKS 与 subject 映射
+func ValidateApplicationIDPConfig(idpType string, cfg map[string]any) error {
+	if !strings.HasPrefix(idpType, oidcTypePrefix) {
+		return nil
+	}
+	for _, key := range oidcDomainOnlyKeys {
+		if _, ok := cfg[key]; ok {
+			return fmt.Errorf("应用级 OIDC 连接参数不允许设置 %s", key)
+		}
+	}
+	if claims, ok := cfg["claims"].(map[string]any); ok {
+		if _, ok := claims["subject"]; ok {
+			return errors.New("应用级 OIDC 连接参数不允许设置 claims.subject")
+		}
+	}
+	return nil
+}
+
+// oidcScopeRequested 是否请求 openid scope（未配置 scopes 时默认包含 openid）
+func oidcScopeRequested(cfg map[string]any) bool {
+	scopes, ok := cfg["scopes"].([]any)
+	if !ok || len(scopes) == 0 {
+		return true
+	}
+	return slices.Contains(scopes, any("openid"))
+}
+
+// validateUpstreamURL 上游地址必须是不含 userinfo / fragment 的绝对 HTTPS 地址
+func validateUpstreamURL(raw string) error {
+	u, err := url.Parse(raw)
+	if err != nil || u.Scheme != "https" || u.Host == "" || u.User != nil || u.Fragment != "" {
+		return fmt.Errorf("必须是绝对 HTTPS 地址: %s", raw)
+	}
+	return nil
+}
@@ -74,6 +74,17 @@ func ValidateLogoutURI(uri string) error {
 	return ValidateRedirectURI(uri)
 }
 
+// ValidateBackchannelLogoutURI 校验后端通道登出 URI：规则同重定向 URI，且不得包含 fragment
+func ValidateBackchannelLogoutURI(uri string) error {
+	if err := ValidateRedirectURI(uri); err != nil {
+		return err
+	}
+	if u, _ := url.Parse(strings.TrimSpace(uri)); u.Fragment != "" {
+		return fmt.Errorf("后端通道登出 URI 不能包含 fragment")
+	}
+	return nil
+}
+
 // isSecureOrigin 判断是否为安全 origin：https 或 localhost/127.0.0.1 的 http
 func isSecureOrigin(u *url.URL) bool {
 	scheme := strings.ToLower(u.Scheme)
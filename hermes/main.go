@@ -136,6 +136,14 @@ func startHTTP(svc *hermes.Service) {
 					challengeSettings.PATCH("/:type", adminRelation, handler.UpdateServiceChallengeSetting)
 					challengeSettings.DELETE("/:type", adminRelation, handler.DeleteServiceChallengeSetting)
 				}
+
+				detailTypes := domainServices.Group("/:service_id/authorization-detail-types")
+				{
+					detailTypes.GET("", handler.ListServiceAuthorizationDetailTypes)
+					detailTypes.POST("", adminRelation, handler.CreateServiceAuthorizationDetailType)
+					detailTypes.PATCH("/:type", adminRelation, handler.UpdateServiceAuthorizationDetailType)
+					detailTypes.DELETE("/:type", adminRelation, handler.DeleteServiceAuthorizationDetailType)
+				}
 			}
 
 			domainApps := domains.Group("/:domain_id/applications")
@@ -180,6 +188,11 @@ func startHTTP(svc *hermes.Service) {
 			groups.POST("/:group_id/members", adminRelation, handler.SetGroupMembers)
 		}
 
+		users := api.Group("/users")
+		{
+			users.POST("/:openid/unlock", adminRelation, handler.UnlockUser)
+		}
+
 		idpKeys := api.Group("/idp-keys")
 		{
 			idpKeys.GET("", handler.ListIDPKeys)
@@ -0,0 +1,5 @@
+-- 应用级 Refresh Token 轮换开关：开启后每次刷新签发新 token，旧 token 重用时撤销整个 family
+ALTER TABLE t_application
+ADD COLUMN refresh_token_rotation TINYINT(1) NOT NULL DEFAULT 0 COMMENT 'Refresh Token 是否轮换（旧 token 重用时撤销整个 family）' AFTER refresh_token_absolute_expires_in;
+
+-- 回滚：ALTER TABLE t_application DROP COLUMN refresh_token_rotation;
@@ -0,0 +1,5 @@
+-- 应用级 DPoP 强制开关：开启后 token 请求必须携带 DPoP proof，签发的 access token 绑定 cnf.jkt
+ALTER TABLE t_application
+ADD COLUMN dpop_bound_access_tokens TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否强制 DPoP 绑定 Access Token（RFC 9449）' AFTER refresh_token_rotation;
+
+-- 回滚：ALTER TABLE t_application DROP COLUMN dpop_bound_access_tokens;
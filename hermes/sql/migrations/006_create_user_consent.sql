@@ -0,0 +1,15 @@
+-- 用户授权同意表：记录用户对应用访问各服务已同意的 scope，用于跳过重复的同意页
+CREATE TABLE IF NOT EXISTS t_user_consent (
+    _id          INT UNSIGNED  AUTO_INCREMENT PRIMARY KEY,
+    openid       VARCHAR(64)   NOT NULL COMMENT '用户 OpenID（关联 t_user.openid）',
+    app_id       VARCHAR(64)   NOT NULL COMMENT '应用 ID',
+    service_id   VARCHAR(32)   NOT NULL COMMENT '服务 ID',
+    scope        VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '已同意的 scope（空格分隔）',
+    created_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_openid_app_service (openid, app_id, service_id),
+    INDEX idx_app_id (app_id),
+    CONSTRAINT fk_consent_user FOREIGN KEY (openid) REFERENCES t_user(openid) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户授权同意';
+
+-- 回滚：DROP TABLE IF EXISTS t_user_consent;
@@ -0,0 +1,5 @@
+-- 应用级后端通道登出：用户登出或会话被吊销时，aegis 向该 URI POST 签名的 logout_token
+ALTER TABLE t_application
+ADD COLUMN backchannel_logout_uri VARCHAR(512) DEFAULT NULL COMMENT '后端通道登出通知 URI（OIDC Back-Channel Logout）' AFTER dpop_bound_access_tokens;
+
+-- 回滚：ALTER TABLE t_application DROP COLUMN backchannel_logout_uri;
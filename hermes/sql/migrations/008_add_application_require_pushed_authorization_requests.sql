@@ -0,0 +1,5 @@
+-- 应用级 PAR 强制：开启后 /auth/authorize 仅接受 /auth/par 签发的 request_uri
+ALTER TABLE t_application
+ADD COLUMN require_pushed_authorization_requests TINYINT(1) NOT NULL DEFAULT 0 COMMENT '是否强制通过 PAR 发起授权请求（RFC 9126）' AFTER backchannel_logout_uri;
+
+-- 回滚：ALTER TABLE t_application DROP COLUMN require_pushed_authorization_requests;
@@ -0,0 +1,13 @@
+-- 服务授权详情类型表：声明服务接受的 authorization_details 类型及其字段 schema（RAR，RFC 9396）
+CREATE TABLE IF NOT EXISTS t_service_authorization_detail_type (
+    _id          INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    service_id   VARCHAR(32)  NOT NULL COMMENT '服务 ID',
+    `type`       VARCHAR(64)  NOT NULL COMMENT '授权详情类型（authorization_details[].type）',
+    description  VARCHAR(512) COMMENT '类型说明（同意页展示）',
+    json_schema  JSON         COMMENT '字段约束（JSON Schema 子集，不含 type 字段），为空时不校验',
+    created_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+    UNIQUE KEY uk_service_type (service_id, `type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='服务授权详情类型（RAR）';
+
+-- 回滚：DROP TABLE IF EXISTS t_service_authorization_detail_type;
@@ -0,0 +1,4 @@
+-- TOTP 凭证补齐 credential_id：验证成功后按 credential_id 更新最后使用时间
+UPDATE t_user_credential
+SET credential_id = CONCAT('totp_', _id)
+WHERE `type` = 'totp' AND credential_id IS NULL;
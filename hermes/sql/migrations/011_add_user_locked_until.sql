@@ -0,0 +1,5 @@
+-- 登录失败锁定：连续失败达到阈值后锁定到 locked_until，管理员可通过 POST /hermes/users/:openid/unlock 解锁
+ALTER TABLE t_user
+ADD COLUMN locked_until DATETIME DEFAULT NULL COMMENT '登录失败锁定截止时间' AFTER last_login_at;
+
+-- 回滚：ALTER TABLE t_user DROP COLUMN locked_until;
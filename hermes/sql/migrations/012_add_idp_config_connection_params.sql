@@ -0,0 +1,10 @@
+-- IDP 连接参数：通用 OIDC 等可配置连接的 issuer / endpoints / scopes / claim 映射，应用级配置覆盖域级同名项
+ALTER TABLE t_domain_idp_config
+ADD COLUMN config JSON DEFAULT NULL COMMENT '连接参数（如通用 OIDC 的 issuer / endpoints / scopes / claim 映射）' AFTER t_app_id;
+
+ALTER TABLE t_application_idp_config
+ADD COLUMN config JSON DEFAULT NULL COMMENT '连接参数（覆盖域 IDP 配置中的同名项）' AFTER t_app_id;
+
+-- 回滚：
+-- ALTER TABLE t_domain_idp_config DROP COLUMN config;
+-- ALTER TABLE t_application_idp_config DROP COLUMN config;
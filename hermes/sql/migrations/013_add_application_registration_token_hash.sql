@@ -0,0 +1,5 @@
+-- 动态客户端注册：registration_access_token 摘要与应用同表持久化，随应用删除失效
+ALTER TABLE t_application
+ADD COLUMN registration_token_hash CHAR(64) DEFAULT NULL COMMENT '动态注册客户端的 registration_access_token 摘要（SHA-256，RFC 7592）' AFTER require_pushed_authorization_requests;
+
+-- 回滚：ALTER TABLE t_application DROP COLUMN registration_token_hash;
@@ -60,6 +60,11 @@ CREATE TABLE IF NOT EXISTS t_application (
     id_token_expires_in             INT UNSIGNED  NOT NULL DEFAULT 3600   COMMENT 'ID Token 有效期（秒）',
     refresh_token_expires_in        INT UNSIGNED  NOT NULL DEFAULT 604800 COMMENT 'Refresh Token 沉寂有效期（秒）',
     refresh_token_absolute_expires_in INT UNSIGNED NOT NULL DEFAULT 0    COMMENT 'Refresh Token 绝对有效期（秒），0=不限制',
+    refresh_token_rotation          TINYINT(1)    NOT NULL DEFAULT 0      COMMENT 'Refresh Token 是否轮换（旧 token 重用时撤销整个 family）',
+    dpop_bound_access_tokens        TINYINT(1)    NOT NULL DEFAULT 0      COMMENT '是否强制 DPoP 绑定 Access Token（RFC 9449）',
+    backchannel_logout_uri          VARCHAR(512)  DEFAULT NULL COMMENT '后端通道登出通知 URI（OIDC Back-Channel Logout）',
+    require_pushed_authorization_requests TINYINT(1) NOT NULL DEFAULT 0   COMMENT '是否强制通过 PAR 发起授权请求（RFC 9126）',
+    registration_token_hash         CHAR(64)      DEFAULT NULL COMMENT '动态注册客户端的 registration_access_token 摘要（SHA-256，RFC 7592）',
     -- 时间戳
     created_at         DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at         DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -97,6 +102,7 @@ CREATE TABLE IF NOT EXISTS t_domain_idp_config (
     delegate     VARCHAR(256)  DEFAULT NULL COMMENT '可替代主认证的独立验证方式（email-code,totp,webauthn）',
     `require`    VARCHAR(256)  DEFAULT NULL COMMENT '前置条件（captcha 等）',
     t_app_id     VARCHAR(256)  NOT NULL COMMENT '引用 t_idp_key 的 t_app_id',
+    config       JSON          DEFAULT NULL COMMENT '连接参数（如通用 OIDC 的 issuer / endpoints / scopes / claim 映射）',
     -- 时间戳
     created_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -119,6 +125,7 @@ CREATE TABLE IF NOT EXISTS t_application_idp_config (
     delegate     VARCHAR(256)  DEFAULT NULL COMMENT '可替代主认证的独立验证方式（email-code,totp,webauthn）',
     `require`    VARCHAR(256)  DEFAULT NULL COMMENT '前置条件（captcha 等）',
     t_app_id     VARCHAR(256)  DEFAULT NULL COMMENT '引用 t_idp_key 的 t_app_id（NULL=使用域默认）',
+    config       JSON          DEFAULT NULL COMMENT '连接参数（覆盖域 IDP 配置中的同名项）',
     -- 时间戳
     created_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
@@ -185,6 +192,21 @@ CREATE TABLE IF NOT EXISTS t_service_challenge_setting (
 UNIQUE KEY uk_service_type (service_id, `type`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='服务 Challenge 配置';
 
+CREATE TABLE IF NOT EXISTS t_service_authorization_detail_type (
+    _id          INT UNSIGNED AUTO_INCREMENT PRIMARY KEY,
+    -- 业务字段
+    service_id   VARCHAR(32)  NOT NULL COMMENT '服务 ID',
+    `type`       VARCHAR(64)  NOT NULL COMMENT '授权详情类型（authorization_details[].type）',
+    description  VARCHAR(512) COMMENT '类型说明（同意页展示）',
+    json_schema  JSON         COMMENT '字段约束（JSON Schema 子集，不含 type 字段），为空时不校验',
+    -- 时间戳
+    created_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME     NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+-- 索引：主查询 WHERE service_id = ?
+UNIQUE KEY uk_service_type (service_id, `type`)
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='服务授权详情类型（RAR）';
+
 -- ==================== 应用服务关系表 ====================
 -- 定义应用可以访问哪些服务的哪些关系
 
@@ -216,7 +238,7 @@ CREATE TABLE IF NOT EXISTS t_user (
     openid           VARCHAR(64)   NOT NULL COMMENT '用户标识（= global identity 的 t_openid）',
     status           TINYINT       NOT NULL DEFAULT 0 COMMENT '状态：0=active, 1=disabled',
     username         VARCHAR(64)   DEFAULT NULL COMMENT '用户名（唯一）',
-    password_hash    VARCHAR(256)  DEFAULT NULL COMMENT '密码哈希（bcrypt）',
+    password_hash    VARCHAR(256)  DEFAULT NULL COMMENT '密码哈希（PHC 格式 argon2id，历史数据为 bcrypt）',
     email_verified   TINYINT(1)    NOT NULL DEFAULT 0 COMMENT '邮箱是否已验证',
     nickname         VARCHAR(128)  DEFAULT NULL COMMENT '昵称',
     picture          VARCHAR(512)  DEFAULT NULL COMMENT '头像 URL',
@@ -225,6 +247,7 @@ CREATE TABLE IF NOT EXISTS t_user (
     phone_cipher     VARCHAR(256)  DEFAULT NULL COMMENT '手机号密文（AES-GCM）',
     -- 时间戳
     last_login_at    DATETIME      DEFAULT NULL COMMENT '最后登录时间',
+    locked_until     DATETIME      DEFAULT NULL COMMENT '登录失败锁定截止时间',
     created_at       DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
     updated_at       DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
 
@@ -271,8 +294,8 @@ CREATE TABLE IF NOT EXISTS t_user_credential (
     _id              INT UNSIGNED  AUTO_INCREMENT PRIMARY KEY,
     -- 业务字段
     openid           VARCHAR(64)   NOT NULL COMMENT '用户标识（关联 t_user.openid）',
-    `type`           VARCHAR(32)   NOT NULL COMMENT '凭证类型：totp/webauthn/passkey',
-    credential_id    VARCHAR(256)  DEFAULT NULL COMMENT 'WebAuthn 凭证 ID（Base64 编码）',
+    `type`           VARCHAR(32)   NOT NULL COMMENT '凭证类型：totp/webauthn/passkey/recovery_code/password_history',
+    credential_id    VARCHAR(256)  DEFAULT NULL COMMENT 'WebAuthn 凭证 ID（Base64 编码）/ TOTP 凭证 ID / 恢复码 ID',
     label            VARCHAR(128)  NOT NULL DEFAULT '' COMMENT '凭证名称，创建时推断，用户可重命名',
     secret           VARCHAR(2048) NOT NULL COMMENT '凭证数据（AES-GCM 加密，Base64 编码的 JSON）',
     enabled          TINYINT(1)    NOT NULL DEFAULT 0 COMMENT '是否已启用',
@@ -288,6 +311,29 @@ UNIQUE KEY uk_credential_id (credential_id),
     INDEX idx_openid_type (openid, `type`)
 ) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户安全凭证（MFA）';
 
+-- ==================== 用户授权同意表 ====================
+-- 用户对应用访问各服务已同意的 scope（用户 + 应用 + 服务 唯一）
+
+CREATE TABLE IF NOT EXISTS t_user_consent (
+    _id          INT UNSIGNED  AUTO_INCREMENT PRIMARY KEY,
+    -- 业务字段
+    openid       VARCHAR(64)   NOT NULL COMMENT '用户 OpenID（关联 t_user.openid）',
+    app_id       VARCHAR(64)   NOT NULL COMMENT '应用 ID',
+    service_id   VARCHAR(32)   NOT NULL COMMENT '服务 ID',
+    scope        VARCHAR(1024) NOT NULL DEFAULT '' COMMENT '已同意的 scope（空格分隔）',
+    -- 时间戳
+    created_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at   DATETIME      NOT NULL DEFAULT CURRENT_TIMESTAMP ON UPDATE CURRENT_TIMESTAMP,
+
+-- 索引
+-- 授权查询：WHERE openid = ? AND app_id = ? AND service_id = ?
+UNIQUE KEY uk_openid_app_service (openid, app_id, service_id),
+    -- 按应用查询：WHERE app_id = ?
+    INDEX idx_app_id (app_id),
+    -- 外键
+    CONSTRAINT fk_consent_user FOREIGN KEY (openid) REFERENCES t_user(openid) ON DELETE CASCADE
+) ENGINE=InnoDB DEFAULT CHARSET=utf8mb4 COLLATE=utf8mb4_unicode_ci COMMENT='用户授权同意';
+
 -- ============================================================================
 -- 三、权限层（Group、Relationship）
 -- ============================================================================
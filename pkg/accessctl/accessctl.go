@@ -1,8 +1,10 @@
 // Package accessctl 提供基于 throttle 的访问控制管理器。
 //
-// 两种能力：
+// 四种能力：
 //   - 频率限流（ProbeRate）：控制请求速率，使用 Policy.Limits
 //   - 验证计数（Strike）：记录一次验证失败并根据计数决定是否限流
+//   - 计数查询（Failures）：只读统计窗口内的验证失败次数（供风险评估使用）
+//   - 登录锁定（Backoff / Fail / Clear）：按失败次数指数退避，达到阈值时锁定，使用 Lockout
 package accessctl
 
 import (
@@ -136,3 +138,132 @@ func (m *Manager) Strike(ctx context.Context, policy *Policy) (ACAction, int) {
 	}
 	return ACAllowed, 0
 }
+
+// Failures 只读统计 Policy.Window 内已记录的验证失败次数（不写入）
+// 查询失败时返回 0，调用方不应据此放宽限流
+func (m *Manager) Failures(ctx context.Context, policy *Policy) int {
+	if m == nil || m.throttler == nil || policy == nil || policy.Key == "" || policy.Window <= 0 {
+		return 0
+	}
+
+	count, err := m.throttler.Count(ctx, policy.Key, policy.Window)
+	if err != nil {
+		logger.Warnf("[AccessCtl] Failures Count error for key %s: %v", policy.Key, err)
+		return 0
+	}
+	return int(count)
+}
+
+// ==================== Lockout ====================
+
+// Lockout 登录锁定策略：窗口内失败超过 FreeAttempts 次后按 BaseDelay 指数退避（上限 MaxDelay），
+// 失败达到 LockAt 次时由调用方锁定账号
+type Lockout struct {
+	Key          string        // 维度 key
+	Window       time.Duration // 失败计数窗口
+	FreeAttempts int           // 不退避的失败次数
+	BaseDelay    time.Duration // 首次退避时长，此后每次失败翻倍
+	MaxDelay     time.Duration // 退避上限
+	Threshold    int           // 达到此失败次数触发锁定（0 = 不锁定，仅退避）
+}
+
+// NewLockout 创建 Lockout 并设置维度 key
+func NewLockout(key string) *Lockout {
+	return &Lockout{Key: key}
+}
+
+// FailWindow 设置失败计数窗口
+func (l *Lockout) FailWindow(window time.Duration) *Lockout {
+	l.Window = window
+	return l
+}
+
+// Backoff 设置退避参数：前 free 次失败不退避，之后从 base 开始每次翻倍，最长 maxDelay
+func (l *Lockout) Backoff(free int, base, maxDelay time.Duration) *Lockout {
+	l.FreeAttempts = free
+	l.BaseDelay = base
+	l.MaxDelay = maxDelay
+	return l
+}
+
+// LockAt 设置触发锁定的失败次数（0 = 不锁定）
+func (l *Lockout) LockAt(threshold int) *Lockout {
+	l.Threshold = threshold
+	return l
+}
+
+// Delay 返回窗口内已失败 failures 次时，下一次尝试前需要等待的时长
+func (l *Lockout) Delay(failures int) time.Duration {
+	over := failures - l.FreeAttempts
+	if over <= 0 || l.BaseDelay <= 0 {
+		return 0
+	}
+	delay := l.BaseDelay
+	for i := 1; i < over; i++ {
+		delay *= 2
+		if l.MaxDelay > 0 && delay >= l.MaxDelay {
+			return l.MaxDelay
+		}
+	}
+	if l.MaxDelay > 0 && delay > l.MaxDelay {
+		return l.MaxDelay
+	}
+	return delay
+}
+
+func (l *Lockout) valid() bool {
+	return l != nil && l.Key != "" && l.Window > 0
+}
+
+// Backoff 检查退避（只读）：返回各维度中最长的剩余等待秒数，0 表示放行
+// 在认证前调用；查询失败的维度视为放行
+func (m *Manager) Backoff(ctx context.Context, lockouts ...*Lockout) int {
+	if m == nil || m.throttler == nil {
+		return 0
+	}
+
+	wait := 0
+	now := time.Now()
+	for _, l := range lockouts {
+		if !l.valid() {
+			continue
+		}
+		stat, err := m.throttler.Stat(ctx, l.Key, l.Window)
+		if err != nil {
+			logger.Warnf("[AccessCtl] Backoff Stat error for key %s: %v", l.Key, err)
+			continue
+		}
+		delay := l.Delay(int(stat.Count))
+		if delay <= 0 {
+			continue
+		}
+		if remaining := stat.Latest.Add(delay).Sub(now); remaining > 0 {
+			wait = max(wait, int(remaining.Seconds())+1)
+		}
+	}
+	return wait
+}
+
+// Fail 记录一次登录失败，返回窗口内的失败次数及是否达到锁定阈值
+func (m *Manager) Fail(ctx context.Context, l *Lockout) (int, bool) {
+	if m == nil || m.throttler == nil || !l.valid() {
+		return 0, false
+	}
+
+	count, err := m.throttler.Record(ctx, l.Key, l.Window)
+	if err != nil {
+		logger.Warnf("[AccessCtl] Fail Record error for key %s: %v", l.Key, err)
+		return 0, false
+	}
+	return int(count), l.Threshold > 0 && count >= int64(l.Threshold)
+}
+
+// Clear 清除失败计数（登录成功或账号已锁定后调用）
+func (m *Manager) Clear(ctx context.Context, l *Lockout) {
+	if m == nil || m.throttler == nil || !l.valid() {
+		return
+	}
+	if err := m.throttler.Reset(ctx, l.Key); err != nil {
+		logger.Warnf("[AccessCtl] Clear Reset error for key %s: %v", l.Key, err)
+	}
+}
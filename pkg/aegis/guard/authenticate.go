@@ -15,9 +15,10 @@ import (
 )
 
 // Authenticate 从 http.Request 提取并验证 token，返回 TokenContext。
+// DPoP 绑定的 token 须以 DPoP scheme 出示并附带 proof。
 // 框架无关，供各 web 框架适配器调用。
 func Authenticate(r *http.Request) (*TokenContext, error) {
-	tokenStr := client.TrimBearer(r.Header.Get(client.AuthorizationHeader))
+	tokenStr, dpopScheme := extractAccessToken(r)
 	if tokenStr == "" {
 		return nil, tokendef.ErrMissingClaims
 	}
@@ -42,6 +43,10 @@ func Authenticate(r *http.Request) (*TokenContext, error) {
 		return nil, err
 	}
 
+	if err := verifyDPoP(r, tokenStr, t, dpopScheme); err != nil {
+		return nil, err
+	}
+
 	ct := parseChallengeToken(r, manager)
 
 	at, ok := t.(tokendef.AccessToken)
@@ -49,6 +54,10 @@ func Authenticate(r *http.Request) (*TokenContext, error) {
 		return nil, fmt.Errorf("token type %T does not implement AccessToken", t)
 	}
 
+	if manager.IsRevoked(r.Context(), at) {
+		return nil, tokendef.ErrTokenRevoked
+	}
+
 	return &TokenContext{AccessToken: at, ChallengeToken: ct}, nil
 }
 
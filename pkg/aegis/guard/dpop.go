@@ -0,0 +1,87 @@
+package guard
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/heliannuuthus/pkg/aegis/utilities/client"
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
+)
+
+// dpopVerifier 资源访问的 DPoP proof 校验器，默认使用进程内防重放缓存
+var dpopVerifier = dpop.NewVerifier(dpop.NewMemoryReplayCache(), dpop.DefaultWindow)
+
+// SetDPoPReplayCache 替换 DPoP proof jti 防重放存储（多实例部署时应使用共享存储）。应在服务启动时调用。
+func SetDPoPReplayCache(replay dpop.ReplayCache) {
+	dpopVerifier = dpop.NewVerifier(replay, dpop.DefaultWindow)
+}
+
+// dpopPublicURL 服务对外的基础地址，用于还原 htu；为空时按直连请求还原
+var dpopPublicURL string
+
+// SetDPoPPublicURL 设置服务对外的基础地址（如 https://aegis.example.com/api），htu 以该地址拼接请求路径。
+// 部署在反向代理之后时必须设置，客户端可控的 X-Forwarded-* 头不参与 htu 比对。应在服务启动时调用。
+func SetDPoPPublicURL(base string) {
+	dpopPublicURL = strings.TrimRight(base, "/")
+}
+
+// confirmed 携带 cnf.jkt 的 token（DPoP 绑定）
+type confirmed interface {
+	JKT() string
+}
+
+// extractAccessToken 从 Authorization 头提取 token，并返回是否使用 DPoP scheme
+func extractAccessToken(r *http.Request) (string, bool) {
+	authorization := r.Header.Get(client.AuthorizationHeader)
+	if tokenStr := client.TrimDPoP(authorization); tokenStr != "" {
+		return tokenStr, true
+	}
+	return client.TrimBearer(authorization), false
+}
+
+// verifyDPoP 校验 DPoP 绑定（RFC 9449 §7）：
+// 绑定的 token 必须以 DPoP scheme 出示并携带同一密钥签名的 proof，未绑定的 token 不得以 DPoP scheme 出示。
+func verifyDPoP(r *http.Request, tokenStr string, t any, scheme bool) error {
+	var jkt string
+	if c, ok := t.(confirmed); ok {
+		jkt = c.JKT()
+	}
+
+	if jkt == "" {
+		if scheme {
+			return fmt.Errorf("%w: token is not DPoP-bound", dpop.ErrInvalidProof)
+		}
+		return nil
+	}
+	if !scheme {
+		return fmt.Errorf("%w: DPoP-bound token presented as bearer", dpop.ErrInvalidProof)
+	}
+
+	proofs := r.Header.Values(dpop.HeaderName)
+	if len(proofs) != 1 {
+		return fmt.Errorf("%w: expected exactly one DPoP header", dpop.ErrInvalidProof)
+	}
+
+	proof, err := dpopVerifier.Verify(r.Context(), proofs[0], r.Method, requestURL(r), tokenStr)
+	if err != nil {
+		return err
+	}
+	if proof.JKT != jkt {
+		return fmt.Errorf("%w: proof key does not match token binding", dpop.ErrInvalidProof)
+	}
+	return nil
+}
+
+// requestURL 还原请求的绝对地址（用于 htu 比对）：优先采用配置的对外地址，否则按直连请求的 TLS 状态与 Host 还原
+func requestURL(r *http.Request) string {
+	if dpopPublicURL != "" {
+		return dpopPublicURL + r.URL.Path
+	}
+
+	scheme := "http"
+	if r.TLS != nil {
+		scheme = "https"
+	}
+	return scheme + "://" + r.Host + r.URL.Path
+}
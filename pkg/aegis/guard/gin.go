@@ -8,6 +8,7 @@ import (
 
 	"github.com/gin-gonic/gin"
 
+	"github.com/heliannuuthus/pkg/aegis/utilities/dpop"
 	"github.com/heliannuuthus/pkg/aegis/utilities/errors"
 	"github.com/heliannuuthus/pkg/aegis/utilities/relation"
 )
@@ -34,6 +35,9 @@ func (g *Gin) Require(requirements ...Requirement) gin.HandlerFunc {
 	return func(c *gin.Context) {
 		tc, err := Authenticate(c.Request)
 		if err != nil {
+			if stderrors.Is(err, dpop.ErrInvalidProof) || stderrors.Is(err, dpop.ErrReplayed) {
+				c.Header("WWW-Authenticate", fmt.Sprintf(`DPoP error="invalid_dpop_proof", algs="%s"`, strings.Join(dpop.SupportedAlgs, " ")))
+			}
 			c.AbortWithStatusJSON(http.StatusUnauthorized, gin.H{
 				"error":   "unauthorized",
 				"message": "未登录或登录已过期",
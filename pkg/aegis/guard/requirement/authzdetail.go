@@ -0,0 +1,87 @@
+package requirement
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/heliannuuthus/pkg/aegis/guard"
+	"github.com/heliannuuthus/pkg/aegis/utilities/errors"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// AuthorizationDetailRequirement 要求 UAT 携带匹配的授权详情（RAR，RFC 9396）。
+type AuthorizationDetailRequirement struct {
+	detailType string
+	actions    []string
+	locations  []string
+	identifier string
+}
+
+// AuthorizationDetail 要求 UAT 的 authorization_details 中存在给定 type 的条目，
+// 并可进一步约束 actions / locations 包含指定值、identifier 相等（支持 {path.id} 占位符）。
+//
+//	reqr.AuthorizationDetail("recipe").Actions("delete").Identifier("{path.id}")
+func AuthorizationDetail(detailType string) *AuthorizationDetailRequirement {
+	return &AuthorizationDetailRequirement{detailType: detailType}
+}
+
+// Actions 要求条目的 actions 包含全部给定值
+func (r *AuthorizationDetailRequirement) Actions(actions ...string) *AuthorizationDetailRequirement {
+	r.actions = append(r.actions, actions...)
+	return r
+}
+
+// Locations 要求条目的 locations 包含全部给定值
+func (r *AuthorizationDetailRequirement) Locations(locations ...string) *AuthorizationDetailRequirement {
+	r.locations = append(r.locations, locations...)
+	return r
+}
+
+// Identifier 要求条目的 identifier 与给定值相等
+func (r *AuthorizationDetailRequirement) Identifier(identifier string) *AuthorizationDetailRequirement {
+	r.identifier = identifier
+	return r
+}
+
+func (r *AuthorizationDetailRequirement) Enforce(ctx context.Context) error {
+	uat, ok := guard.AccessToken(ctx).(*tokendef.UserAccessToken)
+	if !ok || uat == nil {
+		return errors.ErrUnauthorized
+	}
+
+	resolver := guard.GetRelationResolver(ctx)
+	identifier := r.identifier
+	if identifier != "" {
+		identifier = resolver.Render(identifier)
+	}
+
+	for _, detail := range uat.AuthorizationDetails() {
+		if r.matches(detail, identifier) {
+			return nil
+		}
+	}
+	return fmt.Errorf("%w: no authorization detail of type %q satisfies the requirement", errors.ErrForbidden, r.detailType)
+}
+
+func (r *AuthorizationDetailRequirement) matches(detail tokendef.AuthorizationDetail, identifier string) bool {
+	if detail.Type() != r.detailType {
+		return false
+	}
+	if identifier != "" && detail.Identifier() != identifier {
+		return false
+	}
+	actions := detail.Actions()
+	for _, a := range r.actions {
+		if !slices.Contains(actions, a) {
+			return false
+		}
+	}
+	locations := detail.Locations()
+	for _, l := range r.locations {
+		if !slices.Contains(locations, l) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,36 @@
+package requirement
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/heliannuuthus/pkg/aegis/guard"
+	"github.com/heliannuuthus/pkg/aegis/utilities/errors"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+type maxAgeRequirement struct {
+	maxAge time.Duration
+}
+
+// MaxAge 要求 UAT 携带 auth_time，且用户完成认证距今不超过 maxAge。
+// 用于敏感操作前的认证新鲜度检查，不满足时客户端应以 max_age 重新发起授权。
+func MaxAge(maxAge time.Duration) guard.Requirement {
+	return &maxAgeRequirement{maxAge: maxAge}
+}
+
+func (r *maxAgeRequirement) Enforce(ctx context.Context) error {
+	uat, ok := guard.AccessToken(ctx).(*tokendef.UserAccessToken)
+	if !ok || uat == nil {
+		return errors.ErrUnauthorized
+	}
+	authTime := uat.AuthTime()
+	if authTime.IsZero() {
+		return fmt.Errorf("%w: missing auth_time", errors.ErrUnauthorized)
+	}
+	if time.Since(authTime) > r.maxAge {
+		return fmt.Errorf("%w: auth_time older than %s", errors.ErrUnauthorized, r.maxAge)
+	}
+	return nil
+}
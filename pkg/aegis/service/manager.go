@@ -34,7 +34,7 @@ type checkResponse struct {
 }
 
 // Manager 管理多 audience 的 Decryptor（token 解析）和 Issuer（CT 签发）。
-// 同时内化关系检查逻辑，通过 Check 方法远程校验权限。
+// 同时内化关系检查逻辑，通过 Check 方法远程校验权限；通过 IsRevoked 校验本地缓存的吊销列表。
 type Manager struct {
 	endpoint           string
 	encryptKeyProvider key.Provider
@@ -43,6 +43,8 @@ type Manager struct {
 	mu         sync.RWMutex
 	decryptors map[string]*Decryptor
 	issuers    map[string]*issuer.Issuer
+
+	revocations *revocations
 }
 
 // NewManager 创建 Manager。seedProvider 提供原始 seed，内部自动派生加解密和签名密钥。
@@ -53,6 +55,7 @@ func NewManager(endpoint string, seedProvider key.Provider) *Manager {
 		signKeyProvider:    key.SignKeyProvider(seedProvider),
 		decryptors:         make(map[string]*Decryptor),
 		issuers:            make(map[string]*issuer.Issuer),
+		revocations:        newRevocations(),
 	}
 }
 
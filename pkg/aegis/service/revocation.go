@@ -0,0 +1,158 @@
+package service
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"log/slog"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+	"golang.org/x/sync/singleflight"
+
+	"github.com/heliannuuthus/pkg/aegis/utilities/client"
+	tokendef "github.com/heliannuuthus/pkg/aegis/utilities/token"
+)
+
+// revocationRetryInterval 吊销列表拉取失败后的重试间隔，期间沿用上一次的列表
+const revocationRetryInterval = 10 * time.Second
+
+type revocationResponse struct {
+	Token string `json:"token"`
+}
+
+type revocationEntry struct {
+	list      *tokendef.RevocationList
+	refreshAt time.Time
+}
+
+// revocations 按 audience 缓存 aegis 签发的吊销列表。
+// 列表按 Cache-Control max-age 过期后在后台刷新，校验 token 时不产生网络请求（首次加载除外）。
+type revocations struct {
+	mu      sync.RWMutex
+	entries map[string]*revocationEntry
+	group   singleflight.Group
+}
+
+func newRevocations() *revocations {
+	return &revocations{entries: make(map[string]*revocationEntry)}
+}
+
+// IsRevoked 检查 access token 是否已被吊销（按 jti 或按用户签发时间）。
+// 吊销列表不可用时放行并记录告警，不因 aegis 不可达阻断业务请求。
+func (m *Manager) IsRevoked(ctx context.Context, t tokendef.AccessToken) bool {
+	audience := t.Audience()
+
+	m.revocations.mu.RLock()
+	entry, ok := m.revocations.entries[audience]
+	m.revocations.mu.RUnlock()
+
+	if !ok {
+		list, err := m.FetchRevocations(ctx, audience)
+		if err != nil {
+			slog.Warn("[Manager] load revocation list failed", "audience", audience, "error", err)
+			return false
+		}
+		return list.IsRevoked(t)
+	}
+
+	if time.Now().After(entry.refreshAt) {
+		go func() {
+			if _, err := m.FetchRevocations(context.WithoutCancel(ctx), audience); err != nil {
+				slog.Warn("[Manager] background refresh revocation list failed", "audience", audience, "error", err)
+			}
+		}()
+	}
+	return entry.list.IsRevoked(t)
+}
+
+// FetchRevocations 从 aegis 拉取 audience 的吊销列表并更新本地缓存。
+func (m *Manager) FetchRevocations(ctx context.Context, audience string) (*tokendef.RevocationList, error) {
+	v, err, _ := m.revocations.group.Do(audience, func() (any, error) {
+		list, ttl, err := m.doFetchRevocations(ctx, audience)
+		if err != nil {
+			m.deferRevocations(audience)
+			return nil, err
+		}
+
+		m.revocations.mu.Lock()
+		m.revocations.entries[audience] = &revocationEntry{list: list, refreshAt: time.Now().Add(ttl)}
+		m.revocations.mu.Unlock()
+		return list, nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	list, ok := v.(*tokendef.RevocationList)
+	if !ok {
+		return nil, fmt.Errorf("unexpected singleflight result type: %T", v)
+	}
+	return list, nil
+}
+
+// deferRevocations 拉取失败时推迟下一次刷新，避免 aegis 不可用时每个请求都触发拉取。
+func (m *Manager) deferRevocations(audience string) {
+	m.revocations.mu.Lock()
+	defer m.revocations.mu.Unlock()
+
+	list := tokendef.NewRevocationList()
+	if entry, ok := m.revocations.entries[audience]; ok {
+		list = entry.list
+	}
+	m.revocations.entries[audience] = &revocationEntry{list: list, refreshAt: time.Now().Add(revocationRetryInterval)}
+}
+
+func (m *Manager) doFetchRevocations(ctx context.Context, audience string) (*tokendef.RevocationList, time.Duration, error) {
+	ct, err := m.getIssuer(audience).Issue(ctx)
+	if err != nil {
+		return nil, 0, fmt.Errorf("issue CT: %w", err)
+	}
+
+	httpReq, err := http.NewRequestWithContext(ctx, http.MethodGet, m.endpoint+"/revocations", nil)
+	if err != nil {
+		return nil, 0, fmt.Errorf("create request: %w", err)
+	}
+	httpReq.Header.Set("Authorization", tokendef.TokenTypeBearer+" "+ct)
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return nil, 0, fmt.Errorf("send request: %w", err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			slog.Warn("[Manager] close response body", "error", err)
+		}
+	}()
+
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxResponseBody))
+	if err != nil {
+		return nil, 0, fmt.Errorf("read response: %w", err)
+	}
+	if resp.StatusCode != http.StatusOK {
+		return nil, 0, fmt.Errorf("revocations request failed with status %d: %s", resp.StatusCode, body)
+	}
+
+	var result revocationResponse
+	if err := json.Unmarshal(body, &result); err != nil {
+		return nil, 0, fmt.Errorf("unmarshal response: %w", err)
+	}
+
+	ttl, err := client.ParseMaxAge(resp.Header.Get("Cache-Control"))
+	if err != nil {
+		return nil, 0, fmt.Errorf("parse cache-control: %w", err)
+	}
+
+	// 吊销列表由 aegis 以 audience 所在域的密钥签名，与 token 验签共用公钥
+	pasetoToken, err := m.Decryptor(audience).Verifier(audience).Verify(ctx, result.Token)
+	if err != nil {
+		return nil, 0, fmt.Errorf("verify revocation list: %w", err)
+	}
+
+	list, err := tokendef.ParseRevocationList(pasetoToken)
+	if err != nil {
+		return nil, 0, err
+	}
+	return list, ttl, nil
+}
@@ -1,7 +1,9 @@
 package client
 
 import (
+	"fmt"
 	"net/http"
+	"strconv"
 	"strings"
 	"time"
 )
@@ -27,8 +29,32 @@ func Do(req *http.Request) (*http.Response, error) {
 
 // TrimBearer 去除 "Bearer " 前缀，返回 token 本体；无前缀则返回空串。
 func TrimBearer(s string) string {
-	if len(s) > 7 && strings.EqualFold(s[:7], "Bearer ") {
-		return s[7:]
+	return trimScheme(s, "Bearer ")
+}
+
+// TrimDPoP 去除 "DPoP " 前缀（RFC 9449 §7.1），返回 token 本体；无前缀则返回空串。
+func TrimDPoP(s string) string {
+	return trimScheme(s, "DPoP ")
+}
+
+func trimScheme(s, scheme string) string {
+	if len(s) > len(scheme) && strings.EqualFold(s[:len(scheme)], scheme) {
+		return s[len(scheme):]
 	}
 	return ""
 }
+
+// ParseMaxAge 解析 Cache-Control 中的 max-age，缺失或非正数时返回错误。
+func ParseMaxAge(cacheControl string) (time.Duration, error) {
+	for _, directive := range strings.Split(cacheControl, ",") {
+		directive = strings.TrimSpace(directive)
+		if strings.HasPrefix(directive, "max-age=") {
+			seconds, err := strconv.Atoi(directive[8:])
+			if err != nil || seconds <= 0 {
+				return 0, fmt.Errorf("invalid max-age value: %s", directive)
+			}
+			return time.Duration(seconds) * time.Second, nil
+		}
+	}
+	return 0, fmt.Errorf("missing max-age in Cache-Control: %q", cacheControl)
+}
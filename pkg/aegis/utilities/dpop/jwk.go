@@ -0,0 +1,89 @@
+package dpop
+
+import (
+	"crypto"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/sha256"
+	"encoding/base64"
+	"fmt"
+
+	"github.com/go-json-experiment/json"
+)
+
+// JWK DPoP proof 头部携带的公钥（仅支持 P-256 与 Ed25519）
+type JWK struct {
+	Kty string `json:"kty"`
+	Crv string `json:"crv"`
+	X   string `json:"x"`
+	Y   string `json:"y,omitempty"`
+	D   string `json:"d,omitempty"` // 私钥成员，出现即拒绝
+}
+
+// PublicKey 将 JWK 转为公钥，并校验与 alg 匹配
+func (k *JWK) PublicKey(alg string) (crypto.PublicKey, error) {
+	if k.D != "" {
+		return nil, fmt.Errorf("%w: jwk must not contain private key", ErrInvalidProof)
+	}
+
+	x, err := base64.RawURLEncoding.DecodeString(k.X)
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode jwk x: %w", ErrInvalidProof, err)
+	}
+
+	switch {
+	case alg == AlgES256 && k.Kty == "EC" && k.Crv == "P-256":
+		y, err := base64.RawURLEncoding.DecodeString(k.Y)
+		if err != nil {
+			return nil, fmt.Errorf("%w: decode jwk y: %w", ErrInvalidProof, err)
+		}
+		if len(x) != 32 || len(y) != 32 {
+			return nil, fmt.Errorf("%w: invalid P-256 coordinates", ErrInvalidProof)
+		}
+		point := append(append([]byte{0x04}, x...), y...)
+		pub, err := ecdsa.ParseUncompressedPublicKey(elliptic.P256(), point)
+		if err != nil {
+			return nil, fmt.Errorf("%w: %w", ErrInvalidProof, err)
+		}
+		return pub, nil
+
+	case alg == AlgEdDSA && k.Kty == "OKP" && k.Crv == "Ed25519":
+		if len(x) != ed25519.PublicKeySize {
+			return nil, fmt.Errorf("%w: invalid Ed25519 key size", ErrInvalidProof)
+		}
+		return ed25519.PublicKey(x), nil
+
+	default:
+		return nil, fmt.Errorf("%w: unsupported key %s/%s for alg %s", ErrInvalidProof, k.Kty, k.Crv, alg)
+	}
+}
+
+// Thumbprint JWK SHA-256 指纹（RFC 7638），即 cnf.jkt
+func (k *JWK) Thumbprint() (string, error) {
+	var members any
+	switch k.Kty {
+	case "EC":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+			Y   string `json:"y"`
+		}{k.Crv, k.Kty, k.X, k.Y}
+	case "OKP":
+		members = struct {
+			Crv string `json:"crv"`
+			Kty string `json:"kty"`
+			X   string `json:"x"`
+		}{k.Crv, k.Kty, k.X}
+	default:
+		return "", fmt.Errorf("%w: unsupported kty %s", ErrInvalidProof, k.Kty)
+	}
+
+	canonical, err := json.Marshal(members)
+	if err != nil {
+		return "", fmt.Errorf("marshal jwk: %w", err)
+	}
+	sum := sha256.Sum256(canonical)
+	return base64.RawURLEncoding.EncodeToString(sum[:]), nil
+}
@@ -0,0 +1,202 @@
+// Package dpop 实现 DPoP（RFC 9449）proof 校验，供 aegis token 端点与资源服务 guard 共用。
+package dpop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+const (
+	// HeaderName 携带 proof 的请求头
+	HeaderName = "DPoP"
+	// TokenType DPoP 绑定 token 的 token_type 与 Authorization scheme
+	TokenType = "DPoP"
+	// ProofType proof JWT 的 typ 头
+	ProofType = "dpop+jwt"
+
+	AlgES256 = "ES256"
+	AlgEdDSA = "EdDSA"
+
+	// DefaultWindow proof iat 允许的时间偏差
+	DefaultWindow = 5 * time.Minute
+)
+
+// SupportedAlgs 支持的 proof 签名算法
+var SupportedAlgs = []string{AlgES256, AlgEdDSA}
+
+var (
+	ErrInvalidProof = errors.New("invalid dpop proof")
+	ErrReplayed     = errors.New("dpop proof replayed")
+)
+
+// ReplayCache proof jti 防重放存储
+type ReplayCache interface {
+	// Claim 记录 jti，ttl 内重复出现时返回 false
+	Claim(ctx context.Context, jti string, ttl time.Duration) (bool, error)
+}
+
+// Proof 已校验的 DPoP proof
+type Proof struct {
+	JKT      string // 公钥指纹，与 token 的 cnf.jkt 比对
+	JTI      string
+	HTM      string
+	HTU      string
+	IssuedAt time.Time
+}
+
+type header struct {
+	Typ string `json:"typ"`
+	Alg string `json:"alg"`
+	JWK *JWK   `json:"jwk"`
+}
+
+type claims struct {
+	JTI string `json:"jti"`
+	HTM string `json:"htm"`
+	HTU string `json:"htu"`
+	IAT int64  `json:"iat"`
+	ATH string `json:"ath,omitempty"`
+}
+
+// Verifier DPoP proof 校验器
+type Verifier struct {
+	replay ReplayCache
+	window time.Duration
+}
+
+// NewVerifier 创建校验器，window<=0 时使用 DefaultWindow
+func NewVerifier(replay ReplayCache, window time.Duration) *Verifier {
+	if window <= 0 {
+		window = DefaultWindow
+	}
+	return &Verifier{replay: replay, window: window}
+}
+
+// Verify 校验 proof：签名、typ/alg、htm/htu、iat 窗口、jti 防重放。
+// accessToken 非空时（资源访问）同时校验 ath。
+func (v *Verifier) Verify(ctx context.Context, proof, method, requestURL, accessToken string) (*Proof, error) {
+	parts := strings.Split(proof, ".")
+	if len(parts) != 3 {
+		return nil, fmt.Errorf("%w: malformed jwt", ErrInvalidProof)
+	}
+
+	var h header
+	if err := decodeSegment(parts[0], &h); err != nil {
+		return nil, err
+	}
+	if h.Typ != ProofType {
+		return nil, fmt.Errorf("%w: unexpected typ %q", ErrInvalidProof, h.Typ)
+	}
+	if h.JWK == nil {
+		return nil, fmt.Errorf("%w: missing jwk", ErrInvalidProof)
+	}
+
+	pub, err := h.JWK.PublicKey(h.Alg)
+	if err != nil {
+		return nil, err
+	}
+	sig, err := base64.RawURLEncoding.DecodeString(parts[2])
+	if err != nil {
+		return nil, fmt.Errorf("%w: decode signature: %w", ErrInvalidProof, err)
+	}
+	if !verifySignature(pub, []byte(parts[0]+"."+parts[1]), sig) {
+		return nil, fmt.Errorf("%w: signature mismatch", ErrInvalidProof)
+	}
+
+	var c claims
+	if err := decodeSegment(parts[1], &c); err != nil {
+		return nil, err
+	}
+	if c.JTI == "" {
+		return nil, fmt.Errorf("%w: missing jti", ErrInvalidProof)
+	}
+	if !strings.EqualFold(c.HTM, method) {
+		return nil, fmt.Errorf("%w: htm mismatch", ErrInvalidProof)
+	}
+	if !sameURI(c.HTU, requestURL) {
+		return nil, fmt.Errorf("%w: htu mismatch", ErrInvalidProof)
+	}
+
+	iat := time.Unix(c.IAT, 0)
+	if d := time.Since(iat); d > v.window || d < -v.window {
+		return nil, fmt.Errorf("%w: iat outside acceptable window", ErrInvalidProof)
+	}
+
+	if accessToken != "" {
+		sum := sha256.Sum256([]byte(accessToken))
+		if c.ATH != base64.RawURLEncoding.EncodeToString(sum[:]) {
+			return nil, fmt.Errorf("%w: ath mismatch", ErrInvalidProof)
+		}
+	}
+
+	jkt, err := h.JWK.Thumbprint()
+	if err != nil {
+		return nil, err
+	}
+
+	if v.replay != nil {
+		fresh, err := v.replay.Claim(ctx, jkt+":"+c.JTI, 2*v.window)
+		if err != nil {
+			return nil, fmt.Errorf("claim dpop jti: %w", err)
+		}
+		if !fresh {
+			return nil, ErrReplayed
+		}
+	}
+
+	return &Proof{JKT: jkt, JTI: c.JTI, HTM: c.HTM, HTU: c.HTU, IssuedAt: iat}, nil
+}
+
+func decodeSegment(segment string, v any) error {
+	raw, err := base64.RawURLEncoding.DecodeString(segment)
+	if err != nil {
+		return fmt.Errorf("%w: decode segment: %w", ErrInvalidProof, err)
+	}
+	if err := json.Unmarshal(raw, v); err != nil {
+		return fmt.Errorf("%w: unmarshal segment: %w", ErrInvalidProof, err)
+	}
+	return nil
+}
+
+func verifySignature(pub any, signingInput, sig []byte) bool {
+	switch k := pub.(type) {
+	case *ecdsa.PublicKey:
+		if len(sig) != 64 {
+			return false
+		}
+		digest := sha256.Sum256(signingInput)
+		r := new(big.Int).SetBytes(sig[:32])
+		s := new(big.Int).SetBytes(sig[32:])
+		return ecdsa.Verify(k, digest[:], r, s)
+	case ed25519.PublicKey:
+		return ed25519.Verify(k, signingInput, sig)
+	default:
+		return false
+	}
+}
+
+// sameURI 比较 htu 与请求 URL（忽略 query 与 fragment，scheme/host 不区分大小写，RFC 9449 §4.3）
+func sameURI(htu, requestURL string) bool {
+	a, err := url.Parse(htu)
+	if err != nil {
+		return false
+	}
+	b, err := url.Parse(requestURL)
+	if err != nil {
+		return false
+	}
+	return strings.EqualFold(a.Scheme, b.Scheme) &&
+		strings.EqualFold(a.Host, b.Host) &&
+		a.EscapedPath() == b.EscapedPath()
+}
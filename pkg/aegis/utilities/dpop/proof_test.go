@@ -0,0 +1,114 @@
+package dpop
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"crypto/ed25519"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/base64"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/go-json-experiment/json"
+)
+
+func signProof(t *testing.T, alg string, signer any, jwk *JWK, c claims) string {
+	t.Helper()
+	h, err := json.Marshal(header{Typ: ProofType, Alg: alg, JWK: jwk})
+	if err != nil {
+		t.Fatal(err)
+	}
+	p, err := json.Marshal(c)
+	if err != nil {
+		t.Fatal(err)
+	}
+	input := base64.RawURLEncoding.EncodeToString(h) + "." + base64.RawURLEncoding.EncodeToString(p)
+
+	var sig []byte
+	switch k := signer.(type) {
+	case ed25519.PrivateKey:
+		sig = ed25519.Sign(k, []byte(input))
+	case *ecdsa.PrivateKey:
+		digest := sha256.Sum256([]byte(input))
+		r, s, err := ecdsa.Sign(rand.Reader, k, digest[:])
+		if err != nil {
+			t.Fatal(err)
+		}
+		sig = make([]byte, 64)
+		r.FillBytes(sig[:32])
+		s.FillBytes(sig[32:])
+	}
+	return input + "." + base64.RawURLEncoding.EncodeToString(sig)
+}
+
+func TestVerify(t *testing.T) {
+	edPub, edPriv, err := ed25519.GenerateKey(rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	edJWK := &JWK{Kty: "OKP", Crv: "Ed25519", X: base64.RawURLEncoding.EncodeToString(edPub)}
+
+	ecPriv, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecPoint, err := ecPriv.PublicKey.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	ecJWK := &JWK{
+		Kty: "EC", Crv: "P-256",
+		X: base64.RawURLEncoding.EncodeToString(ecPoint[1:33]),
+		Y: base64.RawURLEncoding.EncodeToString(ecPoint[33:]),
+	}
+
+	accessToken := "v4.public.token"
+	ath := sha256.Sum256([]byte(accessToken))
+	now := time.Now().Unix()
+	const target = "https://api.example.com/resource"
+
+	tests := []struct {
+		name    string
+		proof   string
+		url     string
+		token   string
+		wantErr error
+	}{
+		{"ed25519", signProof(t, AlgEdDSA, edPriv, edJWK, claims{JTI: "1", HTM: "GET", HTU: target, IAT: now}), target + "?q=1", "", nil},
+		{"es256 with ath", signProof(t, AlgES256, ecPriv, ecJWK, claims{JTI: "2", HTM: "GET", HTU: target, IAT: now, ATH: base64.RawURLEncoding.EncodeToString(ath[:])}), target, accessToken, nil},
+		{"htu mismatch", signProof(t, AlgEdDSA, edPriv, edJWK, claims{JTI: "3", HTM: "GET", HTU: "https://other.example.com/resource", IAT: now}), target, "", ErrInvalidProof},
+		{"stale iat", signProof(t, AlgEdDSA, edPriv, edJWK, claims{JTI: "4", HTM: "GET", HTU: target, IAT: now - 3600}), target, "", ErrInvalidProof},
+		{"missing ath", signProof(t, AlgEdDSA, edPriv, edJWK, claims{JTI: "5", HTM: "GET", HTU: target, IAT: now}), target, accessToken, ErrInvalidProof},
+		{"alg key mismatch", signProof(t, AlgES256, edPriv, edJWK, claims{JTI: "6", HTM: "GET", HTU: target, IAT: now}), target, "", ErrInvalidProof},
+	}
+
+	v := NewVerifier(NewMemoryReplayCache(), 0)
+	for _, tt := range tests {
+		got, err := v.Verify(context.Background(), tt.proof, "GET", tt.url, tt.token)
+		if !errors.Is(err, tt.wantErr) {
+			t.Fatalf("%s: Verify() error = %v, want %v", tt.name, err, tt.wantErr)
+		}
+		if tt.wantErr == nil && got.JKT == "" {
+			t.Fatalf("%s: Verify() returned empty jkt", tt.name)
+		}
+	}
+
+	if _, err := v.Verify(context.Background(), tests[0].proof, "GET", target, ""); !errors.Is(err, ErrReplayed) {
+		t.Fatalf("replayed proof error = %v, want %v", err, ErrReplayed)
+	}
+}
+
+func TestThumbprint(t *testing.T) {
+	// RFC 8037 §A.3 示例
+	jwk := &JWK{Kty: "OKP", Crv: "Ed25519", X: "11qYAYKxCrfVS_7TyWQHOg7hcvPapiMlrwIaaPcHURo"}
+	got, err := jwk.Thumbprint()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if want := "kPrK_qmxVWaYVA9wwBF6Iuo3vVzz7TxHCTwXBygrS4k"; got != want {
+		t.Fatalf("Thumbprint() = %s, want %s", got, want)
+	}
+}
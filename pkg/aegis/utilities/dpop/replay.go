@@ -0,0 +1,42 @@
+package dpop
+
+import (
+	"context"
+	"sync"
+	"time"
+)
+
+// MemoryReplayCache 进程内 jti 防重放缓存（单实例资源服务使用）
+type MemoryReplayCache struct {
+	mu      sync.Mutex
+	entries map[string]time.Time
+	sweepAt time.Time
+}
+
+// NewMemoryReplayCache 创建进程内防重放缓存
+func NewMemoryReplayCache() *MemoryReplayCache {
+	return &MemoryReplayCache{entries: make(map[string]time.Time)}
+}
+
+// Claim 实现 ReplayCache
+func (c *MemoryReplayCache) Claim(_ context.Context, jti string, ttl time.Duration) (bool, error) {
+	now := time.Now()
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if now.After(c.sweepAt) {
+		for k, exp := range c.entries {
+			if now.After(exp) {
+				delete(c.entries, k)
+			}
+		}
+		c.sweepAt = now.Add(ttl)
+	}
+
+	if exp, ok := c.entries[jti]; ok && now.Before(exp) {
+		return false, nil
+	}
+	c.entries[jti] = now.Add(ttl)
+	return true, nil
+}
@@ -2,6 +2,8 @@ package issuer
 
 import (
 	"context"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
 	"sync"
@@ -58,6 +60,36 @@ func (i *Issuer) Issue(ctx context.Context) (string, error) {
 	return i.sign(ctx, pasetoToken)
 }
 
+// IssueRequestObject 签发请求对象（JAR，RFC 9101），params 为授权请求参数。
+// audience 为 aegis issuer，aegis 以应用公钥验签后仅采用请求对象中的授权参数；jti 在 exp 前只能使用一次。
+func (i *Issuer) IssueRequestObject(ctx context.Context, audience string, params map[string]any, expiresIn time.Duration) (string, error) {
+	token := paseto.NewToken()
+	for k, v := range params {
+		if err := token.Set(k, v); err != nil {
+			return "", fmt.Errorf("set %s: %w", k, err)
+		}
+	}
+	now := time.Now()
+	token.SetIssuer(i.id)
+	token.SetAudience(audience)
+	token.SetIssuedAt(now)
+	token.SetNotBefore(now)
+	token.SetExpiration(now.Add(expiresIn))
+	jti := make([]byte, 16)
+	if _, err := rand.Read(jti); err != nil {
+		return "", fmt.Errorf("generate jti: %w", err)
+	}
+	token.SetJti(hex.EncodeToString(jti))
+	if err := token.Set(tokendef.ClaimType, tokendef.TypeRequestObject); err != nil {
+		return "", fmt.Errorf("set typ: %w", err)
+	}
+	if err := token.Set("client_id", i.id); err != nil {
+		return "", fmt.Errorf("set client_id: %w", err)
+	}
+
+	return i.sign(ctx, &token)
+}
+
 func (i *Issuer) updateKey(rawKey []byte) error {
 	sk, err := paseto.NewV4AsymmetricSecretKeyFromBytes(rawKey)
 	if err != nil {
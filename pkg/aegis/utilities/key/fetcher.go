@@ -8,7 +8,6 @@ import (
 	"log/slog"
 	"net/http"
 	"net/url"
-	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -135,7 +134,7 @@ func (f *PublicKeyFetcher) doFetch(ctx context.Context, clientID string) ([][]by
 		return nil, ErrNotFound
 	}
 
-	ttl, err := parseMaxAge(resp.Header.Get("Cache-Control"))
+	ttl, err := client.ParseMaxAge(resp.Header.Get("Cache-Control"))
 	if err != nil {
 		slog.Warn("[PublicKeyFetcher] parse Cache-Control failed", "id", clientID, "error", err)
 		return nil, fmt.Errorf("parse cache-control: %w", err)
@@ -153,17 +152,3 @@ func (f *PublicKeyFetcher) doFetch(ctx context.Context, clientID string) ([][]by
 	go f.notify(clientID, keys)
 	return keys, nil
 }
-
-func parseMaxAge(cacheControl string) (time.Duration, error) {
-	for _, directive := range strings.Split(cacheControl, ",") {
-		directive = strings.TrimSpace(directive)
-		if strings.HasPrefix(directive, "max-age=") {
-			seconds, err := strconv.Atoi(directive[8:])
-			if err != nil || seconds <= 0 {
-				return 0, fmt.Errorf("invalid max-age value: %s", directive)
-			}
-			return time.Duration(seconds) * time.Second, nil
-		}
-	}
-	return 0, fmt.Errorf("missing max-age in Cache-Control: %q", cacheControl)
-}
@@ -43,6 +43,11 @@ func (r *Resolver) Resolve(resolvable Resolvable) (*Tuple, error) {
 	}, nil
 }
 
+// Render 替换字符串中的占位符，未命中的占位符原样保留。
+func (r *Resolver) Render(s string) string {
+	return r.resolve(s)
+}
+
 func (r *Resolver) get(dotpath string) string {
 	if r == nil || len(r.data) == 0 {
 		return ""
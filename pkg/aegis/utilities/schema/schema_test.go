@@ -0,0 +1,68 @@
+package schema
+
+import (
+	"testing"
+
+	"github.com/go-json-experiment/json"
+)
+
+const recipeSchema = `{
+	"type": "object",
+	"required": ["actions"],
+	"additionalProperties": false,
+	"properties": {
+		"actions": {"type": "array", "items": {"type": "string", "enum": ["read", "delete"]}},
+		"identifier": {"type": "string"},
+		"limit": {"type": "integer"}
+	}
+}`
+
+func TestParse(t *testing.T) {
+	if _, err := Parse([]byte(recipeSchema)); err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+	for name, input := range map[string]string{
+		"unknown keyword": `{"type": "object", "pattern": "^a"}`,
+		"unknown type":    `{"type": "date"}`,
+		"nested type":     `{"properties": {"a": {"type": "map"}}}`,
+		"malformed":       `[]`,
+	} {
+		t.Run(name, func(t *testing.T) {
+			if _, err := Parse([]byte(input)); err == nil {
+				t.Fatal("Parse() error = nil")
+			}
+		})
+	}
+}
+
+func TestValidate(t *testing.T) {
+	s, err := Parse([]byte(recipeSchema))
+	if err != nil {
+		t.Fatalf("Parse() error = %v", err)
+	}
+
+	tests := []struct {
+		name    string
+		input   string
+		wantErr bool
+	}{
+		{name: "valid", input: `{"actions": ["read"], "identifier": "r1", "limit": 10}`},
+		{name: "missing required", input: `{"identifier": "r1"}`, wantErr: true},
+		{name: "additional property", input: `{"actions": ["read"], "owner": "alice"}`, wantErr: true},
+		{name: "enum mismatch", input: `{"actions": ["write"]}`, wantErr: true},
+		{name: "wrong type", input: `{"actions": "read"}`, wantErr: true},
+		{name: "non integer", input: `{"actions": [], "limit": 1.5}`, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var v any
+			if err := json.Unmarshal([]byte(tt.input), &v); err != nil {
+				t.Fatalf("unmarshal: %v", err)
+			}
+			if err := s.Validate(v); (err != nil) != tt.wantErr {
+				t.Fatalf("Validate() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
@@ -0,0 +1,158 @@
+package token
+
+import (
+	"bytes"
+	"fmt"
+	"reflect"
+	"slices"
+
+	"github.com/go-json-experiment/json"
+)
+
+// RFC 9396 §2.2 定义的通用字段
+const (
+	AuthorizationDetailFieldType       = "type"
+	AuthorizationDetailFieldLocations  = "locations"
+	AuthorizationDetailFieldActions    = "actions"
+	AuthorizationDetailFieldDatatypes  = "datatypes"
+	AuthorizationDetailFieldIdentifier = "identifier"
+	AuthorizationDetailFieldPrivileges = "privileges"
+)
+
+// AuthorizationDetail 单条授权详情（RFC 9396 §2），type 必填，其余字段由 type 对应的 schema 约定
+type AuthorizationDetail map[string]any
+
+// Type 返回授权详情类型
+func (d AuthorizationDetail) Type() string {
+	s, _ := d[AuthorizationDetailFieldType].(string)
+	return s
+}
+
+// Identifier 返回资源标识
+func (d AuthorizationDetail) Identifier() string {
+	s, _ := d[AuthorizationDetailFieldIdentifier].(string)
+	return s
+}
+
+// Actions 返回允许的操作列表
+func (d AuthorizationDetail) Actions() []string {
+	return d.strings(AuthorizationDetailFieldActions)
+}
+
+// Locations 返回资源位置列表
+func (d AuthorizationDetail) Locations() []string {
+	return d.strings(AuthorizationDetailFieldLocations)
+}
+
+// Datatypes 返回数据类型列表
+func (d AuthorizationDetail) Datatypes() []string {
+	return d.strings(AuthorizationDetailFieldDatatypes)
+}
+
+// Privileges 返回特权列表
+func (d AuthorizationDetail) Privileges() []string {
+	return d.strings(AuthorizationDetailFieldPrivileges)
+}
+
+func (d AuthorizationDetail) strings(key string) []string {
+	raw, ok := d[key].([]any)
+	if !ok {
+		if s, ok := d[key].([]string); ok {
+			return s
+		}
+		return nil
+	}
+	result := make([]string, 0, len(raw))
+	for _, v := range raw {
+		if s, ok := v.(string); ok {
+			result = append(result, s)
+		}
+	}
+	return result
+}
+
+// Covers 判断 other 是否为 d 的收窄：字段集合一致，数组取值为子集，其余取值相等。
+// 用于 token 请求中按已授予的 authorization_details 申请更小的权限（RFC 9396 §6.1）。
+func (d AuthorizationDetail) Covers(other AuthorizationDetail) bool {
+	if len(d) != len(other) {
+		return false
+	}
+	for k, granted := range d {
+		requested, ok := other[k]
+		if !ok {
+			return false
+		}
+		grantedList, isList := granted.([]any)
+		if !isList {
+			if !reflect.DeepEqual(granted, requested) {
+				return false
+			}
+			continue
+		}
+		requestedList, ok := requested.([]any)
+		if !ok {
+			return false
+		}
+		for _, v := range requestedList {
+			if !slices.ContainsFunc(grantedList, func(g any) bool { return reflect.DeepEqual(g, v) }) {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// AuthorizationDetails authorization_details 参数（JSON 数组）。
+// 表单参数中以 JSON 字符串传输，反序列化时同时接受数组与字符串形式。
+type AuthorizationDetails []AuthorizationDetail
+
+func (ds *AuthorizationDetails) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	if len(data) > 0 && data[0] == '"' {
+		var raw string
+		if err := json.Unmarshal(data, &raw); err != nil {
+			return err
+		}
+		data = []byte(raw)
+	}
+	var details []AuthorizationDetail
+	if err := json.Unmarshal(data, &details); err != nil {
+		return fmt.Errorf("authorization_details must be a JSON array: %w", err)
+	}
+	*ds = details
+	return nil
+}
+
+// Validate 校验每条授权详情均为对象且携带 type
+func (ds AuthorizationDetails) Validate() error {
+	for i, d := range ds {
+		if d == nil {
+			return fmt.Errorf("authorization_details[%d] must be an object", i)
+		}
+		if d.Type() == "" {
+			return fmt.Errorf("authorization_details[%d].type is required", i)
+		}
+	}
+	return nil
+}
+
+// Types 返回出现的 type 集合（按首次出现顺序去重）
+func (ds AuthorizationDetails) Types() []string {
+	var types []string
+	for _, d := range ds {
+		if t := d.Type(); !slices.Contains(types, t) {
+			types = append(types, t)
+		}
+	}
+	return types
+}
+
+// Narrow 校验 requested 中每一条均被 ds 中某条覆盖，返回 requested 本身。
+func (ds AuthorizationDetails) Narrow(requested AuthorizationDetails) (AuthorizationDetails, error) {
+	for i, r := range requested {
+		if !slices.ContainsFunc(ds, func(g AuthorizationDetail) bool { return g.Covers(r) }) {
+			return nil, fmt.Errorf("authorization_details[%d] exceeds the granted authorization", i)
+		}
+	}
+	return requested, nil
+}
@@ -174,5 +174,6 @@ func (c *Claims) Audience() string         { return c.audience }
 func (c *Claims) Subject() string          { return c.subject }
 func (c *Claims) IssuedAt() time.Time      { return c.issuedAt }
 func (c *Claims) ExpiresAt() time.Time     { return c.expiresAt }
+func (c *Claims) JTI() string              { return c.jti }
 func (c *Claims) ExpiresIn() time.Duration { return c.expiresIn }
 func (c *Claims) IsExpired() bool          { return time.Now().After(c.expiresAt) }
@@ -5,6 +5,22 @@ const (
 	ClaimCli   = "cli"
 	ClaimScope = "scope"
 	ClaimAct   = "act"
+	ClaimCnf   = "cnf"
+
+	// ClaimChannelType ChallengeToken 的验证方式（如 totp / webauthn）
+	ClaimChannelType = "channel_type"
+
+	// ClaimAuthTime 用户完成认证的时间（OIDC auth_time），与 iat/exp 一致使用 RFC 3339 格式
+	ClaimAuthTime = "auth_time"
+
+	// ClaimAuthorizationDetails 授予的授权详情（RAR，RFC 9396 §9.1）
+	ClaimAuthorizationDetails = "authorization_details"
+
+	// TypeRequestObject 请求对象（JAR，RFC 9101）的 typ 声明，区分于应用签发的 CT
+	TypeRequestObject = "oauth-authz-req"
+
+	ClaimRevokedJTIs     = "rvk_jti"
+	ClaimRevokedSubjects = "rvk_sub"
 
 	ScopeOpenID  = "openid"
 	ScopeProfile = "profile"
@@ -0,0 +1,88 @@
+package token
+
+import (
+	"fmt"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+// RevocationList access token 吊销列表
+// 由 aegis 按 audience 签发为 v4.public token，资源服务轮询后在本地校验，无需逐请求访问 aegis
+type RevocationList struct {
+	JTIs     map[string]time.Time // jti → token 过期时间（过期后条目自然失效）
+	Subjects map[string]time.Time // openid → 吊销时间，此前签发的 token 均视为吊销
+}
+
+// NewRevocationList 创建空吊销列表
+func NewRevocationList() *RevocationList {
+	return &RevocationList{
+		JTIs:     make(map[string]time.Time),
+		Subjects: make(map[string]time.Time),
+	}
+}
+
+// IsRevoked 判断 token 是否已被吊销（按 jti 或按用户签发时间）
+func (l *RevocationList) IsRevoked(t AccessToken) bool {
+	if l == nil || t == nil {
+		return false
+	}
+	if _, ok := l.JTIs[t.JTI()]; ok {
+		return true
+	}
+	if !t.Identified() {
+		return false
+	}
+	before, ok := l.Subjects[t.OpenID()]
+	return ok && !t.IssuedAt().After(before)
+}
+
+// Build 构建 PASETO Token（不包含签名）
+func (l *RevocationList) Build(issuer, audience string, expiresIn time.Duration) (*paseto.Token, error) {
+	now := time.Now()
+	t := paseto.NewToken()
+	t.SetIssuer(issuer)
+	t.SetAudience(audience)
+	t.SetIssuedAt(now)
+	t.SetNotBefore(now)
+	t.SetExpiration(now.Add(expiresIn))
+
+	if err := t.Set(ClaimRevokedJTIs, toUnixMap(l.JTIs)); err != nil {
+		return nil, fmt.Errorf("set revoked jtis: %w", err)
+	}
+	if err := t.Set(ClaimRevokedSubjects, toUnixMap(l.Subjects)); err != nil {
+		return nil, fmt.Errorf("set revoked subjects: %w", err)
+	}
+	return &t, nil
+}
+
+// ParseRevocationList 从 PASETO Token 解析吊销列表（用于验证后）
+func ParseRevocationList(pasetoToken *paseto.Token) (*RevocationList, error) {
+	var jtis, subjects map[string]int64
+	if err := pasetoToken.Get(ClaimRevokedJTIs, &jtis); err != nil {
+		return nil, fmt.Errorf("get revoked jtis: %w", err)
+	}
+	if err := pasetoToken.Get(ClaimRevokedSubjects, &subjects); err != nil {
+		return nil, fmt.Errorf("get revoked subjects: %w", err)
+	}
+	return &RevocationList{
+		JTIs:     fromUnixMap(jtis),
+		Subjects: fromUnixMap(subjects),
+	}, nil
+}
+
+func toUnixMap(m map[string]time.Time) map[string]int64 {
+	out := make(map[string]int64, len(m))
+	for k, v := range m {
+		out[k] = v.Unix()
+	}
+	return out
+}
+
+func fromUnixMap(m map[string]int64) map[string]time.Time {
+	out := make(map[string]time.Time, len(m))
+	for k, v := range m {
+		out[k] = time.Unix(v, 0)
+	}
+	return out
+}
@@ -0,0 +1,42 @@
+package token
+
+import (
+	"testing"
+	"time"
+)
+
+func TestRevocationListRoundTrip(t *testing.T) {
+	revokedSAT := NewClaimsBuilder().ClientID("app").Audience("svc").Build(NewServiceAccessTokenBuilder())
+	otherSAT := NewClaimsBuilder().ClientID("app").Audience("svc").Build(NewServiceAccessTokenBuilder())
+	userUAT := NewClaimsBuilder().ClientID("app").Audience("svc").Build(NewUserAccessTokenBuilder().OpenID("user-1"))
+	otherUAT := NewClaimsBuilder().ClientID("app").Audience("svc").Build(NewUserAccessTokenBuilder().OpenID("user-2"))
+
+	list := NewRevocationList()
+	list.JTIs[revokedSAT.JTI()] = time.Now().Add(time.Hour)
+	list.Subjects["user-1"] = time.Now()
+
+	pt, err := list.Build("aegis", "svc", time.Minute)
+	if err != nil {
+		t.Fatalf("Build() error = %v", err)
+	}
+	parsed, err := ParseRevocationList(pt)
+	if err != nil {
+		t.Fatalf("ParseRevocationList() error = %v", err)
+	}
+
+	tests := []struct {
+		name  string
+		token AccessToken
+		want  bool
+	}{
+		{"revoked jti", revokedSAT.(AccessToken), true},
+		{"other jti", otherSAT.(AccessToken), false},
+		{"revoked subject", userUAT.(AccessToken), true},
+		{"other subject", otherUAT.(AccessToken), false},
+	}
+	for _, tt := range tests {
+		if got := parsed.IsRevoked(tt.token); got != tt.want {
+			t.Errorf("%s: IsRevoked = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
@@ -11,6 +11,7 @@ import (
 type ServiceAccessToken struct {
 	Claims        // 内嵌基础 Claims
 	scope  string // 授权范围
+	jkt    string // DPoP 公钥指纹（cnf.jkt，RFC 9449），为空表示 Bearer token
 }
 
 // ==================== SAT TokenTypeBuilder ====================
@@ -18,6 +19,7 @@ type ServiceAccessToken struct {
 // SAT SAT 类型构建器，实现 TokenTypeBuilder 接口
 type SAT struct {
 	scope string
+	jkt   string
 }
 
 // NewServiceAccessTokenBuilder 创建 SAT 类型构建器
@@ -31,11 +33,18 @@ func (s *SAT) Scope(scope string) *SAT {
 	return s
 }
 
+// Confirmation 绑定 DPoP 公钥指纹（cnf.jkt）
+func (s *SAT) Confirmation(jkt string) *SAT {
+	s.jkt = jkt
+	return s
+}
+
 // build 实现 TokenTypeBuilder 接口
 func (s *SAT) Build(claims Claims) Token {
 	return &ServiceAccessToken{
 		Claims: claims,
 		scope:  s.scope,
+		jkt:    s.jkt,
 	}
 }
 
@@ -54,9 +63,15 @@ func ParseServiceAccessToken(pasetoToken *paseto.Token) (*ServiceAccessToken, er
 		scope = ""
 	}
 
+	var cnf confirmation
+	if err := pasetoToken.Get(ClaimCnf, &cnf); err != nil {
+		cnf = confirmation{}
+	}
+
 	return &ServiceAccessToken{
 		Claims: claims,
 		scope:  scope,
+		jkt:    cnf.JKT,
 	}, nil
 }
 
@@ -76,9 +91,19 @@ func (s *ServiceAccessToken) Build() (*paseto.Token, error) {
 	if err := t.Set(ClaimScope, s.scope); err != nil {
 		return nil, fmt.Errorf("set scope: %w", err)
 	}
+	if s.jkt != "" {
+		if err := t.Set(ClaimCnf, confirmation{JKT: s.jkt}); err != nil {
+			return nil, fmt.Errorf("set cnf: %w", err)
+		}
+	}
 	return &t, nil
 }
 
+// JKT 返回绑定的 DPoP 公钥指纹（cnf.jkt），为空表示 Bearer token。
+func (s *ServiceAccessToken) JKT() string {
+	return s.jkt
+}
+
 // Scopes 返回 scope 集合。
 func (s *ServiceAccessToken) Scopes() map[string]struct{} {
 	return ParseScopes(s.scope)
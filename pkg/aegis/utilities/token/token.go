@@ -17,6 +17,7 @@ var (
 	ErrMissingClaims    = errors.New("missing required claims")
 	ErrUnsupportedToken = errors.New("unsupported token type")
 	ErrInvalidSignature = errors.New("invalid signature")
+	ErrTokenRevoked     = errors.New("token revoked")
 	ErrInvalidFooter    = pasetokit.ErrInvalidFooter
 	ErrKIDNotFound      = pasetokit.ErrKIDNotFound
 )
@@ -33,6 +34,7 @@ type Token interface {
 	Subject() string
 	IssuedAt() time.Time
 	ExpiresAt() time.Time
+	JTI() string
 
 	IsExpired() bool
 }
@@ -77,7 +79,7 @@ const (
 )
 
 // DetectType infers the token type from claims.
-// Rules: iss==aud==cli → SSO, has typ → Challenge, has cli → UAT, otherwise → CT
+// Rules: iss==aud==cli → SSO, has typ → Challenge, has cli and sub → UAT, has cli only → SAT, otherwise → CT
 func DetectType(t *paseto.Token) TokenType {
 	iss, err := t.GetIssuer()
 	if err != nil {
@@ -102,7 +104,10 @@ func DetectType(t *paseto.Token) TokenType {
 	}
 
 	if cli != "" {
-		return TokenTypeUAT
+		if sub, err := t.GetSubject(); err == nil && sub != "" {
+			return TokenTypeUAT
+		}
+		return TokenTypeSAT
 	}
 
 	return TokenTypeCT
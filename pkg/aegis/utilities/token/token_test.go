@@ -0,0 +1,164 @@
+package token
+
+import (
+	"testing"
+	"time"
+
+	"aidanwoods.dev/go-paseto"
+)
+
+func TestDetectType(t *testing.T) {
+	build := func(claims map[string]string) *paseto.Token {
+		tk := paseto.NewToken()
+		for k, v := range claims {
+			tk.SetString(k, v)
+		}
+		return &tk
+	}
+
+	tests := []struct {
+		name   string
+		claims map[string]string
+		want   TokenType
+	}{
+		{"sso", map[string]string{"iss": "aegis", "aud": "aegis", ClaimCli: "aegis"}, TokenTypeSSO},
+		{"challenge", map[string]string{ClaimType: "email-code", ClaimCli: "app"}, TokenTypeChallenge},
+		{"uat", map[string]string{"iss": "aegis", "aud": "svc", ClaimCli: "app", "sub": "encrypted"}, TokenTypeUAT},
+		{"sat", map[string]string{"iss": "aegis", "aud": "svc", ClaimCli: "app"}, TokenTypeSAT},
+		{"ct", map[string]string{"iss": "app", "aud": "aegis", "sub": "app"}, TokenTypeCT},
+	}
+
+	for _, tt := range tests {
+		if got := DetectType(build(tt.claims)); got != tt.want {
+			t.Fatalf("%s: DetectType = %q, want %q", tt.name, got, tt.want)
+		}
+	}
+}
+
+func TestUserAccessTokenConfirmation(t *testing.T) {
+	for _, jkt := range []string{"", "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"} {
+		uat := NewClaimsBuilder().
+			Issuer("aegis").
+			ClientID("app").
+			Audience("svc").
+			ExpiresIn(time.Hour).
+			Build(NewUserAccessTokenBuilder().Scope("openid").Confirmation(jkt)).(*UserAccessToken)
+
+		pt, err := uat.Build()
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		parsed, err := ParseUserAccessToken(pt)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if parsed.JKT() != jkt {
+			t.Fatalf("JKT = %q, want %q", parsed.JKT(), jkt)
+		}
+		if err := pt.Get(ClaimCnf, &confirmation{}); (err == nil) != (jkt != "") {
+			t.Fatalf("cnf present = %v, want %v", err == nil, jkt != "")
+		}
+	}
+}
+
+func TestServiceAccessTokenConfirmation(t *testing.T) {
+	for _, jkt := range []string{"", "0ZcOCORZNYy-DWpqq30jZyJGHTN0d2HglBV3uiguA4I"} {
+		sat := NewClaimsBuilder().
+			Issuer("aegis").
+			ClientID("app").
+			Audience("svc").
+			ExpiresIn(time.Hour).
+			Build(NewServiceAccessTokenBuilder().Scope("read").Confirmation(jkt)).(*ServiceAccessToken)
+
+		pt, err := sat.Build()
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		if got := DetectType(pt); got != TokenTypeSAT {
+			t.Fatalf("DetectType = %q, want %q", got, TokenTypeSAT)
+		}
+		parsed, err := ParseServiceAccessToken(pt)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if parsed.JKT() != jkt {
+			t.Fatalf("JKT = %q, want %q", parsed.JKT(), jkt)
+		}
+	}
+}
+
+func TestUserAccessTokenAuthTime(t *testing.T) {
+	authTime := time.Now().Add(-10 * time.Minute).Truncate(time.Second)
+	for _, at := range []time.Time{{}, authTime} {
+		uat := NewClaimsBuilder().
+			Issuer("aegis").
+			ClientID("app").
+			Audience("svc").
+			ExpiresIn(time.Hour).
+			Build(NewUserAccessTokenBuilder().Scope("openid").AuthTime(at)).(*UserAccessToken)
+
+		pt, err := uat.Build()
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		parsed, err := ParseUserAccessToken(pt)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if !parsed.AuthTime().Equal(at) {
+			t.Fatalf("AuthTime = %v, want %v", parsed.AuthTime(), at)
+		}
+	}
+}
+
+func TestUserAccessTokenAuthorizationDetails(t *testing.T) {
+	var details AuthorizationDetails
+	if err := details.UnmarshalJSON([]byte(`"[{\"type\":\"recipe\",\"actions\":[\"read\",\"delete\"],\"identifier\":\"r1\"}]"`)); err != nil {
+		t.Fatalf("unmarshal: %v", err)
+	}
+	uat := NewClaimsBuilder().
+		Issuer("aegis").
+		ClientID("app").
+		Audience("svc").
+		ExpiresIn(time.Hour).
+		Build(NewUserAccessTokenBuilder().Scope("openid").AuthorizationDetails(details)).(*UserAccessToken)
+
+	pt, err := uat.Build()
+	if err != nil {
+		t.Fatalf("build: %v", err)
+	}
+	parsed, err := ParseUserAccessToken(pt)
+	if err != nil {
+		t.Fatalf("parse: %v", err)
+	}
+	got := parsed.AuthorizationDetails()
+	if len(got) != 1 || got[0].Type() != "recipe" || got[0].Identifier() != "r1" || len(got[0].Actions()) != 2 {
+		t.Fatalf("AuthorizationDetails = %v", got)
+	}
+}
+
+func TestAuthorizationDetailsNarrow(t *testing.T) {
+	granted := AuthorizationDetails{
+		{"type": "recipe", "actions": []any{"read", "delete"}, "identifier": "r1"},
+	}
+	tests := []struct {
+		name      string
+		requested AuthorizationDetails
+		wantErr   bool
+	}{
+		{name: "same", requested: granted},
+		{name: "subset actions", requested: AuthorizationDetails{{"type": "recipe", "actions": []any{"read"}, "identifier": "r1"}}},
+		{name: "extra action", requested: AuthorizationDetails{{"type": "recipe", "actions": []any{"write"}, "identifier": "r1"}}, wantErr: true},
+		{name: "other identifier", requested: AuthorizationDetails{{"type": "recipe", "actions": []any{"read"}, "identifier": "r2"}}, wantErr: true},
+		{name: "dropped field", requested: AuthorizationDetails{{"type": "recipe", "actions": []any{"read"}}}, wantErr: true},
+		{name: "other type", requested: AuthorizationDetails{{"type": "menu", "actions": []any{"read"}, "identifier": "r1"}}, wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if _, err := granted.Narrow(tt.requested); (err != nil) != tt.wantErr {
+				t.Fatalf("Narrow() error = %v, wantErr %v", err, tt.wantErr)
+			}
+		})
+	}
+}
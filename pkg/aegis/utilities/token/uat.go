@@ -2,6 +2,7 @@ package token
 
 import (
 	"fmt"
+	"time"
 
 	"aidanwoods.dev/go-paseto"
 	"github.com/go-json-experiment/json"
@@ -22,10 +23,18 @@ type userInfo struct {
 type UserAccessToken struct {
 	Claims
 	scope    string
-	actor    string // 代理应用 ID（client_credentials delegation 时设置）
+	actor    string    // 代理应用 ID（token-exchange 代理签发时设置）
+	jkt      string    // DPoP 公钥指纹（cnf.jkt，RFC 9449），为空表示 Bearer token
+	authTime time.Time // 用户完成认证的时间，零值表示未知
+	details  AuthorizationDetails
 	identity *userInfo
 }
 
+// confirmation cnf 声明（RFC 7800 / RFC 9449 §6）
+type confirmation struct {
+	JKT string `json:"jkt"`
+}
+
 // ==================== UAT Builder ====================
 
 type UAT struct {
@@ -36,6 +45,9 @@ type UAT struct {
 	email    string
 	phone    string
 	actor    string
+	jkt      string
+	authTime time.Time
+	details  AuthorizationDetails
 }
 
 func NewUserAccessTokenBuilder() *UAT {
@@ -77,11 +89,32 @@ func (u *UAT) Actor(actor string) *UAT {
 	return u
 }
 
+// Confirmation 绑定 DPoP 公钥指纹（cnf.jkt）
+func (u *UAT) Confirmation(jkt string) *UAT {
+	u.jkt = jkt
+	return u
+}
+
+// AuthTime 设置用户完成认证的时间（auth_time）
+func (u *UAT) AuthTime(authTime time.Time) *UAT {
+	u.authTime = authTime
+	return u
+}
+
+// AuthorizationDetails 设置授予的授权详情（authorization_details）
+func (u *UAT) AuthorizationDetails(details AuthorizationDetails) *UAT {
+	u.details = details
+	return u
+}
+
 func (u *UAT) Build(claims Claims) Token {
 	uat := &UserAccessToken{
-		Claims: claims,
-		scope:  u.scope,
-		actor:  u.actor,
+		Claims:   claims,
+		scope:    u.scope,
+		actor:    u.actor,
+		jkt:      u.jkt,
+		authTime: u.authTime,
+		details:  u.details,
 	}
 
 	if u.openID != "" {
@@ -122,10 +155,28 @@ func ParseUserAccessToken(pasetoToken *paseto.Token) (*UserAccessToken, error) {
 		actor = ""
 	}
 
+	var cnf confirmation
+	if err := pasetoToken.Get(ClaimCnf, &cnf); err != nil {
+		cnf = confirmation{}
+	}
+
+	authTime, err := pasetoToken.GetTime(ClaimAuthTime)
+	if err != nil {
+		authTime = time.Time{}
+	}
+
+	var details AuthorizationDetails
+	if err := pasetoToken.Get(ClaimAuthorizationDetails, &details); err != nil {
+		details = nil
+	}
+
 	return &UserAccessToken{
-		Claims: claims,
-		scope:  scope,
-		actor:  actor,
+		Claims:   claims,
+		scope:    scope,
+		actor:    actor,
+		jkt:      cnf.JKT,
+		authTime: authTime,
+		details:  details,
 	}, nil
 }
 
@@ -171,6 +222,19 @@ func (u *UserAccessToken) Build() (*paseto.Token, error) {
 			return nil, fmt.Errorf("set act: %w", err)
 		}
 	}
+	if u.jkt != "" {
+		if err := t.Set(ClaimCnf, confirmation{JKT: u.jkt}); err != nil {
+			return nil, fmt.Errorf("set cnf: %w", err)
+		}
+	}
+	if !u.authTime.IsZero() {
+		t.SetTime(ClaimAuthTime, u.authTime)
+	}
+	if len(u.details) > 0 {
+		if err := t.Set(ClaimAuthorizationDetails, u.details); err != nil {
+			return nil, fmt.Errorf("set authorization_details: %w", err)
+		}
+	}
 	return &t, nil
 }
 
@@ -221,11 +285,26 @@ func (u *UserAccessToken) Identified() bool {
 	return u.identity != nil
 }
 
-// Actor 返回代理者应用 ID（client_credentials delegation 时为代理应用的 app_id）。
+// Actor 返回代理者应用 ID（token-exchange 代理签发时为调用方应用的 app_id）。
 func (u *UserAccessToken) Actor() string {
 	return u.actor
 }
 
+// JKT 返回绑定的 DPoP 公钥指纹（cnf.jkt），为空表示 Bearer token。
+func (u *UserAccessToken) JKT() string {
+	return u.jkt
+}
+
+// AuthTime 返回用户完成认证的时间（auth_time），零值表示 token 未携带。
+func (u *UserAccessToken) AuthTime() time.Time {
+	return u.authTime
+}
+
+// AuthorizationDetails 返回授予的授权详情（RAR），未携带时为空。
+func (u *UserAccessToken) AuthorizationDetails() AuthorizationDetails {
+	return u.details
+}
+
 // IsDelegated 返回该 UAT 是否为代理签发。
 func (u *UserAccessToken) IsDelegated() bool {
 	return u.actor != ""
@@ -15,11 +15,12 @@ const (
 	ChannelTypeCaptcha ChannelType = "captcha" // 人机验证（Turnstile）
 
 	// 验证类（支持 Type 业务场景配置）
-	ChannelTypeEmailOTP ChannelType = "email-code"    // 邮箱验证码
-	ChannelTypeTOTP     ChannelType = "totp"          // TOTP 动态口令（Authenticator App）
-	ChannelTypeSmsOTP   ChannelType = "sms-code"      // 短信验证码
-	ChannelTypeTgOTP    ChannelType = "telegram-code" // Telegram 验证码
-	ChannelTypeWebAuthn ChannelType = "webauthn"      // WebAuthn/Passkey
+	ChannelTypeEmailOTP     ChannelType = "email-code"    // 邮箱验证码
+	ChannelTypeTOTP         ChannelType = "totp"          // TOTP 动态口令（Authenticator App）
+	ChannelTypeSmsOTP       ChannelType = "sms-code"      // 短信验证码
+	ChannelTypeTgOTP        ChannelType = "telegram-code" // Telegram 验证码
+	ChannelTypeWebAuthn     ChannelType = "webauthn"      // WebAuthn/Passkey
+	ChannelTypeRecoveryCode ChannelType = "recovery-code" // 一次性恢复码
 
 	// 交换类（平台固定能力，不需要 Type）
 	ChannelTypeWechatMP ChannelType = "wechat-mp" // 微信小程序换手机号
@@ -29,7 +30,7 @@ const (
 // IsVerification 检查是否是验证类 ChannelType（排除 captcha 和交换类）
 func (t ChannelType) IsVerification() bool {
 	switch t {
-	case ChannelTypeEmailOTP, ChannelTypeTOTP, ChannelTypeSmsOTP, ChannelTypeTgOTP, ChannelTypeWebAuthn:
+	case ChannelTypeEmailOTP, ChannelTypeTOTP, ChannelTypeSmsOTP, ChannelTypeTgOTP, ChannelTypeWebAuthn, ChannelTypeRecoveryCode:
 		return true
 	default:
 		return false
@@ -58,20 +59,23 @@ func (t ChannelType) IsExchange() bool {
 //   - wechat-mp → 手机号（交换得到）
 //
 // - typ: 业务类型（如 staff:verify / user:verify，交换类为空）
+// - channel_type: 完成验证所用的方式（如 totp / webauthn），用于 MFA 阶段校验第二因子
 // - aud: 目标服务 ID
 // - cli: 发起验证的应用 ID
 type ChallengeToken struct {
-	Claims         // 内嵌基础 Claims
-	subject string // sub - 完成验证的 principal
-	typ     string // typ - 业务类型
+	Claims                  // 内嵌基础 Claims
+	subject     string      // sub - 完成验证的 principal
+	typ         string      // typ - 业务类型
+	channelType ChannelType // channel_type - 验证方式
 }
 
 // ==================== XT TokenTypeBuilder ====================
 
 // XT ChallengeToken 类型构建器，实现 TokenTypeBuilder 接口
 type XT struct {
-	subject string
-	typ     string
+	subject     string
+	typ         string
+	channelType ChannelType
 }
 
 func NewChallengeTokenBuilder() *XT {
@@ -88,11 +92,17 @@ func (x *XT) Type(typ string) *XT {
 	return x
 }
 
+func (x *XT) ChannelType(channelType ChannelType) *XT {
+	x.channelType = channelType
+	return x
+}
+
 func (x *XT) Build(claims Claims) Token {
 	return &ChallengeToken{
-		Claims:  claims,
-		subject: x.subject,
-		typ:     x.typ,
+		Claims:      claims,
+		subject:     x.subject,
+		typ:         x.typ,
+		channelType: x.channelType,
 	}
 }
 
@@ -115,10 +125,16 @@ func ParseChallengeToken(pasetoToken *paseto.Token) (*ChallengeToken, error) {
 		typ = ""
 	}
 
+	var channelType string
+	if err := pasetoToken.Get(ClaimChannelType, &channelType); err != nil {
+		channelType = ""
+	}
+
 	return &ChallengeToken{
-		Claims:  claims,
-		subject: subject,
-		typ:     typ,
+		Claims:      claims,
+		subject:     subject,
+		typ:         typ,
+		channelType: ChannelType(channelType),
 	}, nil
 }
 
@@ -141,6 +157,11 @@ func (c *ChallengeToken) Build() (*paseto.Token, error) {
 			return nil, fmt.Errorf("set typ: %w", err)
 		}
 	}
+	if c.channelType != "" {
+		if err := t.Set(ClaimChannelType, string(c.channelType)); err != nil {
+			return nil, fmt.Errorf("set channel_type: %w", err)
+		}
+	}
 	return &t, nil
 }
 
@@ -153,3 +174,8 @@ func (c *ChallengeToken) Subject() string {
 func (c *ChallengeToken) GetType() string {
 	return c.typ
 }
+
+// GetChannelType 返回完成验证所用的方式
+func (c *ChallengeToken) GetChannelType() ChannelType {
+	return c.channelType
+}
@@ -1,6 +1,9 @@
 package token
 
-import "testing"
+import (
+	"testing"
+	"time"
+)
 
 func TestChannelTypeNames(t *testing.T) {
 	tests := map[ChannelType]string{
@@ -27,3 +30,26 @@ func TestCodeChannelTypesAreVerificationTypes(t *testing.T) {
 		}
 	}
 }
+
+func TestChallengeTokenChannelType(t *testing.T) {
+	for _, channelType := range []ChannelType{"", ChannelTypeTOTP} {
+		ct := NewClaimsBuilder().
+			Issuer("aegis").
+			ClientID("app").
+			Audience("svc").
+			ExpiresIn(time.Minute).
+			Build(NewChallengeTokenBuilder().Subject("user").Type("user:mfa").ChannelType(channelType)).(*ChallengeToken)
+
+		pt, err := ct.Build()
+		if err != nil {
+			t.Fatalf("build: %v", err)
+		}
+		parsed, err := ParseChallengeToken(pt)
+		if err != nil {
+			t.Fatalf("parse: %v", err)
+		}
+		if parsed.GetChannelType() != channelType {
+			t.Fatalf("GetChannelType() = %q, want %q", parsed.GetChannelType(), channelType)
+		}
+	}
+}
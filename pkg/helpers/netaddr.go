@@ -0,0 +1,26 @@
+package helpers
+
+import "net/netip"
+
+// nonPublicPrefixes netip 分类方法未覆盖的保留网段
+var nonPublicPrefixes = []netip.Prefix{
+	netip.MustParsePrefix("0.0.0.0/8"),     // 本网络（RFC 1122）
+	netip.MustParsePrefix("100.64.0.0/10"), // 运营商级 NAT 共享地址（RFC 6598）
+	netip.MustParsePrefix("192.0.0.0/24"),  // IETF 协议分配（RFC 6890）
+	netip.MustParsePrefix("198.18.0.0/15"), // 基准测试（RFC 2544）
+}
+
+// IsPublicAddr 是否为公网单播地址：排除回环、私有、链路本地、组播、未指定地址及其他保留网段
+// 服务端按外部提供的地址发起请求前据此拒绝内网目标（SSRF）
+func IsPublicAddr(addr netip.Addr) bool {
+	addr = addr.Unmap()
+	if !addr.IsGlobalUnicast() || addr.IsPrivate() {
+		return false
+	}
+	for _, prefix := range nonPublicPrefixes {
+		if prefix.Contains(addr) {
+			return false
+		}
+	}
+	return true
+}
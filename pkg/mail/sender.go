@@ -158,6 +158,11 @@ func (s *Sender) SendPasswordChanged(ctx context.Context, email string) error {
 	return s.SendNotification(ctx, email, templates.SceneNotifyPasswordChanged, nil, "", "")
 }
 
+// SendSecurityAlert 发送账户安全警告
+func (s *Sender) SendSecurityAlert(ctx context.Context, email string, details []templates.DetailItem, securityURL string) error {
+	return s.SendNotification(ctx, email, templates.SceneNotifySecurityAlert, details, securityURL, "")
+}
+
 // SendVerifyEmailLink 发送邮箱验证链接
 func (s *Sender) SendVerifyEmailLink(ctx context.Context, email, verifyURL string) error {
 	return s.SendAction(ctx, email, templates.SceneActionVerifyEmail, verifyURL, "")
@@ -0,0 +1,108 @@
+package sms
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+const (
+	defaultTimeout     = 10 * time.Second
+	maxErrorBodyLength = 512
+)
+
+var _ Sender = (*HTTPSender)(nil)
+
+// HTTPSender 通过 HTTP 网关发送短信
+//
+// 请求：POST {endpoint}，Content-Type: application/json，配置了 token 时携带 Authorization: Bearer {token}
+//
+//	{"phone": "+8613800000000", "code": "123456", "scene": "otp_login"}
+//
+// 网关返回 2xx 视为发送成功。
+type HTTPSender struct {
+	endpoint   string
+	token      string
+	httpClient *http.Client
+}
+
+// Option HTTPSender 配置选项
+type Option func(*HTTPSender)
+
+// WithHTTPClient 设置自定义 HTTP 客户端
+func WithHTTPClient(client *http.Client) Option {
+	return func(s *HTTPSender) {
+		s.httpClient = client
+	}
+}
+
+// WithTimeout 设置请求超时时间
+func WithTimeout(timeout time.Duration) Option {
+	return func(s *HTTPSender) {
+		s.httpClient.Timeout = timeout
+	}
+}
+
+// NewHTTPSender 创建 HTTP 网关短信发送器
+func NewHTTPSender(endpoint, token string, opts ...Option) *HTTPSender {
+	s := &HTTPSender{
+		endpoint: endpoint,
+		token:    token,
+		httpClient: &http.Client{
+			Timeout: defaultTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(s)
+	}
+
+	return s
+}
+
+type sendCodeRequest struct {
+	Phone string `json:"phone"`
+	Code  string `json:"code"`
+	Scene string `json:"scene"`
+}
+
+// SendCode 调用网关发送验证码
+func (s *HTTPSender) SendCode(ctx context.Context, phone, code, scene string) error {
+	body, err := json.Marshal(&sendCodeRequest{Phone: phone, Code: code, Scene: scene})
+	if err != nil {
+		return fmt.Errorf("marshal sms request: %w", err)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.endpoint, bytes.NewReader(body))
+	if err != nil {
+		return fmt.Errorf("create sms request: %w", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if s.token != "" {
+		req.Header.Set("Authorization", "Bearer "+s.token)
+	}
+
+	resp, err := s.httpClient.Do(req)
+	if err != nil {
+		logger.Errorf("[SMS] 网关请求失败 - Phone: %s, Scene: %s, Error: %v", helpers.MaskPhone(phone), scene, err)
+		return fmt.Errorf("send sms: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		detail, _ := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodyLength))
+		logger.Errorf("[SMS] 网关返回错误 - Phone: %s, Scene: %s, Status: %d, Body: %s", helpers.MaskPhone(phone), scene, resp.StatusCode, detail)
+		return fmt.Errorf("send sms: gateway returned status %d", resp.StatusCode)
+	}
+
+	logger.Infof("[SMS] 发送验证码成功 - Phone: %s, Scene: %s", helpers.MaskPhone(phone), scene)
+	return nil
+}
@@ -0,0 +1,61 @@
+package sms
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/go-json-experiment/json"
+
+	"github.com/heliannuuthus/pkg/helpers"
+	"github.com/heliannuuthus/pkg/logger"
+)
+
+var _ Sender = (*LogSender)(nil)
+
+// LogSender 开发用短信发送器：不真正发送，日志只记录发送事件（不含验证码）
+// path 非空时以 JSON Lines 追加到文件（含验证码），便于本地调试与端到端测试读取验证码
+type LogSender struct {
+	path string
+	mu   sync.Mutex
+}
+
+// Record 文件中的一条短信记录
+type Record struct {
+	Phone  string    `json:"phone"`
+	Code   string    `json:"code"`
+	Scene  string    `json:"scene"`
+	SentAt time.Time `json:"sent_at"`
+}
+
+// NewLogSender 创建开发用短信发送器
+func NewLogSender(path string) *LogSender {
+	return &LogSender{path: path}
+}
+
+// SendCode 记录发送事件，验证码仅写入 path 指定的文件
+func (s *LogSender) SendCode(_ context.Context, phone, code, scene string) error {
+	logger.Infof("[SMS] 开发模式，未实际发送 - Phone: %s, Scene: %s", helpers.MaskPhone(phone), scene)
+	if s.path == "" {
+		return nil
+	}
+
+	line, err := json.Marshal(&Record{Phone: phone, Code: code, Scene: scene, SentAt: time.Now()})
+	if err != nil {
+		return fmt.Errorf("marshal sms record: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	f, err := os.OpenFile(s.path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0o600)
+	if err != nil {
+		return fmt.Errorf("open sms sink: %w", err)
+	}
+	defer func() { _ = f.Close() }()
+	if _, err := f.Write(append(line, '\n')); err != nil {
+		return fmt.Errorf("write sms sink: %w", err)
+	}
+	return nil
+}
@@ -0,0 +1,14 @@
+// Package sms 提供短信验证码发送能力。
+//
+// Sender 为统一发送接口，内置两种实现：
+//   - LogSender：写日志（可选追加到文件），用于本地开发与测试环境
+//   - HTTPSender：通过 HTTP 网关发送，由网关对接具体的短信服务商并渲染模板
+package sms
+
+import "context"
+
+// Sender 短信发送接口
+// scene 由业务层传入（如 "otp_login"、"otp_register"），网关按 scene 选择短信模板
+type Sender interface {
+	SendCode(ctx context.Context, phone, code, scene string) error
+}
@@ -0,0 +1,75 @@
+package sms
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/go-json-experiment/json"
+)
+
+func TestHTTPSenderSendCode(t *testing.T) {
+	var got sendCodeRequest
+	var authorization string
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		authorization = r.Header.Get("Authorization")
+		if err := json.UnmarshalRead(r.Body, &got); err != nil {
+			t.Errorf("decode request: %v", err)
+		}
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer server.Close()
+
+	sender := NewHTTPSender(server.URL, "secret")
+	if err := sender.SendCode(context.Background(), "+8613800000000", "123456", "otp_login"); err != nil {
+		t.Fatalf("SendCode() error = %v", err)
+	}
+	if authorization != "Bearer secret" {
+		t.Fatalf("Authorization = %q, want bearer token", authorization)
+	}
+	want := sendCodeRequest{Phone: "+8613800000000", Code: "123456", Scene: "otp_login"}
+	if got != want {
+		t.Fatalf("request = %+v, want %+v", got, want)
+	}
+}
+
+func TestHTTPSenderGatewayError(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		http.Error(w, "quota exceeded", http.StatusTooManyRequests)
+	}))
+	defer server.Close()
+
+	if err := NewHTTPSender(server.URL, "").SendCode(context.Background(), "+8613800000000", "123456", "otp_login"); err == nil {
+		t.Fatal("SendCode() error = nil, want gateway error")
+	}
+}
+
+func TestLogSenderAppendsRecords(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "sms.jsonl")
+	sender := NewLogSender(path)
+	for _, code := range []string{"111111", "222222"} {
+		if err := sender.SendCode(context.Background(), "+8613800000000", code, "otp_login"); err != nil {
+			t.Fatalf("SendCode() error = %v", err)
+		}
+	}
+
+	data, err := os.ReadFile(path)
+	if err != nil {
+		t.Fatalf("read sink: %v", err)
+	}
+	var codes []string
+	for _, line := range strings.Split(strings.TrimSpace(string(data)), "\n") {
+		var record Record
+		if err := json.Unmarshal([]byte(line), &record); err != nil {
+			t.Fatalf("decode record: %v", err)
+		}
+		codes = append(codes, record.Code)
+	}
+	if len(codes) != 2 || codes[0] != "111111" || codes[1] != "222222" {
+		t.Fatalf("codes = %v, want both records in order", codes)
+	}
+}
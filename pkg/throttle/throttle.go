@@ -126,6 +126,23 @@ redis.call('EXPIRE', key, ttl)
 return redis.call('ZCARD', key)
 `
 
+// statScript 只读统计指定窗口内的记录数及最近一条记录的时间
+//
+// 返回: {count, latest_score}
+const statScript = `
+local key = KEYS[1]
+local window_start = tonumber(ARGV[1])
+
+redis.call('ZREMRANGEBYSCORE', key, '-inf', window_start)
+local count = redis.call('ZCARD', key)
+local latest = redis.call('ZRANGE', key, -1, -1, 'WITHSCORES')
+local latest_score = 0
+if #latest >= 2 then
+    latest_score = tonumber(latest[2])
+end
+return {count, latest_score}
+`
+
 // Throttler 基于 Redis Sorted Set 的滑动窗口节流器
 type Throttler struct {
 	redis redis.Client
@@ -306,6 +323,41 @@ func (t *Throttler) Record(ctx context.Context, key string, window time.Duration
 	return toInt64(result), nil
 }
 
+// Stat 窗口内的记录统计
+type Stat struct {
+	Count  int64
+	Latest time.Time // 最近一条记录的时间，Count 为 0 时为零值
+}
+
+// Stat 只读统计指定窗口内的记录数及最近一条记录的时间（不写入）
+func (t *Throttler) Stat(ctx context.Context, key string, window time.Duration) (*Stat, error) {
+	windowStartMs := time.Now().Add(-window).UnixMilli()
+
+	result, err := t.redis.Eval(ctx, statScript, []string{key}, windowStartMs)
+	if err != nil {
+		return nil, fmt.Errorf("throttle stat failed: %w", err)
+	}
+
+	vals, ok := result.([]interface{})
+	if !ok || len(vals) < 2 {
+		return nil, fmt.Errorf("unexpected throttle stat result: %v", result)
+	}
+
+	stat := &Stat{Count: toInt64(vals[0])}
+	if latestMs := toInt64(vals[1]); latestMs > 0 {
+		stat.Latest = time.UnixMilli(latestMs)
+	}
+	return stat, nil
+}
+
+// Reset 清除 key 下的全部记录
+func (t *Throttler) Reset(ctx context.Context, key string) error {
+	if err := t.redis.Del(ctx, key); err != nil {
+		return fmt.Errorf("throttle reset failed: %w", err)
+	}
+	return nil
+}
+
 // ==================== 辅助函数 ====================
 
 // parseAndSortWindows 解析限流配置并按窗口从大到小排序
@@ -135,6 +135,59 @@ func (x *RotateKeyRequest) GetWindowSeconds() int64 {
 	return 0
 }
 
+// CreateKeyRequest 为尚无有效密钥的 owner 生成首个密钥（已有密钥时应使用 RotateKey）
+type CreateKeyRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	OwnerType     string                 `protobuf:"bytes,1,opt,name=owner_type,json=ownerType,proto3" json:"owner_type,omitempty"`
+	OwnerId       string                 `protobuf:"bytes,2,opt,name=owner_id,json=ownerId,proto3" json:"owner_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateKeyRequest) Reset() {
+	*x = CreateKeyRequest{}
+	mi := &file_hermes_v1_key_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateKeyRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateKeyRequest) ProtoMessage() {}
+
+func (x *CreateKeyRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_key_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateKeyRequest.ProtoReflect.Descriptor instead.
+func (*CreateKeyRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *CreateKeyRequest) GetOwnerType() string {
+	if x != nil {
+		return x.OwnerType
+	}
+	return ""
+}
+
+func (x *CreateKeyRequest) GetOwnerId() string {
+	if x != nil {
+		return x.OwnerId
+	}
+	return ""
+}
+
 // KeySet 密钥集合（已解密）
 type KeySet struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -146,7 +199,7 @@ type KeySet struct {
 
 func (x *KeySet) Reset() {
 	*x = KeySet{}
-	mi := &file_hermes_v1_key_proto_msgTypes[2]
+	mi := &file_hermes_v1_key_proto_msgTypes[3]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -158,7 +211,7 @@ func (x *KeySet) String() string {
 func (*KeySet) ProtoMessage() {}
 
 func (x *KeySet) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[2]
+	mi := &file_hermes_v1_key_proto_msgTypes[3]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -171,7 +224,7 @@ func (x *KeySet) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use KeySet.ProtoReflect.Descriptor instead.
 func (*KeySet) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{2}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{3}
 }
 
 func (x *KeySet) GetMain() []byte {
@@ -198,7 +251,7 @@ type GetIDPKeyRequest struct {
 
 func (x *GetIDPKeyRequest) Reset() {
 	*x = GetIDPKeyRequest{}
-	mi := &file_hermes_v1_key_proto_msgTypes[3]
+	mi := &file_hermes_v1_key_proto_msgTypes[4]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -210,7 +263,7 @@ func (x *GetIDPKeyRequest) String() string {
 func (*GetIDPKeyRequest) ProtoMessage() {}
 
 func (x *GetIDPKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[3]
+	mi := &file_hermes_v1_key_proto_msgTypes[4]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -223,7 +276,7 @@ func (x *GetIDPKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetIDPKeyRequest.ProtoReflect.Descriptor instead.
 func (*GetIDPKeyRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{3}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{4}
 }
 
 func (x *GetIDPKeyRequest) GetIdpType() string {
@@ -253,7 +306,7 @@ type IDPKey struct {
 
 func (x *IDPKey) Reset() {
 	*x = IDPKey{}
-	mi := &file_hermes_v1_key_proto_msgTypes[4]
+	mi := &file_hermes_v1_key_proto_msgTypes[5]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -265,7 +318,7 @@ func (x *IDPKey) String() string {
 func (*IDPKey) ProtoMessage() {}
 
 func (x *IDPKey) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[4]
+	mi := &file_hermes_v1_key_proto_msgTypes[5]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -278,7 +331,7 @@ func (x *IDPKey) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IDPKey.ProtoReflect.Descriptor instead.
 func (*IDPKey) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{4}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{5}
 }
 
 func (x *IDPKey) GetId() uint32 {
@@ -325,7 +378,7 @@ type IDPKeyList struct {
 
 func (x *IDPKeyList) Reset() {
 	*x = IDPKeyList{}
-	mi := &file_hermes_v1_key_proto_msgTypes[5]
+	mi := &file_hermes_v1_key_proto_msgTypes[6]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -337,7 +390,7 @@ func (x *IDPKeyList) String() string {
 func (*IDPKeyList) ProtoMessage() {}
 
 func (x *IDPKeyList) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[5]
+	mi := &file_hermes_v1_key_proto_msgTypes[6]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -350,7 +403,7 @@ func (x *IDPKeyList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use IDPKeyList.ProtoReflect.Descriptor instead.
 func (*IDPKeyList) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{5}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{6}
 }
 
 func (x *IDPKeyList) GetKeys() []*IDPKey {
@@ -371,7 +424,7 @@ type CreateIDPKeyRequest struct {
 
 func (x *CreateIDPKeyRequest) Reset() {
 	*x = CreateIDPKeyRequest{}
-	mi := &file_hermes_v1_key_proto_msgTypes[6]
+	mi := &file_hermes_v1_key_proto_msgTypes[7]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -383,7 +436,7 @@ func (x *CreateIDPKeyRequest) String() string {
 func (*CreateIDPKeyRequest) ProtoMessage() {}
 
 func (x *CreateIDPKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[6]
+	mi := &file_hermes_v1_key_proto_msgTypes[7]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -396,7 +449,7 @@ func (x *CreateIDPKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateIDPKeyRequest.ProtoReflect.Descriptor instead.
 func (*CreateIDPKeyRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{6}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{7}
 }
 
 func (x *CreateIDPKeyRequest) GetIdpType() string {
@@ -431,7 +484,7 @@ type UpdateIDPKeyRequest struct {
 
 func (x *UpdateIDPKeyRequest) Reset() {
 	*x = UpdateIDPKeyRequest{}
-	mi := &file_hermes_v1_key_proto_msgTypes[7]
+	mi := &file_hermes_v1_key_proto_msgTypes[8]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -443,7 +496,7 @@ func (x *UpdateIDPKeyRequest) String() string {
 func (*UpdateIDPKeyRequest) ProtoMessage() {}
 
 func (x *UpdateIDPKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[7]
+	mi := &file_hermes_v1_key_proto_msgTypes[8]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -456,7 +509,7 @@ func (x *UpdateIDPKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateIDPKeyRequest.ProtoReflect.Descriptor instead.
 func (*UpdateIDPKeyRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{7}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{8}
 }
 
 func (x *UpdateIDPKeyRequest) GetIdpType() string {
@@ -490,7 +543,7 @@ type DeleteIDPKeyRequest struct {
 
 func (x *DeleteIDPKeyRequest) Reset() {
 	*x = DeleteIDPKeyRequest{}
-	mi := &file_hermes_v1_key_proto_msgTypes[8]
+	mi := &file_hermes_v1_key_proto_msgTypes[9]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -502,7 +555,7 @@ func (x *DeleteIDPKeyRequest) String() string {
 func (*DeleteIDPKeyRequest) ProtoMessage() {}
 
 func (x *DeleteIDPKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[8]
+	mi := &file_hermes_v1_key_proto_msgTypes[9]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -515,7 +568,7 @@ func (x *DeleteIDPKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteIDPKeyRequest.ProtoReflect.Descriptor instead.
 func (*DeleteIDPKeyRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{8}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{9}
 }
 
 func (x *DeleteIDPKeyRequest) GetIdpType() string {
@@ -542,7 +595,7 @@ type ResolveIDPKeyRequest struct {
 
 func (x *ResolveIDPKeyRequest) Reset() {
 	*x = ResolveIDPKeyRequest{}
-	mi := &file_hermes_v1_key_proto_msgTypes[9]
+	mi := &file_hermes_v1_key_proto_msgTypes[10]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -554,7 +607,7 @@ func (x *ResolveIDPKeyRequest) String() string {
 func (*ResolveIDPKeyRequest) ProtoMessage() {}
 
 func (x *ResolveIDPKeyRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[9]
+	mi := &file_hermes_v1_key_proto_msgTypes[10]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -567,7 +620,7 @@ func (x *ResolveIDPKeyRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveIDPKeyRequest.ProtoReflect.Descriptor instead.
 func (*ResolveIDPKeyRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{9}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{10}
 }
 
 func (x *ResolveIDPKeyRequest) GetAppId() string {
@@ -594,7 +647,7 @@ type ResolveIDPKeyResponse struct {
 
 func (x *ResolveIDPKeyResponse) Reset() {
 	*x = ResolveIDPKeyResponse{}
-	mi := &file_hermes_v1_key_proto_msgTypes[10]
+	mi := &file_hermes_v1_key_proto_msgTypes[11]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -606,7 +659,7 @@ func (x *ResolveIDPKeyResponse) String() string {
 func (*ResolveIDPKeyResponse) ProtoMessage() {}
 
 func (x *ResolveIDPKeyResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_key_proto_msgTypes[10]
+	mi := &file_hermes_v1_key_proto_msgTypes[11]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -619,7 +672,7 @@ func (x *ResolveIDPKeyResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ResolveIDPKeyResponse.ProtoReflect.Descriptor instead.
 func (*ResolveIDPKeyResponse) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_key_proto_rawDescGZIP(), []int{10}
+	return file_hermes_v1_key_proto_rawDescGZIP(), []int{11}
 }
 
 func (x *ResolveIDPKeyResponse) GetTAppId() string {
@@ -649,7 +702,11 @@ const file_hermes_v1_key_proto_rawDesc = "" +
 	"\n" +
 	"owner_type\x18\x01 \x01(\tR\townerType\x12\x19\n" +
 	"\bowner_id\x18\x02 \x01(\tR\aownerId\x12%\n" +
-	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\"0\n" +
+	"\x0ewindow_seconds\x18\x03 \x01(\x03R\rwindowSeconds\"L\n" +
+	"\x10CreateKeyRequest\x12\x1d\n" +
+	"\n" +
+	"owner_type\x18\x01 \x01(\tR\townerType\x12\x19\n" +
+	"\bowner_id\x18\x02 \x01(\tR\aownerId\"0\n" +
 	"\x06KeySet\x12\x12\n" +
 	"\x04main\x18\x01 \x01(\fR\x04main\x12\x12\n" +
 	"\x04keys\x18\x02 \x03(\fR\x04keys\"G\n" +
@@ -684,11 +741,12 @@ const file_hermes_v1_key_proto_rawDesc = "" +
 	"\bidp_type\x18\x02 \x01(\tR\aidpType\"L\n" +
 	"\x15ResolveIDPKeyResponse\x12\x18\n" +
 	"\bt_app_id\x18\x01 \x01(\tR\x06tAppId\x12\x19\n" +
-	"\bt_secret\x18\x02 \x01(\tR\atSecret2\xa9\x04\n" +
+	"\bt_secret\x18\x02 \x01(\tR\atSecret2\xe6\x04\n" +
 	"\n" +
 	"KeyService\x127\n" +
 	"\aGetKeys\x12\x19.hermes.v1.GetKeysRequest\x1a\x11.hermes.v1.KeySet\x12@\n" +
-	"\tRotateKey\x12\x1b.hermes.v1.RotateKeyRequest\x1a\x16.google.protobuf.Empty\x12<\n" +
+	"\tRotateKey\x12\x1b.hermes.v1.RotateKeyRequest\x1a\x16.google.protobuf.Empty\x12;\n" +
+	"\tCreateKey\x12\x1b.hermes.v1.CreateKeyRequest\x1a\x11.hermes.v1.KeySet\x12<\n" +
 	"\vListIDPKeys\x12\x16.google.protobuf.Empty\x1a\x15.hermes.v1.IDPKeyList\x12;\n" +
 	"\tGetIDPKey\x12\x1b.hermes.v1.GetIDPKeyRequest\x1a\x11.hermes.v1.IDPKey\x12A\n" +
 	"\fCreateIDPKey\x12\x1e.hermes.v1.CreateIDPKeyRequest\x1a\x11.hermes.v1.IDPKey\x12F\n" +
@@ -710,44 +768,47 @@ func file_hermes_v1_key_proto_rawDescGZIP() []byte {
 	return file_hermes_v1_key_proto_rawDescData
 }
 
-var file_hermes_v1_key_proto_msgTypes = make([]protoimpl.MessageInfo, 11)
+var file_hermes_v1_key_proto_msgTypes = make([]protoimpl.MessageInfo, 12)
 var file_hermes_v1_key_proto_goTypes = []any{
 	(*GetKeysRequest)(nil),        // 0: hermes.v1.GetKeysRequest
 	(*RotateKeyRequest)(nil),      // 1: hermes.v1.RotateKeyRequest
-	(*KeySet)(nil),                // 2: hermes.v1.KeySet
-	(*GetIDPKeyRequest)(nil),      // 3: hermes.v1.GetIDPKeyRequest
-	(*IDPKey)(nil),                // 4: hermes.v1.IDPKey
-	(*IDPKeyList)(nil),            // 5: hermes.v1.IDPKeyList
-	(*CreateIDPKeyRequest)(nil),   // 6: hermes.v1.CreateIDPKeyRequest
-	(*UpdateIDPKeyRequest)(nil),   // 7: hermes.v1.UpdateIDPKeyRequest
-	(*DeleteIDPKeyRequest)(nil),   // 8: hermes.v1.DeleteIDPKeyRequest
-	(*ResolveIDPKeyRequest)(nil),  // 9: hermes.v1.ResolveIDPKeyRequest
-	(*ResolveIDPKeyResponse)(nil), // 10: hermes.v1.ResolveIDPKeyResponse
-	(*timestamppb.Timestamp)(nil), // 11: google.protobuf.Timestamp
-	(*emptypb.Empty)(nil),         // 12: google.protobuf.Empty
+	(*CreateKeyRequest)(nil),      // 2: hermes.v1.CreateKeyRequest
+	(*KeySet)(nil),                // 3: hermes.v1.KeySet
+	(*GetIDPKeyRequest)(nil),      // 4: hermes.v1.GetIDPKeyRequest
+	(*IDPKey)(nil),                // 5: hermes.v1.IDPKey
+	(*IDPKeyList)(nil),            // 6: hermes.v1.IDPKeyList
+	(*CreateIDPKeyRequest)(nil),   // 7: hermes.v1.CreateIDPKeyRequest
+	(*UpdateIDPKeyRequest)(nil),   // 8: hermes.v1.UpdateIDPKeyRequest
+	(*DeleteIDPKeyRequest)(nil),   // 9: hermes.v1.DeleteIDPKeyRequest
+	(*ResolveIDPKeyRequest)(nil),  // 10: hermes.v1.ResolveIDPKeyRequest
+	(*ResolveIDPKeyResponse)(nil), // 11: hermes.v1.ResolveIDPKeyResponse
+	(*timestamppb.Timestamp)(nil), // 12: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 13: google.protobuf.Empty
 }
 var file_hermes_v1_key_proto_depIdxs = []int32{
-	11, // 0: hermes.v1.IDPKey.created_at:type_name -> google.protobuf.Timestamp
-	11, // 1: hermes.v1.IDPKey.updated_at:type_name -> google.protobuf.Timestamp
-	4,  // 2: hermes.v1.IDPKeyList.keys:type_name -> hermes.v1.IDPKey
+	12, // 0: hermes.v1.IDPKey.created_at:type_name -> google.protobuf.Timestamp
+	12, // 1: hermes.v1.IDPKey.updated_at:type_name -> google.protobuf.Timestamp
+	5,  // 2: hermes.v1.IDPKeyList.keys:type_name -> hermes.v1.IDPKey
 	0,  // 3: hermes.v1.KeyService.GetKeys:input_type -> hermes.v1.GetKeysRequest
 	1,  // 4: hermes.v1.KeyService.RotateKey:input_type -> hermes.v1.RotateKeyRequest
-	12, // 5: hermes.v1.KeyService.ListIDPKeys:input_type -> google.protobuf.Empty
-	3,  // 6: hermes.v1.KeyService.GetIDPKey:input_type -> hermes.v1.GetIDPKeyRequest
-	6,  // 7: hermes.v1.KeyService.CreateIDPKey:input_type -> hermes.v1.CreateIDPKeyRequest
-	7,  // 8: hermes.v1.KeyService.UpdateIDPKey:input_type -> hermes.v1.UpdateIDPKeyRequest
-	8,  // 9: hermes.v1.KeyService.DeleteIDPKey:input_type -> hermes.v1.DeleteIDPKeyRequest
-	9,  // 10: hermes.v1.KeyService.ResolveIDPKey:input_type -> hermes.v1.ResolveIDPKeyRequest
-	2,  // 11: hermes.v1.KeyService.GetKeys:output_type -> hermes.v1.KeySet
-	12, // 12: hermes.v1.KeyService.RotateKey:output_type -> google.protobuf.Empty
-	5,  // 13: hermes.v1.KeyService.ListIDPKeys:output_type -> hermes.v1.IDPKeyList
-	4,  // 14: hermes.v1.KeyService.GetIDPKey:output_type -> hermes.v1.IDPKey
-	4,  // 15: hermes.v1.KeyService.CreateIDPKey:output_type -> hermes.v1.IDPKey
-	12, // 16: hermes.v1.KeyService.UpdateIDPKey:output_type -> google.protobuf.Empty
-	12, // 17: hermes.v1.KeyService.DeleteIDPKey:output_type -> google.protobuf.Empty
-	10, // 18: hermes.v1.KeyService.ResolveIDPKey:output_type -> hermes.v1.ResolveIDPKeyResponse
-	11, // [11:19] is the sub-list for method output_type
-	3,  // [3:11] is the sub-list for method input_type
+	2,  // 5: hermes.v1.KeyService.CreateKey:input_type -> hermes.v1.CreateKeyRequest
+	13, // 6: hermes.v1.KeyService.ListIDPKeys:input_type -> google.protobuf.Empty
+	4,  // 7: hermes.v1.KeyService.GetIDPKey:input_type -> hermes.v1.GetIDPKeyRequest
+	7,  // 8: hermes.v1.KeyService.CreateIDPKey:input_type -> hermes.v1.CreateIDPKeyRequest
+	8,  // 9: hermes.v1.KeyService.UpdateIDPKey:input_type -> hermes.v1.UpdateIDPKeyRequest
+	9,  // 10: hermes.v1.KeyService.DeleteIDPKey:input_type -> hermes.v1.DeleteIDPKeyRequest
+	10, // 11: hermes.v1.KeyService.ResolveIDPKey:input_type -> hermes.v1.ResolveIDPKeyRequest
+	3,  // 12: hermes.v1.KeyService.GetKeys:output_type -> hermes.v1.KeySet
+	13, // 13: hermes.v1.KeyService.RotateKey:output_type -> google.protobuf.Empty
+	3,  // 14: hermes.v1.KeyService.CreateKey:output_type -> hermes.v1.KeySet
+	6,  // 15: hermes.v1.KeyService.ListIDPKeys:output_type -> hermes.v1.IDPKeyList
+	5,  // 16: hermes.v1.KeyService.GetIDPKey:output_type -> hermes.v1.IDPKey
+	5,  // 17: hermes.v1.KeyService.CreateIDPKey:output_type -> hermes.v1.IDPKey
+	13, // 18: hermes.v1.KeyService.UpdateIDPKey:output_type -> google.protobuf.Empty
+	13, // 19: hermes.v1.KeyService.DeleteIDPKey:output_type -> google.protobuf.Empty
+	11, // 20: hermes.v1.KeyService.ResolveIDPKey:output_type -> hermes.v1.ResolveIDPKeyResponse
+	12, // [12:21] is the sub-list for method output_type
+	3,  // [3:12] is the sub-list for method input_type
 	3,  // [3:3] is the sub-list for extension type_name
 	3,  // [3:3] is the sub-list for extension extendee
 	0,  // [0:3] is the sub-list for field type_name
@@ -758,14 +819,14 @@ func file_hermes_v1_key_proto_init() {
 	if File_hermes_v1_key_proto != nil {
 		return
 	}
-	file_hermes_v1_key_proto_msgTypes[7].OneofWrappers = []any{}
+	file_hermes_v1_key_proto_msgTypes[8].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hermes_v1_key_proto_rawDesc), len(file_hermes_v1_key_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   11,
+			NumMessages:   12,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
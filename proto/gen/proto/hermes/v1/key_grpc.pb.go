@@ -22,6 +22,7 @@ const _ = grpc.SupportPackageIsVersion9
 const (
 	KeyService_GetKeys_FullMethodName       = "/hermes.v1.KeyService/GetKeys"
 	KeyService_RotateKey_FullMethodName     = "/hermes.v1.KeyService/RotateKey"
+	KeyService_CreateKey_FullMethodName     = "/hermes.v1.KeyService/CreateKey"
 	KeyService_ListIDPKeys_FullMethodName   = "/hermes.v1.KeyService/ListIDPKeys"
 	KeyService_GetIDPKey_FullMethodName     = "/hermes.v1.KeyService/GetIDPKey"
 	KeyService_CreateIDPKey_FullMethodName  = "/hermes.v1.KeyService/CreateIDPKey"
@@ -38,6 +39,7 @@ const (
 type KeyServiceClient interface {
 	GetKeys(ctx context.Context, in *GetKeysRequest, opts ...grpc.CallOption) (*KeySet, error)
 	RotateKey(ctx context.Context, in *RotateKeyRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*KeySet, error)
 	ListIDPKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IDPKeyList, error)
 	GetIDPKey(ctx context.Context, in *GetIDPKeyRequest, opts ...grpc.CallOption) (*IDPKey, error)
 	CreateIDPKey(ctx context.Context, in *CreateIDPKeyRequest, opts ...grpc.CallOption) (*IDPKey, error)
@@ -74,6 +76,16 @@ func (c *keyServiceClient) RotateKey(ctx context.Context, in *RotateKeyRequest,
 	return out, nil
 }
 
+func (c *keyServiceClient) CreateKey(ctx context.Context, in *CreateKeyRequest, opts ...grpc.CallOption) (*KeySet, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(KeySet)
+	err := c.cc.Invoke(ctx, KeyService_CreateKey_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *keyServiceClient) ListIDPKeys(ctx context.Context, in *emptypb.Empty, opts ...grpc.CallOption) (*IDPKeyList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(IDPKeyList)
@@ -142,6 +154,7 @@ func (c *keyServiceClient) ResolveIDPKey(ctx context.Context, in *ResolveIDPKeyR
 type KeyServiceServer interface {
 	GetKeys(context.Context, *GetKeysRequest) (*KeySet, error)
 	RotateKey(context.Context, *RotateKeyRequest) (*emptypb.Empty, error)
+	CreateKey(context.Context, *CreateKeyRequest) (*KeySet, error)
 	ListIDPKeys(context.Context, *emptypb.Empty) (*IDPKeyList, error)
 	GetIDPKey(context.Context, *GetIDPKeyRequest) (*IDPKey, error)
 	CreateIDPKey(context.Context, *CreateIDPKeyRequest) (*IDPKey, error)
@@ -164,6 +177,9 @@ func (UnimplementedKeyServiceServer) GetKeys(context.Context, *GetKeysRequest) (
 func (UnimplementedKeyServiceServer) RotateKey(context.Context, *RotateKeyRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method RotateKey not implemented")
 }
+func (UnimplementedKeyServiceServer) CreateKey(context.Context, *CreateKeyRequest) (*KeySet, error) {
+	return nil, status.Error(codes.Unimplemented, "method CreateKey not implemented")
+}
 func (UnimplementedKeyServiceServer) ListIDPKeys(context.Context, *emptypb.Empty) (*IDPKeyList, error) {
 	return nil, status.Error(codes.Unimplemented, "method ListIDPKeys not implemented")
 }
@@ -239,6 +255,24 @@ func _KeyService_RotateKey_Handler(srv interface{}, ctx context.Context, dec fun
 	return interceptor(ctx, in, info, handler)
 }
 
+func _KeyService_CreateKey_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(CreateKeyRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(KeyServiceServer).CreateKey(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: KeyService_CreateKey_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(KeyServiceServer).CreateKey(ctx, req.(*CreateKeyRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _KeyService_ListIDPKeys_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(emptypb.Empty)
 	if err := dec(in); err != nil {
@@ -362,6 +396,10 @@ var KeyService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "RotateKey",
 			Handler:    _KeyService_RotateKey_Handler,
 		},
+		{
+			MethodName: "CreateKey",
+			Handler:    _KeyService_CreateKey_Handler,
+		},
 		{
 			MethodName: "ListIDPKeys",
 			Handler:    _KeyService_ListIDPKeys_Handler,
@@ -258,6 +258,7 @@ type DomainIDPConfig struct {
 	Require       *string                `protobuf:"bytes,7,opt,name=require,proto3,oneof" json:"require,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Config        *string                `protobuf:"bytes,10,opt,name=config,proto3,oneof" json:"config,omitempty"` // 连接参数（JSON），如通用 OIDC 的 issuer / endpoints / scopes / claim 映射
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -355,6 +356,13 @@ func (x *DomainIDPConfig) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *DomainIDPConfig) GetConfig() string {
+	if x != nil && x.Config != nil {
+		return *x.Config
+	}
+	return ""
+}
+
 type DomainIDPConfigList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Configs       []*DomainIDPConfig     `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
@@ -630,6 +638,7 @@ type ApplicationIDPConfig struct {
 	Require       *string                `protobuf:"bytes,7,opt,name=require,proto3,oneof" json:"require,omitempty"`
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Config        *string                `protobuf:"bytes,10,opt,name=config,proto3,oneof" json:"config,omitempty"` // 连接参数（JSON），如通用 OIDC 的 issuer / endpoints / scopes / claim 映射
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -727,6 +736,13 @@ func (x *ApplicationIDPConfig) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *ApplicationIDPConfig) GetConfig() string {
+	if x != nil && x.Config != nil {
+		return *x.Config
+	}
+	return ""
+}
+
 type ApplicationIDPConfigList struct {
 	state         protoimpl.MessageState  `protogen:"open.v1"`
 	Configs       []*ApplicationIDPConfig `protobuf:"bytes,1,rep,name=configs,proto3" json:"configs,omitempty"`
@@ -1036,23 +1052,29 @@ func (x *GetApplicationRequest) GetAppId() string {
 }
 
 type Application struct {
-	state                         protoimpl.MessageState `protogen:"open.v1"`
-	Id                            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
-	DomainId                      string                 `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
-	AppId                         string                 `protobuf:"bytes,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Name                          string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
-	Description                   *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	LogoUrl                       *string                `protobuf:"bytes,6,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
-	AllowedRedirectUris           []string               `protobuf:"bytes,7,rep,name=allowed_redirect_uris,json=allowedRedirectUris,proto3" json:"allowed_redirect_uris,omitempty"`
-	AllowedOrigins                []string               `protobuf:"bytes,8,rep,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
-	AllowedLogoutUris             []string               `protobuf:"bytes,9,rep,name=allowed_logout_uris,json=allowedLogoutUris,proto3" json:"allowed_logout_uris,omitempty"`
-	IdTokenExpiresIn              uint32                 `protobuf:"varint,10,opt,name=id_token_expires_in,json=idTokenExpiresIn,proto3" json:"id_token_expires_in,omitempty"`
-	RefreshTokenExpiresIn         uint32                 `protobuf:"varint,11,opt,name=refresh_token_expires_in,json=refreshTokenExpiresIn,proto3" json:"refresh_token_expires_in,omitempty"`
-	RefreshTokenAbsoluteExpiresIn uint32                 `protobuf:"varint,12,opt,name=refresh_token_absolute_expires_in,json=refreshTokenAbsoluteExpiresIn,proto3" json:"refresh_token_absolute_expires_in,omitempty"`
-	CreatedAt                     *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
-	UpdatedAt                     *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+	state                              protoimpl.MessageState `protogen:"open.v1"`
+	Id                                 uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	DomainId                           string                 `protobuf:"bytes,2,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	AppId                              string                 `protobuf:"bytes,3,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Name                               string                 `protobuf:"bytes,4,opt,name=name,proto3" json:"name,omitempty"`
+	Description                        *string                `protobuf:"bytes,5,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	LogoUrl                            *string                `protobuf:"bytes,6,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	AllowedRedirectUris                []string               `protobuf:"bytes,7,rep,name=allowed_redirect_uris,json=allowedRedirectUris,proto3" json:"allowed_redirect_uris,omitempty"`
+	AllowedOrigins                     []string               `protobuf:"bytes,8,rep,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
+	AllowedLogoutUris                  []string               `protobuf:"bytes,9,rep,name=allowed_logout_uris,json=allowedLogoutUris,proto3" json:"allowed_logout_uris,omitempty"`
+	IdTokenExpiresIn                   uint32                 `protobuf:"varint,10,opt,name=id_token_expires_in,json=idTokenExpiresIn,proto3" json:"id_token_expires_in,omitempty"`
+	RefreshTokenExpiresIn              uint32                 `protobuf:"varint,11,opt,name=refresh_token_expires_in,json=refreshTokenExpiresIn,proto3" json:"refresh_token_expires_in,omitempty"`
+	RefreshTokenAbsoluteExpiresIn      uint32                 `protobuf:"varint,12,opt,name=refresh_token_absolute_expires_in,json=refreshTokenAbsoluteExpiresIn,proto3" json:"refresh_token_absolute_expires_in,omitempty"`
+	CreatedAt                          *timestamppb.Timestamp `protobuf:"bytes,13,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt                          *timestamppb.Timestamp `protobuf:"bytes,14,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	RefreshTokenRotation               bool                   `protobuf:"varint,15,opt,name=refresh_token_rotation,json=refreshTokenRotation,proto3" json:"refresh_token_rotation,omitempty"`
+	DpopBoundAccessTokens              bool                   `protobuf:"varint,16,opt,name=dpop_bound_access_tokens,json=dpopBoundAccessTokens,proto3" json:"dpop_bound_access_tokens,omitempty"`
+	BackchannelLogoutUri               *string                `protobuf:"bytes,17,opt,name=backchannel_logout_uri,json=backchannelLogoutUri,proto3,oneof" json:"backchannel_logout_uri,omitempty"`
+	RequirePushedAuthorizationRequests bool                   `protobuf:"varint,18,opt,name=require_pushed_authorization_requests,json=requirePushedAuthorizationRequests,proto3" json:"require_pushed_authorization_requests,omitempty"`
+	// 动态注册客户端的 registration_access_token 摘要（RFC 7592），非动态注册时为空
+	RegistrationTokenHash *string `protobuf:"bytes,19,opt,name=registration_token_hash,json=registrationTokenHash,proto3,oneof" json:"registration_token_hash,omitempty"`
+	unknownFields         protoimpl.UnknownFields
+	sizeCache             protoimpl.SizeCache
 }
 
 func (x *Application) Reset() {
@@ -1183,6 +1205,41 @@ func (x *Application) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+func (x *Application) GetRefreshTokenRotation() bool {
+	if x != nil {
+		return x.RefreshTokenRotation
+	}
+	return false
+}
+
+func (x *Application) GetDpopBoundAccessTokens() bool {
+	if x != nil {
+		return x.DpopBoundAccessTokens
+	}
+	return false
+}
+
+func (x *Application) GetBackchannelLogoutUri() string {
+	if x != nil && x.BackchannelLogoutUri != nil {
+		return *x.BackchannelLogoutUri
+	}
+	return ""
+}
+
+func (x *Application) GetRequirePushedAuthorizationRequests() bool {
+	if x != nil {
+		return x.RequirePushedAuthorizationRequests
+	}
+	return false
+}
+
+func (x *Application) GetRegistrationTokenHash() string {
+	if x != nil && x.RegistrationTokenHash != nil {
+		return *x.RegistrationTokenHash
+	}
+	return ""
+}
+
 type ApplicationList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Applications  []*Application         `protobuf:"bytes,1,rep,name=applications,proto3" json:"applications,omitempty"`
@@ -1236,20 +1293,26 @@ func (x *ApplicationList) GetNextCursor() string {
 }
 
 type CreateApplicationRequest struct {
-	state                         protoimpl.MessageState `protogen:"open.v1"`
-	DomainId                      string                 `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
-	AppId                         *string                `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3,oneof" json:"app_id,omitempty"`
-	Name                          string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
-	Description                   string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
-	AllowedRedirectUris           []string               `protobuf:"bytes,5,rep,name=allowed_redirect_uris,json=allowedRedirectUris,proto3" json:"allowed_redirect_uris,omitempty"`
-	AllowedOrigins                []string               `protobuf:"bytes,6,rep,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
-	AllowedLogoutUris             []string               `protobuf:"bytes,7,rep,name=allowed_logout_uris,json=allowedLogoutUris,proto3" json:"allowed_logout_uris,omitempty"`
-	NeedKey                       bool                   `protobuf:"varint,8,opt,name=need_key,json=needKey,proto3" json:"need_key,omitempty"`
-	IdTokenExpiresIn              *uint32                `protobuf:"varint,9,opt,name=id_token_expires_in,json=idTokenExpiresIn,proto3,oneof" json:"id_token_expires_in,omitempty"`
-	RefreshTokenExpiresIn         *uint32                `protobuf:"varint,10,opt,name=refresh_token_expires_in,json=refreshTokenExpiresIn,proto3,oneof" json:"refresh_token_expires_in,omitempty"`
-	RefreshTokenAbsoluteExpiresIn *uint32                `protobuf:"varint,11,opt,name=refresh_token_absolute_expires_in,json=refreshTokenAbsoluteExpiresIn,proto3,oneof" json:"refresh_token_absolute_expires_in,omitempty"`
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+	state                              protoimpl.MessageState `protogen:"open.v1"`
+	DomainId                           string                 `protobuf:"bytes,1,opt,name=domain_id,json=domainId,proto3" json:"domain_id,omitempty"`
+	AppId                              *string                `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3,oneof" json:"app_id,omitempty"`
+	Name                               string                 `protobuf:"bytes,3,opt,name=name,proto3" json:"name,omitempty"`
+	Description                        string                 `protobuf:"bytes,4,opt,name=description,proto3" json:"description,omitempty"`
+	AllowedRedirectUris                []string               `protobuf:"bytes,5,rep,name=allowed_redirect_uris,json=allowedRedirectUris,proto3" json:"allowed_redirect_uris,omitempty"`
+	AllowedOrigins                     []string               `protobuf:"bytes,6,rep,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
+	AllowedLogoutUris                  []string               `protobuf:"bytes,7,rep,name=allowed_logout_uris,json=allowedLogoutUris,proto3" json:"allowed_logout_uris,omitempty"`
+	NeedKey                            bool                   `protobuf:"varint,8,opt,name=need_key,json=needKey,proto3" json:"need_key,omitempty"`
+	IdTokenExpiresIn                   *uint32                `protobuf:"varint,9,opt,name=id_token_expires_in,json=idTokenExpiresIn,proto3,oneof" json:"id_token_expires_in,omitempty"`
+	RefreshTokenExpiresIn              *uint32                `protobuf:"varint,10,opt,name=refresh_token_expires_in,json=refreshTokenExpiresIn,proto3,oneof" json:"refresh_token_expires_in,omitempty"`
+	RefreshTokenAbsoluteExpiresIn      *uint32                `protobuf:"varint,11,opt,name=refresh_token_absolute_expires_in,json=refreshTokenAbsoluteExpiresIn,proto3,oneof" json:"refresh_token_absolute_expires_in,omitempty"`
+	RefreshTokenRotation               *bool                  `protobuf:"varint,12,opt,name=refresh_token_rotation,json=refreshTokenRotation,proto3,oneof" json:"refresh_token_rotation,omitempty"`
+	DpopBoundAccessTokens              *bool                  `protobuf:"varint,13,opt,name=dpop_bound_access_tokens,json=dpopBoundAccessTokens,proto3,oneof" json:"dpop_bound_access_tokens,omitempty"`
+	BackchannelLogoutUri               *string                `protobuf:"bytes,14,opt,name=backchannel_logout_uri,json=backchannelLogoutUri,proto3,oneof" json:"backchannel_logout_uri,omitempty"`
+	RequirePushedAuthorizationRequests *bool                  `protobuf:"varint,15,opt,name=require_pushed_authorization_requests,json=requirePushedAuthorizationRequests,proto3,oneof" json:"require_pushed_authorization_requests,omitempty"`
+	LogoUrl                            *string                `protobuf:"bytes,16,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	RegistrationTokenHash              *string                `protobuf:"bytes,17,opt,name=registration_token_hash,json=registrationTokenHash,proto3,oneof" json:"registration_token_hash,omitempty"`
+	unknownFields                      protoimpl.UnknownFields
+	sizeCache                          protoimpl.SizeCache
 }
 
 func (x *CreateApplicationRequest) Reset() {
@@ -1359,20 +1422,66 @@ func (x *CreateApplicationRequest) GetRefreshTokenAbsoluteExpiresIn() uint32 {
 	return 0
 }
 
+func (x *CreateApplicationRequest) GetRefreshTokenRotation() bool {
+	if x != nil && x.RefreshTokenRotation != nil {
+		return *x.RefreshTokenRotation
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetDpopBoundAccessTokens() bool {
+	if x != nil && x.DpopBoundAccessTokens != nil {
+		return *x.DpopBoundAccessTokens
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetBackchannelLogoutUri() string {
+	if x != nil && x.BackchannelLogoutUri != nil {
+		return *x.BackchannelLogoutUri
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetRequirePushedAuthorizationRequests() bool {
+	if x != nil && x.RequirePushedAuthorizationRequests != nil {
+		return *x.RequirePushedAuthorizationRequests
+	}
+	return false
+}
+
+func (x *CreateApplicationRequest) GetLogoUrl() string {
+	if x != nil && x.LogoUrl != nil {
+		return *x.LogoUrl
+	}
+	return ""
+}
+
+func (x *CreateApplicationRequest) GetRegistrationTokenHash() string {
+	if x != nil && x.RegistrationTokenHash != nil {
+		return *x.RegistrationTokenHash
+	}
+	return ""
+}
+
 type UpdateApplicationRequest struct {
-	state                         protoimpl.MessageState `protogen:"open.v1"`
-	AppId                         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
-	Name                          *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
-	Description                   *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
-	LogoUrl                       *string                `protobuf:"bytes,4,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
-	AllowedRedirectUris           *OptionalStringList    `protobuf:"bytes,5,opt,name=allowed_redirect_uris,json=allowedRedirectUris,proto3" json:"allowed_redirect_uris,omitempty"`
-	AllowedOrigins                *OptionalStringList    `protobuf:"bytes,6,opt,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
-	AllowedLogoutUris             *OptionalStringList    `protobuf:"bytes,7,opt,name=allowed_logout_uris,json=allowedLogoutUris,proto3" json:"allowed_logout_uris,omitempty"`
-	IdTokenExpiresIn              *uint32                `protobuf:"varint,8,opt,name=id_token_expires_in,json=idTokenExpiresIn,proto3,oneof" json:"id_token_expires_in,omitempty"`
-	RefreshTokenExpiresIn         *uint32                `protobuf:"varint,9,opt,name=refresh_token_expires_in,json=refreshTokenExpiresIn,proto3,oneof" json:"refresh_token_expires_in,omitempty"`
-	RefreshTokenAbsoluteExpiresIn *uint32                `protobuf:"varint,10,opt,name=refresh_token_absolute_expires_in,json=refreshTokenAbsoluteExpiresIn,proto3,oneof" json:"refresh_token_absolute_expires_in,omitempty"`
-	unknownFields                 protoimpl.UnknownFields
-	sizeCache                     protoimpl.SizeCache
+	state                              protoimpl.MessageState `protogen:"open.v1"`
+	AppId                              string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	Name                               *string                `protobuf:"bytes,2,opt,name=name,proto3,oneof" json:"name,omitempty"`
+	Description                        *string                `protobuf:"bytes,3,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	LogoUrl                            *string                `protobuf:"bytes,4,opt,name=logo_url,json=logoUrl,proto3,oneof" json:"logo_url,omitempty"`
+	AllowedRedirectUris                *OptionalStringList    `protobuf:"bytes,5,opt,name=allowed_redirect_uris,json=allowedRedirectUris,proto3" json:"allowed_redirect_uris,omitempty"`
+	AllowedOrigins                     *OptionalStringList    `protobuf:"bytes,6,opt,name=allowed_origins,json=allowedOrigins,proto3" json:"allowed_origins,omitempty"`
+	AllowedLogoutUris                  *OptionalStringList    `protobuf:"bytes,7,opt,name=allowed_logout_uris,json=allowedLogoutUris,proto3" json:"allowed_logout_uris,omitempty"`
+	IdTokenExpiresIn                   *uint32                `protobuf:"varint,8,opt,name=id_token_expires_in,json=idTokenExpiresIn,proto3,oneof" json:"id_token_expires_in,omitempty"`
+	RefreshTokenExpiresIn              *uint32                `protobuf:"varint,9,opt,name=refresh_token_expires_in,json=refreshTokenExpiresIn,proto3,oneof" json:"refresh_token_expires_in,omitempty"`
+	RefreshTokenAbsoluteExpiresIn      *uint32                `protobuf:"varint,10,opt,name=refresh_token_absolute_expires_in,json=refreshTokenAbsoluteExpiresIn,proto3,oneof" json:"refresh_token_absolute_expires_in,omitempty"`
+	RefreshTokenRotation               *bool                  `protobuf:"varint,11,opt,name=refresh_token_rotation,json=refreshTokenRotation,proto3,oneof" json:"refresh_token_rotation,omitempty"`
+	DpopBoundAccessTokens              *bool                  `protobuf:"varint,12,opt,name=dpop_bound_access_tokens,json=dpopBoundAccessTokens,proto3,oneof" json:"dpop_bound_access_tokens,omitempty"`
+	BackchannelLogoutUri               *string                `protobuf:"bytes,13,opt,name=backchannel_logout_uri,json=backchannelLogoutUri,proto3,oneof" json:"backchannel_logout_uri,omitempty"`
+	RequirePushedAuthorizationRequests *bool                  `protobuf:"varint,14,opt,name=require_pushed_authorization_requests,json=requirePushedAuthorizationRequests,proto3,oneof" json:"require_pushed_authorization_requests,omitempty"`
+	unknownFields                      protoimpl.UnknownFields
+	sizeCache                          protoimpl.SizeCache
 }
 
 func (x *UpdateApplicationRequest) Reset() {
@@ -1475,6 +1584,78 @@ func (x *UpdateApplicationRequest) GetRefreshTokenAbsoluteExpiresIn() uint32 {
 	return 0
 }
 
+func (x *UpdateApplicationRequest) GetRefreshTokenRotation() bool {
+	if x != nil && x.RefreshTokenRotation != nil {
+		return *x.RefreshTokenRotation
+	}
+	return false
+}
+
+func (x *UpdateApplicationRequest) GetDpopBoundAccessTokens() bool {
+	if x != nil && x.DpopBoundAccessTokens != nil {
+		return *x.DpopBoundAccessTokens
+	}
+	return false
+}
+
+func (x *UpdateApplicationRequest) GetBackchannelLogoutUri() string {
+	if x != nil && x.BackchannelLogoutUri != nil {
+		return *x.BackchannelLogoutUri
+	}
+	return ""
+}
+
+func (x *UpdateApplicationRequest) GetRequirePushedAuthorizationRequests() bool {
+	if x != nil && x.RequirePushedAuthorizationRequests != nil {
+		return *x.RequirePushedAuthorizationRequests
+	}
+	return false
+}
+
+type DeleteApplicationRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	AppId         string                 `protobuf:"bytes,1,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteApplicationRequest) Reset() {
+	*x = DeleteApplicationRequest{}
+	mi := &file_hermes_v1_provision_proto_msgTypes[19]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteApplicationRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteApplicationRequest) ProtoMessage() {}
+
+func (x *DeleteApplicationRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_provision_proto_msgTypes[19]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteApplicationRequest.ProtoReflect.Descriptor instead.
+func (*DeleteApplicationRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{19}
+}
+
+func (x *DeleteApplicationRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
 // OptionalStringList 可选字符串列表（区分缺失 vs 空列表）
 type OptionalStringList struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -1486,7 +1667,7 @@ type OptionalStringList struct {
 
 func (x *OptionalStringList) Reset() {
 	*x = OptionalStringList{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[19]
+	mi := &file_hermes_v1_provision_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1498,7 +1679,7 @@ func (x *OptionalStringList) String() string {
 func (*OptionalStringList) ProtoMessage() {}
 
 func (x *OptionalStringList) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[19]
+	mi := &file_hermes_v1_provision_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1511,7 +1692,7 @@ func (x *OptionalStringList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OptionalStringList.ProtoReflect.Descriptor instead.
 func (*OptionalStringList) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{19}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *OptionalStringList) GetPresent() bool {
@@ -1539,7 +1720,7 @@ type ListApplicationsRequest struct {
 
 func (x *ListApplicationsRequest) Reset() {
 	*x = ListApplicationsRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[20]
+	mi := &file_hermes_v1_provision_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1551,7 +1732,7 @@ func (x *ListApplicationsRequest) String() string {
 func (*ListApplicationsRequest) ProtoMessage() {}
 
 func (x *ListApplicationsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[20]
+	mi := &file_hermes_v1_provision_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1564,7 +1745,7 @@ func (x *ListApplicationsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListApplicationsRequest.ProtoReflect.Descriptor instead.
 func (*ListApplicationsRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{20}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *ListApplicationsRequest) GetDomainId() string {
@@ -1597,7 +1778,7 @@ type GetServiceRequest struct {
 
 func (x *GetServiceRequest) Reset() {
 	*x = GetServiceRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[21]
+	mi := &file_hermes_v1_provision_proto_msgTypes[22]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1609,7 +1790,7 @@ func (x *GetServiceRequest) String() string {
 func (*GetServiceRequest) ProtoMessage() {}
 
 func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[21]
+	mi := &file_hermes_v1_provision_proto_msgTypes[22]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1622,7 +1803,7 @@ func (x *GetServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetServiceRequest.ProtoReflect.Descriptor instead.
 func (*GetServiceRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{21}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{22}
 }
 
 func (x *GetServiceRequest) GetServiceId() string {
@@ -1651,7 +1832,7 @@ type Service struct {
 
 func (x *Service) Reset() {
 	*x = Service{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[22]
+	mi := &file_hermes_v1_provision_proto_msgTypes[23]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1663,7 +1844,7 @@ func (x *Service) String() string {
 func (*Service) ProtoMessage() {}
 
 func (x *Service) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[22]
+	mi := &file_hermes_v1_provision_proto_msgTypes[23]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1676,7 +1857,7 @@ func (x *Service) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Service.ProtoReflect.Descriptor instead.
 func (*Service) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{22}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{23}
 }
 
 func (x *Service) GetId() uint32 {
@@ -1766,7 +1947,7 @@ type ServiceList struct {
 
 func (x *ServiceList) Reset() {
 	*x = ServiceList{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[23]
+	mi := &file_hermes_v1_provision_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1778,7 +1959,7 @@ func (x *ServiceList) String() string {
 func (*ServiceList) ProtoMessage() {}
 
 func (x *ServiceList) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[23]
+	mi := &file_hermes_v1_provision_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1791,7 +1972,7 @@ func (x *ServiceList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceList.ProtoReflect.Descriptor instead.
 func (*ServiceList) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{23}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *ServiceList) GetServices() []*Service {
@@ -1822,7 +2003,7 @@ type CreateServiceRequest struct {
 
 func (x *CreateServiceRequest) Reset() {
 	*x = CreateServiceRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[24]
+	mi := &file_hermes_v1_provision_proto_msgTypes[25]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1834,7 +2015,7 @@ func (x *CreateServiceRequest) String() string {
 func (*CreateServiceRequest) ProtoMessage() {}
 
 func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[24]
+	mi := &file_hermes_v1_provision_proto_msgTypes[25]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1847,7 +2028,7 @@ func (x *CreateServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateServiceRequest.ProtoReflect.Descriptor instead.
 func (*CreateServiceRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{24}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{25}
 }
 
 func (x *CreateServiceRequest) GetServiceId() string {
@@ -1905,7 +2086,7 @@ type UpdateServiceRequest struct {
 
 func (x *UpdateServiceRequest) Reset() {
 	*x = UpdateServiceRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[25]
+	mi := &file_hermes_v1_provision_proto_msgTypes[26]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1917,7 +2098,7 @@ func (x *UpdateServiceRequest) String() string {
 func (*UpdateServiceRequest) ProtoMessage() {}
 
 func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[25]
+	mi := &file_hermes_v1_provision_proto_msgTypes[26]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1930,7 +2111,7 @@ func (x *UpdateServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateServiceRequest.ProtoReflect.Descriptor instead.
 func (*UpdateServiceRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{25}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{26}
 }
 
 func (x *UpdateServiceRequest) GetServiceId() string {
@@ -1977,7 +2158,7 @@ type DeleteServiceRequest struct {
 
 func (x *DeleteServiceRequest) Reset() {
 	*x = DeleteServiceRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[26]
+	mi := &file_hermes_v1_provision_proto_msgTypes[27]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1989,7 +2170,7 @@ func (x *DeleteServiceRequest) String() string {
 func (*DeleteServiceRequest) ProtoMessage() {}
 
 func (x *DeleteServiceRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[26]
+	mi := &file_hermes_v1_provision_proto_msgTypes[27]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2002,7 +2183,7 @@ func (x *DeleteServiceRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteServiceRequest.ProtoReflect.Descriptor instead.
 func (*DeleteServiceRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{26}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{27}
 }
 
 func (x *DeleteServiceRequest) GetServiceId() string {
@@ -2023,7 +2204,7 @@ type ListServicesRequest struct {
 
 func (x *ListServicesRequest) Reset() {
 	*x = ListServicesRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[27]
+	mi := &file_hermes_v1_provision_proto_msgTypes[28]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2035,7 +2216,7 @@ func (x *ListServicesRequest) String() string {
 func (*ListServicesRequest) ProtoMessage() {}
 
 func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[27]
+	mi := &file_hermes_v1_provision_proto_msgTypes[28]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2048,7 +2229,7 @@ func (x *ListServicesRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListServicesRequest.ProtoReflect.Descriptor instead.
 func (*ListServicesRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{27}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{28}
 }
 
 func (x *ListServicesRequest) GetDomainId() string {
@@ -2082,7 +2263,7 @@ type GetServiceChallengeSettingRequest struct {
 
 func (x *GetServiceChallengeSettingRequest) Reset() {
 	*x = GetServiceChallengeSettingRequest{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[28]
+	mi := &file_hermes_v1_provision_proto_msgTypes[29]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2094,7 +2275,7 @@ func (x *GetServiceChallengeSettingRequest) String() string {
 func (*GetServiceChallengeSettingRequest) ProtoMessage() {}
 
 func (x *GetServiceChallengeSettingRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[28]
+	mi := &file_hermes_v1_provision_proto_msgTypes[29]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2107,7 +2288,7 @@ func (x *GetServiceChallengeSettingRequest) ProtoReflect() protoreflect.Message
 
 // Deprecated: Use GetServiceChallengeSettingRequest.ProtoReflect.Descriptor instead.
 func (*GetServiceChallengeSettingRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{28}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{29}
 }
 
 func (x *GetServiceChallengeSettingRequest) GetServiceId() string {
@@ -2139,7 +2320,7 @@ type ServiceChallengeSetting struct {
 
 func (x *ServiceChallengeSetting) Reset() {
 	*x = ServiceChallengeSetting{}
-	mi := &file_hermes_v1_provision_proto_msgTypes[29]
+	mi := &file_hermes_v1_provision_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -2151,7 +2332,7 @@ func (x *ServiceChallengeSetting) String() string {
 func (*ServiceChallengeSetting) ProtoMessage() {}
 
 func (x *ServiceChallengeSetting) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_provision_proto_msgTypes[29]
+	mi := &file_hermes_v1_provision_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -2164,7 +2345,7 @@ func (x *ServiceChallengeSetting) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ServiceChallengeSetting.ProtoReflect.Descriptor instead.
 func (*ServiceChallengeSetting) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{29}
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *ServiceChallengeSetting) GetId() uint32 {
@@ -2216,6 +2397,142 @@ func (x *ServiceChallengeSetting) GetUpdatedAt() *timestamppb.Timestamp {
 	return nil
 }
 
+type ServiceAuthorizationDetailType struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	ServiceId     string                 `protobuf:"bytes,2,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Type          string                 `protobuf:"bytes,3,opt,name=type,proto3" json:"type,omitempty"`
+	Description   *string                `protobuf:"bytes,4,opt,name=description,proto3,oneof" json:"description,omitempty"`
+	JsonSchema    *string                `protobuf:"bytes,5,opt,name=json_schema,json=jsonSchema,proto3,oneof" json:"json_schema,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceAuthorizationDetailType) Reset() {
+	*x = ServiceAuthorizationDetailType{}
+	mi := &file_hermes_v1_provision_proto_msgTypes[31]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceAuthorizationDetailType) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceAuthorizationDetailType) ProtoMessage() {}
+
+func (x *ServiceAuthorizationDetailType) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_provision_proto_msgTypes[31]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceAuthorizationDetailType.ProtoReflect.Descriptor instead.
+func (*ServiceAuthorizationDetailType) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{31}
+}
+
+func (x *ServiceAuthorizationDetailType) GetId() uint32 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *ServiceAuthorizationDetailType) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *ServiceAuthorizationDetailType) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ServiceAuthorizationDetailType) GetDescription() string {
+	if x != nil && x.Description != nil {
+		return *x.Description
+	}
+	return ""
+}
+
+func (x *ServiceAuthorizationDetailType) GetJsonSchema() string {
+	if x != nil && x.JsonSchema != nil {
+		return *x.JsonSchema
+	}
+	return ""
+}
+
+func (x *ServiceAuthorizationDetailType) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *ServiceAuthorizationDetailType) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type ServiceAuthorizationDetailTypeList struct {
+	state         protoimpl.MessageState            `protogen:"open.v1"`
+	Types         []*ServiceAuthorizationDetailType `protobuf:"bytes,1,rep,name=types,proto3" json:"types,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ServiceAuthorizationDetailTypeList) Reset() {
+	*x = ServiceAuthorizationDetailTypeList{}
+	mi := &file_hermes_v1_provision_proto_msgTypes[32]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ServiceAuthorizationDetailTypeList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ServiceAuthorizationDetailTypeList) ProtoMessage() {}
+
+func (x *ServiceAuthorizationDetailTypeList) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_provision_proto_msgTypes[32]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ServiceAuthorizationDetailTypeList.ProtoReflect.Descriptor instead.
+func (*ServiceAuthorizationDetailTypeList) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_provision_proto_rawDescGZIP(), []int{32}
+}
+
+func (x *ServiceAuthorizationDetailTypeList) GetTypes() []*ServiceAuthorizationDetailType {
+	if x != nil {
+		return x.Types
+	}
+	return nil
+}
+
 var File_hermes_v1_provision_proto protoreflect.FileDescriptor
 
 const file_hermes_v1_provision_proto_rawDesc = "" +
@@ -2240,7 +2557,7 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
 	"\vdescription\x18\x03 \x01(\tH\x01R\vdescription\x88\x01\x01B\a\n" +
 	"\x05_nameB\x0e\n" +
-	"\f_description\"\xeb\x02\n" +
+	"\f_description\"\x93\x03\n" +
 	"\x0fDomainIDPConfig\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
 	"\tdomain_id\x18\x02 \x01(\tR\bdomainId\x12\x12\n" +
@@ -2252,11 +2569,14 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\v\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\x06config\x18\n" +
+	" \x01(\tH\x03R\x06config\x88\x01\x01B\v\n" +
 	"\t_strategyB\v\n" +
 	"\t_delegateB\n" +
 	"\n" +
-	"\b_require\"K\n" +
+	"\b_requireB\t\n" +
+	"\a_config\"K\n" +
 	"\x13DomainIDPConfigList\x124\n" +
 	"\aconfigs\x18\x01 \x03(\v2\x1a.hermes.v1.DomainIDPConfigR\aconfigs\"\xf2\x01\n" +
 	"\x1cCreateDomainIDPConfigRequest\x12\x1b\n" +
@@ -2284,7 +2604,7 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\b_require\"O\n" +
 	"\x1cDeleteDomainIDPConfigRequest\x12\x1b\n" +
 	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x12\n" +
-	"\x04type\x18\x02 \x01(\tR\x04type\"\xea\x02\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\"\x92\x03\n" +
 	"\x14ApplicationIDPConfig\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x15\n" +
 	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x12\n" +
@@ -2296,11 +2616,14 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\v\n" +
+	"updated_at\x18\t \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12\x1b\n" +
+	"\x06config\x18\n" +
+	" \x01(\tH\x03R\x06config\x88\x01\x01B\v\n" +
 	"\t_strategyB\v\n" +
 	"\t_delegateB\n" +
 	"\n" +
-	"\b_require\"U\n" +
+	"\b_requireB\t\n" +
+	"\a_config\"U\n" +
 	"\x18ApplicationIDPConfigList\x129\n" +
 	"\aconfigs\x18\x01 \x03(\v2\x1f.hermes.v1.ApplicationIDPConfigR\aconfigs\"\xf1\x01\n" +
 	"!CreateApplicationIDPConfigRequest\x12\x15\n" +
@@ -2330,7 +2653,7 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x12\n" +
 	"\x04type\x18\x02 \x01(\tR\x04type\".\n" +
 	"\x15GetApplicationRequest\x12\x15\n" +
-	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xfe\x04\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"\xef\a\n" +
 	"\vApplication\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1b\n" +
 	"\tdomain_id\x18\x02 \x01(\tR\bdomainId\x12\x15\n" +
@@ -2348,13 +2671,20 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\n" +
 	"created_at\x18\r \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
 	"\n" +
-	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"updated_at\x18\x0e \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x124\n" +
+	"\x16refresh_token_rotation\x18\x0f \x01(\bR\x14refreshTokenRotation\x127\n" +
+	"\x18dpop_bound_access_tokens\x18\x10 \x01(\bR\x15dpopBoundAccessTokens\x129\n" +
+	"\x16backchannel_logout_uri\x18\x11 \x01(\tH\x02R\x14backchannelLogoutUri\x88\x01\x01\x12Q\n" +
+	"%require_pushed_authorization_requests\x18\x12 \x01(\bR\"requirePushedAuthorizationRequests\x12;\n" +
+	"\x17registration_token_hash\x18\x13 \x01(\tH\x03R\x15registrationTokenHash\x88\x01\x01B\x0e\n" +
 	"\f_descriptionB\v\n" +
-	"\t_logo_url\"n\n" +
+	"\t_logo_urlB\x19\n" +
+	"\x17_backchannel_logout_uriB\x1a\n" +
+	"\x18_registration_token_hash\"n\n" +
 	"\x0fApplicationList\x12:\n" +
 	"\fapplications\x18\x01 \x03(\v2\x16.hermes.v1.ApplicationR\fapplications\x12\x1f\n" +
 	"\vnext_cursor\x18\x02 \x01(\tR\n" +
-	"nextCursor\"\xd8\x04\n" +
+	"nextCursor\"\xe7\b\n" +
 	"\x18CreateApplicationRequest\x12\x1b\n" +
 	"\tdomain_id\x18\x01 \x01(\tR\bdomainId\x12\x1a\n" +
 	"\x06app_id\x18\x02 \x01(\tH\x00R\x05appId\x88\x01\x01\x12\x12\n" +
@@ -2367,11 +2697,23 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\x13id_token_expires_in\x18\t \x01(\rH\x01R\x10idTokenExpiresIn\x88\x01\x01\x12<\n" +
 	"\x18refresh_token_expires_in\x18\n" +
 	" \x01(\rH\x02R\x15refreshTokenExpiresIn\x88\x01\x01\x12M\n" +
-	"!refresh_token_absolute_expires_in\x18\v \x01(\rH\x03R\x1drefreshTokenAbsoluteExpiresIn\x88\x01\x01B\t\n" +
+	"!refresh_token_absolute_expires_in\x18\v \x01(\rH\x03R\x1drefreshTokenAbsoluteExpiresIn\x88\x01\x01\x129\n" +
+	"\x16refresh_token_rotation\x18\f \x01(\bH\x04R\x14refreshTokenRotation\x88\x01\x01\x12<\n" +
+	"\x18dpop_bound_access_tokens\x18\r \x01(\bH\x05R\x15dpopBoundAccessTokens\x88\x01\x01\x129\n" +
+	"\x16backchannel_logout_uri\x18\x0e \x01(\tH\x06R\x14backchannelLogoutUri\x88\x01\x01\x12V\n" +
+	"%require_pushed_authorization_requests\x18\x0f \x01(\bH\aR\"requirePushedAuthorizationRequests\x88\x01\x01\x12\x1e\n" +
+	"\blogo_url\x18\x10 \x01(\tH\bR\alogoUrl\x88\x01\x01\x12;\n" +
+	"\x17registration_token_hash\x18\x11 \x01(\tH\tR\x15registrationTokenHash\x88\x01\x01B\t\n" +
 	"\a_app_idB\x16\n" +
 	"\x14_id_token_expires_inB\x1b\n" +
 	"\x19_refresh_token_expires_inB$\n" +
-	"\"_refresh_token_absolute_expires_in\"\xbd\x05\n" +
+	"\"_refresh_token_absolute_expires_inB\x19\n" +
+	"\x17_refresh_token_rotationB\x1b\n" +
+	"\x19_dpop_bound_access_tokensB\x19\n" +
+	"\x17_backchannel_logout_uriB(\n" +
+	"&_require_pushed_authorization_requestsB\v\n" +
+	"\t_logo_urlB\x1a\n" +
+	"\x18_registration_token_hash\"\xc6\b\n" +
 	"\x18UpdateApplicationRequest\x12\x15\n" +
 	"\x06app_id\x18\x01 \x01(\tR\x05appId\x12\x17\n" +
 	"\x04name\x18\x02 \x01(\tH\x00R\x04name\x88\x01\x01\x12%\n" +
@@ -2383,13 +2725,23 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\x13id_token_expires_in\x18\b \x01(\rH\x03R\x10idTokenExpiresIn\x88\x01\x01\x12<\n" +
 	"\x18refresh_token_expires_in\x18\t \x01(\rH\x04R\x15refreshTokenExpiresIn\x88\x01\x01\x12M\n" +
 	"!refresh_token_absolute_expires_in\x18\n" +
-	" \x01(\rH\x05R\x1drefreshTokenAbsoluteExpiresIn\x88\x01\x01B\a\n" +
+	" \x01(\rH\x05R\x1drefreshTokenAbsoluteExpiresIn\x88\x01\x01\x129\n" +
+	"\x16refresh_token_rotation\x18\v \x01(\bH\x06R\x14refreshTokenRotation\x88\x01\x01\x12<\n" +
+	"\x18dpop_bound_access_tokens\x18\f \x01(\bH\aR\x15dpopBoundAccessTokens\x88\x01\x01\x129\n" +
+	"\x16backchannel_logout_uri\x18\r \x01(\tH\bR\x14backchannelLogoutUri\x88\x01\x01\x12V\n" +
+	"%require_pushed_authorization_requests\x18\x0e \x01(\bH\tR\"requirePushedAuthorizationRequests\x88\x01\x01B\a\n" +
 	"\x05_nameB\x0e\n" +
 	"\f_descriptionB\v\n" +
 	"\t_logo_urlB\x16\n" +
 	"\x14_id_token_expires_inB\x1b\n" +
 	"\x19_refresh_token_expires_inB$\n" +
-	"\"_refresh_token_absolute_expires_in\"F\n" +
+	"\"_refresh_token_absolute_expires_inB\x19\n" +
+	"\x17_refresh_token_rotationB\x1b\n" +
+	"\x19_dpop_bound_access_tokensB\x19\n" +
+	"\x17_backchannel_logout_uriB(\n" +
+	"&_require_pushed_authorization_requests\"1\n" +
+	"\x18DeleteApplicationRequest\x12\x15\n" +
+	"\x06app_id\x18\x01 \x01(\tR\x05appId\"F\n" +
 	"\x12OptionalStringList\x12\x18\n" +
 	"\apresent\x18\x01 \x01(\bR\apresent\x12\x16\n" +
 	"\x06values\x18\x02 \x03(\tR\x06values\"\x85\x01\n" +
@@ -2472,7 +2824,23 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x1a9\n" +
 	"\vLimitsEntry\x12\x10\n" +
 	"\x03key\x18\x01 \x01(\tR\x03key\x12\x14\n" +
-	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x012\xed\r\n" +
+	"\x05value\x18\x02 \x01(\x05R\x05value:\x028\x01\"\xc6\x02\n" +
+	"\x1eServiceAuthorizationDetailType\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\rR\x02id\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x02 \x01(\tR\tserviceId\x12\x12\n" +
+	"\x04type\x18\x03 \x01(\tR\x04type\x12%\n" +
+	"\vdescription\x18\x04 \x01(\tH\x00R\vdescription\x88\x01\x01\x12$\n" +
+	"\vjson_schema\x18\x05 \x01(\tH\x01R\n" +
+	"jsonSchema\x88\x01\x01\x129\n" +
+	"\n" +
+	"created_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAtB\x0e\n" +
+	"\f_descriptionB\x0e\n" +
+	"\f_json_schema\"e\n" +
+	"\"ServiceAuthorizationDetailTypeList\x12?\n" +
+	"\x05types\x18\x01 \x03(\v2).hermes.v1.ServiceAuthorizationDetailTypeR\x05types2\xb3\x0f\n" +
 	"\x10ProvisionService\x12;\n" +
 	"\tGetDomain\x12\x1b.hermes.v1.GetDomainRequest\x1a\x11.hermes.v1.Domain\x12<\n" +
 	"\vListDomains\x12\x16.google.protobuf.Empty\x1a\x15.hermes.v1.DomainList\x12A\n" +
@@ -2484,7 +2852,8 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\x11CreateApplication\x12#.hermes.v1.CreateApplicationRequest\x1a\x16.hermes.v1.Application\x12J\n" +
 	"\x0eGetApplication\x12 .hermes.v1.GetApplicationRequest\x1a\x16.hermes.v1.Application\x12R\n" +
 	"\x10ListApplications\x12\".hermes.v1.ListApplicationsRequest\x1a\x1a.hermes.v1.ApplicationList\x12P\n" +
-	"\x11UpdateApplication\x12#.hermes.v1.UpdateApplicationRequest\x1a\x16.hermes.v1.Application\x12a\n" +
+	"\x11UpdateApplication\x12#.hermes.v1.UpdateApplicationRequest\x1a\x16.hermes.v1.Application\x12P\n" +
+	"\x11DeleteApplication\x12#.hermes.v1.DeleteApplicationRequest\x1a\x16.google.protobuf.Empty\x12a\n" +
 	"\x18GetApplicationIDPConfigs\x12 .hermes.v1.GetApplicationRequest\x1a#.hermes.v1.ApplicationIDPConfigList\x12k\n" +
 	"\x1aCreateApplicationIDPConfig\x12,.hermes.v1.CreateApplicationIDPConfigRequest\x1a\x1f.hermes.v1.ApplicationIDPConfig\x12k\n" +
 	"\x1aUpdateApplicationIDPConfig\x12,.hermes.v1.UpdateApplicationIDPConfigRequest\x1a\x1f.hermes.v1.ApplicationIDPConfig\x12b\n" +
@@ -2495,7 +2864,8 @@ const file_hermes_v1_provision_proto_rawDesc = "" +
 	"\fListServices\x12\x1e.hermes.v1.ListServicesRequest\x1a\x16.hermes.v1.ServiceList\x12D\n" +
 	"\rUpdateService\x12\x1f.hermes.v1.UpdateServiceRequest\x1a\x12.hermes.v1.Service\x12H\n" +
 	"\rDeleteService\x12\x1f.hermes.v1.DeleteServiceRequest\x1a\x16.google.protobuf.Empty\x12n\n" +
-	"\x1aGetServiceChallengeSetting\x12,.hermes.v1.GetServiceChallengeSettingRequest\x1a\".hermes.v1.ServiceChallengeSettingB\xa1\x01\n" +
+	"\x1aGetServiceChallengeSetting\x12,.hermes.v1.GetServiceChallengeSettingRequest\x1a\".hermes.v1.ServiceChallengeSetting\x12r\n" +
+	"#ListServiceAuthorizationDetailTypes\x12\x1c.hermes.v1.GetServiceRequest\x1a-.hermes.v1.ServiceAuthorizationDetailTypeListB\xa1\x01\n" +
 	"\rcom.hermes.v1B\x0eProvisionProtoP\x01Z;github.com/heliannuuthus/proto/gen/proto/hermes/v1;hermesv1\xa2\x02\x03HXX\xaa\x02\tHermes.V1\xca\x02\tHermes\\V1\xe2\x02\x15Hermes\\V1\\GPBMetadata\xea\x02\n" +
 	"Hermes::V1b\x06proto3"
 
@@ -2511,115 +2881,125 @@ func file_hermes_v1_provision_proto_rawDescGZIP() []byte {
 	return file_hermes_v1_provision_proto_rawDescData
 }
 
-var file_hermes_v1_provision_proto_msgTypes = make([]protoimpl.MessageInfo, 31)
+var file_hermes_v1_provision_proto_msgTypes = make([]protoimpl.MessageInfo, 34)
 var file_hermes_v1_provision_proto_goTypes = []any{
-	(*GetDomainRequest)(nil),                  // 0: hermes.v1.GetDomainRequest
-	(*Domain)(nil),                            // 1: hermes.v1.Domain
-	(*DomainList)(nil),                        // 2: hermes.v1.DomainList
-	(*UpdateDomainRequest)(nil),               // 3: hermes.v1.UpdateDomainRequest
-	(*DomainIDPConfig)(nil),                   // 4: hermes.v1.DomainIDPConfig
-	(*DomainIDPConfigList)(nil),               // 5: hermes.v1.DomainIDPConfigList
-	(*CreateDomainIDPConfigRequest)(nil),      // 6: hermes.v1.CreateDomainIDPConfigRequest
-	(*UpdateDomainIDPConfigRequest)(nil),      // 7: hermes.v1.UpdateDomainIDPConfigRequest
-	(*DeleteDomainIDPConfigRequest)(nil),      // 8: hermes.v1.DeleteDomainIDPConfigRequest
-	(*ApplicationIDPConfig)(nil),              // 9: hermes.v1.ApplicationIDPConfig
-	(*ApplicationIDPConfigList)(nil),          // 10: hermes.v1.ApplicationIDPConfigList
-	(*CreateApplicationIDPConfigRequest)(nil), // 11: hermes.v1.CreateApplicationIDPConfigRequest
-	(*UpdateApplicationIDPConfigRequest)(nil), // 12: hermes.v1.UpdateApplicationIDPConfigRequest
-	(*DeleteApplicationIDPConfigRequest)(nil), // 13: hermes.v1.DeleteApplicationIDPConfigRequest
-	(*GetApplicationRequest)(nil),             // 14: hermes.v1.GetApplicationRequest
-	(*Application)(nil),                       // 15: hermes.v1.Application
-	(*ApplicationList)(nil),                   // 16: hermes.v1.ApplicationList
-	(*CreateApplicationRequest)(nil),          // 17: hermes.v1.CreateApplicationRequest
-	(*UpdateApplicationRequest)(nil),          // 18: hermes.v1.UpdateApplicationRequest
-	(*OptionalStringList)(nil),                // 19: hermes.v1.OptionalStringList
-	(*ListApplicationsRequest)(nil),           // 20: hermes.v1.ListApplicationsRequest
-	(*GetServiceRequest)(nil),                 // 21: hermes.v1.GetServiceRequest
-	(*Service)(nil),                           // 22: hermes.v1.Service
-	(*ServiceList)(nil),                       // 23: hermes.v1.ServiceList
-	(*CreateServiceRequest)(nil),              // 24: hermes.v1.CreateServiceRequest
-	(*UpdateServiceRequest)(nil),              // 25: hermes.v1.UpdateServiceRequest
-	(*DeleteServiceRequest)(nil),              // 26: hermes.v1.DeleteServiceRequest
-	(*ListServicesRequest)(nil),               // 27: hermes.v1.ListServicesRequest
-	(*GetServiceChallengeSettingRequest)(nil), // 28: hermes.v1.GetServiceChallengeSettingRequest
-	(*ServiceChallengeSetting)(nil),           // 29: hermes.v1.ServiceChallengeSetting
-	nil,                                       // 30: hermes.v1.ServiceChallengeSetting.LimitsEntry
-	(*timestamppb.Timestamp)(nil),             // 31: google.protobuf.Timestamp
-	(*Pagination)(nil),                        // 32: hermes.v1.Pagination
-	(*emptypb.Empty)(nil),                     // 33: google.protobuf.Empty
+	(*GetDomainRequest)(nil),                   // 0: hermes.v1.GetDomainRequest
+	(*Domain)(nil),                             // 1: hermes.v1.Domain
+	(*DomainList)(nil),                         // 2: hermes.v1.DomainList
+	(*UpdateDomainRequest)(nil),                // 3: hermes.v1.UpdateDomainRequest
+	(*DomainIDPConfig)(nil),                    // 4: hermes.v1.DomainIDPConfig
+	(*DomainIDPConfigList)(nil),                // 5: hermes.v1.DomainIDPConfigList
+	(*CreateDomainIDPConfigRequest)(nil),       // 6: hermes.v1.CreateDomainIDPConfigRequest
+	(*UpdateDomainIDPConfigRequest)(nil),       // 7: hermes.v1.UpdateDomainIDPConfigRequest
+	(*DeleteDomainIDPConfigRequest)(nil),       // 8: hermes.v1.DeleteDomainIDPConfigRequest
+	(*ApplicationIDPConfig)(nil),               // 9: hermes.v1.ApplicationIDPConfig
+	(*ApplicationIDPConfigList)(nil),           // 10: hermes.v1.ApplicationIDPConfigList
+	(*CreateApplicationIDPConfigRequest)(nil),  // 11: hermes.v1.CreateApplicationIDPConfigRequest
+	(*UpdateApplicationIDPConfigRequest)(nil),  // 12: hermes.v1.UpdateApplicationIDPConfigRequest
+	(*DeleteApplicationIDPConfigRequest)(nil),  // 13: hermes.v1.DeleteApplicationIDPConfigRequest
+	(*GetApplicationRequest)(nil),              // 14: hermes.v1.GetApplicationRequest
+	(*Application)(nil),                        // 15: hermes.v1.Application
+	(*ApplicationList)(nil),                    // 16: hermes.v1.ApplicationList
+	(*CreateApplicationRequest)(nil),           // 17: hermes.v1.CreateApplicationRequest
+	(*UpdateApplicationRequest)(nil),           // 18: hermes.v1.UpdateApplicationRequest
+	(*DeleteApplicationRequest)(nil),           // 19: hermes.v1.DeleteApplicationRequest
+	(*OptionalStringList)(nil),                 // 20: hermes.v1.OptionalStringList
+	(*ListApplicationsRequest)(nil),            // 21: hermes.v1.ListApplicationsRequest
+	(*GetServiceRequest)(nil),                  // 22: hermes.v1.GetServiceRequest
+	(*Service)(nil),                            // 23: hermes.v1.Service
+	(*ServiceList)(nil),                        // 24: hermes.v1.ServiceList
+	(*CreateServiceRequest)(nil),               // 25: hermes.v1.CreateServiceRequest
+	(*UpdateServiceRequest)(nil),               // 26: hermes.v1.UpdateServiceRequest
+	(*DeleteServiceRequest)(nil),               // 27: hermes.v1.DeleteServiceRequest
+	(*ListServicesRequest)(nil),                // 28: hermes.v1.ListServicesRequest
+	(*GetServiceChallengeSettingRequest)(nil),  // 29: hermes.v1.GetServiceChallengeSettingRequest
+	(*ServiceChallengeSetting)(nil),            // 30: hermes.v1.ServiceChallengeSetting
+	(*ServiceAuthorizationDetailType)(nil),     // 31: hermes.v1.ServiceAuthorizationDetailType
+	(*ServiceAuthorizationDetailTypeList)(nil), // 32: hermes.v1.ServiceAuthorizationDetailTypeList
+	nil,                           // 33: hermes.v1.ServiceChallengeSetting.LimitsEntry
+	(*timestamppb.Timestamp)(nil), // 34: google.protobuf.Timestamp
+	(*Pagination)(nil),            // 35: hermes.v1.Pagination
+	(*emptypb.Empty)(nil),         // 36: google.protobuf.Empty
 }
 var file_hermes_v1_provision_proto_depIdxs = []int32{
-	31, // 0: hermes.v1.Domain.created_at:type_name -> google.protobuf.Timestamp
-	31, // 1: hermes.v1.Domain.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 0: hermes.v1.Domain.created_at:type_name -> google.protobuf.Timestamp
+	34, // 1: hermes.v1.Domain.updated_at:type_name -> google.protobuf.Timestamp
 	1,  // 2: hermes.v1.DomainList.domains:type_name -> hermes.v1.Domain
-	31, // 3: hermes.v1.DomainIDPConfig.created_at:type_name -> google.protobuf.Timestamp
-	31, // 4: hermes.v1.DomainIDPConfig.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 3: hermes.v1.DomainIDPConfig.created_at:type_name -> google.protobuf.Timestamp
+	34, // 4: hermes.v1.DomainIDPConfig.updated_at:type_name -> google.protobuf.Timestamp
 	4,  // 5: hermes.v1.DomainIDPConfigList.configs:type_name -> hermes.v1.DomainIDPConfig
-	31, // 6: hermes.v1.ApplicationIDPConfig.created_at:type_name -> google.protobuf.Timestamp
-	31, // 7: hermes.v1.ApplicationIDPConfig.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 6: hermes.v1.ApplicationIDPConfig.created_at:type_name -> google.protobuf.Timestamp
+	34, // 7: hermes.v1.ApplicationIDPConfig.updated_at:type_name -> google.protobuf.Timestamp
 	9,  // 8: hermes.v1.ApplicationIDPConfigList.configs:type_name -> hermes.v1.ApplicationIDPConfig
-	31, // 9: hermes.v1.Application.created_at:type_name -> google.protobuf.Timestamp
-	31, // 10: hermes.v1.Application.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 9: hermes.v1.Application.created_at:type_name -> google.protobuf.Timestamp
+	34, // 10: hermes.v1.Application.updated_at:type_name -> google.protobuf.Timestamp
 	15, // 11: hermes.v1.ApplicationList.applications:type_name -> hermes.v1.Application
-	19, // 12: hermes.v1.UpdateApplicationRequest.allowed_redirect_uris:type_name -> hermes.v1.OptionalStringList
-	19, // 13: hermes.v1.UpdateApplicationRequest.allowed_origins:type_name -> hermes.v1.OptionalStringList
-	19, // 14: hermes.v1.UpdateApplicationRequest.allowed_logout_uris:type_name -> hermes.v1.OptionalStringList
-	32, // 15: hermes.v1.ListApplicationsRequest.pagination:type_name -> hermes.v1.Pagination
-	31, // 16: hermes.v1.Service.created_at:type_name -> google.protobuf.Timestamp
-	31, // 17: hermes.v1.Service.updated_at:type_name -> google.protobuf.Timestamp
-	29, // 18: hermes.v1.Service.challenge_settings:type_name -> hermes.v1.ServiceChallengeSetting
-	22, // 19: hermes.v1.ServiceList.services:type_name -> hermes.v1.Service
-	32, // 20: hermes.v1.ListServicesRequest.pagination:type_name -> hermes.v1.Pagination
-	30, // 21: hermes.v1.ServiceChallengeSetting.limits:type_name -> hermes.v1.ServiceChallengeSetting.LimitsEntry
-	31, // 22: hermes.v1.ServiceChallengeSetting.created_at:type_name -> google.protobuf.Timestamp
-	31, // 23: hermes.v1.ServiceChallengeSetting.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 24: hermes.v1.ProvisionService.GetDomain:input_type -> hermes.v1.GetDomainRequest
-	33, // 25: hermes.v1.ProvisionService.ListDomains:input_type -> google.protobuf.Empty
-	3,  // 26: hermes.v1.ProvisionService.UpdateDomain:input_type -> hermes.v1.UpdateDomainRequest
-	0,  // 27: hermes.v1.ProvisionService.GetDomainIDPConfigs:input_type -> hermes.v1.GetDomainRequest
-	6,  // 28: hermes.v1.ProvisionService.CreateDomainIDPConfig:input_type -> hermes.v1.CreateDomainIDPConfigRequest
-	7,  // 29: hermes.v1.ProvisionService.UpdateDomainIDPConfig:input_type -> hermes.v1.UpdateDomainIDPConfigRequest
-	8,  // 30: hermes.v1.ProvisionService.DeleteDomainIDPConfig:input_type -> hermes.v1.DeleteDomainIDPConfigRequest
-	17, // 31: hermes.v1.ProvisionService.CreateApplication:input_type -> hermes.v1.CreateApplicationRequest
-	14, // 32: hermes.v1.ProvisionService.GetApplication:input_type -> hermes.v1.GetApplicationRequest
-	20, // 33: hermes.v1.ProvisionService.ListApplications:input_type -> hermes.v1.ListApplicationsRequest
-	18, // 34: hermes.v1.ProvisionService.UpdateApplication:input_type -> hermes.v1.UpdateApplicationRequest
-	14, // 35: hermes.v1.ProvisionService.GetApplicationIDPConfigs:input_type -> hermes.v1.GetApplicationRequest
-	11, // 36: hermes.v1.ProvisionService.CreateApplicationIDPConfig:input_type -> hermes.v1.CreateApplicationIDPConfigRequest
-	12, // 37: hermes.v1.ProvisionService.UpdateApplicationIDPConfig:input_type -> hermes.v1.UpdateApplicationIDPConfigRequest
-	13, // 38: hermes.v1.ProvisionService.DeleteApplicationIDPConfig:input_type -> hermes.v1.DeleteApplicationIDPConfigRequest
-	24, // 39: hermes.v1.ProvisionService.CreateService:input_type -> hermes.v1.CreateServiceRequest
-	21, // 40: hermes.v1.ProvisionService.GetService:input_type -> hermes.v1.GetServiceRequest
-	27, // 41: hermes.v1.ProvisionService.ListServices:input_type -> hermes.v1.ListServicesRequest
-	25, // 42: hermes.v1.ProvisionService.UpdateService:input_type -> hermes.v1.UpdateServiceRequest
-	26, // 43: hermes.v1.ProvisionService.DeleteService:input_type -> hermes.v1.DeleteServiceRequest
-	28, // 44: hermes.v1.ProvisionService.GetServiceChallengeSetting:input_type -> hermes.v1.GetServiceChallengeSettingRequest
-	1,  // 45: hermes.v1.ProvisionService.GetDomain:output_type -> hermes.v1.Domain
-	2,  // 46: hermes.v1.ProvisionService.ListDomains:output_type -> hermes.v1.DomainList
-	1,  // 47: hermes.v1.ProvisionService.UpdateDomain:output_type -> hermes.v1.Domain
-	5,  // 48: hermes.v1.ProvisionService.GetDomainIDPConfigs:output_type -> hermes.v1.DomainIDPConfigList
-	4,  // 49: hermes.v1.ProvisionService.CreateDomainIDPConfig:output_type -> hermes.v1.DomainIDPConfig
-	4,  // 50: hermes.v1.ProvisionService.UpdateDomainIDPConfig:output_type -> hermes.v1.DomainIDPConfig
-	33, // 51: hermes.v1.ProvisionService.DeleteDomainIDPConfig:output_type -> google.protobuf.Empty
-	15, // 52: hermes.v1.ProvisionService.CreateApplication:output_type -> hermes.v1.Application
-	15, // 53: hermes.v1.ProvisionService.GetApplication:output_type -> hermes.v1.Application
-	16, // 54: hermes.v1.ProvisionService.ListApplications:output_type -> hermes.v1.ApplicationList
-	15, // 55: hermes.v1.ProvisionService.UpdateApplication:output_type -> hermes.v1.Application
-	10, // 56: hermes.v1.ProvisionService.GetApplicationIDPConfigs:output_type -> hermes.v1.ApplicationIDPConfigList
-	9,  // 57: hermes.v1.ProvisionService.CreateApplicationIDPConfig:output_type -> hermes.v1.ApplicationIDPConfig
-	9,  // 58: hermes.v1.ProvisionService.UpdateApplicationIDPConfig:output_type -> hermes.v1.ApplicationIDPConfig
-	33, // 59: hermes.v1.ProvisionService.DeleteApplicationIDPConfig:output_type -> google.protobuf.Empty
-	22, // 60: hermes.v1.ProvisionService.CreateService:output_type -> hermes.v1.Service
-	22, // 61: hermes.v1.ProvisionService.GetService:output_type -> hermes.v1.Service
-	23, // 62: hermes.v1.ProvisionService.ListServices:output_type -> hermes.v1.ServiceList
-	22, // 63: hermes.v1.ProvisionService.UpdateService:output_type -> hermes.v1.Service
-	33, // 64: hermes.v1.ProvisionService.DeleteService:output_type -> google.protobuf.Empty
-	29, // 65: hermes.v1.ProvisionService.GetServiceChallengeSetting:output_type -> hermes.v1.ServiceChallengeSetting
-	45, // [45:66] is the sub-list for method output_type
-	24, // [24:45] is the sub-list for method input_type
-	24, // [24:24] is the sub-list for extension type_name
-	24, // [24:24] is the sub-list for extension extendee
-	0,  // [0:24] is the sub-list for field type_name
+	20, // 12: hermes.v1.UpdateApplicationRequest.allowed_redirect_uris:type_name -> hermes.v1.OptionalStringList
+	20, // 13: hermes.v1.UpdateApplicationRequest.allowed_origins:type_name -> hermes.v1.OptionalStringList
+	20, // 14: hermes.v1.UpdateApplicationRequest.allowed_logout_uris:type_name -> hermes.v1.OptionalStringList
+	35, // 15: hermes.v1.ListApplicationsRequest.pagination:type_name -> hermes.v1.Pagination
+	34, // 16: hermes.v1.Service.created_at:type_name -> google.protobuf.Timestamp
+	34, // 17: hermes.v1.Service.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 18: hermes.v1.Service.challenge_settings:type_name -> hermes.v1.ServiceChallengeSetting
+	23, // 19: hermes.v1.ServiceList.services:type_name -> hermes.v1.Service
+	35, // 20: hermes.v1.ListServicesRequest.pagination:type_name -> hermes.v1.Pagination
+	33, // 21: hermes.v1.ServiceChallengeSetting.limits:type_name -> hermes.v1.ServiceChallengeSetting.LimitsEntry
+	34, // 22: hermes.v1.ServiceChallengeSetting.created_at:type_name -> google.protobuf.Timestamp
+	34, // 23: hermes.v1.ServiceChallengeSetting.updated_at:type_name -> google.protobuf.Timestamp
+	34, // 24: hermes.v1.ServiceAuthorizationDetailType.created_at:type_name -> google.protobuf.Timestamp
+	34, // 25: hermes.v1.ServiceAuthorizationDetailType.updated_at:type_name -> google.protobuf.Timestamp
+	31, // 26: hermes.v1.ServiceAuthorizationDetailTypeList.types:type_name -> hermes.v1.ServiceAuthorizationDetailType
+	0,  // 27: hermes.v1.ProvisionService.GetDomain:input_type -> hermes.v1.GetDomainRequest
+	36, // 28: hermes.v1.ProvisionService.ListDomains:input_type -> google.protobuf.Empty
+	3,  // 29: hermes.v1.ProvisionService.UpdateDomain:input_type -> hermes.v1.UpdateDomainRequest
+	0,  // 30: hermes.v1.ProvisionService.GetDomainIDPConfigs:input_type -> hermes.v1.GetDomainRequest
+	6,  // 31: hermes.v1.ProvisionService.CreateDomainIDPConfig:input_type -> hermes.v1.CreateDomainIDPConfigRequest
+	7,  // 32: hermes.v1.ProvisionService.UpdateDomainIDPConfig:input_type -> hermes.v1.UpdateDomainIDPConfigRequest
+	8,  // 33: hermes.v1.ProvisionService.DeleteDomainIDPConfig:input_type -> hermes.v1.DeleteDomainIDPConfigRequest
+	17, // 34: hermes.v1.ProvisionService.CreateApplication:input_type -> hermes.v1.CreateApplicationRequest
+	14, // 35: hermes.v1.ProvisionService.GetApplication:input_type -> hermes.v1.GetApplicationRequest
+	21, // 36: hermes.v1.ProvisionService.ListApplications:input_type -> hermes.v1.ListApplicationsRequest
+	18, // 37: hermes.v1.ProvisionService.UpdateApplication:input_type -> hermes.v1.UpdateApplicationRequest
+	19, // 38: hermes.v1.ProvisionService.DeleteApplication:input_type -> hermes.v1.DeleteApplicationRequest
+	14, // 39: hermes.v1.ProvisionService.GetApplicationIDPConfigs:input_type -> hermes.v1.GetApplicationRequest
+	11, // 40: hermes.v1.ProvisionService.CreateApplicationIDPConfig:input_type -> hermes.v1.CreateApplicationIDPConfigRequest
+	12, // 41: hermes.v1.ProvisionService.UpdateApplicationIDPConfig:input_type -> hermes.v1.UpdateApplicationIDPConfigRequest
+	13, // 42: hermes.v1.ProvisionService.DeleteApplicationIDPConfig:input_type -> hermes.v1.DeleteApplicationIDPConfigRequest
+	25, // 43: hermes.v1.ProvisionService.CreateService:input_type -> hermes.v1.CreateServiceRequest
+	22, // 44: hermes.v1.ProvisionService.GetService:input_type -> hermes.v1.GetServiceRequest
+	28, // 45: hermes.v1.ProvisionService.ListServices:input_type -> hermes.v1.ListServicesRequest
+	26, // 46: hermes.v1.ProvisionService.UpdateService:input_type -> hermes.v1.UpdateServiceRequest
+	27, // 47: hermes.v1.ProvisionService.DeleteService:input_type -> hermes.v1.DeleteServiceRequest
+	29, // 48: hermes.v1.ProvisionService.GetServiceChallengeSetting:input_type -> hermes.v1.GetServiceChallengeSettingRequest
+	22, // 49: hermes.v1.ProvisionService.ListServiceAuthorizationDetailTypes:input_type -> hermes.v1.GetServiceRequest
+	1,  // 50: hermes.v1.ProvisionService.GetDomain:output_type -> hermes.v1.Domain
+	2,  // 51: hermes.v1.ProvisionService.ListDomains:output_type -> hermes.v1.DomainList
+	1,  // 52: hermes.v1.ProvisionService.UpdateDomain:output_type -> hermes.v1.Domain
+	5,  // 53: hermes.v1.ProvisionService.GetDomainIDPConfigs:output_type -> hermes.v1.DomainIDPConfigList
+	4,  // 54: hermes.v1.ProvisionService.CreateDomainIDPConfig:output_type -> hermes.v1.DomainIDPConfig
+	4,  // 55: hermes.v1.ProvisionService.UpdateDomainIDPConfig:output_type -> hermes.v1.DomainIDPConfig
+	36, // 56: hermes.v1.ProvisionService.DeleteDomainIDPConfig:output_type -> google.protobuf.Empty
+	15, // 57: hermes.v1.ProvisionService.CreateApplication:output_type -> hermes.v1.Application
+	15, // 58: hermes.v1.ProvisionService.GetApplication:output_type -> hermes.v1.Application
+	16, // 59: hermes.v1.ProvisionService.ListApplications:output_type -> hermes.v1.ApplicationList
+	15, // 60: hermes.v1.ProvisionService.UpdateApplication:output_type -> hermes.v1.Application
+	36, // 61: hermes.v1.ProvisionService.DeleteApplication:output_type -> google.protobuf.Empty
+	10, // 62: hermes.v1.ProvisionService.GetApplicationIDPConfigs:output_type -> hermes.v1.ApplicationIDPConfigList
+	9,  // 63: hermes.v1.ProvisionService.CreateApplicationIDPConfig:output_type -> hermes.v1.ApplicationIDPConfig
+	9,  // 64: hermes.v1.ProvisionService.UpdateApplicationIDPConfig:output_type -> hermes.v1.ApplicationIDPConfig
+	36, // 65: hermes.v1.ProvisionService.DeleteApplicationIDPConfig:output_type -> google.protobuf.Empty
+	23, // 66: hermes.v1.ProvisionService.CreateService:output_type -> hermes.v1.Service
+	23, // 67: hermes.v1.ProvisionService.GetService:output_type -> hermes.v1.Service
+	24, // 68: hermes.v1.ProvisionService.ListServices:output_type -> hermes.v1.ServiceList
+	23, // 69: hermes.v1.ProvisionService.UpdateService:output_type -> hermes.v1.Service
+	36, // 70: hermes.v1.ProvisionService.DeleteService:output_type -> google.protobuf.Empty
+	30, // 71: hermes.v1.ProvisionService.GetServiceChallengeSetting:output_type -> hermes.v1.ServiceChallengeSetting
+	32, // 72: hermes.v1.ProvisionService.ListServiceAuthorizationDetailTypes:output_type -> hermes.v1.ServiceAuthorizationDetailTypeList
+	50, // [50:73] is the sub-list for method output_type
+	27, // [27:50] is the sub-list for method input_type
+	27, // [27:27] is the sub-list for extension type_name
+	27, // [27:27] is the sub-list for extension extendee
+	0,  // [0:27] is the sub-list for field type_name
 }
 
 func init() { file_hermes_v1_provision_proto_init() }
@@ -2639,16 +3019,17 @@ func file_hermes_v1_provision_proto_init() {
 	file_hermes_v1_provision_proto_msgTypes[15].OneofWrappers = []any{}
 	file_hermes_v1_provision_proto_msgTypes[17].OneofWrappers = []any{}
 	file_hermes_v1_provision_proto_msgTypes[18].OneofWrappers = []any{}
-	file_hermes_v1_provision_proto_msgTypes[22].OneofWrappers = []any{}
-	file_hermes_v1_provision_proto_msgTypes[24].OneofWrappers = []any{}
+	file_hermes_v1_provision_proto_msgTypes[23].OneofWrappers = []any{}
 	file_hermes_v1_provision_proto_msgTypes[25].OneofWrappers = []any{}
+	file_hermes_v1_provision_proto_msgTypes[26].OneofWrappers = []any{}
+	file_hermes_v1_provision_proto_msgTypes[31].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hermes_v1_provision_proto_rawDesc), len(file_hermes_v1_provision_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   31,
+			NumMessages:   34,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,27 +20,29 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	ProvisionService_GetDomain_FullMethodName                  = "/hermes.v1.ProvisionService/GetDomain"
-	ProvisionService_ListDomains_FullMethodName                = "/hermes.v1.ProvisionService/ListDomains"
-	ProvisionService_UpdateDomain_FullMethodName               = "/hermes.v1.ProvisionService/UpdateDomain"
-	ProvisionService_GetDomainIDPConfigs_FullMethodName        = "/hermes.v1.ProvisionService/GetDomainIDPConfigs"
-	ProvisionService_CreateDomainIDPConfig_FullMethodName      = "/hermes.v1.ProvisionService/CreateDomainIDPConfig"
-	ProvisionService_UpdateDomainIDPConfig_FullMethodName      = "/hermes.v1.ProvisionService/UpdateDomainIDPConfig"
-	ProvisionService_DeleteDomainIDPConfig_FullMethodName      = "/hermes.v1.ProvisionService/DeleteDomainIDPConfig"
-	ProvisionService_CreateApplication_FullMethodName          = "/hermes.v1.ProvisionService/CreateApplication"
-	ProvisionService_GetApplication_FullMethodName             = "/hermes.v1.ProvisionService/GetApplication"
-	ProvisionService_ListApplications_FullMethodName           = "/hermes.v1.ProvisionService/ListApplications"
-	ProvisionService_UpdateApplication_FullMethodName          = "/hermes.v1.ProvisionService/UpdateApplication"
-	ProvisionService_GetApplicationIDPConfigs_FullMethodName   = "/hermes.v1.ProvisionService/GetApplicationIDPConfigs"
-	ProvisionService_CreateApplicationIDPConfig_FullMethodName = "/hermes.v1.ProvisionService/CreateApplicationIDPConfig"
-	ProvisionService_UpdateApplicationIDPConfig_FullMethodName = "/hermes.v1.ProvisionService/UpdateApplicationIDPConfig"
-	ProvisionService_DeleteApplicationIDPConfig_FullMethodName = "/hermes.v1.ProvisionService/DeleteApplicationIDPConfig"
-	ProvisionService_CreateService_FullMethodName              = "/hermes.v1.ProvisionService/CreateService"
-	ProvisionService_GetService_FullMethodName                 = "/hermes.v1.ProvisionService/GetService"
-	ProvisionService_ListServices_FullMethodName               = "/hermes.v1.ProvisionService/ListServices"
-	ProvisionService_UpdateService_FullMethodName              = "/hermes.v1.ProvisionService/UpdateService"
-	ProvisionService_DeleteService_FullMethodName              = "/hermes.v1.ProvisionService/DeleteService"
-	ProvisionService_GetServiceChallengeSetting_FullMethodName = "/hermes.v1.ProvisionService/GetServiceChallengeSetting"
+	ProvisionService_GetDomain_FullMethodName                           = "/hermes.v1.ProvisionService/GetDomain"
+	ProvisionService_ListDomains_FullMethodName                         = "/hermes.v1.ProvisionService/ListDomains"
+	ProvisionService_UpdateDomain_FullMethodName                        = "/hermes.v1.ProvisionService/UpdateDomain"
+	ProvisionService_GetDomainIDPConfigs_FullMethodName                 = "/hermes.v1.ProvisionService/GetDomainIDPConfigs"
+	ProvisionService_CreateDomainIDPConfig_FullMethodName               = "/hermes.v1.ProvisionService/CreateDomainIDPConfig"
+	ProvisionService_UpdateDomainIDPConfig_FullMethodName               = "/hermes.v1.ProvisionService/UpdateDomainIDPConfig"
+	ProvisionService_DeleteDomainIDPConfig_FullMethodName               = "/hermes.v1.ProvisionService/DeleteDomainIDPConfig"
+	ProvisionService_CreateApplication_FullMethodName                   = "/hermes.v1.ProvisionService/CreateApplication"
+	ProvisionService_GetApplication_FullMethodName                      = "/hermes.v1.ProvisionService/GetApplication"
+	ProvisionService_ListApplications_FullMethodName                    = "/hermes.v1.ProvisionService/ListApplications"
+	ProvisionService_UpdateApplication_FullMethodName                   = "/hermes.v1.ProvisionService/UpdateApplication"
+	ProvisionService_DeleteApplication_FullMethodName                   = "/hermes.v1.ProvisionService/DeleteApplication"
+	ProvisionService_GetApplicationIDPConfigs_FullMethodName            = "/hermes.v1.ProvisionService/GetApplicationIDPConfigs"
+	ProvisionService_CreateApplicationIDPConfig_FullMethodName          = "/hermes.v1.ProvisionService/CreateApplicationIDPConfig"
+	ProvisionService_UpdateApplicationIDPConfig_FullMethodName          = "/hermes.v1.ProvisionService/UpdateApplicationIDPConfig"
+	ProvisionService_DeleteApplicationIDPConfig_FullMethodName          = "/hermes.v1.ProvisionService/DeleteApplicationIDPConfig"
+	ProvisionService_CreateService_FullMethodName                       = "/hermes.v1.ProvisionService/CreateService"
+	ProvisionService_GetService_FullMethodName                          = "/hermes.v1.ProvisionService/GetService"
+	ProvisionService_ListServices_FullMethodName                        = "/hermes.v1.ProvisionService/ListServices"
+	ProvisionService_UpdateService_FullMethodName                       = "/hermes.v1.ProvisionService/UpdateService"
+	ProvisionService_DeleteService_FullMethodName                       = "/hermes.v1.ProvisionService/DeleteService"
+	ProvisionService_GetServiceChallengeSetting_FullMethodName          = "/hermes.v1.ProvisionService/GetServiceChallengeSetting"
+	ProvisionService_ListServiceAuthorizationDetailTypes_FullMethodName = "/hermes.v1.ProvisionService/ListServiceAuthorizationDetailTypes"
 )
 
 // ProvisionServiceClient is the client API for ProvisionService service.
@@ -60,6 +62,7 @@ type ProvisionServiceClient interface {
 	GetApplication(ctx context.Context, in *GetApplicationRequest, opts ...grpc.CallOption) (*Application, error)
 	ListApplications(ctx context.Context, in *ListApplicationsRequest, opts ...grpc.CallOption) (*ApplicationList, error)
 	UpdateApplication(ctx context.Context, in *UpdateApplicationRequest, opts ...grpc.CallOption) (*Application, error)
+	DeleteApplication(ctx context.Context, in *DeleteApplicationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetApplicationIDPConfigs(ctx context.Context, in *GetApplicationRequest, opts ...grpc.CallOption) (*ApplicationIDPConfigList, error)
 	CreateApplicationIDPConfig(ctx context.Context, in *CreateApplicationIDPConfigRequest, opts ...grpc.CallOption) (*ApplicationIDPConfig, error)
 	UpdateApplicationIDPConfig(ctx context.Context, in *UpdateApplicationIDPConfigRequest, opts ...grpc.CallOption) (*ApplicationIDPConfig, error)
@@ -70,6 +73,7 @@ type ProvisionServiceClient interface {
 	UpdateService(ctx context.Context, in *UpdateServiceRequest, opts ...grpc.CallOption) (*Service, error)
 	DeleteService(ctx context.Context, in *DeleteServiceRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetServiceChallengeSetting(ctx context.Context, in *GetServiceChallengeSettingRequest, opts ...grpc.CallOption) (*ServiceChallengeSetting, error)
+	ListServiceAuthorizationDetailTypes(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*ServiceAuthorizationDetailTypeList, error)
 }
 
 type provisionServiceClient struct {
@@ -190,6 +194,16 @@ func (c *provisionServiceClient) UpdateApplication(ctx context.Context, in *Upda
 	return out, nil
 }
 
+func (c *provisionServiceClient) DeleteApplication(ctx context.Context, in *DeleteApplicationRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, ProvisionService_DeleteApplication_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *provisionServiceClient) GetApplicationIDPConfigs(ctx context.Context, in *GetApplicationRequest, opts ...grpc.CallOption) (*ApplicationIDPConfigList, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(ApplicationIDPConfigList)
@@ -290,6 +304,16 @@ func (c *provisionServiceClient) GetServiceChallengeSetting(ctx context.Context,
 	return out, nil
 }
 
+func (c *provisionServiceClient) ListServiceAuthorizationDetailTypes(ctx context.Context, in *GetServiceRequest, opts ...grpc.CallOption) (*ServiceAuthorizationDetailTypeList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ServiceAuthorizationDetailTypeList)
+	err := c.cc.Invoke(ctx, ProvisionService_ListServiceAuthorizationDetailTypes_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // ProvisionServiceServer is the server API for ProvisionService service.
 // All implementations must embed UnimplementedProvisionServiceServer
 // for forward compatibility.
@@ -307,6 +331,7 @@ type ProvisionServiceServer interface {
 	GetApplication(context.Context, *GetApplicationRequest) (*Application, error)
 	ListApplications(context.Context, *ListApplicationsRequest) (*ApplicationList, error)
 	UpdateApplication(context.Context, *UpdateApplicationRequest) (*Application, error)
+	DeleteApplication(context.Context, *DeleteApplicationRequest) (*emptypb.Empty, error)
 	GetApplicationIDPConfigs(context.Context, *GetApplicationRequest) (*ApplicationIDPConfigList, error)
 	CreateApplicationIDPConfig(context.Context, *CreateApplicationIDPConfigRequest) (*ApplicationIDPConfig, error)
 	UpdateApplicationIDPConfig(context.Context, *UpdateApplicationIDPConfigRequest) (*ApplicationIDPConfig, error)
@@ -317,6 +342,7 @@ type ProvisionServiceServer interface {
 	UpdateService(context.Context, *UpdateServiceRequest) (*Service, error)
 	DeleteService(context.Context, *DeleteServiceRequest) (*emptypb.Empty, error)
 	GetServiceChallengeSetting(context.Context, *GetServiceChallengeSettingRequest) (*ServiceChallengeSetting, error)
+	ListServiceAuthorizationDetailTypes(context.Context, *GetServiceRequest) (*ServiceAuthorizationDetailTypeList, error)
 	mustEmbedUnimplementedProvisionServiceServer()
 }
 
@@ -360,6 +386,9 @@ func (UnimplementedProvisionServiceServer) ListApplications(context.Context, *Li
 func (UnimplementedProvisionServiceServer) UpdateApplication(context.Context, *UpdateApplicationRequest) (*Application, error) {
 	return nil, status.Error(codes.Unimplemented, "method UpdateApplication not implemented")
 }
+func (UnimplementedProvisionServiceServer) DeleteApplication(context.Context, *DeleteApplicationRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method DeleteApplication not implemented")
+}
 func (UnimplementedProvisionServiceServer) GetApplicationIDPConfigs(context.Context, *GetApplicationRequest) (*ApplicationIDPConfigList, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetApplicationIDPConfigs not implemented")
 }
@@ -390,6 +419,9 @@ func (UnimplementedProvisionServiceServer) DeleteService(context.Context, *Delet
 func (UnimplementedProvisionServiceServer) GetServiceChallengeSetting(context.Context, *GetServiceChallengeSettingRequest) (*ServiceChallengeSetting, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetServiceChallengeSetting not implemented")
 }
+func (UnimplementedProvisionServiceServer) ListServiceAuthorizationDetailTypes(context.Context, *GetServiceRequest) (*ServiceAuthorizationDetailTypeList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListServiceAuthorizationDetailTypes not implemented")
+}
 func (UnimplementedProvisionServiceServer) mustEmbedUnimplementedProvisionServiceServer() {}
 func (UnimplementedProvisionServiceServer) testEmbeddedByValue()                          {}
 
@@ -609,6 +641,24 @@ func _ProvisionService_UpdateApplication_Handler(srv interface{}, ctx context.Co
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionService_DeleteApplication_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteApplicationRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionServiceServer).DeleteApplication(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisionService_DeleteApplication_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionServiceServer).DeleteApplication(ctx, req.(*DeleteApplicationRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _ProvisionService_GetApplicationIDPConfigs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetApplicationRequest)
 	if err := dec(in); err != nil {
@@ -789,6 +839,24 @@ func _ProvisionService_GetServiceChallengeSetting_Handler(srv interface{}, ctx c
 	return interceptor(ctx, in, info, handler)
 }
 
+func _ProvisionService_ListServiceAuthorizationDetailTypes_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetServiceRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(ProvisionServiceServer).ListServiceAuthorizationDetailTypes(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: ProvisionService_ListServiceAuthorizationDetailTypes_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(ProvisionServiceServer).ListServiceAuthorizationDetailTypes(ctx, req.(*GetServiceRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // ProvisionService_ServiceDesc is the grpc.ServiceDesc for ProvisionService service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -840,6 +908,10 @@ var ProvisionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "UpdateApplication",
 			Handler:    _ProvisionService_UpdateApplication_Handler,
 		},
+		{
+			MethodName: "DeleteApplication",
+			Handler:    _ProvisionService_DeleteApplication_Handler,
+		},
 		{
 			MethodName: "GetApplicationIDPConfigs",
 			Handler:    _ProvisionService_GetApplicationIDPConfigs_Handler,
@@ -880,6 +952,10 @@ var ProvisionService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetServiceChallengeSetting",
 			Handler:    _ProvisionService_GetServiceChallengeSetting_Handler,
 		},
+		{
+			MethodName: "ListServiceAuthorizationDetailTypes",
+			Handler:    _ProvisionService_ListServiceAuthorizationDetailTypes_Handler,
+		},
 	},
 	Streams:  []grpc.StreamDesc{},
 	Metadata: "hermes/v1/provision.proto",
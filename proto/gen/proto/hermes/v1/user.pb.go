@@ -36,6 +36,7 @@ type User struct {
 	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
 	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,10,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
 	PasswordHash  *string                `protobuf:"bytes,11,opt,name=password_hash,json=passwordHash,proto3,oneof" json:"password_hash,omitempty"`
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,12,opt,name=locked_until,json=lockedUntil,proto3,oneof" json:"locked_until,omitempty"` // 登录失败过多时的临时锁定截止时间
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -147,6 +148,13 @@ func (x *User) GetPasswordHash() string {
 	return ""
 }
 
+func (x *User) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
 // DecryptedUser 解密后的用户（含明文手机号）
 type DecryptedUser struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
@@ -494,6 +502,7 @@ type PatchUserRequest struct {
 	PasswordHash  *string                `protobuf:"bytes,6,opt,name=password_hash,json=passwordHash,proto3,oneof" json:"password_hash,omitempty"`
 	LastLoginAt   *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=last_login_at,json=lastLoginAt,proto3,oneof" json:"last_login_at,omitempty"`
 	Phone         *string                `protobuf:"bytes,8,opt,name=phone,proto3,oneof" json:"phone,omitempty"`
+	LockedUntil   *timestamppb.Timestamp `protobuf:"bytes,9,opt,name=locked_until,json=lockedUntil,proto3,oneof" json:"locked_until,omitempty"`
 	unknownFields protoimpl.UnknownFields
 	sizeCache     protoimpl.SizeCache
 }
@@ -584,6 +593,13 @@ func (x *PatchUserRequest) GetPhone() string {
 	return ""
 }
 
+func (x *PatchUserRequest) GetLockedUntil() *timestamppb.Timestamp {
+	if x != nil {
+		return x.LockedUntil
+	}
+	return nil
+}
+
 type UserIdentity struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -864,6 +880,66 @@ func (x *AddIdentityRequest) GetRawData() string {
 	return ""
 }
 
+type RemoveIdentityRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Domain        string                 `protobuf:"bytes,1,opt,name=domain,proto3" json:"domain,omitempty"`
+	Openid        string                 `protobuf:"bytes,2,opt,name=openid,proto3" json:"openid,omitempty"`
+	Idp           string                 `protobuf:"bytes,3,opt,name=idp,proto3" json:"idp,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RemoveIdentityRequest) Reset() {
+	*x = RemoveIdentityRequest{}
+	mi := &file_hermes_v1_user_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RemoveIdentityRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RemoveIdentityRequest) ProtoMessage() {}
+
+func (x *RemoveIdentityRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RemoveIdentityRequest.ProtoReflect.Descriptor instead.
+func (*RemoveIdentityRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *RemoveIdentityRequest) GetDomain() string {
+	if x != nil {
+		return x.Domain
+	}
+	return ""
+}
+
+func (x *RemoveIdentityRequest) GetOpenid() string {
+	if x != nil {
+		return x.Openid
+	}
+	return ""
+}
+
+func (x *RemoveIdentityRequest) GetIdp() string {
+	if x != nil {
+		return x.Idp
+	}
+	return ""
+}
+
 type GetPasswordCredentialRequest struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Idp           string                 `protobuf:"bytes,1,opt,name=idp,proto3" json:"idp,omitempty"`
@@ -874,7 +950,7 @@ type GetPasswordCredentialRequest struct {
 
 func (x *GetPasswordCredentialRequest) Reset() {
 	*x = GetPasswordCredentialRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[12]
+	mi := &file_hermes_v1_user_proto_msgTypes[13]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -886,7 +962,7 @@ func (x *GetPasswordCredentialRequest) String() string {
 func (*GetPasswordCredentialRequest) ProtoMessage() {}
 
 func (x *GetPasswordCredentialRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[12]
+	mi := &file_hermes_v1_user_proto_msgTypes[13]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -899,7 +975,7 @@ func (x *GetPasswordCredentialRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetPasswordCredentialRequest.ProtoReflect.Descriptor instead.
 func (*GetPasswordCredentialRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{12}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{13}
 }
 
 func (x *GetPasswordCredentialRequest) GetIdp() string {
@@ -930,7 +1006,7 @@ type PasswordStoreCredential struct {
 
 func (x *PasswordStoreCredential) Reset() {
 	*x = PasswordStoreCredential{}
-	mi := &file_hermes_v1_user_proto_msgTypes[13]
+	mi := &file_hermes_v1_user_proto_msgTypes[14]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -942,7 +1018,7 @@ func (x *PasswordStoreCredential) String() string {
 func (*PasswordStoreCredential) ProtoMessage() {}
 
 func (x *PasswordStoreCredential) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[13]
+	mi := &file_hermes_v1_user_proto_msgTypes[14]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -955,7 +1031,7 @@ func (x *PasswordStoreCredential) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PasswordStoreCredential.ProtoReflect.Descriptor instead.
 func (*PasswordStoreCredential) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{13}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{14}
 }
 
 func (x *PasswordStoreCredential) GetOpenid() string {
@@ -1009,7 +1085,7 @@ type CredentialIDRequest struct {
 
 func (x *CredentialIDRequest) Reset() {
 	*x = CredentialIDRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[14]
+	mi := &file_hermes_v1_user_proto_msgTypes[15]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1021,7 +1097,7 @@ func (x *CredentialIDRequest) String() string {
 func (*CredentialIDRequest) ProtoMessage() {}
 
 func (x *CredentialIDRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[14]
+	mi := &file_hermes_v1_user_proto_msgTypes[15]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1034,7 +1110,7 @@ func (x *CredentialIDRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CredentialIDRequest.ProtoReflect.Descriptor instead.
 func (*CredentialIDRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{14}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{15}
 }
 
 func (x *CredentialIDRequest) GetCredentialId() string {
@@ -1062,7 +1138,7 @@ type UserCredential struct {
 
 func (x *UserCredential) Reset() {
 	*x = UserCredential{}
-	mi := &file_hermes_v1_user_proto_msgTypes[15]
+	mi := &file_hermes_v1_user_proto_msgTypes[16]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1074,7 +1150,7 @@ func (x *UserCredential) String() string {
 func (*UserCredential) ProtoMessage() {}
 
 func (x *UserCredential) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[15]
+	mi := &file_hermes_v1_user_proto_msgTypes[16]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1087,7 +1163,7 @@ func (x *UserCredential) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserCredential.ProtoReflect.Descriptor instead.
 func (*UserCredential) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{15}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{16}
 }
 
 func (x *UserCredential) GetId() uint32 {
@@ -1169,7 +1245,7 @@ type UserCredentialList struct {
 
 func (x *UserCredentialList) Reset() {
 	*x = UserCredentialList{}
-	mi := &file_hermes_v1_user_proto_msgTypes[16]
+	mi := &file_hermes_v1_user_proto_msgTypes[17]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1181,7 +1257,7 @@ func (x *UserCredentialList) String() string {
 func (*UserCredentialList) ProtoMessage() {}
 
 func (x *UserCredentialList) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[16]
+	mi := &file_hermes_v1_user_proto_msgTypes[17]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1194,7 +1270,7 @@ func (x *UserCredentialList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UserCredentialList.ProtoReflect.Descriptor instead.
 func (*UserCredentialList) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{16}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{17}
 }
 
 func (x *UserCredentialList) GetCredentials() []*UserCredential {
@@ -1217,7 +1293,7 @@ type CreateCredentialRequest struct {
 
 func (x *CreateCredentialRequest) Reset() {
 	*x = CreateCredentialRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[17]
+	mi := &file_hermes_v1_user_proto_msgTypes[18]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1229,7 +1305,7 @@ func (x *CreateCredentialRequest) String() string {
 func (*CreateCredentialRequest) ProtoMessage() {}
 
 func (x *CreateCredentialRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[17]
+	mi := &file_hermes_v1_user_proto_msgTypes[18]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1242,7 +1318,7 @@ func (x *CreateCredentialRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateCredentialRequest.ProtoReflect.Descriptor instead.
 func (*CreateCredentialRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{17}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{18}
 }
 
 func (x *CreateCredentialRequest) GetOpenid() string {
@@ -1290,7 +1366,7 @@ type GetCredentialsByTypeRequest struct {
 
 func (x *GetCredentialsByTypeRequest) Reset() {
 	*x = GetCredentialsByTypeRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[18]
+	mi := &file_hermes_v1_user_proto_msgTypes[19]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1302,7 +1378,7 @@ func (x *GetCredentialsByTypeRequest) String() string {
 func (*GetCredentialsByTypeRequest) ProtoMessage() {}
 
 func (x *GetCredentialsByTypeRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[18]
+	mi := &file_hermes_v1_user_proto_msgTypes[19]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1315,7 +1391,7 @@ func (x *GetCredentialsByTypeRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetCredentialsByTypeRequest.ProtoReflect.Descriptor instead.
 func (*GetCredentialsByTypeRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{18}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{19}
 }
 
 func (x *GetCredentialsByTypeRequest) GetOpenid() string {
@@ -1346,7 +1422,7 @@ type PatchCredentialRequest struct {
 
 func (x *PatchCredentialRequest) Reset() {
 	*x = PatchCredentialRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[19]
+	mi := &file_hermes_v1_user_proto_msgTypes[20]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1358,7 +1434,7 @@ func (x *PatchCredentialRequest) String() string {
 func (*PatchCredentialRequest) ProtoMessage() {}
 
 func (x *PatchCredentialRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[19]
+	mi := &file_hermes_v1_user_proto_msgTypes[20]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1371,7 +1447,7 @@ func (x *PatchCredentialRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use PatchCredentialRequest.ProtoReflect.Descriptor instead.
 func (*PatchCredentialRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{19}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{20}
 }
 
 func (x *PatchCredentialRequest) GetCredentialId() string {
@@ -1426,7 +1502,7 @@ type DeleteCredentialRequest struct {
 
 func (x *DeleteCredentialRequest) Reset() {
 	*x = DeleteCredentialRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[20]
+	mi := &file_hermes_v1_user_proto_msgTypes[21]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1438,7 +1514,7 @@ func (x *DeleteCredentialRequest) String() string {
 func (*DeleteCredentialRequest) ProtoMessage() {}
 
 func (x *DeleteCredentialRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[20]
+	mi := &file_hermes_v1_user_proto_msgTypes[21]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1451,7 +1527,7 @@ func (x *DeleteCredentialRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use DeleteCredentialRequest.ProtoReflect.Descriptor instead.
 func (*DeleteCredentialRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{20}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{21}
 }
 
 func (x *DeleteCredentialRequest) GetOpenid() string {
@@ -1468,6 +1544,110 @@ func (x *DeleteCredentialRequest) GetCredentialId() string {
 	return ""
 }
 
+type ConsumeCredentialResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consumed      bool                   `protobuf:"varint,1,opt,name=consumed,proto3" json:"consumed,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ConsumeCredentialResponse) Reset() {
+	*x = ConsumeCredentialResponse{}
+	mi := &file_hermes_v1_user_proto_msgTypes[22]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ConsumeCredentialResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ConsumeCredentialResponse) ProtoMessage() {}
+
+func (x *ConsumeCredentialResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[22]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ConsumeCredentialResponse.ProtoReflect.Descriptor instead.
+func (*ConsumeCredentialResponse) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{22}
+}
+
+func (x *ConsumeCredentialResponse) GetConsumed() bool {
+	if x != nil {
+		return x.Consumed
+	}
+	return false
+}
+
+type ReplaceCredentialsRequest struct {
+	state         protoimpl.MessageState     `protogen:"open.v1"`
+	Openid        string                     `protobuf:"bytes,1,opt,name=openid,proto3" json:"openid,omitempty"`
+	Type          string                     `protobuf:"bytes,2,opt,name=type,proto3" json:"type,omitempty"`
+	Credentials   []*CreateCredentialRequest `protobuf:"bytes,3,rep,name=credentials,proto3" json:"credentials,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ReplaceCredentialsRequest) Reset() {
+	*x = ReplaceCredentialsRequest{}
+	mi := &file_hermes_v1_user_proto_msgTypes[23]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ReplaceCredentialsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ReplaceCredentialsRequest) ProtoMessage() {}
+
+func (x *ReplaceCredentialsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[23]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ReplaceCredentialsRequest.ProtoReflect.Descriptor instead.
+func (*ReplaceCredentialsRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{23}
+}
+
+func (x *ReplaceCredentialsRequest) GetOpenid() string {
+	if x != nil {
+		return x.Openid
+	}
+	return ""
+}
+
+func (x *ReplaceCredentialsRequest) GetType() string {
+	if x != nil {
+		return x.Type
+	}
+	return ""
+}
+
+func (x *ReplaceCredentialsRequest) GetCredentials() []*CreateCredentialRequest {
+	if x != nil {
+		return x.Credentials
+	}
+	return nil
+}
+
 type OpenIDResponse struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Openid        string                 `protobuf:"bytes,1,opt,name=openid,proto3" json:"openid,omitempty"`
@@ -1477,7 +1657,7 @@ type OpenIDResponse struct {
 
 func (x *OpenIDResponse) Reset() {
 	*x = OpenIDResponse{}
-	mi := &file_hermes_v1_user_proto_msgTypes[21]
+	mi := &file_hermes_v1_user_proto_msgTypes[24]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1489,7 +1669,7 @@ func (x *OpenIDResponse) String() string {
 func (*OpenIDResponse) ProtoMessage() {}
 
 func (x *OpenIDResponse) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[21]
+	mi := &file_hermes_v1_user_proto_msgTypes[24]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1502,7 +1682,7 @@ func (x *OpenIDResponse) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use OpenIDResponse.ProtoReflect.Descriptor instead.
 func (*OpenIDResponse) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{21}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{24}
 }
 
 func (x *OpenIDResponse) GetOpenid() string {
@@ -1512,6 +1692,317 @@ func (x *OpenIDResponse) GetOpenid() string {
 	return ""
 }
 
+// UserConsent 用户对应用访问某服务的授权同意
+type UserConsent struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Openid        string                 `protobuf:"bytes,1,opt,name=openid,proto3" json:"openid,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	ServiceId     string                 `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,5,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserConsent) Reset() {
+	*x = UserConsent{}
+	mi := &file_hermes_v1_user_proto_msgTypes[25]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserConsent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserConsent) ProtoMessage() {}
+
+func (x *UserConsent) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[25]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserConsent.ProtoReflect.Descriptor instead.
+func (*UserConsent) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{25}
+}
+
+func (x *UserConsent) GetOpenid() string {
+	if x != nil {
+		return x.Openid
+	}
+	return ""
+}
+
+func (x *UserConsent) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *UserConsent) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *UserConsent) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+func (x *UserConsent) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *UserConsent) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type UserConsentList struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Consents      []*UserConsent         `protobuf:"bytes,1,rep,name=consents,proto3" json:"consents,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UserConsentList) Reset() {
+	*x = UserConsentList{}
+	mi := &file_hermes_v1_user_proto_msgTypes[26]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UserConsentList) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UserConsentList) ProtoMessage() {}
+
+func (x *UserConsentList) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[26]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UserConsentList.ProtoReflect.Descriptor instead.
+func (*UserConsentList) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{26}
+}
+
+func (x *UserConsentList) GetConsents() []*UserConsent {
+	if x != nil {
+		return x.Consents
+	}
+	return nil
+}
+
+type GetConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Openid        string                 `protobuf:"bytes,1,opt,name=openid,proto3" json:"openid,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	ServiceId     string                 `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetConsentRequest) Reset() {
+	*x = GetConsentRequest{}
+	mi := &file_hermes_v1_user_proto_msgTypes[27]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetConsentRequest) ProtoMessage() {}
+
+func (x *GetConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[27]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetConsentRequest.ProtoReflect.Descriptor instead.
+func (*GetConsentRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{27}
+}
+
+func (x *GetConsentRequest) GetOpenid() string {
+	if x != nil {
+		return x.Openid
+	}
+	return ""
+}
+
+func (x *GetConsentRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *GetConsentRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+// SaveConsentRequest 合并写入授权（scopes 与已有授权取并集）
+type SaveConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Openid        string                 `protobuf:"bytes,1,opt,name=openid,proto3" json:"openid,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	ServiceId     string                 `protobuf:"bytes,3,opt,name=service_id,json=serviceId,proto3" json:"service_id,omitempty"`
+	Scopes        []string               `protobuf:"bytes,4,rep,name=scopes,proto3" json:"scopes,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *SaveConsentRequest) Reset() {
+	*x = SaveConsentRequest{}
+	mi := &file_hermes_v1_user_proto_msgTypes[28]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *SaveConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*SaveConsentRequest) ProtoMessage() {}
+
+func (x *SaveConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[28]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use SaveConsentRequest.ProtoReflect.Descriptor instead.
+func (*SaveConsentRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{28}
+}
+
+func (x *SaveConsentRequest) GetOpenid() string {
+	if x != nil {
+		return x.Openid
+	}
+	return ""
+}
+
+func (x *SaveConsentRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
+func (x *SaveConsentRequest) GetServiceId() string {
+	if x != nil {
+		return x.ServiceId
+	}
+	return ""
+}
+
+func (x *SaveConsentRequest) GetScopes() []string {
+	if x != nil {
+		return x.Scopes
+	}
+	return nil
+}
+
+// RevokeConsentRequest 撤销用户对应用的全部授权
+type RevokeConsentRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Openid        string                 `protobuf:"bytes,1,opt,name=openid,proto3" json:"openid,omitempty"`
+	AppId         string                 `protobuf:"bytes,2,opt,name=app_id,json=appId,proto3" json:"app_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RevokeConsentRequest) Reset() {
+	*x = RevokeConsentRequest{}
+	mi := &file_hermes_v1_user_proto_msgTypes[29]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RevokeConsentRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RevokeConsentRequest) ProtoMessage() {}
+
+func (x *RevokeConsentRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_hermes_v1_user_proto_msgTypes[29]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RevokeConsentRequest.ProtoReflect.Descriptor instead.
+func (*RevokeConsentRequest) Descriptor() ([]byte, []int) {
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{29}
+}
+
+func (x *RevokeConsentRequest) GetOpenid() string {
+	if x != nil {
+		return x.Openid
+	}
+	return ""
+}
+
+func (x *RevokeConsentRequest) GetAppId() string {
+	if x != nil {
+		return x.AppId
+	}
+	return ""
+}
+
 type Group struct {
 	state         protoimpl.MessageState `protogen:"open.v1"`
 	Id            uint32                 `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
@@ -1527,7 +2018,7 @@ type Group struct {
 
 func (x *Group) Reset() {
 	*x = Group{}
-	mi := &file_hermes_v1_user_proto_msgTypes[22]
+	mi := &file_hermes_v1_user_proto_msgTypes[30]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1539,7 +2030,7 @@ func (x *Group) String() string {
 func (*Group) ProtoMessage() {}
 
 func (x *Group) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[22]
+	mi := &file_hermes_v1_user_proto_msgTypes[30]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1552,7 +2043,7 @@ func (x *Group) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use Group.ProtoReflect.Descriptor instead.
 func (*Group) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{22}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{30}
 }
 
 func (x *Group) GetId() uint32 {
@@ -1614,7 +2105,7 @@ type GroupList struct {
 
 func (x *GroupList) Reset() {
 	*x = GroupList{}
-	mi := &file_hermes_v1_user_proto_msgTypes[23]
+	mi := &file_hermes_v1_user_proto_msgTypes[31]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1626,7 +2117,7 @@ func (x *GroupList) String() string {
 func (*GroupList) ProtoMessage() {}
 
 func (x *GroupList) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[23]
+	mi := &file_hermes_v1_user_proto_msgTypes[31]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1639,7 +2130,7 @@ func (x *GroupList) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GroupList.ProtoReflect.Descriptor instead.
 func (*GroupList) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{23}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{31}
 }
 
 func (x *GroupList) GetGroups() []*Group {
@@ -1665,7 +2156,7 @@ type GetGroupRequest struct {
 
 func (x *GetGroupRequest) Reset() {
 	*x = GetGroupRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[24]
+	mi := &file_hermes_v1_user_proto_msgTypes[32]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1677,7 +2168,7 @@ func (x *GetGroupRequest) String() string {
 func (*GetGroupRequest) ProtoMessage() {}
 
 func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[24]
+	mi := &file_hermes_v1_user_proto_msgTypes[32]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1690,7 +2181,7 @@ func (x *GetGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use GetGroupRequest.ProtoReflect.Descriptor instead.
 func (*GetGroupRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{24}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{32}
 }
 
 func (x *GetGroupRequest) GetGroupId() string {
@@ -1712,7 +2203,7 @@ type CreateGroupRequest struct {
 
 func (x *CreateGroupRequest) Reset() {
 	*x = CreateGroupRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[25]
+	mi := &file_hermes_v1_user_proto_msgTypes[33]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1724,7 +2215,7 @@ func (x *CreateGroupRequest) String() string {
 func (*CreateGroupRequest) ProtoMessage() {}
 
 func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[25]
+	mi := &file_hermes_v1_user_proto_msgTypes[33]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1737,7 +2228,7 @@ func (x *CreateGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use CreateGroupRequest.ProtoReflect.Descriptor instead.
 func (*CreateGroupRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{25}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{33}
 }
 
 func (x *CreateGroupRequest) GetGroupId() string {
@@ -1779,7 +2270,7 @@ type UpdateGroupRequest struct {
 
 func (x *UpdateGroupRequest) Reset() {
 	*x = UpdateGroupRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[26]
+	mi := &file_hermes_v1_user_proto_msgTypes[34]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1791,7 +2282,7 @@ func (x *UpdateGroupRequest) String() string {
 func (*UpdateGroupRequest) ProtoMessage() {}
 
 func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[26]
+	mi := &file_hermes_v1_user_proto_msgTypes[34]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1804,7 +2295,7 @@ func (x *UpdateGroupRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use UpdateGroupRequest.ProtoReflect.Descriptor instead.
 func (*UpdateGroupRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{26}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{34}
 }
 
 func (x *UpdateGroupRequest) GetGroupId() string {
@@ -1838,7 +2329,7 @@ type ListGroupsRequest struct {
 
 func (x *ListGroupsRequest) Reset() {
 	*x = ListGroupsRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[27]
+	mi := &file_hermes_v1_user_proto_msgTypes[35]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1850,7 +2341,7 @@ func (x *ListGroupsRequest) String() string {
 func (*ListGroupsRequest) ProtoMessage() {}
 
 func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[27]
+	mi := &file_hermes_v1_user_proto_msgTypes[35]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1863,7 +2354,7 @@ func (x *ListGroupsRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use ListGroupsRequest.ProtoReflect.Descriptor instead.
 func (*ListGroupsRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{27}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{35}
 }
 
 func (x *ListGroupsRequest) GetFilter() string {
@@ -1890,7 +2381,7 @@ type SetGroupMembersRequest struct {
 
 func (x *SetGroupMembersRequest) Reset() {
 	*x = SetGroupMembersRequest{}
-	mi := &file_hermes_v1_user_proto_msgTypes[28]
+	mi := &file_hermes_v1_user_proto_msgTypes[36]
 	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 	ms.StoreMessageInfo(mi)
 }
@@ -1902,7 +2393,7 @@ func (x *SetGroupMembersRequest) String() string {
 func (*SetGroupMembersRequest) ProtoMessage() {}
 
 func (x *SetGroupMembersRequest) ProtoReflect() protoreflect.Message {
-	mi := &file_hermes_v1_user_proto_msgTypes[28]
+	mi := &file_hermes_v1_user_proto_msgTypes[36]
 	if x != nil {
 		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
 		if ms.LoadMessageInfo() == nil {
@@ -1915,7 +2406,7 @@ func (x *SetGroupMembersRequest) ProtoReflect() protoreflect.Message {
 
 // Deprecated: Use SetGroupMembersRequest.ProtoReflect.Descriptor instead.
 func (*SetGroupMembersRequest) Descriptor() ([]byte, []int) {
-	return file_hermes_v1_user_proto_rawDescGZIP(), []int{28}
+	return file_hermes_v1_user_proto_rawDescGZIP(), []int{36}
 }
 
 func (x *SetGroupMembersRequest) GetGroupId() string {
@@ -1936,7 +2427,7 @@ var File_hermes_v1_user_proto protoreflect.FileDescriptor
 
 const file_hermes_v1_user_proto_rawDesc = "" +
 	"\n" +
-	"\x14hermes/v1/user.proto\x12\thermes.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16hermes/v1/common.proto\"\xf4\x03\n" +
+	"\x14hermes/v1/user.proto\x12\thermes.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\x1a\x16hermes/v1/common.proto\"\xc9\x04\n" +
 	"\x04User\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
 	"\x06openid\x18\x02 \x01(\tR\x06openid\x12\x16\n" +
@@ -1951,13 +2442,15 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\n" +
 	"updated_at\x18\n" +
 	" \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12(\n" +
-	"\rpassword_hash\x18\v \x01(\tH\x04R\fpasswordHash\x88\x01\x01B\v\n" +
+	"\rpassword_hash\x18\v \x01(\tH\x04R\fpasswordHash\x88\x01\x01\x12B\n" +
+	"\flocked_until\x18\f \x01(\v2\x1a.google.protobuf.TimestampH\x05R\vlockedUntil\x88\x01\x01B\v\n" +
 	"\t_nicknameB\n" +
 	"\n" +
 	"\b_pictureB\b\n" +
 	"\x06_emailB\x10\n" +
 	"\x0e_last_login_atB\x10\n" +
-	"\x0e_password_hash\"J\n" +
+	"\x0e_password_hashB\x0f\n" +
+	"\r_locked_until\"J\n" +
 	"\rDecryptedUser\x12#\n" +
 	"\x04user\x18\x01 \x01(\v2\x0f.hermes.v1.UserR\x04user\x12\x14\n" +
 	"\x05phone\x18\x02 \x01(\tR\x05phone\"[\n" +
@@ -1986,7 +2479,7 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\x06_phoneB\n" +
 	"\n" +
 	"\b_pictureB\v\n" +
-	"\t_raw_data\"\x88\x03\n" +
+	"\t_raw_data\"\xdd\x03\n" +
 	"\x10PatchUserRequest\x12\x16\n" +
 	"\x06openid\x18\x01 \x01(\tR\x06openid\x12\x1f\n" +
 	"\bnickname\x18\x02 \x01(\tH\x00R\bnickname\x88\x01\x01\x12\x1d\n" +
@@ -1995,7 +2488,8 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\x06status\x18\x05 \x01(\x05H\x03R\x06status\x88\x01\x01\x12(\n" +
 	"\rpassword_hash\x18\x06 \x01(\tH\x04R\fpasswordHash\x88\x01\x01\x12C\n" +
 	"\rlast_login_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampH\x05R\vlastLoginAt\x88\x01\x01\x12\x19\n" +
-	"\x05phone\x18\b \x01(\tH\x06R\x05phone\x88\x01\x01B\v\n" +
+	"\x05phone\x18\b \x01(\tH\x06R\x05phone\x88\x01\x01\x12B\n" +
+	"\flocked_until\x18\t \x01(\v2\x1a.google.protobuf.TimestampH\aR\vlockedUntil\x88\x01\x01B\v\n" +
 	"\t_nicknameB\n" +
 	"\n" +
 	"\b_pictureB\b\n" +
@@ -2003,7 +2497,8 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\a_statusB\x10\n" +
 	"\x0e_password_hashB\x10\n" +
 	"\x0e_last_login_atB\b\n" +
-	"\x06_phone\"\x9e\x02\n" +
+	"\x06_phoneB\x0f\n" +
+	"\r_locked_until\"\x9e\x02\n" +
 	"\fUserIdentity\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x16\n" +
 	"\x06domain\x18\x02 \x01(\tR\x06domain\x12\x16\n" +
@@ -2030,7 +2525,11 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\x03idp\x18\x03 \x01(\tR\x03idp\x12\x19\n" +
 	"\bt_openid\x18\x04 \x01(\tR\atOpenid\x12\x1e\n" +
 	"\braw_data\x18\x05 \x01(\tH\x00R\arawData\x88\x01\x01B\v\n" +
-	"\t_raw_data\"P\n" +
+	"\t_raw_data\"Y\n" +
+	"\x15RemoveIdentityRequest\x12\x16\n" +
+	"\x06domain\x18\x01 \x01(\tR\x06domain\x12\x16\n" +
+	"\x06openid\x18\x02 \x01(\tR\x06openid\x12\x10\n" +
+	"\x03idp\x18\x03 \x01(\tR\x03idp\"P\n" +
 	"\x1cGetPasswordCredentialRequest\x12\x10\n" +
 	"\x03idp\x18\x01 \x01(\tR\x03idp\x12\x1e\n" +
 	"\n" +
@@ -2095,9 +2594,41 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\v_sign_count\"V\n" +
 	"\x17DeleteCredentialRequest\x12\x16\n" +
 	"\x06openid\x18\x01 \x01(\tR\x06openid\x12#\n" +
-	"\rcredential_id\x18\x02 \x01(\tR\fcredentialId\"(\n" +
+	"\rcredential_id\x18\x02 \x01(\tR\fcredentialId\"7\n" +
+	"\x19ConsumeCredentialResponse\x12\x1a\n" +
+	"\bconsumed\x18\x01 \x01(\bR\bconsumed\"\x8d\x01\n" +
+	"\x19ReplaceCredentialsRequest\x12\x16\n" +
+	"\x06openid\x18\x01 \x01(\tR\x06openid\x12\x12\n" +
+	"\x04type\x18\x02 \x01(\tR\x04type\x12D\n" +
+	"\vcredentials\x18\x03 \x03(\v2\".hermes.v1.CreateCredentialRequestR\vcredentials\"(\n" +
 	"\x0eOpenIDResponse\x12\x16\n" +
-	"\x06openid\x18\x01 \x01(\tR\x06openid\"\x92\x02\n" +
+	"\x06openid\x18\x01 \x01(\tR\x06openid\"\xe9\x01\n" +
+	"\vUserConsent\x12\x16\n" +
+	"\x06openid\x18\x01 \x01(\tR\x06openid\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x03 \x01(\tR\tserviceId\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\x129\n" +
+	"\n" +
+	"created_at\x18\x05 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"E\n" +
+	"\x0fUserConsentList\x122\n" +
+	"\bconsents\x18\x01 \x03(\v2\x16.hermes.v1.UserConsentR\bconsents\"a\n" +
+	"\x11GetConsentRequest\x12\x16\n" +
+	"\x06openid\x18\x01 \x01(\tR\x06openid\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x03 \x01(\tR\tserviceId\"z\n" +
+	"\x12SaveConsentRequest\x12\x16\n" +
+	"\x06openid\x18\x01 \x01(\tR\x06openid\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\x12\x1d\n" +
+	"\n" +
+	"service_id\x18\x03 \x01(\tR\tserviceId\x12\x16\n" +
+	"\x06scopes\x18\x04 \x03(\tR\x06scopes\"E\n" +
+	"\x14RevokeConsentRequest\x12\x16\n" +
+	"\x06openid\x18\x01 \x01(\tR\x06openid\x12\x15\n" +
+	"\x06app_id\x18\x02 \x01(\tR\x05appId\"\x92\x02\n" +
 	"\x05Group\x12\x0e\n" +
 	"\x02id\x18\x01 \x01(\rR\x02id\x12\x19\n" +
 	"\bgroup_id\x18\x02 \x01(\tR\agroupId\x12\x1d\n" +
@@ -2136,7 +2667,7 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"pagination\"N\n" +
 	"\x16SetGroupMembersRequest\x12\x19\n" +
 	"\bgroup_id\x18\x01 \x01(\tR\agroupId\x12\x19\n" +
-	"\buser_ids\x18\x02 \x03(\tR\auserIds2\xd2\x10\n" +
+	"\buser_ids\x18\x02 \x03(\tR\auserIds2\xf5\x14\n" +
 	"\vUserService\x128\n" +
 	"\vGetByOpenID\x12\x18.hermes.v1.OpenIDRequest\x1a\x0f.hermes.v1.User\x12A\n" +
 	"\rGetByIdentity\x12\x1f.hermes.v1.GetByIdentityRequest\x1a\x0f.hermes.v1.User\x12D\n" +
@@ -2151,7 +2682,8 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\rGetIdentities\x12\x18.hermes.v1.OpenIDRequest\x1a\x17.hermes.v1.IdentityList\x12S\n" +
 	"\x17GetIdentitiesByIdentity\x12\x1f.hermes.v1.GetByIdentityRequest\x1a\x17.hermes.v1.IdentityList\x12Q\n" +
 	"\x11GetIdentityByType\x12#.hermes.v1.GetIdentityByTypeRequest\x1a\x17.hermes.v1.UserIdentity\x12D\n" +
-	"\vAddIdentity\x12\x1d.hermes.v1.AddIdentityRequest\x1a\x16.google.protobuf.Empty\x12d\n" +
+	"\vAddIdentity\x12\x1d.hermes.v1.AddIdentityRequest\x1a\x16.google.protobuf.Empty\x12J\n" +
+	"\x0eRemoveIdentity\x12 .hermes.v1.RemoveIdentityRequest\x1a\x16.google.protobuf.Empty\x12d\n" +
 	"\x15GetPasswordCredential\x12'.hermes.v1.GetPasswordCredentialRequest\x1a\".hermes.v1.PasswordStoreCredential\x12N\n" +
 	"\x10CreateCredential\x12\".hermes.v1.CreateCredentialRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
 	"\x11GetCredentialByID\x12\x1e.hermes.v1.CredentialIDRequest\x1a\x19.hermes.v1.UserCredential\x12M\n" +
@@ -2159,8 +2691,15 @@ const file_hermes_v1_user_proto_rawDesc = "" +
 	"\x18GetUserCredentialsByType\x12&.hermes.v1.GetCredentialsByTypeRequest\x1a\x1d.hermes.v1.UserCredentialList\x12L\n" +
 	"\x0fPatchCredential\x12!.hermes.v1.PatchCredentialRequest\x1a\x16.google.protobuf.Empty\x12N\n" +
 	"\x10DeleteCredential\x12\".hermes.v1.DeleteCredentialRequest\x1a\x16.google.protobuf.Empty\x12]\n" +
-	"\x1bDeleteUserCredentialsByType\x12&.hermes.v1.GetCredentialsByTypeRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
-	"\x17GetOpenIDByCredentialID\x12\x1e.hermes.v1.CredentialIDRequest\x1a\x19.hermes.v1.OpenIDResponse\x12>\n" +
+	"\x1bDeleteUserCredentialsByType\x12&.hermes.v1.GetCredentialsByTypeRequest\x1a\x16.google.protobuf.Empty\x12]\n" +
+	"\x11ConsumeCredential\x12\".hermes.v1.DeleteCredentialRequest\x1a$.hermes.v1.ConsumeCredentialResponse\x12\\\n" +
+	"\x1cReplaceUserCredentialsByType\x12$.hermes.v1.ReplaceCredentialsRequest\x1a\x16.google.protobuf.Empty\x12T\n" +
+	"\x17GetOpenIDByCredentialID\x12\x1e.hermes.v1.CredentialIDRequest\x1a\x19.hermes.v1.OpenIDResponse\x12B\n" +
+	"\n" +
+	"GetConsent\x12\x1c.hermes.v1.GetConsentRequest\x1a\x16.hermes.v1.UserConsent\x12D\n" +
+	"\fListConsents\x12\x18.hermes.v1.OpenIDRequest\x1a\x1a.hermes.v1.UserConsentList\x12D\n" +
+	"\vSaveConsent\x12\x1d.hermes.v1.SaveConsentRequest\x1a\x16.hermes.v1.UserConsent\x12H\n" +
+	"\rRevokeConsent\x12\x1f.hermes.v1.RevokeConsentRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
 	"\vCreateGroup\x12\x1d.hermes.v1.CreateGroupRequest\x1a\x10.hermes.v1.Group\x128\n" +
 	"\bGetGroup\x12\x1a.hermes.v1.GetGroupRequest\x1a\x10.hermes.v1.Group\x12@\n" +
 	"\n" +
@@ -2184,7 +2723,7 @@ func file_hermes_v1_user_proto_rawDescGZIP() []byte {
 	return file_hermes_v1_user_proto_rawDescData
 }
 
-var file_hermes_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 29)
+var file_hermes_v1_user_proto_msgTypes = make([]protoimpl.MessageInfo, 37)
 var file_hermes_v1_user_proto_goTypes = []any{
 	(*User)(nil),                         // 0: hermes.v1.User
 	(*DecryptedUser)(nil),                // 1: hermes.v1.DecryptedUser
@@ -2198,110 +2737,138 @@ var file_hermes_v1_user_proto_goTypes = []any{
 	(*IdentityList)(nil),                 // 9: hermes.v1.IdentityList
 	(*GetIdentityByTypeRequest)(nil),     // 10: hermes.v1.GetIdentityByTypeRequest
 	(*AddIdentityRequest)(nil),           // 11: hermes.v1.AddIdentityRequest
-	(*GetPasswordCredentialRequest)(nil), // 12: hermes.v1.GetPasswordCredentialRequest
-	(*PasswordStoreCredential)(nil),      // 13: hermes.v1.PasswordStoreCredential
-	(*CredentialIDRequest)(nil),          // 14: hermes.v1.CredentialIDRequest
-	(*UserCredential)(nil),               // 15: hermes.v1.UserCredential
-	(*UserCredentialList)(nil),           // 16: hermes.v1.UserCredentialList
-	(*CreateCredentialRequest)(nil),      // 17: hermes.v1.CreateCredentialRequest
-	(*GetCredentialsByTypeRequest)(nil),  // 18: hermes.v1.GetCredentialsByTypeRequest
-	(*PatchCredentialRequest)(nil),       // 19: hermes.v1.PatchCredentialRequest
-	(*DeleteCredentialRequest)(nil),      // 20: hermes.v1.DeleteCredentialRequest
-	(*OpenIDResponse)(nil),               // 21: hermes.v1.OpenIDResponse
-	(*Group)(nil),                        // 22: hermes.v1.Group
-	(*GroupList)(nil),                    // 23: hermes.v1.GroupList
-	(*GetGroupRequest)(nil),              // 24: hermes.v1.GetGroupRequest
-	(*CreateGroupRequest)(nil),           // 25: hermes.v1.CreateGroupRequest
-	(*UpdateGroupRequest)(nil),           // 26: hermes.v1.UpdateGroupRequest
-	(*ListGroupsRequest)(nil),            // 27: hermes.v1.ListGroupsRequest
-	(*SetGroupMembersRequest)(nil),       // 28: hermes.v1.SetGroupMembersRequest
-	(*timestamppb.Timestamp)(nil),        // 29: google.protobuf.Timestamp
-	(*Pagination)(nil),                   // 30: hermes.v1.Pagination
-	(*OpenIDRequest)(nil),                // 31: hermes.v1.OpenIDRequest
-	(*emptypb.Empty)(nil),                // 32: google.protobuf.Empty
-	(*StringList)(nil),                   // 33: hermes.v1.StringList
+	(*RemoveIdentityRequest)(nil),        // 12: hermes.v1.RemoveIdentityRequest
+	(*GetPasswordCredentialRequest)(nil), // 13: hermes.v1.GetPasswordCredentialRequest
+	(*PasswordStoreCredential)(nil),      // 14: hermes.v1.PasswordStoreCredential
+	(*CredentialIDRequest)(nil),          // 15: hermes.v1.CredentialIDRequest
+	(*UserCredential)(nil),               // 16: hermes.v1.UserCredential
+	(*UserCredentialList)(nil),           // 17: hermes.v1.UserCredentialList
+	(*CreateCredentialRequest)(nil),      // 18: hermes.v1.CreateCredentialRequest
+	(*GetCredentialsByTypeRequest)(nil),  // 19: hermes.v1.GetCredentialsByTypeRequest
+	(*PatchCredentialRequest)(nil),       // 20: hermes.v1.PatchCredentialRequest
+	(*DeleteCredentialRequest)(nil),      // 21: hermes.v1.DeleteCredentialRequest
+	(*ConsumeCredentialResponse)(nil),    // 22: hermes.v1.ConsumeCredentialResponse
+	(*ReplaceCredentialsRequest)(nil),    // 23: hermes.v1.ReplaceCredentialsRequest
+	(*OpenIDResponse)(nil),               // 24: hermes.v1.OpenIDResponse
+	(*UserConsent)(nil),                  // 25: hermes.v1.UserConsent
+	(*UserConsentList)(nil),              // 26: hermes.v1.UserConsentList
+	(*GetConsentRequest)(nil),            // 27: hermes.v1.GetConsentRequest
+	(*SaveConsentRequest)(nil),           // 28: hermes.v1.SaveConsentRequest
+	(*RevokeConsentRequest)(nil),         // 29: hermes.v1.RevokeConsentRequest
+	(*Group)(nil),                        // 30: hermes.v1.Group
+	(*GroupList)(nil),                    // 31: hermes.v1.GroupList
+	(*GetGroupRequest)(nil),              // 32: hermes.v1.GetGroupRequest
+	(*CreateGroupRequest)(nil),           // 33: hermes.v1.CreateGroupRequest
+	(*UpdateGroupRequest)(nil),           // 34: hermes.v1.UpdateGroupRequest
+	(*ListGroupsRequest)(nil),            // 35: hermes.v1.ListGroupsRequest
+	(*SetGroupMembersRequest)(nil),       // 36: hermes.v1.SetGroupMembersRequest
+	(*timestamppb.Timestamp)(nil),        // 37: google.protobuf.Timestamp
+	(*Pagination)(nil),                   // 38: hermes.v1.Pagination
+	(*OpenIDRequest)(nil),                // 39: hermes.v1.OpenIDRequest
+	(*emptypb.Empty)(nil),                // 40: google.protobuf.Empty
+	(*StringList)(nil),                   // 41: hermes.v1.StringList
 }
 var file_hermes_v1_user_proto_depIdxs = []int32{
-	29, // 0: hermes.v1.User.last_login_at:type_name -> google.protobuf.Timestamp
-	29, // 1: hermes.v1.User.created_at:type_name -> google.protobuf.Timestamp
-	29, // 2: hermes.v1.User.updated_at:type_name -> google.protobuf.Timestamp
-	0,  // 3: hermes.v1.DecryptedUser.user:type_name -> hermes.v1.User
-	8,  // 4: hermes.v1.CreateUserRequest.identity:type_name -> hermes.v1.UserIdentity
-	6,  // 5: hermes.v1.CreateUserRequest.user_info:type_name -> hermes.v1.TUserInfo
-	29, // 6: hermes.v1.PatchUserRequest.last_login_at:type_name -> google.protobuf.Timestamp
-	29, // 7: hermes.v1.UserIdentity.created_at:type_name -> google.protobuf.Timestamp
-	29, // 8: hermes.v1.UserIdentity.updated_at:type_name -> google.protobuf.Timestamp
-	8,  // 9: hermes.v1.IdentityList.identities:type_name -> hermes.v1.UserIdentity
-	29, // 10: hermes.v1.UserCredential.last_used_at:type_name -> google.protobuf.Timestamp
-	29, // 11: hermes.v1.UserCredential.created_at:type_name -> google.protobuf.Timestamp
-	29, // 12: hermes.v1.UserCredential.updated_at:type_name -> google.protobuf.Timestamp
-	15, // 13: hermes.v1.UserCredentialList.credentials:type_name -> hermes.v1.UserCredential
-	29, // 14: hermes.v1.PatchCredentialRequest.last_used_at:type_name -> google.protobuf.Timestamp
-	29, // 15: hermes.v1.Group.created_at:type_name -> google.protobuf.Timestamp
-	29, // 16: hermes.v1.Group.updated_at:type_name -> google.protobuf.Timestamp
-	22, // 17: hermes.v1.GroupList.groups:type_name -> hermes.v1.Group
-	30, // 18: hermes.v1.ListGroupsRequest.pagination:type_name -> hermes.v1.Pagination
-	31, // 19: hermes.v1.UserService.GetByOpenID:input_type -> hermes.v1.OpenIDRequest
-	2,  // 20: hermes.v1.UserService.GetByIdentity:input_type -> hermes.v1.GetByIdentityRequest
-	3,  // 21: hermes.v1.UserService.GetByEmail:input_type -> hermes.v1.GetByEmailRequest
-	4,  // 22: hermes.v1.UserService.GetByPhonePlain:input_type -> hermes.v1.GetByPhonePlainRequest
-	31, // 23: hermes.v1.UserService.GetDecryptedUser:input_type -> hermes.v1.OpenIDRequest
-	2,  // 24: hermes.v1.UserService.GetDecryptedUserByIdentity:input_type -> hermes.v1.GetByIdentityRequest
-	5,  // 25: hermes.v1.UserService.CreateUser:input_type -> hermes.v1.CreateUserRequest
-	7,  // 26: hermes.v1.UserService.PatchUser:input_type -> hermes.v1.PatchUserRequest
-	31, // 27: hermes.v1.UserService.GetIdentities:input_type -> hermes.v1.OpenIDRequest
-	2,  // 28: hermes.v1.UserService.GetIdentitiesByIdentity:input_type -> hermes.v1.GetByIdentityRequest
-	10, // 29: hermes.v1.UserService.GetIdentityByType:input_type -> hermes.v1.GetIdentityByTypeRequest
-	11, // 30: hermes.v1.UserService.AddIdentity:input_type -> hermes.v1.AddIdentityRequest
-	12, // 31: hermes.v1.UserService.GetPasswordCredential:input_type -> hermes.v1.GetPasswordCredentialRequest
-	17, // 32: hermes.v1.UserService.CreateCredential:input_type -> hermes.v1.CreateCredentialRequest
-	14, // 33: hermes.v1.UserService.GetCredentialByID:input_type -> hermes.v1.CredentialIDRequest
-	31, // 34: hermes.v1.UserService.GetUserCredentials:input_type -> hermes.v1.OpenIDRequest
-	18, // 35: hermes.v1.UserService.GetUserCredentialsByType:input_type -> hermes.v1.GetCredentialsByTypeRequest
-	19, // 36: hermes.v1.UserService.PatchCredential:input_type -> hermes.v1.PatchCredentialRequest
-	20, // 37: hermes.v1.UserService.DeleteCredential:input_type -> hermes.v1.DeleteCredentialRequest
-	18, // 38: hermes.v1.UserService.DeleteUserCredentialsByType:input_type -> hermes.v1.GetCredentialsByTypeRequest
-	14, // 39: hermes.v1.UserService.GetOpenIDByCredentialID:input_type -> hermes.v1.CredentialIDRequest
-	25, // 40: hermes.v1.UserService.CreateGroup:input_type -> hermes.v1.CreateGroupRequest
-	24, // 41: hermes.v1.UserService.GetGroup:input_type -> hermes.v1.GetGroupRequest
-	27, // 42: hermes.v1.UserService.ListGroups:input_type -> hermes.v1.ListGroupsRequest
-	26, // 43: hermes.v1.UserService.UpdateGroup:input_type -> hermes.v1.UpdateGroupRequest
-	24, // 44: hermes.v1.UserService.DeleteGroup:input_type -> hermes.v1.GetGroupRequest
-	28, // 45: hermes.v1.UserService.SetGroupMembers:input_type -> hermes.v1.SetGroupMembersRequest
-	24, // 46: hermes.v1.UserService.GetGroupMembers:input_type -> hermes.v1.GetGroupRequest
-	0,  // 47: hermes.v1.UserService.GetByOpenID:output_type -> hermes.v1.User
-	0,  // 48: hermes.v1.UserService.GetByIdentity:output_type -> hermes.v1.User
-	1,  // 49: hermes.v1.UserService.GetByEmail:output_type -> hermes.v1.DecryptedUser
-	1,  // 50: hermes.v1.UserService.GetByPhonePlain:output_type -> hermes.v1.DecryptedUser
-	1,  // 51: hermes.v1.UserService.GetDecryptedUser:output_type -> hermes.v1.DecryptedUser
-	1,  // 52: hermes.v1.UserService.GetDecryptedUserByIdentity:output_type -> hermes.v1.DecryptedUser
-	1,  // 53: hermes.v1.UserService.CreateUser:output_type -> hermes.v1.DecryptedUser
-	0,  // 54: hermes.v1.UserService.PatchUser:output_type -> hermes.v1.User
-	9,  // 55: hermes.v1.UserService.GetIdentities:output_type -> hermes.v1.IdentityList
-	9,  // 56: hermes.v1.UserService.GetIdentitiesByIdentity:output_type -> hermes.v1.IdentityList
-	8,  // 57: hermes.v1.UserService.GetIdentityByType:output_type -> hermes.v1.UserIdentity
-	32, // 58: hermes.v1.UserService.AddIdentity:output_type -> google.protobuf.Empty
-	13, // 59: hermes.v1.UserService.GetPasswordCredential:output_type -> hermes.v1.PasswordStoreCredential
-	32, // 60: hermes.v1.UserService.CreateCredential:output_type -> google.protobuf.Empty
-	15, // 61: hermes.v1.UserService.GetCredentialByID:output_type -> hermes.v1.UserCredential
-	16, // 62: hermes.v1.UserService.GetUserCredentials:output_type -> hermes.v1.UserCredentialList
-	16, // 63: hermes.v1.UserService.GetUserCredentialsByType:output_type -> hermes.v1.UserCredentialList
-	32, // 64: hermes.v1.UserService.PatchCredential:output_type -> google.protobuf.Empty
-	32, // 65: hermes.v1.UserService.DeleteCredential:output_type -> google.protobuf.Empty
-	32, // 66: hermes.v1.UserService.DeleteUserCredentialsByType:output_type -> google.protobuf.Empty
-	21, // 67: hermes.v1.UserService.GetOpenIDByCredentialID:output_type -> hermes.v1.OpenIDResponse
-	22, // 68: hermes.v1.UserService.CreateGroup:output_type -> hermes.v1.Group
-	22, // 69: hermes.v1.UserService.GetGroup:output_type -> hermes.v1.Group
-	23, // 70: hermes.v1.UserService.ListGroups:output_type -> hermes.v1.GroupList
-	22, // 71: hermes.v1.UserService.UpdateGroup:output_type -> hermes.v1.Group
-	32, // 72: hermes.v1.UserService.DeleteGroup:output_type -> google.protobuf.Empty
-	32, // 73: hermes.v1.UserService.SetGroupMembers:output_type -> google.protobuf.Empty
-	33, // 74: hermes.v1.UserService.GetGroupMembers:output_type -> hermes.v1.StringList
-	47, // [47:75] is the sub-list for method output_type
-	19, // [19:47] is the sub-list for method input_type
-	19, // [19:19] is the sub-list for extension type_name
-	19, // [19:19] is the sub-list for extension extendee
-	0,  // [0:19] is the sub-list for field type_name
+	37, // 0: hermes.v1.User.last_login_at:type_name -> google.protobuf.Timestamp
+	37, // 1: hermes.v1.User.created_at:type_name -> google.protobuf.Timestamp
+	37, // 2: hermes.v1.User.updated_at:type_name -> google.protobuf.Timestamp
+	37, // 3: hermes.v1.User.locked_until:type_name -> google.protobuf.Timestamp
+	0,  // 4: hermes.v1.DecryptedUser.user:type_name -> hermes.v1.User
+	8,  // 5: hermes.v1.CreateUserRequest.identity:type_name -> hermes.v1.UserIdentity
+	6,  // 6: hermes.v1.CreateUserRequest.user_info:type_name -> hermes.v1.TUserInfo
+	37, // 7: hermes.v1.PatchUserRequest.last_login_at:type_name -> google.protobuf.Timestamp
+	37, // 8: hermes.v1.PatchUserRequest.locked_until:type_name -> google.protobuf.Timestamp
+	37, // 9: hermes.v1.UserIdentity.created_at:type_name -> google.protobuf.Timestamp
+	37, // 10: hermes.v1.UserIdentity.updated_at:type_name -> google.protobuf.Timestamp
+	8,  // 11: hermes.v1.IdentityList.identities:type_name -> hermes.v1.UserIdentity
+	37, // 12: hermes.v1.UserCredential.last_used_at:type_name -> google.protobuf.Timestamp
+	37, // 13: hermes.v1.UserCredential.created_at:type_name -> google.protobuf.Timestamp
+	37, // 14: hermes.v1.UserCredential.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 15: hermes.v1.UserCredentialList.credentials:type_name -> hermes.v1.UserCredential
+	37, // 16: hermes.v1.PatchCredentialRequest.last_used_at:type_name -> google.protobuf.Timestamp
+	18, // 17: hermes.v1.ReplaceCredentialsRequest.credentials:type_name -> hermes.v1.CreateCredentialRequest
+	37, // 18: hermes.v1.UserConsent.created_at:type_name -> google.protobuf.Timestamp
+	37, // 19: hermes.v1.UserConsent.updated_at:type_name -> google.protobuf.Timestamp
+	25, // 20: hermes.v1.UserConsentList.consents:type_name -> hermes.v1.UserConsent
+	37, // 21: hermes.v1.Group.created_at:type_name -> google.protobuf.Timestamp
+	37, // 22: hermes.v1.Group.updated_at:type_name -> google.protobuf.Timestamp
+	30, // 23: hermes.v1.GroupList.groups:type_name -> hermes.v1.Group
+	38, // 24: hermes.v1.ListGroupsRequest.pagination:type_name -> hermes.v1.Pagination
+	39, // 25: hermes.v1.UserService.GetByOpenID:input_type -> hermes.v1.OpenIDRequest
+	2,  // 26: hermes.v1.UserService.GetByIdentity:input_type -> hermes.v1.GetByIdentityRequest
+	3,  // 27: hermes.v1.UserService.GetByEmail:input_type -> hermes.v1.GetByEmailRequest
+	4,  // 28: hermes.v1.UserService.GetByPhonePlain:input_type -> hermes.v1.GetByPhonePlainRequest
+	39, // 29: hermes.v1.UserService.GetDecryptedUser:input_type -> hermes.v1.OpenIDRequest
+	2,  // 30: hermes.v1.UserService.GetDecryptedUserByIdentity:input_type -> hermes.v1.GetByIdentityRequest
+	5,  // 31: hermes.v1.UserService.CreateUser:input_type -> hermes.v1.CreateUserRequest
+	7,  // 32: hermes.v1.UserService.PatchUser:input_type -> hermes.v1.PatchUserRequest
+	39, // 33: hermes.v1.UserService.GetIdentities:input_type -> hermes.v1.OpenIDRequest
+	2,  // 34: hermes.v1.UserService.GetIdentitiesByIdentity:input_type -> hermes.v1.GetByIdentityRequest
+	10, // 35: hermes.v1.UserService.GetIdentityByType:input_type -> hermes.v1.GetIdentityByTypeRequest
+	11, // 36: hermes.v1.UserService.AddIdentity:input_type -> hermes.v1.AddIdentityRequest
+	12, // 37: hermes.v1.UserService.RemoveIdentity:input_type -> hermes.v1.RemoveIdentityRequest
+	13, // 38: hermes.v1.UserService.GetPasswordCredential:input_type -> hermes.v1.GetPasswordCredentialRequest
+	18, // 39: hermes.v1.UserService.CreateCredential:input_type -> hermes.v1.CreateCredentialRequest
+	15, // 40: hermes.v1.UserService.GetCredentialByID:input_type -> hermes.v1.CredentialIDRequest
+	39, // 41: hermes.v1.UserService.GetUserCredentials:input_type -> hermes.v1.OpenIDRequest
+	19, // 42: hermes.v1.UserService.GetUserCredentialsByType:input_type -> hermes.v1.GetCredentialsByTypeRequest
+	20, // 43: hermes.v1.UserService.PatchCredential:input_type -> hermes.v1.PatchCredentialRequest
+	21, // 44: hermes.v1.UserService.DeleteCredential:input_type -> hermes.v1.DeleteCredentialRequest
+	19, // 45: hermes.v1.UserService.DeleteUserCredentialsByType:input_type -> hermes.v1.GetCredentialsByTypeRequest
+	21, // 46: hermes.v1.UserService.ConsumeCredential:input_type -> hermes.v1.DeleteCredentialRequest
+	23, // 47: hermes.v1.UserService.ReplaceUserCredentialsByType:input_type -> hermes.v1.ReplaceCredentialsRequest
+	15, // 48: hermes.v1.UserService.GetOpenIDByCredentialID:input_type -> hermes.v1.CredentialIDRequest
+	27, // 49: hermes.v1.UserService.GetConsent:input_type -> hermes.v1.GetConsentRequest
+	39, // 50: hermes.v1.UserService.ListConsents:input_type -> hermes.v1.OpenIDRequest
+	28, // 51: hermes.v1.UserService.SaveConsent:input_type -> hermes.v1.SaveConsentRequest
+	29, // 52: hermes.v1.UserService.RevokeConsent:input_type -> hermes.v1.RevokeConsentRequest
+	33, // 53: hermes.v1.UserService.CreateGroup:input_type -> hermes.v1.CreateGroupRequest
+	32, // 54: hermes.v1.UserService.GetGroup:input_type -> hermes.v1.GetGroupRequest
+	35, // 55: hermes.v1.UserService.ListGroups:input_type -> hermes.v1.ListGroupsRequest
+	34, // 56: hermes.v1.UserService.UpdateGroup:input_type -> hermes.v1.UpdateGroupRequest
+	32, // 57: hermes.v1.UserService.DeleteGroup:input_type -> hermes.v1.GetGroupRequest
+	36, // 58: hermes.v1.UserService.SetGroupMembers:input_type -> hermes.v1.SetGroupMembersRequest
+	32, // 59: hermes.v1.UserService.GetGroupMembers:input_type -> hermes.v1.GetGroupRequest
+	0,  // 60: hermes.v1.UserService.GetByOpenID:output_type -> hermes.v1.User
+	0,  // 61: hermes.v1.UserService.GetByIdentity:output_type -> hermes.v1.User
+	1,  // 62: hermes.v1.UserService.GetByEmail:output_type -> hermes.v1.DecryptedUser
+	1,  // 63: hermes.v1.UserService.GetByPhonePlain:output_type -> hermes.v1.DecryptedUser
+	1,  // 64: hermes.v1.UserService.GetDecryptedUser:output_type -> hermes.v1.DecryptedUser
+	1,  // 65: hermes.v1.UserService.GetDecryptedUserByIdentity:output_type -> hermes.v1.DecryptedUser
+	1,  // 66: hermes.v1.UserService.CreateUser:output_type -> hermes.v1.DecryptedUser
+	0,  // 67: hermes.v1.UserService.PatchUser:output_type -> hermes.v1.User
+	9,  // 68: hermes.v1.UserService.GetIdentities:output_type -> hermes.v1.IdentityList
+	9,  // 69: hermes.v1.UserService.GetIdentitiesByIdentity:output_type -> hermes.v1.IdentityList
+	8,  // 70: hermes.v1.UserService.GetIdentityByType:output_type -> hermes.v1.UserIdentity
+	40, // 71: hermes.v1.UserService.AddIdentity:output_type -> google.protobuf.Empty
+	40, // 72: hermes.v1.UserService.RemoveIdentity:output_type -> google.protobuf.Empty
+	14, // 73: hermes.v1.UserService.GetPasswordCredential:output_type -> hermes.v1.PasswordStoreCredential
+	40, // 74: hermes.v1.UserService.CreateCredential:output_type -> google.protobuf.Empty
+	16, // 75: hermes.v1.UserService.GetCredentialByID:output_type -> hermes.v1.UserCredential
+	17, // 76: hermes.v1.UserService.GetUserCredentials:output_type -> hermes.v1.UserCredentialList
+	17, // 77: hermes.v1.UserService.GetUserCredentialsByType:output_type -> hermes.v1.UserCredentialList
+	40, // 78: hermes.v1.UserService.PatchCredential:output_type -> google.protobuf.Empty
+	40, // 79: hermes.v1.UserService.DeleteCredential:output_type -> google.protobuf.Empty
+	40, // 80: hermes.v1.UserService.DeleteUserCredentialsByType:output_type -> google.protobuf.Empty
+	22, // 81: hermes.v1.UserService.ConsumeCredential:output_type -> hermes.v1.ConsumeCredentialResponse
+	40, // 82: hermes.v1.UserService.ReplaceUserCredentialsByType:output_type -> google.protobuf.Empty
+	24, // 83: hermes.v1.UserService.GetOpenIDByCredentialID:output_type -> hermes.v1.OpenIDResponse
+	25, // 84: hermes.v1.UserService.GetConsent:output_type -> hermes.v1.UserConsent
+	26, // 85: hermes.v1.UserService.ListConsents:output_type -> hermes.v1.UserConsentList
+	25, // 86: hermes.v1.UserService.SaveConsent:output_type -> hermes.v1.UserConsent
+	40, // 87: hermes.v1.UserService.RevokeConsent:output_type -> google.protobuf.Empty
+	30, // 88: hermes.v1.UserService.CreateGroup:output_type -> hermes.v1.Group
+	30, // 89: hermes.v1.UserService.GetGroup:output_type -> hermes.v1.Group
+	31, // 90: hermes.v1.UserService.ListGroups:output_type -> hermes.v1.GroupList
+	30, // 91: hermes.v1.UserService.UpdateGroup:output_type -> hermes.v1.Group
+	40, // 92: hermes.v1.UserService.DeleteGroup:output_type -> google.protobuf.Empty
+	40, // 93: hermes.v1.UserService.SetGroupMembers:output_type -> google.protobuf.Empty
+	41, // 94: hermes.v1.UserService.GetGroupMembers:output_type -> hermes.v1.StringList
+	60, // [60:95] is the sub-list for method output_type
+	25, // [25:60] is the sub-list for method input_type
+	25, // [25:25] is the sub-list for extension type_name
+	25, // [25:25] is the sub-list for extension extendee
+	0,  // [0:25] is the sub-list for field type_name
 }
 
 func init() { file_hermes_v1_user_proto_init() }
@@ -2316,20 +2883,20 @@ func file_hermes_v1_user_proto_init() {
 	file_hermes_v1_user_proto_msgTypes[7].OneofWrappers = []any{}
 	file_hermes_v1_user_proto_msgTypes[8].OneofWrappers = []any{}
 	file_hermes_v1_user_proto_msgTypes[11].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[13].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[15].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[17].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[19].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[22].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[25].OneofWrappers = []any{}
-	file_hermes_v1_user_proto_msgTypes[26].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[14].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[16].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[18].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[20].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[30].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[33].OneofWrappers = []any{}
+	file_hermes_v1_user_proto_msgTypes[34].OneofWrappers = []any{}
 	type x struct{}
 	out := protoimpl.TypeBuilder{
 		File: protoimpl.DescBuilder{
 			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
 			RawDescriptor: unsafe.Slice(unsafe.StringData(file_hermes_v1_user_proto_rawDesc), len(file_hermes_v1_user_proto_rawDesc)),
 			NumEnums:      0,
-			NumMessages:   29,
+			NumMessages:   37,
 			NumExtensions: 0,
 			NumServices:   1,
 		},
@@ -20,34 +20,41 @@ import (
 const _ = grpc.SupportPackageIsVersion9
 
 const (
-	UserService_GetByOpenID_FullMethodName                 = "/hermes.v1.UserService/GetByOpenID"
-	UserService_GetByIdentity_FullMethodName               = "/hermes.v1.UserService/GetByIdentity"
-	UserService_GetByEmail_FullMethodName                  = "/hermes.v1.UserService/GetByEmail"
-	UserService_GetByPhonePlain_FullMethodName             = "/hermes.v1.UserService/GetByPhonePlain"
-	UserService_GetDecryptedUser_FullMethodName            = "/hermes.v1.UserService/GetDecryptedUser"
-	UserService_GetDecryptedUserByIdentity_FullMethodName  = "/hermes.v1.UserService/GetDecryptedUserByIdentity"
-	UserService_CreateUser_FullMethodName                  = "/hermes.v1.UserService/CreateUser"
-	UserService_PatchUser_FullMethodName                   = "/hermes.v1.UserService/PatchUser"
-	UserService_GetIdentities_FullMethodName               = "/hermes.v1.UserService/GetIdentities"
-	UserService_GetIdentitiesByIdentity_FullMethodName     = "/hermes.v1.UserService/GetIdentitiesByIdentity"
-	UserService_GetIdentityByType_FullMethodName           = "/hermes.v1.UserService/GetIdentityByType"
-	UserService_AddIdentity_FullMethodName                 = "/hermes.v1.UserService/AddIdentity"
-	UserService_GetPasswordCredential_FullMethodName       = "/hermes.v1.UserService/GetPasswordCredential"
-	UserService_CreateCredential_FullMethodName            = "/hermes.v1.UserService/CreateCredential"
-	UserService_GetCredentialByID_FullMethodName           = "/hermes.v1.UserService/GetCredentialByID"
-	UserService_GetUserCredentials_FullMethodName          = "/hermes.v1.UserService/GetUserCredentials"
-	UserService_GetUserCredentialsByType_FullMethodName    = "/hermes.v1.UserService/GetUserCredentialsByType"
-	UserService_PatchCredential_FullMethodName             = "/hermes.v1.UserService/PatchCredential"
-	UserService_DeleteCredential_FullMethodName            = "/hermes.v1.UserService/DeleteCredential"
-	UserService_DeleteUserCredentialsByType_FullMethodName = "/hermes.v1.UserService/DeleteUserCredentialsByType"
-	UserService_GetOpenIDByCredentialID_FullMethodName     = "/hermes.v1.UserService/GetOpenIDByCredentialID"
-	UserService_CreateGroup_FullMethodName                 = "/hermes.v1.UserService/CreateGroup"
-	UserService_GetGroup_FullMethodName                    = "/hermes.v1.UserService/GetGroup"
-	UserService_ListGroups_FullMethodName                  = "/hermes.v1.UserService/ListGroups"
-	UserService_UpdateGroup_FullMethodName                 = "/hermes.v1.UserService/UpdateGroup"
-	UserService_DeleteGroup_FullMethodName                 = "/hermes.v1.UserService/DeleteGroup"
-	UserService_SetGroupMembers_FullMethodName             = "/hermes.v1.UserService/SetGroupMembers"
-	UserService_GetGroupMembers_FullMethodName             = "/hermes.v1.UserService/GetGroupMembers"
+	UserService_GetByOpenID_FullMethodName                  = "/hermes.v1.UserService/GetByOpenID"
+	UserService_GetByIdentity_FullMethodName                = "/hermes.v1.UserService/GetByIdentity"
+	UserService_GetByEmail_FullMethodName                   = "/hermes.v1.UserService/GetByEmail"
+	UserService_GetByPhonePlain_FullMethodName              = "/hermes.v1.UserService/GetByPhonePlain"
+	UserService_GetDecryptedUser_FullMethodName             = "/hermes.v1.UserService/GetDecryptedUser"
+	UserService_GetDecryptedUserByIdentity_FullMethodName   = "/hermes.v1.UserService/GetDecryptedUserByIdentity"
+	UserService_CreateUser_FullMethodName                   = "/hermes.v1.UserService/CreateUser"
+	UserService_PatchUser_FullMethodName                    = "/hermes.v1.UserService/PatchUser"
+	UserService_GetIdentities_FullMethodName                = "/hermes.v1.UserService/GetIdentities"
+	UserService_GetIdentitiesByIdentity_FullMethodName      = "/hermes.v1.UserService/GetIdentitiesByIdentity"
+	UserService_GetIdentityByType_FullMethodName            = "/hermes.v1.UserService/GetIdentityByType"
+	UserService_AddIdentity_FullMethodName                  = "/hermes.v1.UserService/AddIdentity"
+	UserService_RemoveIdentity_FullMethodName               = "/hermes.v1.UserService/RemoveIdentity"
+	UserService_GetPasswordCredential_FullMethodName        = "/hermes.v1.UserService/GetPasswordCredential"
+	UserService_CreateCredential_FullMethodName             = "/hermes.v1.UserService/CreateCredential"
+	UserService_GetCredentialByID_FullMethodName            = "/hermes.v1.UserService/GetCredentialByID"
+	UserService_GetUserCredentials_FullMethodName           = "/hermes.v1.UserService/GetUserCredentials"
+	UserService_GetUserCredentialsByType_FullMethodName     = "/hermes.v1.UserService/GetUserCredentialsByType"
+	UserService_PatchCredential_FullMethodName              = "/hermes.v1.UserService/PatchCredential"
+	UserService_DeleteCredential_FullMethodName             = "/hermes.v1.UserService/DeleteCredential"
+	UserService_DeleteUserCredentialsByType_FullMethodName  = "/hermes.v1.UserService/DeleteUserCredentialsByType"
+	UserService_ConsumeCredential_FullMethodName            = "/hermes.v1.UserService/ConsumeCredential"
+	UserService_ReplaceUserCredentialsByType_FullMethodName = "/hermes.v1.UserService/ReplaceUserCredentialsByType"
+	UserService_GetOpenIDByCredentialID_FullMethodName      = "/hermes.v1.UserService/GetOpenIDByCredentialID"
+	UserService_GetConsent_FullMethodName                   = "/hermes.v1.UserService/GetConsent"
+	UserService_ListConsents_FullMethodName                 = "/hermes.v1.UserService/ListConsents"
+	UserService_SaveConsent_FullMethodName                  = "/hermes.v1.UserService/SaveConsent"
+	UserService_RevokeConsent_FullMethodName                = "/hermes.v1.UserService/RevokeConsent"
+	UserService_CreateGroup_FullMethodName                  = "/hermes.v1.UserService/CreateGroup"
+	UserService_GetGroup_FullMethodName                     = "/hermes.v1.UserService/GetGroup"
+	UserService_ListGroups_FullMethodName                   = "/hermes.v1.UserService/ListGroups"
+	UserService_UpdateGroup_FullMethodName                  = "/hermes.v1.UserService/UpdateGroup"
+	UserService_DeleteGroup_FullMethodName                  = "/hermes.v1.UserService/DeleteGroup"
+	UserService_SetGroupMembers_FullMethodName              = "/hermes.v1.UserService/SetGroupMembers"
+	UserService_GetGroupMembers_FullMethodName              = "/hermes.v1.UserService/GetGroupMembers"
 )
 
 // UserServiceClient is the client API for UserService service.
@@ -68,6 +75,7 @@ type UserServiceClient interface {
 	GetIdentitiesByIdentity(ctx context.Context, in *GetByIdentityRequest, opts ...grpc.CallOption) (*IdentityList, error)
 	GetIdentityByType(ctx context.Context, in *GetIdentityByTypeRequest, opts ...grpc.CallOption) (*UserIdentity, error)
 	AddIdentity(ctx context.Context, in *AddIdentityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	RemoveIdentity(ctx context.Context, in *RemoveIdentityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetPasswordCredential(ctx context.Context, in *GetPasswordCredentialRequest, opts ...grpc.CallOption) (*PasswordStoreCredential, error)
 	CreateCredential(ctx context.Context, in *CreateCredentialRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetCredentialByID(ctx context.Context, in *CredentialIDRequest, opts ...grpc.CallOption) (*UserCredential, error)
@@ -76,7 +84,13 @@ type UserServiceClient interface {
 	PatchCredential(ctx context.Context, in *PatchCredentialRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	DeleteUserCredentialsByType(ctx context.Context, in *GetCredentialsByTypeRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
+	ConsumeCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*ConsumeCredentialResponse, error)
+	ReplaceUserCredentialsByType(ctx context.Context, in *ReplaceCredentialsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	GetOpenIDByCredentialID(ctx context.Context, in *CredentialIDRequest, opts ...grpc.CallOption) (*OpenIDResponse, error)
+	GetConsent(ctx context.Context, in *GetConsentRequest, opts ...grpc.CallOption) (*UserConsent, error)
+	ListConsents(ctx context.Context, in *OpenIDRequest, opts ...grpc.CallOption) (*UserConsentList, error)
+	SaveConsent(ctx context.Context, in *SaveConsentRequest, opts ...grpc.CallOption) (*UserConsent, error)
+	RevokeConsent(ctx context.Context, in *RevokeConsentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error)
 	CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*Group, error)
 	GetGroup(ctx context.Context, in *GetGroupRequest, opts ...grpc.CallOption) (*Group, error)
 	ListGroups(ctx context.Context, in *ListGroupsRequest, opts ...grpc.CallOption) (*GroupList, error)
@@ -214,6 +228,16 @@ func (c *userServiceClient) AddIdentity(ctx context.Context, in *AddIdentityRequ
 	return out, nil
 }
 
+func (c *userServiceClient) RemoveIdentity(ctx context.Context, in *RemoveIdentityRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RemoveIdentity_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetPasswordCredential(ctx context.Context, in *GetPasswordCredentialRequest, opts ...grpc.CallOption) (*PasswordStoreCredential, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(PasswordStoreCredential)
@@ -294,6 +318,26 @@ func (c *userServiceClient) DeleteUserCredentialsByType(ctx context.Context, in
 	return out, nil
 }
 
+func (c *userServiceClient) ConsumeCredential(ctx context.Context, in *DeleteCredentialRequest, opts ...grpc.CallOption) (*ConsumeCredentialResponse, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(ConsumeCredentialResponse)
+	err := c.cc.Invoke(ctx, UserService_ConsumeCredential_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ReplaceUserCredentialsByType(ctx context.Context, in *ReplaceCredentialsRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_ReplaceUserCredentialsByType_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) GetOpenIDByCredentialID(ctx context.Context, in *CredentialIDRequest, opts ...grpc.CallOption) (*OpenIDResponse, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(OpenIDResponse)
@@ -304,6 +348,46 @@ func (c *userServiceClient) GetOpenIDByCredentialID(ctx context.Context, in *Cre
 	return out, nil
 }
 
+func (c *userServiceClient) GetConsent(ctx context.Context, in *GetConsentRequest, opts ...grpc.CallOption) (*UserConsent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserConsent)
+	err := c.cc.Invoke(ctx, UserService_GetConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) ListConsents(ctx context.Context, in *OpenIDRequest, opts ...grpc.CallOption) (*UserConsentList, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserConsentList)
+	err := c.cc.Invoke(ctx, UserService_ListConsents_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) SaveConsent(ctx context.Context, in *SaveConsentRequest, opts ...grpc.CallOption) (*UserConsent, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(UserConsent)
+	err := c.cc.Invoke(ctx, UserService_SaveConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *userServiceClient) RevokeConsent(ctx context.Context, in *RevokeConsentRequest, opts ...grpc.CallOption) (*emptypb.Empty, error) {
+	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
+	out := new(emptypb.Empty)
+	err := c.cc.Invoke(ctx, UserService_RevokeConsent_FullMethodName, in, out, cOpts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 func (c *userServiceClient) CreateGroup(ctx context.Context, in *CreateGroupRequest, opts ...grpc.CallOption) (*Group, error) {
 	cOpts := append([]grpc.CallOption{grpc.StaticMethod()}, opts...)
 	out := new(Group)
@@ -392,6 +476,7 @@ type UserServiceServer interface {
 	GetIdentitiesByIdentity(context.Context, *GetByIdentityRequest) (*IdentityList, error)
 	GetIdentityByType(context.Context, *GetIdentityByTypeRequest) (*UserIdentity, error)
 	AddIdentity(context.Context, *AddIdentityRequest) (*emptypb.Empty, error)
+	RemoveIdentity(context.Context, *RemoveIdentityRequest) (*emptypb.Empty, error)
 	GetPasswordCredential(context.Context, *GetPasswordCredentialRequest) (*PasswordStoreCredential, error)
 	CreateCredential(context.Context, *CreateCredentialRequest) (*emptypb.Empty, error)
 	GetCredentialByID(context.Context, *CredentialIDRequest) (*UserCredential, error)
@@ -400,7 +485,13 @@ type UserServiceServer interface {
 	PatchCredential(context.Context, *PatchCredentialRequest) (*emptypb.Empty, error)
 	DeleteCredential(context.Context, *DeleteCredentialRequest) (*emptypb.Empty, error)
 	DeleteUserCredentialsByType(context.Context, *GetCredentialsByTypeRequest) (*emptypb.Empty, error)
+	ConsumeCredential(context.Context, *DeleteCredentialRequest) (*ConsumeCredentialResponse, error)
+	ReplaceUserCredentialsByType(context.Context, *ReplaceCredentialsRequest) (*emptypb.Empty, error)
 	GetOpenIDByCredentialID(context.Context, *CredentialIDRequest) (*OpenIDResponse, error)
+	GetConsent(context.Context, *GetConsentRequest) (*UserConsent, error)
+	ListConsents(context.Context, *OpenIDRequest) (*UserConsentList, error)
+	SaveConsent(context.Context, *SaveConsentRequest) (*UserConsent, error)
+	RevokeConsent(context.Context, *RevokeConsentRequest) (*emptypb.Empty, error)
 	CreateGroup(context.Context, *CreateGroupRequest) (*Group, error)
 	GetGroup(context.Context, *GetGroupRequest) (*Group, error)
 	ListGroups(context.Context, *ListGroupsRequest) (*GroupList, error)
@@ -454,6 +545,9 @@ func (UnimplementedUserServiceServer) GetIdentityByType(context.Context, *GetIde
 func (UnimplementedUserServiceServer) AddIdentity(context.Context, *AddIdentityRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method AddIdentity not implemented")
 }
+func (UnimplementedUserServiceServer) RemoveIdentity(context.Context, *RemoveIdentityRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RemoveIdentity not implemented")
+}
 func (UnimplementedUserServiceServer) GetPasswordCredential(context.Context, *GetPasswordCredentialRequest) (*PasswordStoreCredential, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetPasswordCredential not implemented")
 }
@@ -478,9 +572,27 @@ func (UnimplementedUserServiceServer) DeleteCredential(context.Context, *DeleteC
 func (UnimplementedUserServiceServer) DeleteUserCredentialsByType(context.Context, *GetCredentialsByTypeRequest) (*emptypb.Empty, error) {
 	return nil, status.Error(codes.Unimplemented, "method DeleteUserCredentialsByType not implemented")
 }
+func (UnimplementedUserServiceServer) ConsumeCredential(context.Context, *DeleteCredentialRequest) (*ConsumeCredentialResponse, error) {
+	return nil, status.Error(codes.Unimplemented, "method ConsumeCredential not implemented")
+}
+func (UnimplementedUserServiceServer) ReplaceUserCredentialsByType(context.Context, *ReplaceCredentialsRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method ReplaceUserCredentialsByType not implemented")
+}
 func (UnimplementedUserServiceServer) GetOpenIDByCredentialID(context.Context, *CredentialIDRequest) (*OpenIDResponse, error) {
 	return nil, status.Error(codes.Unimplemented, "method GetOpenIDByCredentialID not implemented")
 }
+func (UnimplementedUserServiceServer) GetConsent(context.Context, *GetConsentRequest) (*UserConsent, error) {
+	return nil, status.Error(codes.Unimplemented, "method GetConsent not implemented")
+}
+func (UnimplementedUserServiceServer) ListConsents(context.Context, *OpenIDRequest) (*UserConsentList, error) {
+	return nil, status.Error(codes.Unimplemented, "method ListConsents not implemented")
+}
+func (UnimplementedUserServiceServer) SaveConsent(context.Context, *SaveConsentRequest) (*UserConsent, error) {
+	return nil, status.Error(codes.Unimplemented, "method SaveConsent not implemented")
+}
+func (UnimplementedUserServiceServer) RevokeConsent(context.Context, *RevokeConsentRequest) (*emptypb.Empty, error) {
+	return nil, status.Error(codes.Unimplemented, "method RevokeConsent not implemented")
+}
 func (UnimplementedUserServiceServer) CreateGroup(context.Context, *CreateGroupRequest) (*Group, error) {
 	return nil, status.Error(codes.Unimplemented, "method CreateGroup not implemented")
 }
@@ -739,6 +851,24 @@ func _UserService_AddIdentity_Handler(srv interface{}, ctx context.Context, dec
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_RemoveIdentity_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RemoveIdentityRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RemoveIdentity(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RemoveIdentity_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RemoveIdentity(ctx, req.(*RemoveIdentityRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetPasswordCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(GetPasswordCredentialRequest)
 	if err := dec(in); err != nil {
@@ -883,6 +1013,42 @@ func _UserService_DeleteUserCredentialsByType_Handler(srv interface{}, ctx conte
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_ConsumeCredential_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DeleteCredentialRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ConsumeCredential(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ConsumeCredential_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ConsumeCredential(ctx, req.(*DeleteCredentialRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ReplaceUserCredentialsByType_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(ReplaceCredentialsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ReplaceUserCredentialsByType(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ReplaceUserCredentialsByType_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ReplaceUserCredentialsByType(ctx, req.(*ReplaceCredentialsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_GetOpenIDByCredentialID_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CredentialIDRequest)
 	if err := dec(in); err != nil {
@@ -901,6 +1067,78 @@ func _UserService_GetOpenIDByCredentialID_Handler(srv interface{}, ctx context.C
 	return interceptor(ctx, in, info, handler)
 }
 
+func _UserService_GetConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(GetConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).GetConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_GetConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).GetConsent(ctx, req.(*GetConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_ListConsents_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(OpenIDRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).ListConsents(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_ListConsents_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).ListConsents(ctx, req.(*OpenIDRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_SaveConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(SaveConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).SaveConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_SaveConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).SaveConsent(ctx, req.(*SaveConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _UserService_RevokeConsent_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(RevokeConsentRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(UserServiceServer).RevokeConsent(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: UserService_RevokeConsent_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(UserServiceServer).RevokeConsent(ctx, req.(*RevokeConsentRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 func _UserService_CreateGroup_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
 	in := new(CreateGroupRequest)
 	if err := dec(in); err != nil {
@@ -1082,6 +1320,10 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "AddIdentity",
 			Handler:    _UserService_AddIdentity_Handler,
 		},
+		{
+			MethodName: "RemoveIdentity",
+			Handler:    _UserService_RemoveIdentity_Handler,
+		},
 		{
 			MethodName: "GetPasswordCredential",
 			Handler:    _UserService_GetPasswordCredential_Handler,
@@ -1114,10 +1356,34 @@ var UserService_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "DeleteUserCredentialsByType",
 			Handler:    _UserService_DeleteUserCredentialsByType_Handler,
 		},
+		{
+			MethodName: "ConsumeCredential",
+			Handler:    _UserService_ConsumeCredential_Handler,
+		},
+		{
+			MethodName: "ReplaceUserCredentialsByType",
+			Handler:    _UserService_ReplaceUserCredentialsByType_Handler,
+		},
 		{
 			MethodName: "GetOpenIDByCredentialID",
 			Handler:    _UserService_GetOpenIDByCredentialID_Handler,
 		},
+		{
+			MethodName: "GetConsent",
+			Handler:    _UserService_GetConsent_Handler,
+		},
+		{
+			MethodName: "ListConsents",
+			Handler:    _UserService_ListConsents_Handler,
+		},
+		{
+			MethodName: "SaveConsent",
+			Handler:    _UserService_SaveConsent_Handler,
+		},
+		{
+			MethodName: "RevokeConsent",
+			Handler:    _UserService_RevokeConsent_Handler,
+		},
 		{
 			MethodName: "CreateGroup",
 			Handler:    _UserService_CreateGroup_Handler,